@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+	diskinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/storage/disk"
+)
+
+// runBreach ingests breach corpus file of "HASH[:COUNT]" lines into on-disk
+// store served by BreachService. Ingested corpus of the same algorithm is
+// replaced, running server sees it after restart.
+func runBreach(args []string) error {
+	var (
+		algName   string
+		inFile    string
+		dir       string
+		chunkSize int
+	)
+
+	fs := flag.NewFlagSet("breach", flag.ExitOnError)
+	fs.StringVar(&algName, "algorithm", "sha1", "corpus hash algorithm: sha1, ntlm or sha256")
+	fs.StringVar(&inFile, "in", "", "corpus file, stdin if empty")
+	fs.StringVar(&dir, "dir", "data/breach", "store directory, breach.dir of server config")
+	fs.IntVar(&chunkSize, "chunk", 0, "number of hashes sorted in memory at once, about 4 million if zero")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	alg, err := breach.ParseAlgorithm(algName)
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if inFile != "" {
+		f, err := os.Open(inFile)
+		if err != nil {
+			return fmt.Errorf("open corpus: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	records, err := diskinfra.IngestBreachCorpus(dir, alg, in, chunkSize)
+	if err != nil {
+		return fmt.Errorf("ingest corpus: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "ingested %d distinct %s hashes\n", records, alg)
+
+	return nil
+}
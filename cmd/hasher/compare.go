@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/pkg/hasherclient"
+)
+
+type compareFlags struct {
+	hashingFlags
+
+	addr      string
+	useTLS    bool
+	caFile    string
+	certFile  string
+	keyFile   string
+	apiKey    string
+	sample    int
+	batchSize int
+	timeout   time.Duration
+}
+
+// runCompare hashes a sample of lines both in-process and through a running
+// server and reports every mismatch. Hashing flags must mirror settings of
+// the caller tenant on the server.
+func runCompare(args []string) error {
+	var f compareFlags
+
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	f.register(fs)
+	fs.StringVar(&f.addr, "addr", "localhost:6969", "server address")
+	fs.BoolVar(&f.useTLS, "tls", false, "use TLS")
+	fs.StringVar(&f.caFile, "ca", "", "CA certificate file to verify server, system pool if empty")
+	fs.StringVar(&f.certFile, "cert", "", "client certificate file for mutual TLS")
+	fs.StringVar(&f.keyFile, "key", "", "client key file for mutual TLS")
+	fs.StringVar(&f.apiKey, "api-key", os.Getenv("HASHER_API_KEY"), "API key, HASHER_API_KEY env by default")
+	fs.IntVar(&f.sample, "sample", 1000, "maximum number of lines to compare, 0 means all")
+	fs.IntVar(&f.batchSize, "batch-size", 500, "number of inputs per server request")
+	fs.DurationVar(&f.timeout, "timeout", 30*time.Second, "per request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if f.batchSize < 1 {
+		return errors.New("batch size must be positive")
+	}
+
+	svc, err := f.newLocalService()
+	if err != nil {
+		return err
+	}
+
+	cli, err := f.dial()
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	var inputs []string
+	errStop := errors.New("sample collected")
+	err = readLines(fs.Args(), func(line string) error {
+		if line == "" {
+			return nil
+		}
+		inputs = append(inputs, line)
+		if f.sample > 0 && len(inputs) >= f.sample {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	mismatches := 0
+	for start := 0; start < len(inputs); start += f.batchSize {
+		batch := inputs[start:min(start+f.batchSize, len(inputs))]
+
+		remote, err := f.hashRemote(cli, batch)
+		if err != nil {
+			return err
+		}
+
+		for i, input := range batch {
+			local := ""
+			if h, err := svc.CreateHash(context.Background(), input, 0, 0, f.params()); err == nil {
+				local = h.Hashed()
+			} else {
+				local = "error: " + err.Error()
+			}
+
+			if local != remote[i] {
+				mismatches++
+				fmt.Fprintf(out, "MISMATCH %q local=%s server=%s\n", input, local, remote[i])
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "compared %d, mismatched %d\n", len(inputs), mismatches)
+	if mismatches > 0 {
+		return fmt.Errorf("%d mismatches found", mismatches)
+	}
+
+	return nil
+}
+
+func (f *compareFlags) hashRemote(cli *hasherclient.Client, inputs []string) ([]string, error) {
+	alg, err := hasherclient.ParseAlgorithm(f.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	norm, err := hasherclient.ParseNormalization(f.normalization)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]hasherclient.Request, len(inputs))
+	for i, in := range inputs {
+		reqs[i] = hasherclient.Request{
+			Input:         in,
+			Algorithm:     alg,
+			Normalization: norm,
+			Salt:          f.salt,
+			PepperVersion: f.pepperVersion,
+		}
+	}
+
+	results, err := cli.HashBatch(context.Background(), reqs)
+	if err != nil {
+		return nil, fmt.Errorf("hash batch: %w", err)
+	}
+
+	hashes := make([]string, len(inputs))
+	for i, r := range results {
+		hashes[i] = r.Hash
+		if r.Err != nil {
+			hashes[i] = "error: " + r.Err.Error()
+		}
+	}
+
+	return hashes, nil
+}
+
+func (f *compareFlags) dial() (*hasherclient.Client, error) {
+	opts := []hasherclient.Option{
+		hasherclient.WithTimeout(f.timeout),
+		hasherclient.WithAPIKey(f.apiKey),
+	}
+
+	if f.useTLS {
+		tlsCfg, err := hasherclient.LoadTLSConfig(f.caFile, f.certFile, f.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, hasherclient.WithTLSConfig(tlsCfg))
+	}
+
+	return hasherclient.New(f.addr, opts...)
+}
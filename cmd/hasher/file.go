@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/fileformat"
+)
+
+// fileSpec represents mapping spec file. JSON files are accepted too.
+//
+//	format: csv
+//	columns:
+//	  - column: email
+//	    algorithm: sha256
+//	    normalization: lower
+type fileSpec struct {
+	Format  string `koanf:"format"`
+	Columns []struct {
+		Column        string `koanf:"column"`
+		Algorithm     string `koanf:"algorithm"`
+		Normalization string `koanf:"normalization"`
+	} `koanf:"columns"`
+}
+
+// ruleFlags collects repeated -rule flags.
+type ruleFlags []string
+
+func (f *ruleFlags) String() string { return strings.Join(*f, ",") }
+
+func (f *ruleFlags) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// runFile hashes columns of CSV or NDJSON file according to mapping spec and
+// writes hashed file preserving other columns. Row errors are reported to
+// stderr or report file and don't stop hashing.
+func runFile(args []string) error {
+	var (
+		hf         hashingFlags
+		rules      ruleFlags
+		specFile   string
+		formatName string
+		inFile     string
+		outFile    string
+		reportFile string
+	)
+
+	fs := flag.NewFlagSet("file", flag.ExitOnError)
+	hf.register(fs)
+	fs.Var(&rules, "rule", "column rule column[=algorithm[:normalization]], may be repeated")
+	fs.StringVar(&specFile, "spec", "", "YAML or JSON mapping spec file")
+	fs.StringVar(&formatName, "format", "", "file format: csv or ndjson, detected by input extension if empty")
+	fs.StringVar(&inFile, "in", "", "input file, stdin if empty")
+	fs.StringVar(&outFile, "out", "", "output file, stdout if empty")
+	fs.StringVar(&reportFile, "report", "", "write JSON report to file instead of stderr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	spec, specFormat, err := loadSpec(specFile, rules)
+	if err != nil {
+		return err
+	}
+
+	format, err := detectFormat(formatName, specFormat, inFile)
+	if err != nil {
+		return err
+	}
+
+	hashSvc, err := hf.newLocalService()
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if inFile != "" {
+		f, err := os.Open(inFile)
+		if err != nil {
+			return fmt.Errorf("open input: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	out := io.Writer(os.Stdout)
+	if outFile != "" {
+		f, err := os.Create(outFile)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	svc := application.NewFileService(hashSvc, fileformat.All(), 0)
+	report, err := svc.HashFile(context.Background(), format, spec, hf.params(), in, out)
+	if err != nil {
+		return err
+	}
+
+	if err := writeReport(reportFile, report); err != nil {
+		return err
+	}
+
+	if report.FailedRows > 0 {
+		return fmt.Errorf("%d of %d rows failed", report.FailedRows, report.Rows)
+	}
+
+	return nil
+}
+
+// loadSpec builds mapping spec from spec file and -rule flags. Returns
+// format set in spec file.
+func loadSpec(specFile string, flags ruleFlags) (*record.Spec, string, error) {
+	var (
+		fs    fileSpec
+		rules []*record.Rule
+	)
+
+	if specFile != "" {
+		k := koanf.New(".")
+		if err := k.Load(file.Provider(specFile), yaml.Parser()); err != nil {
+			return nil, "", fmt.Errorf("load spec: %w", err)
+		}
+
+		if err := k.Unmarshal("", &fs); err != nil {
+			return nil, "", fmt.Errorf("unmarshal spec: %w", err)
+		}
+
+		for _, c := range fs.Columns {
+			var (
+				alg  hash.Algorithm
+				norm hash.Normalization
+				err  error
+			)
+			if c.Algorithm != "" {
+				if alg, err = hash.ParseAlgorithm(c.Algorithm); err != nil {
+					return nil, "", fmt.Errorf("column %q: %w", c.Column, err)
+				}
+			}
+			if c.Normalization != "" {
+				if norm, err = hash.ParseNormalization(c.Normalization); err != nil {
+					return nil, "", fmt.Errorf("column %q: %w", c.Column, err)
+				}
+			}
+
+			rule, err := record.NewRule(c.Column, alg, norm)
+			if err != nil {
+				return nil, "", fmt.Errorf("new rule: %w", err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	for _, f := range flags {
+		rule, err := record.ParseRule(f)
+		if err != nil {
+			return nil, "", fmt.Errorf("parse rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	spec, err := record.NewSpec(rules...)
+	if err != nil {
+		return nil, "", fmt.Errorf("new spec: %w", err)
+	}
+
+	return spec, fs.Format, nil
+}
+
+func detectFormat(flagFormat, specFormat, inFile string) (record.Format, error) {
+	name := flagFormat
+	if name == "" {
+		name = specFormat
+	}
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Ext(inFile), ".")
+	}
+	if name == "" {
+		return 0, fmt.Errorf("file format is required for stdin")
+	}
+
+	format, err := record.ParseFormat(strings.ToLower(name))
+	if err != nil {
+		return 0, fmt.Errorf("parse format %q: %w", name, err)
+	}
+
+	return format, nil
+}
+
+func writeReport(reportFile string, report *application.FileReport) error {
+	if reportFile != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal report: %w", err)
+		}
+
+		if err := os.WriteFile(reportFile, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, e := range report.Errors {
+		if e.Column == "" {
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", e.Row, e.Err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "row %d column %q: %s\n", e.Row, e.Column, e.Err)
+	}
+
+	return nil
+}
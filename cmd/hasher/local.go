@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	memoryinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/cache/memory"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/canonical"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/secrets"
+)
+
+const (
+	// maxLineSize is a maximum size of a single input line.
+	maxLineSize = 1 << 20
+	// localCacheSize is a number of hashes cached by in-process service.
+	localCacheSize = 10000
+)
+
+// hashingFlags represents flags mirroring tenant settings of the server, so
+// local hashes match server ones.
+type hashingFlags struct {
+	algorithm     string
+	normalization string
+	pepperFile    string
+	peppersFile   string
+	pepperVersion string
+	salt          string
+}
+
+func (f *hashingFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.algorithm, "algorithm", "", "hash algorithm: md5 or sha256")
+	fs.StringVar(&f.normalization, "normalization", "", "input normalization: none, trim, lower or digits")
+	fs.StringVar(&f.pepperFile, "pepper-file", "", "file with tenant pepper, trailing newline is ignored")
+	fs.StringVar(&f.peppersFile, "peppers-file", "", "file with server peppers, a version=secret line per pepper")
+	fs.StringVar(&f.pepperVersion, "pepper-version", "", "server pepper version, the last in peppers file if empty")
+	fs.StringVar(&f.salt, "salt", "", "salt hashed with every input")
+}
+
+// params returns hash params set by flags.
+func (f *hashingFlags) params() hash.Params {
+	return hash.Params{Salt: f.salt, PepperVersion: f.pepperVersion}
+}
+
+// newLocalService creates hash service running in-process with the same
+// hashers as the server and without Redis.
+func (f *hashingFlags) newLocalService() (*application.HashService, error) {
+	settings := tenant.Settings{}
+
+	if f.algorithm != "" {
+		alg, err := hash.ParseAlgorithm(f.algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("parse algorithm: %w", err)
+		}
+		settings.Algorithm = alg
+	}
+
+	norm, err := hash.ParseNormalization(f.normalization)
+	if err != nil {
+		return nil, fmt.Errorf("parse normalization: %w", err)
+	}
+	settings.Normalization = norm
+
+	if f.pepperFile != "" {
+		pepper, err := os.ReadFile(f.pepperFile)
+		if err != nil {
+			return nil, fmt.Errorf("read pepper: %w", err)
+		}
+		settings.Pepper = strings.TrimRight(string(pepper), "\r\n")
+	}
+
+	def, err := tenant.NewDefault(settings)
+	if err != nil {
+		return nil, fmt.Errorf("new default tenant: %w", err)
+	}
+
+	tenants, err := tenant.NewRegistry(def)
+	if err != nil {
+		return nil, fmt.Errorf("new tenant registry: %w", err)
+	}
+
+	var peppers *hash.Peppers
+	if f.peppersFile != "" {
+		peppers, err = secrets.LoadPeppers(f.peppersFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("load peppers: %w", err)
+		}
+	}
+
+	return application.NewHashService(
+		memoryinfra.NewHashRepository(localCacheSize),
+		memoryinfra.NewUsageRepository(),
+		tenants,
+		peppers,
+		hasher.All(),
+		&canonical.JCS{},
+		nil,
+	), nil
+}
+
+type localResult struct {
+	Input string `json:"input"`
+	Hash  string `json:"hash,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// runLocal hashes every line of provided files or stdin and prints a hash per
+// line. Empty lines produce empty output lines.
+func runLocal(args []string) error {
+	var (
+		hf       hashingFlags
+		jsonLine bool
+	)
+
+	fs := flag.NewFlagSet("local", flag.ExitOnError)
+	hf.register(fs)
+	fs.BoolVar(&jsonLine, "json", false, "write JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := hf.newLocalService()
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	failed := false
+	err = readLines(fs.Args(), func(line string) error {
+		res := localResult{Input: line}
+		if line != "" {
+			h, err := svc.CreateHash(context.Background(), line, 0, 0, hf.params())
+			if err != nil {
+				res.Error = err.Error()
+				failed = true
+			} else {
+				res.Hash = h.Hashed()
+			}
+		}
+
+		if jsonLine {
+			b, err := json.Marshal(res)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "%s\n", b)
+			return err
+		}
+
+		if res.Error != "" {
+			fmt.Fprintf(os.Stderr, "%q: %s\n", res.Input, res.Error)
+		}
+
+		_, err := fmt.Fprintln(out, res.Hash)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("some inputs failed")
+	}
+
+	return nil
+}
+
+// readLines calls fn for every line of files or stdin if no files provided.
+func readLines(files []string, fn func(line string) error) error {
+	if len(files) == 0 {
+		return scanLines(os.Stdin, fn)
+	}
+
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return fmt.Errorf("open input: %w", err)
+		}
+
+		err = scanLines(f, fn)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("read %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func scanLines(r io.Reader, fn func(line string) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for sc.Scan() {
+		if err := fn(sc.Text()); err != nil {
+			return err
+		}
+	}
+
+	return sc.Err()
+}
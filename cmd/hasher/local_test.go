@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	memoryinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/cache/memory"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/canonical"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+)
+
+var localInputs = []string{
+	"alice@example.com",
+	"  Alice@Example.COM ",
+	"+1 (555) 010-9999",
+	"Привет, мир",
+	"a=b:c\tsalt",
+}
+
+var serverPeppers = map[string]string{
+	"v1": "first-server-secret",
+	"v2": "second-server-secret",
+}
+
+func TestRunLocal_MatchesServer(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputs        []string
+		algorithm     hash.Algorithm
+		normalization hash.Normalization
+		tenantPepper  string
+		serverPepper  bool
+		salt          string
+		pepperVersion string
+	}{
+		{name: "md5", algorithm: hash.AlgorithmMD5},
+		{name: "sha256", algorithm: hash.AlgorithmSHA256},
+		{name: "trim", algorithm: hash.AlgorithmSHA256, normalization: hash.NormalizationTrim},
+		{name: "lower", algorithm: hash.AlgorithmMD5, normalization: hash.NormalizationLower},
+		{
+			name:          "digits",
+			inputs:        []string{"+1 (555) 010-9999", "8 800 555-35-35", "0"},
+			algorithm:     hash.AlgorithmSHA256,
+			normalization: hash.NormalizationDigits,
+			salt:          "0",
+		},
+		{name: "salt", algorithm: hash.AlgorithmSHA256, salt: "campaign-42"},
+		{name: "tenant pepper", algorithm: hash.AlgorithmSHA256, tenantPepper: "tenant-secret"},
+		{name: "server pepper", algorithm: hash.AlgorithmMD5, serverPepper: true},
+		{name: "previous server pepper", algorithm: hash.AlgorithmSHA256, serverPepper: true, pepperVersion: "v1"},
+		{
+			name:          "all",
+			algorithm:     hash.AlgorithmSHA256,
+			normalization: hash.NormalizationLower,
+			tenantPepper:  "tenant-secret",
+			serverPepper:  true,
+			salt:          "campaign-42",
+			pepperVersion: "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := tt.inputs
+			if inputs == nil {
+				inputs = localInputs
+			}
+
+			dir := t.TempDir()
+			inputFile := writeFile(t, dir, "inputs.txt", strings.Join(inputs, "\n")+"\n")
+
+			args := []string{
+				"-algorithm", tt.algorithm.String(),
+				"-normalization", tt.normalization.String(),
+				"-salt", tt.salt,
+				"-pepper-version", tt.pepperVersion,
+			}
+			if tt.tenantPepper != "" {
+				args = append(args, "-pepper-file", writeFile(t, dir, "pepper", tt.tenantPepper+"\n"))
+			}
+			if tt.serverPepper {
+				args = append(args, "-peppers-file", writeFile(t, dir, "peppers", "v1=first-server-secret\nv2=second-server-secret\n"))
+			}
+
+			got := captureStdout(t, func() error {
+				return runLocal(append(args, inputFile))
+			})
+
+			expect := serverHashes(t, inputs, tt.algorithm, tt.normalization, tt.tenantPepper, tt.serverPepper,
+				hash.Params{Salt: tt.salt, PepperVersion: tt.pepperVersion})
+			if got != expect {
+				t.Errorf("local hashes differ from server ones\nlocal:\n%s\nserver:\n%s", got, expect)
+			}
+		})
+	}
+}
+
+// serverHashes hashes inputs the way server does for caller of tenant with
+// given settings and returns a hash per line.
+func serverHashes(t *testing.T, inputs []string, alg hash.Algorithm, norm hash.Normalization, pepper string, serverPepper bool, params hash.Params) string {
+	t.Helper()
+
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{
+		Algorithm:     alg,
+		Normalization: norm,
+		Pepper:        pepper,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenants, err := tenant.NewRegistry(tenant.Default(), sales)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var peppers *hash.Peppers
+	if serverPepper {
+		if peppers, err = hash.NewPeppers("v2", serverPeppers); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := application.NewHashService(
+		memoryinfra.NewHashRepository(0),
+		memoryinfra.NewUsageRepository(),
+		tenants,
+		peppers,
+		hasher.All(),
+		&canonical.JCS{},
+		nil,
+	)
+
+	id, err := identity.New("importer", identity.SourceAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := identity.NewContext(context.Background(), id)
+
+	var b strings.Builder
+	for _, input := range inputs {
+		h, err := svc.CreateHash(ctx, input, 0, 0, params)
+		if err != nil {
+			t.Fatalf("hash %q: %v", input, err)
+		}
+		b.WriteString(h.Hashed() + "\n")
+	}
+
+	return b.String()
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// captureStdout returns everything fn writes to stdout.
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stdout := os.Stdout
+	os.Stdout = f
+	err = fn()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(out)
+}
@@ -1,5 +1,14 @@
 // Package main provides entry-point for application. Determines application
 // mode, setups logger, initializes configuration and bootstrapps application.
+//
+// Besides the server, provides offline subcommands:
+//
+//	hasher [serve]     starts gRPC server
+//	hasher local       hashes stdin or files in-process without Redis
+//	hasher compare     hashes a sample locally and through a running server
+//	hasher file        hashes columns of CSV or NDJSON file in-process
+//	hasher breach      ingests breach corpus into on-disk range store
+//	hasher sum         writes or verifies sha256sum compatible manifests
 package main
 
 import (
@@ -8,6 +17,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/tmybsv/leadgen-test-task/internal/app"
@@ -15,6 +25,37 @@ import (
 )
 
 func main() {
+	cmd, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
+	}
+
+	var err error
+	switch cmd {
+	case "serve":
+		serve()
+		return
+	case "local":
+		err = runLocal(args)
+	case "compare":
+		err = runCompare(args)
+	case "file":
+		err = runFile(args)
+	case "breach":
+		err = runBreach(args)
+	case "sum":
+		err = runSum(args)
+	default:
+		err = fmt.Errorf("unknown command %q, expected serve, local, compare, file, breach or sum", cmd)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "hasher:", err)
+		os.Exit(1)
+	}
+}
+
+func serve() {
 	mode := config.Mode(os.Getenv("HASHER_MODE"))
 	if mode == "" {
 		mode = config.ModeDevelopment
@@ -31,7 +72,11 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	app := app.New(cfg, log)
+	app, err := app.New(cfg, log)
+	if err != nil {
+		log.Error("failed to create new app", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 	defer app.Stop()
 
 	errCh := make(chan error, 1)
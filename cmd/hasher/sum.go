@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/checksum"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+)
+
+// runSum writes checksum manifest of files and directories or, with -check,
+// verifies files listed in manifests. Manifests are compatible with
+// sha256sum and md5sum, stdin is used if no paths provided.
+func runSum(args []string) error {
+	var (
+		algName string
+		tag     bool
+		check   bool
+		quiet   bool
+		workers int
+	)
+
+	fs := flag.NewFlagSet("sum", flag.ExitOnError)
+	fs.StringVar(&algName, "algorithm", "sha256", "hash algorithm: md5 or sha256, assumed for untagged manifest lines")
+	fs.BoolVar(&tag, "tag", false, "write BSD tag lines")
+	fs.BoolVar(&check, "check", false, "verify checksums listed in manifests")
+	fs.BoolVar(&quiet, "quiet", false, "don't report verified files")
+	fs.IntVar(&workers, "workers", 0, "number of files hashed in parallel, number of CPUs if zero")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	alg, err := hash.ParseAlgorithm(algName)
+	if err != nil {
+		return fmt.Errorf("parse algorithm: %w", err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	if check {
+		return checkManifests(out, fs.Args(), alg, workers, quiet)
+	}
+
+	format := checksum.FormatGNU
+	if tag {
+		format = checksum.FormatTag
+	}
+
+	if fs.NArg() == 0 {
+		sum, err := hasher.Sum(alg, os.Stdin)
+		if err != nil {
+			return fmt.Errorf("hash stdin: %w", err)
+		}
+		_, err = fmt.Fprintln(out, checksum.FormatLine(checksum.Entry{Path: "-", Algorithm: alg, Checksum: sum}, format))
+		return err
+	}
+
+	files, err := checksum.Files(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	err = checksum.Sum(context.Background(), alg, files, workers, func(r checksum.Result) error {
+		if r.Err != nil {
+			fmt.Fprintln(os.Stderr, "hasher:", r.Err)
+			failed++
+			return nil
+		}
+
+		_, err := fmt.Fprintln(out, checksum.FormatLine(r.Entry, format))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d files could not be read", failed)
+	}
+
+	return nil
+}
+
+// checkManifests verifies files listed in manifests and reports them like
+// sha256sum --check does.
+func checkManifests(out io.Writer, manifests []string, alg hash.Algorithm, workers int, quiet bool) error {
+	var (
+		entries   []checksum.Entry
+		malformed int
+	)
+	err := readLines(manifests, func(line string) error {
+		e, err := checksum.ParseLine(line, alg)
+		if err != nil {
+			malformed++
+			return nil
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[checksum.Status]int)
+	err = checksum.Check(context.Background(), entries, workers, func(r checksum.CheckResult) error {
+		counts[r.Status]++
+
+		if r.Err != nil {
+			fmt.Fprintln(os.Stderr, "hasher:", r.Err)
+		}
+		if quiet && r.Status == checksum.StatusOK {
+			return nil
+		}
+
+		_, err := fmt.Fprintln(out, r)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if malformed > 0 {
+		fmt.Fprintf(os.Stderr, "hasher: WARNING: %d lines are improperly formatted\n", malformed)
+	}
+	if n := counts[checksum.StatusMissing]; n > 0 {
+		fmt.Fprintf(os.Stderr, "hasher: WARNING: %d listed files could not be read\n", n)
+	}
+	if n := counts[checksum.StatusFailed]; n > 0 {
+		fmt.Fprintf(os.Stderr, "hasher: WARNING: %d computed checksums did NOT match\n", n)
+	}
+
+	switch {
+	case len(entries) == 0:
+		return errors.New("no properly formatted checksum lines found")
+	case counts[checksum.StatusOK] != len(entries):
+		return errors.New("verification failed")
+	default:
+		return nil
+	}
+}
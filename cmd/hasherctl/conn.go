@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/tmybsv/leadgen-test-task/pkg/hasherclient"
+)
+
+func dial(opts *options) (*hasherclient.Client, error) {
+	clientOpts := []hasherclient.Option{
+		hasherclient.WithTimeout(opts.timeout),
+		hasherclient.WithAPIKey(opts.apiKey),
+	}
+
+	if opts.tls {
+		tlsCfg, err := hasherclient.LoadTLSConfig(opts.caFile, opts.certFile, opts.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.ServerName = opts.serverName
+		tlsCfg.InsecureSkipVerify = opts.insecure
+
+		clientOpts = append(clientOpts, hasherclient.WithTLSConfig(tlsCfg))
+	}
+
+	return hasherclient.New(opts.addr, clientOpts...)
+}
@@ -0,0 +1,193 @@
+// Package main provides hasher service command line client.
+//
+// Hashes arguments, stdin lines or CSV columns through a running service and
+// prints results as plain text, CSV or JSON lines. In verify mode compares
+// service hashes with expected ones.
+//
+// Usage:
+//
+//	hasherctl [flags] [input ...]
+//	hasherctl -verify [flags] [hash input]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// errMismatch is returned when at least one hash didn't match in verify mode
+// or at least one input failed to hash.
+var errMismatch = errors.New("some inputs failed")
+
+type options struct {
+	addr          string
+	algorithm     string
+	normalization string
+	encoding      string
+	timeout       time.Duration
+
+	in          string
+	out         string
+	csv         bool
+	columns     string
+	expected    string
+	noHeader    bool
+	appendCols  bool
+	verify      bool
+	json        bool
+	concurrency int
+	batchSize   int
+
+	tls        bool
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	insecure   bool
+	apiKey     string
+}
+
+func main() {
+	opts := parseFlags()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, opts, flag.Args()); err != nil {
+		if !errors.Is(err, errMismatch) {
+			fmt.Fprintln(os.Stderr, "hasherctl:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func parseFlags() *options {
+	o := &options{}
+
+	flag.StringVar(&o.addr, "addr", "localhost:6969", "service address")
+	flag.StringVar(&o.algorithm, "algorithm", "", "hash algorithm: md5 or sha256, tenant default if empty")
+	flag.StringVar(&o.normalization, "normalization", "", "input normalization: none, trim, lower or digits, tenant default if empty")
+	flag.StringVar(&o.encoding, "encoding", "hex", "output encoding: hex, base64 or base64url")
+	flag.DurationVar(&o.timeout, "timeout", 30*time.Second, "per request timeout")
+
+	flag.StringVar(&o.in, "in", "", "input file, stdin if empty")
+	flag.StringVar(&o.out, "out", "", "output file, stdout if empty")
+	flag.BoolVar(&o.csv, "csv", false, "read and write CSV")
+	flag.StringVar(&o.columns, "columns", "", "comma separated CSV column names or 1-based indexes to hash")
+	flag.StringVar(&o.expected, "expected", "", "CSV column with expected hash in verify mode")
+	flag.BoolVar(&o.noHeader, "no-header", false, "CSV input has no header row")
+	flag.BoolVar(&o.appendCols, "append", false, "append hashed CSV columns instead of replacing them")
+	flag.BoolVar(&o.verify, "verify", false, "verify \"hash input\" pairs instead of printing hashes")
+	flag.BoolVar(&o.json, "json", false, "write JSON lines")
+	flag.IntVar(&o.concurrency, "concurrency", 4, "number of concurrent batch requests")
+	flag.IntVar(&o.batchSize, "batch-size", 100, "number of inputs per batch request")
+
+	flag.BoolVar(&o.tls, "tls", false, "use TLS")
+	flag.StringVar(&o.caFile, "ca", "", "CA certificate file to verify server, system pool if empty")
+	flag.StringVar(&o.certFile, "cert", "", "client certificate file for mutual TLS")
+	flag.StringVar(&o.keyFile, "key", "", "client key file for mutual TLS")
+	flag.StringVar(&o.serverName, "server-name", "", "override server name used to verify certificate")
+	flag.BoolVar(&o.insecure, "insecure", false, "skip server certificate verification")
+	flag.StringVar(&o.apiKey, "api-key", os.Getenv("HASHER_API_KEY"), "API key, HASHER_API_KEY env by default")
+
+	flag.Parse()
+
+	return o
+}
+
+func run(ctx context.Context, opts *options, args []string) error {
+	if opts.concurrency < 1 || opts.batchSize < 1 {
+		return errors.New("concurrency and batch size must be positive")
+	}
+
+	enc, err := newEncoder(opts.encoding)
+	if err != nil {
+		return err
+	}
+
+	in, err := openInput(opts.in)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := openOutput(opts.out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	src, err := newSource(opts, args, in)
+	if err != nil {
+		return err
+	}
+
+	sink, err := newSink(opts, src.header(), out)
+	if err != nil {
+		return err
+	}
+
+	cli, err := dial(opts)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	p, err := newPipeline(cli, opts, enc)
+	if err != nil {
+		return err
+	}
+
+	if err := p.run(ctx, src, sink); err != nil {
+		return err
+	}
+
+	if err := sink.flush(); err != nil {
+		return fmt.Errorf("flush output: %w", err)
+	}
+
+	if sink.failed() {
+		return errMismatch
+	}
+
+	return nil
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "" || path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open input: %w", err)
+	}
+
+	return f, nil
+}
+
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create output: %w", err)
+	}
+
+	return f, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// encoder converts hex hash returned by service to output encoding.
+type encoder func(hexHash string) (string, error)
+
+func newEncoder(name string) (encoder, error) {
+	var enc *base64.Encoding
+	switch name {
+	case "", "hex":
+		return func(h string) (string, error) { return h, nil }, nil
+	case "base64":
+		enc = base64.StdEncoding
+	case "base64url":
+		enc = base64.RawURLEncoding
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", name)
+	}
+
+	return func(h string) (string, error) {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return "", fmt.Errorf("decode hash: %w", err)
+		}
+		return enc.EncodeToString(b), nil
+	}, nil
+}
+
+type sink interface {
+	write(r *row) error
+	flush() error
+	// failed reports whether any input failed or didn't match.
+	failed() bool
+}
+
+func newSink(opts *options, header []string, out io.Writer) (sink, error) {
+	w := bufio.NewWriter(out)
+
+	switch {
+	case opts.verify:
+		return &verifySink{w: w, json: opts.json}, nil
+	case opts.csv:
+		return newCSVSink(opts, header, w)
+	default:
+		return &textSink{w: w, json: opts.json}, nil
+	}
+}
+
+// textSink writes a hash per line or JSON lines.
+type textSink struct {
+	w      *bufio.Writer
+	json   bool
+	errors bool
+}
+
+type textResult struct {
+	Input string `json:"input"`
+	Hash  string `json:"hash,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func (s *textSink) write(r *row) error {
+	res := textResult{Input: r.fields[r.targets[0]], Hash: r.hashes[0], Error: r.errs[0]}
+	if res.Error != "" {
+		s.errors = true
+	}
+
+	if s.json {
+		return writeJSON(s.w, res)
+	}
+
+	if res.Error != "" {
+		fmt.Fprintf(os.Stderr, "%q: %s\n", res.Input, res.Error)
+	}
+
+	_, err := fmt.Fprintln(s.w, res.Hash)
+	return err
+}
+
+func (s *textSink) flush() error { return s.w.Flush() }
+
+func (s *textSink) failed() bool { return s.errors }
+
+// verifySink writes verification verdict per input.
+type verifySink struct {
+	w          *bufio.Writer
+	json       bool
+	mismatches bool
+}
+
+type verifyResult struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Hash     string `json:"hash,omitempty"`
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (s *verifySink) write(r *row) error {
+	res := verifyResult{
+		Input:    r.fields[r.targets[0]],
+		Expected: r.expected,
+		Hash:     r.hashes[0],
+		Error:    r.errs[0],
+	}
+	res.OK = res.Error == "" && res.Hash != "" && strings.EqualFold(res.Hash, res.Expected)
+	if !res.OK {
+		s.mismatches = true
+	}
+
+	if s.json {
+		return writeJSON(s.w, res)
+	}
+
+	verdict := "OK"
+	if !res.OK {
+		verdict = "FAILED"
+	}
+	if res.Error != "" {
+		verdict += " (" + res.Error + ")"
+	}
+
+	_, err := fmt.Fprintf(s.w, "%s: %s\n", res.Input, verdict)
+	return err
+}
+
+func (s *verifySink) flush() error { return s.w.Flush() }
+
+func (s *verifySink) failed() bool { return s.mismatches }
+
+// csvSink writes CSV rows with hashed columns replaced or appended, or JSON
+// objects keyed by header.
+type csvSink struct {
+	w       *bufio.Writer
+	csv     *csv.Writer
+	json    bool
+	append  bool
+	header  []string
+	targets []string
+	line    int
+	errors  bool
+}
+
+type csvResult struct {
+	Fields map[string]string `json:"fields"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func newCSVSink(opts *options, header []string, w *bufio.Writer) (*csvSink, error) {
+	s := &csvSink{
+		w:      w,
+		csv:    csv.NewWriter(w),
+		json:   opts.json,
+		append: opts.appendCols,
+		header: header,
+		line:   1,
+	}
+
+	if header == nil {
+		return s, nil
+	}
+
+	out := header
+	if s.append {
+		out = append([]string{}, header...)
+		for _, c := range strings.Split(opts.columns, ",") {
+			out = append(out, s.columnName(c)+"_hash")
+		}
+	}
+
+	if !s.json {
+		if err := s.csv.Write(out); err != nil {
+			return nil, fmt.Errorf("write CSV header: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *csvSink) write(r *row) error {
+	s.line++
+
+	fields := append([]string{}, r.fields...)
+	if s.append {
+		fields = append(fields, r.hashes...)
+	} else {
+		for i, t := range r.targets {
+			fields[t] = r.hashes[i]
+		}
+	}
+
+	errs := map[string]string{}
+	for i, t := range r.targets {
+		if r.errs[i] != "" {
+			errs[s.name(t)] = r.errs[i]
+		}
+	}
+	if len(errs) > 0 {
+		s.errors = true
+	}
+
+	if s.json {
+		res := csvResult{Fields: make(map[string]string, len(fields))}
+		for i, f := range fields {
+			res.Fields[s.outputName(i, r)] = f
+		}
+		if len(errs) > 0 {
+			res.Errors = errs
+		}
+		return writeJSON(s.w, res)
+	}
+
+	for col, e := range errs {
+		fmt.Fprintf(os.Stderr, "line %d, column %s: %s\n", s.line, col, e)
+	}
+
+	return s.csv.Write(fields)
+}
+
+func (s *csvSink) flush() error {
+	s.csv.Flush()
+	if err := s.csv.Error(); err != nil {
+		return err
+	}
+
+	return s.w.Flush()
+}
+
+func (s *csvSink) failed() bool { return s.errors }
+
+// name returns input column name by index.
+func (s *csvSink) name(i int) string {
+	if i < len(s.header) {
+		return s.header[i]
+	}
+
+	return strconv.Itoa(i + 1)
+}
+
+// outputName returns output column name by index, appended hash columns are
+// named after their source columns.
+func (s *csvSink) outputName(i int, r *row) string {
+	if i < len(r.fields) {
+		return s.name(i)
+	}
+
+	return s.name(r.targets[i-len(r.fields)]) + "_hash"
+}
+
+func (s *csvSink) columnName(c string) string {
+	c = strings.TrimSpace(c)
+	if n, err := strconv.Atoi(c); err == nil {
+		return s.name(n - 1)
+	}
+
+	return c
+}
+
+func writeJSON(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewEncoder(t *testing.T) {
+	tests := []struct {
+		name      string
+		hash      string
+		expect    string
+		expectErr bool
+	}{
+		{"", "fbff", "fbff", false},
+		{"hex", "fbff", "fbff", false},
+		{"base64", "fbff", "+/8=", false},
+		{"base64url", "fbff", "-_8", false},
+		{"base64", "not-hex", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, err := newEncoder(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := enc(tt.hash)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+
+	if _, err := newEncoder("base32"); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
+
+func TestSink(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         options
+		header       []string
+		rows         []*row
+		expect       string
+		expectFailed bool
+	}{
+		{
+			name:   "text",
+			rows:   []*row{{fields: []string{"foo"}, targets: []int{0}, hashes: []string{"h1"}, errs: []string{""}}},
+			expect: "h1\n",
+		},
+		{
+			name:         "text JSON with error",
+			opts:         options{json: true},
+			rows:         []*row{{fields: []string{""}, targets: []int{0}, hashes: []string{""}, errs: []string{"empty input"}}},
+			expect:       `{"input":"","error":"empty input"}` + "\n",
+			expectFailed: true,
+		},
+		{
+			name: "verify",
+			opts: options{verify: true},
+			rows: []*row{
+				{fields: []string{"foo"}, targets: []int{0}, expected: "H1", hashes: []string{"h1"}, errs: []string{""}},
+				{fields: []string{"bar"}, targets: []int{0}, expected: "h1", hashes: []string{"h2"}, errs: []string{""}},
+			},
+			expect:       "foo: OK\nbar: FAILED\n",
+			expectFailed: true,
+		},
+		{
+			name:   "verify CSV input column",
+			opts:   options{verify: true, json: true},
+			rows:   []*row{{fields: []string{"h1", "foo@example.com"}, targets: []int{1}, expected: "h1", hashes: []string{"h1"}, errs: []string{""}}},
+			expect: `{"input":"foo@example.com","expected":"h1","hash":"h1","ok":true}` + "\n",
+		},
+		{
+			name:   "CSV replace",
+			opts:   options{csv: true, columns: "email"},
+			header: []string{"id", "email"},
+			rows:   []*row{{fields: []string{"1", "foo@example.com"}, targets: []int{1}, hashes: []string{"h1"}, errs: []string{""}}},
+			expect: "id,email\n1,h1\n",
+		},
+		{
+			name:   "CSV append",
+			opts:   options{csv: true, columns: "2", appendCols: true},
+			header: []string{"id", "email"},
+			rows:   []*row{{fields: []string{"1", "foo@example.com"}, targets: []int{1}, hashes: []string{"h1"}, errs: []string{""}}},
+			expect: "id,email,email_hash\n1,foo@example.com,h1\n",
+		},
+		{
+			name:         "CSV JSON append with error",
+			opts:         options{csv: true, json: true, columns: "email", appendCols: true},
+			header:       []string{"id", "email"},
+			rows:         []*row{{fields: []string{"1", ""}, targets: []int{1}, hashes: []string{""}, errs: []string{"empty input"}}},
+			expect:       `{"fields":{"email":"","email_hash":"","id":"1"},"errors":{"email":"empty input"}}` + "\n",
+			expectFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			s, err := newSink(&tt.opts, tt.header, &out)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, r := range tt.rows {
+				if err := s.write(r); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := s.flush(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != tt.expect {
+				t.Errorf("expected output %q, got %q", tt.expect, out.String())
+			}
+			if s.failed() != tt.expectFailed {
+				t.Errorf("expected failed %v, got %v", tt.expectFailed, s.failed())
+			}
+		})
+	}
+}
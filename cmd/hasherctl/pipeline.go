@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/pkg/hasherclient"
+)
+
+// batch represents rows hashed by a single request. done is closed once rows
+// are filled with results.
+type batch struct {
+	rows []*row
+	err  error
+	done chan struct{}
+}
+
+// pipeline hashes rows in batches with bounded concurrency and passes them to
+// sink in input order.
+type pipeline struct {
+	cli  *hasherclient.Client
+	opts *options
+	enc  encoder
+	alg  hasherclient.Algorithm
+	norm hasherclient.Normalization
+}
+
+func newPipeline(cli *hasherclient.Client, opts *options, enc encoder) (*pipeline, error) {
+	alg, err := hasherclient.ParseAlgorithm(opts.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	norm, err := hasherclient.ParseNormalization(opts.normalization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pipeline{
+		cli:  cli,
+		opts: opts,
+		enc:  enc,
+		alg:  alg,
+		norm: norm,
+	}, nil
+}
+
+func (p *pipeline) run(ctx context.Context, src source, sink sink) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// pending keeps batches in input order and bounds number of batches in
+	// flight, jobs feeds workers.
+	pending := make(chan *batch, p.opts.concurrency)
+	jobs := make(chan *batch)
+
+	var readErr error
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		readErr = p.read(ctx, src, pending, jobs)
+	}()
+
+	var wg sync.WaitGroup
+	for range p.opts.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				b.err = p.hash(ctx, b.rows)
+				close(b.done)
+			}
+		}()
+	}
+
+	var writeErr error
+	for b := range pending {
+		<-b.done
+		if writeErr != nil {
+			continue
+		}
+
+		if b.err != nil {
+			writeErr = b.err
+			cancel()
+			continue
+		}
+
+		for _, r := range b.rows {
+			if err := sink.write(r); err != nil {
+				writeErr = fmt.Errorf("write output: %w", err)
+				cancel()
+				break
+			}
+		}
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return readErr
+}
+
+func (p *pipeline) read(ctx context.Context, src source, pending, jobs chan<- *batch) error {
+	for {
+		rows, err := p.readBatch(src)
+		if len(rows) > 0 {
+			b := &batch{rows: rows, done: make(chan struct{})}
+			select {
+			case pending <- b:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			select {
+			case jobs <- b:
+			case <-ctx.Done():
+				b.err = ctx.Err()
+				close(b.done)
+				return ctx.Err()
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readBatch reads rows until batch holds batchSize inputs.
+func (p *pipeline) readBatch(src source) ([]*row, error) {
+	var (
+		rows   []*row
+		inputs int
+	)
+	for inputs < p.opts.batchSize {
+		r, err := src.next()
+		if err != nil {
+			return rows, err
+		}
+
+		rows = append(rows, r)
+		inputs += len(r.targets)
+	}
+
+	return rows, nil
+}
+
+func (p *pipeline) hash(ctx context.Context, rows []*row) error {
+	var reqs []hasherclient.Request
+	for _, r := range rows {
+		r.hashes = make([]string, len(r.targets))
+		r.errs = make([]string, len(r.targets))
+		for _, t := range r.targets {
+			if r.fields[t] == "" {
+				continue
+			}
+			reqs = append(reqs, hasherclient.Request{
+				Input:         r.fields[t],
+				Algorithm:     p.alg,
+				Normalization: p.norm,
+			})
+		}
+	}
+
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	results, err := p.cli.HashBatch(ctx, reqs)
+	if err != nil {
+		return fmt.Errorf("hash batch: %w", err)
+	}
+
+	i := 0
+	for _, r := range rows {
+		for j, t := range r.targets {
+			if r.fields[t] == "" {
+				continue
+			}
+
+			res := results[i]
+			i++
+
+			if res.Err != nil {
+				r.errs[j] = res.Err.Error()
+				continue
+			}
+
+			encoded, err := p.enc(res.Hash)
+			if err != nil {
+				r.errs[j] = err.Error()
+				continue
+			}
+			r.hashes[j] = encoded
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineSize is a maximum size of a single stdin line.
+const maxLineSize = 1 << 20
+
+// row represents a single input record. Fields at targets indexes are hashed,
+// hashes and errors are filled per target.
+type row struct {
+	fields   []string
+	targets  []int
+	expected string
+	hashes   []string
+	errs     []string
+}
+
+type source interface {
+	// header returns CSV header, nil for non-CSV sources.
+	header() []string
+
+	// next returns next row or io.EOF.
+	next() (*row, error)
+}
+
+func newSource(opts *options, args []string, in io.Reader) (source, error) {
+	switch {
+	case opts.csv:
+		if len(args) > 0 {
+			return nil, errors.New("arguments can't be used with -csv")
+		}
+		return newCSVSource(opts, in)
+	case len(args) > 0:
+		return newArgsSource(opts.verify, args)
+	default:
+		sc := bufio.NewScanner(in)
+		sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		return &lineSource{sc: sc, verify: opts.verify}, nil
+	}
+}
+
+type argsSource struct {
+	rows []*row
+}
+
+func newArgsSource(verify bool, args []string) (*argsSource, error) {
+	if verify {
+		if len(args) != 2 {
+			return nil, errors.New("verify mode expects hash and input arguments")
+		}
+		return &argsSource{rows: []*row{{fields: []string{args[1]}, targets: []int{0}, expected: args[0]}}}, nil
+	}
+
+	rows := make([]*row, 0, len(args))
+	for _, a := range args {
+		rows = append(rows, &row{fields: []string{a}, targets: []int{0}})
+	}
+
+	return &argsSource{rows: rows}, nil
+}
+
+func (*argsSource) header() []string { return nil }
+
+func (s *argsSource) next() (*row, error) {
+	if len(s.rows) == 0 {
+		return nil, io.EOF
+	}
+
+	r := s.rows[0]
+	s.rows = s.rows[1:]
+
+	return r, nil
+}
+
+// lineSource reads an input per line. In verify mode every line is a hash
+// followed by whitespace and input, like checksum tools print.
+type lineSource struct {
+	sc     *bufio.Scanner
+	verify bool
+}
+
+func (*lineSource) header() []string { return nil }
+
+func (s *lineSource) next() (*row, error) {
+	if !s.sc.Scan() {
+		if err := s.sc.Err(); err != nil {
+			return nil, fmt.Errorf("read line: %w", err)
+		}
+		return nil, io.EOF
+	}
+
+	line := s.sc.Text()
+	if !s.verify {
+		return &row{fields: []string{line}, targets: []int{0}}, nil
+	}
+
+	expected, input, ok := strings.Cut(line, " ")
+	if !ok {
+		expected, input, _ = strings.Cut(line, "\t")
+	}
+
+	return &row{
+		fields:   []string{strings.TrimLeft(input, " \t")},
+		targets:  []int{0},
+		expected: expected,
+	}, nil
+}
+
+type csvSource struct {
+	r        *csv.Reader
+	hdr      []string
+	targets  []int
+	expected int
+}
+
+func newCSVSource(opts *options, in io.Reader) (*csvSource, error) {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+
+	s := &csvSource{r: r, expected: -1}
+	if !opts.noHeader {
+		hdr, err := r.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read CSV header: %w", err)
+		}
+		s.hdr = hdr
+	}
+
+	if opts.columns == "" {
+		return nil, errors.New("-columns is required with -csv")
+	}
+
+	for _, c := range strings.Split(opts.columns, ",") {
+		idx, err := s.column(c)
+		if err != nil {
+			return nil, err
+		}
+		s.targets = append(s.targets, idx)
+	}
+
+	if opts.verify {
+		if len(s.targets) != 1 || opts.expected == "" {
+			return nil, errors.New("CSV verify mode expects single -columns and -expected column")
+		}
+
+		idx, err := s.column(opts.expected)
+		if err != nil {
+			return nil, err
+		}
+		s.expected = idx
+	}
+
+	return s, nil
+}
+
+func (s *csvSource) header() []string { return s.hdr }
+
+func (s *csvSource) next() (*row, error) {
+	fields, err := s.r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV: %w", err)
+	}
+
+	r := &row{fields: fields, targets: s.targets}
+	for _, t := range s.targets {
+		if t >= len(fields) {
+			return nil, fmt.Errorf("CSV line %d has no column %d", s.line(), t+1)
+		}
+	}
+
+	if s.expected >= 0 {
+		if s.expected >= len(fields) {
+			return nil, fmt.Errorf("CSV line %d has no column %d", s.line(), s.expected+1)
+		}
+		r.expected = fields[s.expected]
+	}
+
+	return r, nil
+}
+
+func (s *csvSource) line() int {
+	line, _ := s.r.FieldPos(0)
+	return line
+}
+
+// column resolves column by header name or 1-based index.
+func (s *csvSource) column(name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if n, err := strconv.Atoi(name); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("invalid column index %d", n)
+		}
+		return n - 1, nil
+	}
+
+	for i, h := range s.hdr {
+		if h == name {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("column %q not found in header", name)
+}
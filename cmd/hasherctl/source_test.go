@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readRows(t *testing.T, src source) []*row {
+	t.Helper()
+
+	var rows []*row
+	for {
+		r, err := src.next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rows = append(rows, r)
+	}
+}
+
+func TestNewSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         options
+		args         []string
+		in           string
+		expectHeader []string
+		expectRows   []*row
+		expectErr    bool
+	}{
+		{
+			name:       "arguments",
+			args:       []string{"foo", "bar"},
+			expectRows: []*row{{fields: []string{"foo"}, targets: []int{0}}, {fields: []string{"bar"}, targets: []int{0}}},
+		},
+		{
+			name:       "verify arguments",
+			opts:       options{verify: true},
+			args:       []string{"abc", "foo"},
+			expectRows: []*row{{fields: []string{"foo"}, targets: []int{0}, expected: "abc"}},
+		},
+		{
+			name:      "verify arguments count",
+			opts:      options{verify: true},
+			args:      []string{"abc"},
+			expectErr: true,
+		},
+		{
+			name:       "lines",
+			in:         "foo\n\nbar baz\n",
+			expectRows: []*row{{fields: []string{"foo"}, targets: []int{0}}, {fields: []string{""}, targets: []int{0}}, {fields: []string{"bar baz"}, targets: []int{0}}},
+		},
+		{
+			name: "verify lines",
+			opts: options{verify: true},
+			in:   "abc  foo bar\ndef\tbaz\n",
+			expectRows: []*row{
+				{fields: []string{"foo bar"}, targets: []int{0}, expected: "abc"},
+				{fields: []string{"baz"}, targets: []int{0}, expected: "def"},
+			},
+		},
+		{
+			name:         "CSV columns by name and index",
+			opts:         options{csv: true, columns: "email, 3"},
+			in:           "id,email,phone\n1,foo@example.com,555\n",
+			expectHeader: []string{"id", "email", "phone"},
+			expectRows:   []*row{{fields: []string{"1", "foo@example.com", "555"}, targets: []int{1, 2}}},
+		},
+		{
+			name:       "CSV without header",
+			opts:       options{csv: true, noHeader: true, columns: "2"},
+			in:         "1,foo@example.com\n",
+			expectRows: []*row{{fields: []string{"1", "foo@example.com"}, targets: []int{1}}},
+		},
+		{
+			name:         "CSV verify",
+			opts:         options{csv: true, verify: true, columns: "email", expected: "hash"},
+			in:           "hash,email\nabc,foo@example.com\n",
+			expectHeader: []string{"hash", "email"},
+			expectRows:   []*row{{fields: []string{"abc", "foo@example.com"}, targets: []int{1}, expected: "abc"}},
+		},
+		{
+			name:      "CSV arguments",
+			opts:      options{csv: true, columns: "1"},
+			args:      []string{"foo"},
+			expectErr: true,
+		},
+		{
+			name:      "CSV without columns",
+			opts:      options{csv: true},
+			in:        "email\nfoo@example.com\n",
+			expectErr: true,
+		},
+		{
+			name:      "CSV unknown column",
+			opts:      options{csv: true, columns: "phone"},
+			in:        "email\nfoo@example.com\n",
+			expectErr: true,
+		},
+		{
+			name:      "CSV zero column index",
+			opts:      options{csv: true, noHeader: true, columns: "0"},
+			in:        "foo@example.com\n",
+			expectErr: true,
+		},
+		{
+			name:      "CSV verify without expected column",
+			opts:      options{csv: true, verify: true, columns: "email"},
+			in:        "email\nfoo@example.com\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := newSource(&tt.opts, tt.args, strings.NewReader(tt.in))
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if hdr := src.header(); !reflect.DeepEqual(hdr, tt.expectHeader) {
+				t.Errorf("expected header %v, got %v", tt.expectHeader, hdr)
+			}
+			if rows := readRows(t, src); !reflect.DeepEqual(rows, tt.expectRows) {
+				t.Errorf("expected rows %+v, got %+v", tt.expectRows, rows)
+			}
+		})
+	}
+}
+
+func TestCSVSource_MissingColumn(t *testing.T) {
+	src, err := newSource(&options{csv: true, columns: "phone"}, nil, strings.NewReader("email,phone\nfoo@example.com\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := src.next(); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected missing column error at line 2, got %v", err)
+	}
+}
@@ -2,30 +2,72 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 
 	"github.com/redis/go-redis/v9"
 	grpcapp "github.com/tmybsv/leadgen-test-task/internal/app/grpc"
 	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/experiment"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
 	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	memoryinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/cache/memory"
 	redisinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/cache/redis"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/canonical"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/certs"
 	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/config"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/deriver"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/fileformat"
+	fpeinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/fpe"
 	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/limiter"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/secrets"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/signer"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/sketch"
+	diskinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/storage/disk"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/tokenizer"
+	grpcsrv "github.com/tmybsv/leadgen-test-task/internal/presentation/grpc"
 )
 
-// App represents main application with gRPC server and Redis client.
+// App represents main application with gRPC server, job workers and Redis
+// client.
 type App struct {
-	GRPCServer *grpcapp.App
-	redisCli   *redis.Client
-	log        *slog.Logger
+	GRPCServer  *grpcapp.App
+	jobSvc      *application.JobService
+	translogSvc *application.TransparencyLogService
+	breachStore *diskinfra.BreachStore
+	redisCli    *redis.Client
+	log         *slog.Logger
 }
 
 // New creates new app instance with given configuration and logger.
 //
-// Initializes Redis client, hashes repository, hash service with MD5 and SHA256
-// algorithms support and then creates gRPC server.
-func New(cfg *config.Config, log *slog.Logger) *App {
+// Initializes Redis client, hashes and tenant usage repositories, tenants,
+// server peppers, transparency log and hash service with MD5 and SHA256
+// algorithms support. Initializes leads deduplication, TLS certificates,
+// tokenization, format-preserving encryption, key derivation, receipts,
+// similarity search, membership filters, unique counters, experiments,
+// placement, breach corpus and rate limiter if enabled. Then creates gRPC
+// server and starts job workers, which resume unfinished jobs.
+func New(cfg *config.Config, log *slog.Logger) (*App, error) {
+	tlsCfg, err := newTLSConfig(cfg.GRPC.TLS, log)
+	if err != nil {
+		return nil, fmt.Errorf("new TLS config: %w", err)
+	}
+
 	redisCli := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
 		Password: cfg.Redis.Password,
@@ -33,29 +75,604 @@ func New(cfg *config.Config, log *slog.Logger) *App {
 	})
 
 	hashRepo := redisinfra.NewHashRepository(redisCli, cfg.Redis.TTL)
+	usageRepo := redisinfra.NewUsageRepository(redisCli)
 
-	hashers := map[hash.Algorithm]hash.Hasher{
-		hash.AlgorithmMD5:    &hasher.MD5{},
-		hash.AlgorithmSHA256: &hasher.SHA256{},
+	tenants, err := newTenantRegistry(cfg.Tenants)
+	if err != nil {
+		return nil, fmt.Errorf("new tenant registry: %w", err)
 	}
 
-	hashSvc := application.NewHashService(hashRepo, hashers)
+	peppers, err := newPeppers(cfg.Pepper)
+	if err != nil {
+		return nil, fmt.Errorf("new peppers: %w", err)
+	}
 
-	grpcApp := grpcapp.New(cfg.GRPC.Port, hashSvc, log)
+	translogSvc, err := newTransLogService(cfg.TransLog, tenants, log)
+	if err != nil {
+		return nil, fmt.Errorf("new transparency log service: %w", err)
+	}
 
-	return &App{
-		GRPCServer: grpcApp,
-		redisCli:   redisCli,
-		log:        log,
+	var hashLog application.HashLog
+	if translogSvc != nil {
+		if err := translogSvc.Start(context.Background()); err != nil {
+			return nil, fmt.Errorf("start transparency log service: %w", err)
+		}
+		hashLog = translogSvc
+	}
+
+	hashSvc := application.NewHashService(hashRepo, usageRepo, tenants, peppers, hasher.All(), &canonical.JCS{}, hashLog)
+
+	recipes, err := newRecipes(cfg.Recipes)
+	if err != nil {
+		return nil, fmt.Errorf("new recipes: %w", err)
+	}
+
+	jobRepo, err := newJobRepository(cfg.Jobs, redisCli)
+	if err != nil {
+		return nil, fmt.Errorf("new job repository: %w", err)
+	}
+
+	jobSvc := application.NewJobService(jobRepo, hashSvc, application.JobOptions{
+		Workers:     cfg.Jobs.Workers,
+		Concurrency: cfg.Jobs.Concurrency,
+		ChunkSize:   cfg.Jobs.ChunkSize,
+		MaxRows:     cfg.Jobs.MaxRows,
+		LeaseTTL:    cfg.Jobs.LeaseTTL,
+	}, log)
+
+	tokenSvc, err := newTokenService(cfg.Vault, redisCli)
+	if err != nil {
+		return nil, fmt.Errorf("new token service: %w", err)
+	}
+
+	fpeSvc, err := newFPEService(cfg.FPE)
+	if err != nil {
+		return nil, fmt.Errorf("new fpe service: %w", err)
+	}
+
+	kdfSvc, err := newKDFService(cfg.KDF, tenants)
+	if err != nil {
+		return nil, fmt.Errorf("new kdf service: %w", err)
+	}
+
+	receiptSvc, err := newReceiptService(cfg.Receipts)
+	if err != nil {
+		return nil, fmt.Errorf("new receipt service: %w", err)
+	}
+
+	similaritySvc, err := newSimilarityService(cfg.Similarity.Indexes, redisCli, tenants)
+	if err != nil {
+		return nil, fmt.Errorf("new similarity service: %w", err)
 	}
+
+	filterRepo, err := newFilterRepository(cfg.Filters, redisCli)
+	if err != nil {
+		return nil, fmt.Errorf("new filter repository: %w", err)
+	}
+
+	leadRepo, err := newLeadRepository(cfg.Dedup, redisCli)
+	if err != nil {
+		return nil, fmt.Errorf("new lead repository: %w", err)
+	}
+
+	counterRepo, err := newCounterRepository(cfg.Counters, redisCli)
+	if err != nil {
+		return nil, fmt.Errorf("new counter repository: %w", err)
+	}
+
+	experimentSvc, err := newExperimentService(cfg.Experiments)
+	if err != nil {
+		return nil, fmt.Errorf("new experiment service: %w", err)
+	}
+
+	var (
+		breachStore *diskinfra.BreachStore
+		breachSvc   *application.BreachService
+	)
+	if cfg.Breach.Dir != "" {
+		if breachStore, err = diskinfra.OpenBreachStore(cfg.Breach.Dir); err != nil {
+			return nil, fmt.Errorf("open breach store: %w", err)
+		}
+		breachSvc = application.NewBreachService(breachStore)
+	}
+
+	grpcOpts := grpcapp.Options{
+		TLS:           tlsCfg,
+		Authenticator: newAuthenticator(cfg),
+	}
+	if cfg.RateLimit.Enabled {
+		grpcOpts.RateLimitPolicy = newRateLimitPolicy(cfg.RateLimit)
+		grpcOpts.RateLimiter = limiter.NewTokenBucket()
+		if cfg.RateLimit.Distributed {
+			grpcOpts.RateLimiter = redisinfra.NewRateLimiter(redisCli)
+		}
+	}
+
+	grpcApp := grpcapp.New(cfg.GRPC.Port, grpcOpts, grpcsrv.Services{
+		Hash:       hashSvc,
+		File:       application.NewFileService(hashSvc, fileformat.All(), 0),
+		Record:     application.NewRecordService(hashSvc, recipes),
+		Job:        jobSvc,
+		Token:      tokenSvc,
+		FPE:        fpeSvc,
+		KDF:        kdfSvc,
+		Merkle:     application.NewMerkleService(hasher.All()),
+		TransLog:   translogSvc,
+		Receipt:    receiptSvc,
+		Dedup:      application.NewDedupService(hashSvc, leadRepo),
+		Similarity: similaritySvc,
+		Filter:     application.NewFilterService(hashSvc, filterRepo),
+		Counter:    application.NewCounterService(counterRepo, tenants, cfg.Counters.Bucket),
+		Experiment: experimentSvc,
+		Placement:  application.NewPlacementService(redisinfra.NewNodeSetRepository(redisCli), tenants),
+		Breach:     breachSvc,
+	}, log)
+
+	if err := jobSvc.Start(context.Background()); err != nil {
+		return nil, fmt.Errorf("start job service: %w", err)
+	}
+
+	return &App{
+		GRPCServer:  grpcApp,
+		jobSvc:      jobSvc,
+		translogSvc: translogSvc,
+		breachStore: breachStore,
+		redisCli:    redisCli,
+		log:         log,
+	}, nil
 }
 
-// Stop stops a gRPC server gracefully and closes connection with Redis.
+// Stop stops a gRPC server gracefully, stops job workers, signs the final
+// transparency log tree head, closes breach corpus and connection with Redis.
 func (a *App) Stop() error {
 	a.GRPCServer.Stop()
+	a.jobSvc.Stop()
+	if a.translogSvc != nil {
+		a.translogSvc.Stop()
+	}
+	if a.breachStore != nil {
+		if err := a.breachStore.Close(); err != nil {
+			a.log.Error("failed to close breach store", slog.String("error", err.Error()))
+		}
+	}
 	if err := a.redisCli.Close(); err != nil {
 		return fmt.Errorf("close redis connecion: %w", err)
 	}
 
 	return nil
 }
+
+func newTLSConfig(cfg config.TLS, log *slog.Logger) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	minVersion, err := certs.ParseVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parse min version: %w", err)
+	}
+
+	reloader, err := certs.NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile, cfg.ReloadInterval, log)
+	if err != nil {
+		return nil, fmt.Errorf("new certificates reloader: %w", err)
+	}
+
+	return reloader.TLSConfig(minVersion, cfg.RequireClientCert), nil
+}
+
+func newPeppers(cfg config.Pepper) (*hash.Peppers, error) {
+	if cfg.File == "" {
+		return nil, nil
+	}
+
+	return secrets.LoadPeppers(cfg.File, cfg.Version)
+}
+
+func newTokenService(cfg config.Vault, redisCli *redis.Client) (*application.TokenService, error) {
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+
+	cipher, err := tokenizer.LoadKeyfile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load keyfile: %w", err)
+	}
+
+	detokenizers, err := identity.ParseAllowlist(cfg.Detokenizers)
+	if err != nil {
+		return nil, fmt.Errorf("parse detokenizers: %w", err)
+	}
+
+	return application.NewTokenService(redisinfra.NewTokenRepository(redisCli), cipher, detokenizers), nil
+}
+
+func newKDFService(cfg config.KDF, tenants *tenant.Registry) (*application.KDFService, error) {
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+
+	d, err := deriver.LoadKeyfile(cfg.KeyFile, hasher.Functions())
+	if err != nil {
+		return nil, fmt.Errorf("load keyfile: %w", err)
+	}
+
+	derivers, err := identity.ParseAllowlist(cfg.Derivers)
+	if err != nil {
+		return nil, fmt.Errorf("parse derivers: %w", err)
+	}
+
+	return application.NewKDFService(d, derivers, tenants), nil
+}
+
+func newTransLogService(cfg config.TransLog, tenants *tenant.Registry, log *slog.Logger) (*application.TransparencyLogService, error) {
+	if cfg.Dir == "" {
+		return nil, nil
+	}
+
+	headSigner, err := signer.LoadEd25519(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load signing key: %w", err)
+	}
+
+	logRepo, err := diskinfra.NewLogRepository(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("new log repository: %w", err)
+	}
+
+	return application.NewTransparencyLogService(logRepo, headSigner, &hasher.SHA256{}, tenants, application.TransparencyLogOptions{
+		Tenants:      cfg.Tenants,
+		SignInterval: cfg.SignInterval,
+	}, log), nil
+}
+
+func newReceiptService(cfg config.Receipts) (*application.ReceiptService, error) {
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+
+	receiptSigner, err := signer.LoadEd25519(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load signing key: %w", err)
+	}
+
+	keys := []receipt.Key{{ID: receiptSigner.KeyID(), PublicKey: receiptSigner.PublicKey()}}
+	for _, file := range cfg.PreviousKeys {
+		pub, err := signer.LoadEd25519PublicKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("load previous key %q: %w", file, err)
+		}
+		keys = append(keys, receipt.Key{ID: signer.Thumbprint(pub), PublicKey: pub})
+	}
+
+	return application.NewReceiptService(receiptSigner, keys), nil
+}
+
+func newRecipes(cfgs []config.Recipe) (*record.Recipes, error) {
+	recipes := make([]*record.Recipe, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		r, err := newRecipe(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("new %q recipe: %w", cfg.Name, err)
+		}
+		recipes = append(recipes, r)
+	}
+
+	return record.NewRecipes(recipes...)
+}
+
+func newRecipe(cfg config.Recipe) (*record.Recipe, error) {
+	encoding := record.EncodingLengthPrefix
+	if cfg.Encoding != "" {
+		var err error
+		if encoding, err = record.ParseEncoding(cfg.Encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	var alg hash.Algorithm
+	if cfg.Algorithm != "" {
+		var err error
+		if alg, err = hash.ParseAlgorithm(cfg.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]record.RecipeField, len(cfg.Fields))
+	for i, f := range cfg.Fields {
+		norm, err := hash.ParseNormalization(f.Normalization)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+
+		fields[i] = record.RecipeField{
+			Name:          f.Name,
+			Normalization: norm,
+			Optional:      f.Optional,
+		}
+	}
+
+	return record.NewRecipe(cfg.Name, fields, encoding, cfg.Separator, alg)
+}
+
+func newFPEService(cfg config.FPE) (*application.FPEService, error) {
+	if len(cfg.Schemes) == 0 {
+		return nil, nil
+	}
+
+	schemes := make([]*fpe.Scheme, 0, len(cfg.Schemes))
+	for _, sc := range cfg.Schemes {
+		s, err := newFPEScheme(sc)
+		if err != nil {
+			return nil, fmt.Errorf("new %q scheme: %w", sc.Name, err)
+		}
+		schemes = append(schemes, s)
+	}
+
+	decrypters, err := identity.ParseAllowlist(cfg.Decrypters)
+	if err != nil {
+		return nil, fmt.Errorf("parse decrypters: %w", err)
+	}
+
+	return application.NewFPEService(decrypters, schemes...)
+}
+
+func newFPEScheme(cfg config.FPEScheme) (*fpe.Scheme, error) {
+	mode, err := fpe.ParseMode(cfg.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	radix := cfg.Radix
+	if radix == 0 {
+		radix = 10
+	}
+
+	alphabet, err := fpe.RadixAlphabet(radix)
+	if cfg.Alphabet != "" {
+		alphabet, err = fpe.NewAlphabet(cfg.Alphabet)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("new alphabet: %w", err)
+	}
+
+	key, err := fpeinfra.LoadKey(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tweak, err := hex.DecodeString(cfg.Tweak)
+	if err != nil {
+		return nil, fmt.Errorf("decode tweak: %w", err)
+	}
+
+	var cipher fpe.Cipher
+	switch mode {
+	case fpe.ModeFF1:
+		cipher, err = fpeinfra.NewFF1(key, alphabet.Radix())
+	case fpe.ModeFF31:
+		cipher, err = fpeinfra.NewFF31(key, alphabet.Radix())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("new %v cipher: %w", mode, err)
+	}
+
+	return fpe.NewScheme(cfg.Name, mode, alphabet, cipher, tweak)
+}
+
+func newSimilarityService(cfgs []config.SimilarityIndex, redisCli *redis.Client, tenants *tenant.Registry) (*application.SimilarityService, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	indexes := make([]*similarity.Index, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		idx, err := newSimilarityIndex(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("new %q index: %w", cfg.Name, err)
+		}
+		indexes = append(indexes, idx)
+	}
+
+	return application.NewSimilarityService(redisinfra.NewSignatureRepository(redisCli), tenants, indexes...)
+}
+
+func newExperimentService(cfgs []config.Experiment) (*application.ExperimentService, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	exps := make([]*experiment.Experiment, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		e, err := newExperiment(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("new %q experiment: %w", cfg.Name, err)
+		}
+		exps = append(exps, e)
+	}
+
+	experiments, err := experiment.NewExperiments(exps...)
+	if err != nil {
+		return nil, err
+	}
+
+	return application.NewExperimentService(experiments, hasher.All())
+}
+
+func newExperiment(cfg config.Experiment) (*experiment.Experiment, error) {
+	alg := hash.AlgorithmSHA256
+	if cfg.Algorithm != "" {
+		var err error
+		if alg, err = hash.ParseAlgorithm(cfg.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	traffic := cfg.Traffic
+	if traffic == 0 {
+		traffic = 1
+	}
+
+	variants := make([]experiment.Variant, len(cfg.Variants))
+	for i, v := range cfg.Variants {
+		variants[i] = experiment.Variant{Name: v.Name, Weight: v.Weight}
+	}
+
+	return experiment.NewExperiment(cfg.Name, cfg.Salt, alg, variants, experiment.Allocation{
+		Layer:   cfg.Layer,
+		Offset:  cfg.Offset,
+		Traffic: traffic,
+		Holdout: cfg.Holdout,
+	})
+}
+
+func newSimilarityIndex(cfg config.SimilarityIndex) (*similarity.Index, error) {
+	alg, err := similarity.ParseAlgorithm(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	bands := cfg.Bands
+	if bands == 0 {
+		bands = 32
+		if alg == similarity.AlgorithmSimHash {
+			bands = 4
+		}
+	}
+
+	var sketcher similarity.Sketcher
+	switch alg {
+	case similarity.AlgorithmMinHash:
+		permutations := cfg.Permutations
+		if permutations == 0 {
+			permutations = 128
+		}
+		sketcher, err = sketch.NewMinHash(permutations, bands)
+	case similarity.AlgorithmSimHash:
+		sketcher, err = sketch.NewSimHash(bands)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("new %v sketcher: %w", alg, err)
+	}
+
+	shingle := cfg.Shingle
+	if shingle == 0 {
+		shingle = 3
+	}
+	if cfg.Words {
+		shingle = 0
+	}
+
+	return similarity.NewIndex(cfg.Name, alg, sketcher, shingle)
+}
+
+func newLeadRepository(cfg config.Dedup, redisCli *redis.Client) (dedup.Repository, error) {
+	switch cfg.Store {
+	case config.LeadStoreRedis:
+		return redisinfra.NewLeadRepository(redisCli, cfg.Retention), nil
+	case config.LeadStoreMemory:
+		return memoryinfra.NewLeadRepository(cfg.Retention), nil
+	default:
+		return nil, fmt.Errorf("unsupported lead store %q", cfg.Store)
+	}
+}
+
+func newCounterRepository(cfg config.Counters, redisCli *redis.Client) (cardinality.Repository, error) {
+	if cfg.Bucket <= 0 {
+		return nil, cardinality.ErrInvalidBucket
+	}
+
+	switch cfg.Store {
+	case config.CounterStoreRedis:
+		return redisinfra.NewCounterRepository(redisCli, cfg.Retention), nil
+	case config.CounterStoreMemory:
+		return memoryinfra.NewCounterRepository(cfg.Retention), nil
+	default:
+		return nil, fmt.Errorf("unsupported counter store %q", cfg.Store)
+	}
+}
+
+func newFilterRepository(cfg config.Filters, redisCli *redis.Client) (filter.Repository, error) {
+	switch cfg.Store {
+	case config.FilterStoreRedis:
+		return redisinfra.NewFilterRepository(redisCli), nil
+	case config.FilterStoreDisk:
+		return diskinfra.NewFilterRepository(cfg.Dir)
+	default:
+		return nil, fmt.Errorf("unsupported filter store %q", cfg.Store)
+	}
+}
+
+func newJobRepository(cfg config.Jobs, redisCli *redis.Client) (job.Repository, error) {
+	switch cfg.Store {
+	case config.JobStoreRedis:
+		return redisinfra.NewJobRepository(redisCli, cfg.Retention), nil
+	case config.JobStoreDisk:
+		return diskinfra.NewJobRepository(cfg.Dir, cfg.Retention)
+	default:
+		return nil, fmt.Errorf("unsupported job store %q", cfg.Store)
+	}
+}
+
+func newAuthenticator(cfg *config.Config) *grpcsrv.Authenticator {
+	apiKeys := make(map[string]string, len(cfg.Auth.APIKeys))
+	for _, k := range cfg.Auth.APIKeys {
+		apiKeys[k.Key] = k.Client
+	}
+
+	return grpcsrv.NewAuthenticator(apiKeys)
+}
+
+func newRateLimitPolicy(cfg config.RateLimit) *ratelimit.Policy {
+	methodLimits := func(methods []config.MethodLimit) map[string]ratelimit.Limit {
+		limits := make(map[string]ratelimit.Limit, len(methods))
+		for _, m := range methods {
+			limits[m.Method] = ratelimit.Limit{Rate: m.Rate, Burst: m.Burst}
+		}
+		return limits
+	}
+
+	clients := make(map[string]ratelimit.ClientLimits, len(cfg.Clients))
+	for _, c := range cfg.Clients {
+		clients[c.Client] = ratelimit.ClientLimits{
+			Limit:   ratelimit.Limit{Rate: c.Rate, Burst: c.Burst},
+			Methods: methodLimits(c.Methods),
+		}
+	}
+
+	return ratelimit.NewPolicy(ratelimit.Limit{Rate: cfg.Rate, Burst: cfg.Burst}, methodLimits(cfg.Methods), clients)
+}
+
+func newTenantRegistry(cfgs []config.Tenant) (*tenant.Registry, error) {
+	tenants := make([]*tenant.Tenant, 0, len(cfgs))
+	for _, c := range cfgs {
+		var (
+			alg hash.Algorithm
+			err error
+		)
+		if c.Algorithm != "" {
+			if alg, err = hash.ParseAlgorithm(c.Algorithm); err != nil {
+				return nil, fmt.Errorf("tenant %q algorithm: %w", c.Name, err)
+			}
+		}
+
+		norm, err := hash.ParseNormalization(c.Normalization)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q normalization: %w", c.Name, err)
+		}
+
+		t, err := tenant.New(c.Name, c.Clients, tenant.Settings{
+			Algorithm:     alg,
+			Normalization: norm,
+			Pepper:        c.Pepper,
+			TTL:           c.TTL,
+			DailyQuota:    c.DailyQuota,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new tenant %q: %w", c.Name, err)
+		}
+
+		tenants = append(tenants, t)
+	}
+
+	return tenant.NewRegistry(tenant.Default(), tenants...)
+}
@@ -3,31 +3,48 @@ package grpcapp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
-	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
 	grpcsrv "github.com/tmybsv/leadgen-test-task/internal/presentation/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 )
 
 // App represents wrapper to bootstrap gRPC server.
 type App struct {
 	port int
+	tls  bool
 	srv  *grpc.Server
 	log  *slog.Logger
 }
 
-// New creates new instance of application with given port, hash service and
-// logger.
+// Options represents optional gRPC server features.
 //
-// Configures recovery and logging gRPC interceptors and registers server.
-func New(port int, hashSvc *application.HashService, log *slog.Logger) *App {
+// Plaintext listener is used if TLS is nil. Rate limiting is disabled if
+// RateLimiter is nil.
+type Options struct {
+	TLS             *tls.Config
+	Authenticator   *grpcsrv.Authenticator
+	RateLimiter     ratelimit.Limiter
+	RateLimitPolicy *ratelimit.Policy
+}
+
+// New creates new instance of application with given port, options,
+// application services and logger.
+//
+// Configures recovery, authentication, logging and rate limiting unary and
+// stream gRPC interceptors and registers servers.
+func New(port int, opts Options, svcs grpcsrv.Services, log *slog.Logger) *App {
 	recOpts := []recovery.Option{
 		recovery.WithRecoveryHandler(func(p any) (err error) {
 			log.Error("recovered from panic", slog.Any("panic", p))
@@ -37,17 +54,44 @@ func New(port int, hashSvc *application.HashService, log *slog.Logger) *App {
 
 	logOpts := []logging.Option{
 		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+		logging.WithFieldsFromContext(callerFields),
+	}
+
+	authn := opts.Authenticator
+	if authn == nil {
+		authn = grpcsrv.NewAuthenticator(nil)
 	}
 
-	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+	interceptors := []grpc.UnaryServerInterceptor{
 		recovery.UnaryServerInterceptor(recOpts...),
+		auth.UnaryServerInterceptor(authn.Authenticate),
 		logging.UnaryServerInterceptor(interceptorLogger(log), logOpts...),
-	))
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		recovery.StreamServerInterceptor(recOpts...),
+		auth.StreamServerInterceptor(authn.Authenticate),
+		logging.StreamServerInterceptor(interceptorLogger(log), logOpts...),
+	}
+	if opts.RateLimiter != nil {
+		interceptors = append(interceptors, grpcsrv.RateLimitUnaryInterceptor(opts.RateLimiter, opts.RateLimitPolicy, log))
+		streamInterceptors = append(streamInterceptors, grpcsrv.RateLimitStreamInterceptor(opts.RateLimiter, opts.RateLimitPolicy, log))
+	}
 
-	grpcsrv.Register(srv, hashSvc)
+	srvOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	if opts.TLS != nil {
+		srvOpts = append(srvOpts, grpc.Creds(credentials.NewTLS(opts.TLS)))
+	}
+
+	srv := grpc.NewServer(srvOpts...)
+
+	grpcsrv.Register(srv, svcs)
 
 	return &App{
 		port: port,
+		tls:  opts.TLS != nil,
 		srv:  srv,
 		log:  log,
 	}
@@ -61,7 +105,7 @@ func (a *App) Run() error {
 		return fmt.Errorf("listen TCP: %w", err)
 	}
 
-	a.log.Info("gRPC server starting", slog.Int("port", a.port))
+	a.log.Info("gRPC server starting", slog.Int("port", a.port), slog.Bool("tls", a.tls))
 	if err := a.srv.Serve(l); err != nil {
 		return fmt.Errorf("serve gRPC server: %w", err)
 	}
@@ -80,3 +124,12 @@ func interceptorLogger(log *slog.Logger) logging.Logger {
 		log.Log(ctx, slog.Level(lvl), msg, fields...)
 	})
 }
+
+func callerFields(ctx context.Context) logging.Fields {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return logging.Fields{"caller", id.Subject(), "caller.source", id.Source().String()}
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+)
+
+// BreachService serves k-anonymity range lookups of breach corpus hashes.
+// Contains implementation of corpus store.
+//
+// Callers send only hash prefix shared by about a million of hashes and
+// match returned suffixes locally, so checked hashes are never revealed.
+type BreachService struct {
+	corpus breach.Repository
+}
+
+// NewBreachService creates new instance of breach service.
+func NewBreachService(corpus breach.Repository) *BreachService {
+	return &BreachService{
+		corpus: corpus,
+	}
+}
+
+// Range returns entries of corpus hashes of given algorithm starting with
+// prefix, sorted by suffix. Padded ranges contain random suffixes with zero
+// count, so response size doesn't reveal prefix.
+func (s *BreachService) Range(ctx context.Context, alg breach.Algorithm, prefix string, pad bool) ([]breach.Entry, error) {
+	if alg.Size() == 0 {
+		return nil, breach.ErrUnsupportedAlgorithm
+	}
+
+	prefix, err := breach.ValidatePrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.corpus.Range(ctx, alg, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if pad {
+		entries = breach.Pad(alg, entries)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+)
+
+type mockBreachRepository struct {
+	rangeFunc func(ctx context.Context, alg breach.Algorithm, prefix string) ([]breach.Entry, error)
+}
+
+func (m *mockBreachRepository) Range(ctx context.Context, alg breach.Algorithm, prefix string) ([]breach.Entry, error) {
+	return m.rangeFunc(ctx, alg, prefix)
+}
+
+func TestBreachService_Range(t *testing.T) {
+	var gotPrefix string
+	svc := NewBreachService(&mockBreachRepository{
+		rangeFunc: func(_ context.Context, alg breach.Algorithm, prefix string) ([]breach.Entry, error) {
+			gotPrefix = prefix
+			if alg != breach.AlgorithmSHA1 {
+				return nil, breach.ErrNotIngested
+			}
+			return []breach.Entry{{Suffix: "1E4C9B93F3F0682250B6CF8331B7EE68FD8", Count: 10}}, nil
+		},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		alg          breach.Algorithm
+		prefix       string
+		pad          bool
+		expectMin    int
+		expectMax    int
+		expectPrefix string
+		expectErr    error
+	}{
+		{"not padded", breach.AlgorithmSHA1, "5baa6", false, 1, 1, "5BAA6", nil},
+		{"padded", breach.AlgorithmSHA1, "5BAA6", true, breach.MinPadded, breach.MaxPadded, "5BAA6", nil},
+		{"invalid prefix", breach.AlgorithmSHA1, "5BAA", false, 0, 0, "", breach.ErrInvalidPrefix},
+		{"unsupported algorithm", 0, "5BAA6", false, 0, 0, "", breach.ErrUnsupportedAlgorithm},
+		{"not ingested", breach.AlgorithmNTLM, "5BAA6", false, 0, 0, "5BAA6", breach.ErrNotIngested},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPrefix = ""
+			entries, err := svc.Range(ctx, tt.alg, tt.prefix, tt.pad)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if gotPrefix != tt.expectPrefix {
+				t.Errorf("expected store to be queried by %q, got %q", tt.expectPrefix, gotPrefix)
+			}
+			if len(entries) < tt.expectMin || len(entries) > tt.expectMax {
+				t.Errorf("expected %d to %d entries, got %d", tt.expectMin, tt.expectMax, len(entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// BucketCount represents estimated number of unique digests of a single time
+// bucket.
+type BucketCount struct {
+	Start time.Time
+	Count uint64
+}
+
+// CounterService serves unique counting of hashed inputs. Contains
+// implementation of counters repository and tenants registry.
+//
+// Digests are counted per caller tenant, counter key and time bucket of
+// bucket size, inputs and digests are not stored. Inputs hashed with
+// different algorithms, salts or peppers are counted as different ones.
+type CounterService struct {
+	counterRepo cardinality.Repository
+	tenants     *tenant.Registry
+	bucket      time.Duration
+	now         func() time.Time
+}
+
+// NewCounterService creates new instance of counter service.
+func NewCounterService(counterRepo cardinality.Repository, tenants *tenant.Registry, bucket time.Duration) *CounterService {
+	return &CounterService{
+		counterRepo: counterRepo,
+		tenants:     tenants,
+		bucket:      bucket,
+		now:         time.Now,
+	}
+}
+
+// Add adds hash digest to counter of current time bucket.
+func (s *CounterService) Add(ctx context.Context, key string, h *hash.Hash) error {
+	if err := cardinality.ValidateKey(key); err != nil {
+		return err
+	}
+
+	bucket := cardinality.Bucket(s.now(), s.bucket)
+	if err := s.counterRepo.Add(ctx, callerTenant(ctx, s.tenants).Name(), key, bucket, h.Hashed()); err != nil {
+		return fmt.Errorf("add to counter: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnique estimates number of unique digests added to counter within
+// buckets overlapping range from start to end. Returns estimate of the whole
+// range and estimates of every bucket. Zero end means now, zero start means
+// start of end bucket.
+func (s *CounterService) CountUnique(ctx context.Context, key string, start, end time.Time) (uint64, []BucketCount, error) {
+	if err := cardinality.ValidateKey(key); err != nil {
+		return 0, nil, err
+	}
+
+	buckets, err := s.buckets(start, end)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	t := callerTenant(ctx, s.tenants).Name()
+
+	total, err := s.counterRepo.Count(ctx, t, []string{key}, buckets)
+	if err != nil {
+		return 0, nil, fmt.Errorf("count unique: %w", err)
+	}
+
+	ns, err := s.counterRepo.CountBuckets(ctx, t, key, buckets)
+	if err != nil {
+		return 0, nil, fmt.Errorf("count buckets: %w", err)
+	}
+
+	counts := make([]BucketCount, len(buckets))
+	for i, b := range buckets {
+		counts[i] = BucketCount{Start: b, Count: ns[i]}
+	}
+
+	return total, counts, nil
+}
+
+// MergeCounts estimates number of unique digests added to any of counters
+// within buckets overlapping range, see CountUnique for range defaults.
+// Digests added to several counters are counted once. At most 16 keys are
+// merged at once.
+func (s *CounterService) MergeCounts(ctx context.Context, keys []string, start, end time.Time) (uint64, error) {
+	if err := cardinality.ValidateKeys(keys); err != nil {
+		return 0, err
+	}
+
+	buckets, err := s.buckets(start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := s.counterRepo.Count(ctx, callerTenant(ctx, s.tenants).Name(), keys, buckets)
+	if err != nil {
+		return 0, fmt.Errorf("count unique: %w", err)
+	}
+
+	return n, nil
+}
+
+func (s *CounterService) buckets(start, end time.Time) ([]time.Time, error) {
+	if end.IsZero() {
+		end = s.now()
+	}
+
+	if start.IsZero() {
+		start = cardinality.Bucket(end, s.bucket)
+	}
+
+	return cardinality.Buckets(start, end, s.bucket)
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// mockCounterRepository counts unique digests exactly.
+type mockCounterRepository struct {
+	mu      sync.Mutex
+	digests map[string]map[string]bool
+}
+
+func (m *mockCounterRepository) Add(_ context.Context, tenant, key string, bucket time.Time, digest string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := tenant + "/" + key + "/" + strconv.FormatInt(bucket.Unix(), 10)
+	if m.digests[k] == nil {
+		m.digests[k] = map[string]bool{}
+	}
+	m.digests[k][digest] = true
+	return nil
+}
+
+func (m *mockCounterRepository) Count(_ context.Context, tenant string, keys []string, buckets []time.Time) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	union := map[string]bool{}
+	for _, key := range keys {
+		for _, b := range buckets {
+			for d := range m.digests[tenant+"/"+key+"/"+strconv.FormatInt(b.Unix(), 10)] {
+				union[d] = true
+			}
+		}
+	}
+	return uint64(len(union)), nil
+}
+
+func (m *mockCounterRepository) CountBuckets(ctx context.Context, tenant, key string, buckets []time.Time) ([]uint64, error) {
+	counts := make([]uint64, len(buckets))
+	for i, b := range buckets {
+		n, err := m.Count(ctx, tenant, []string{key}, []time.Time{b})
+		if err != nil {
+			return nil, err
+		}
+		counts[i] = n
+	}
+	return counts, nil
+}
+
+func TestCounterService(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewCounterService(&mockCounterRepository{digests: map[string]map[string]bool{}}, mustRegistry(sales), 24*time.Hour)
+	day1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := day1.Add(12 * time.Hour)
+	svc.now = func() time.Time { return now }
+
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	add := func(ctx context.Context, key string, digests ...string) {
+		t.Helper()
+		for _, d := range digests {
+			if err := svc.Add(ctx, key, mustCreateHash("input", d, hash.AlgorithmMD5)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	add(ctx, "spring", "a", "b", "a")
+	add(ctx, "autumn", "b", "c")
+	add(context.Background(), "spring", "x")
+	now = now.Add(24 * time.Hour)
+	add(ctx, "spring", "b", "d")
+
+	total, buckets, err := svc.CountUnique(ctx, "spring", day1, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("expected 3 unique digests, got %d", total)
+	}
+	expectBuckets := []BucketCount{{Start: day1, Count: 2}, {Start: day1.Add(24 * time.Hour), Count: 2}}
+	if len(buckets) != len(expectBuckets) {
+		t.Fatalf("expected %d buckets, got %d", len(expectBuckets), len(buckets))
+	}
+	for i, b := range buckets {
+		if !b.Start.Equal(expectBuckets[i].Start) || b.Count != expectBuckets[i].Count {
+			t.Errorf("expected bucket %+v, got %+v", expectBuckets[i], b)
+		}
+	}
+
+	if total, _, err := svc.CountUnique(ctx, "spring", time.Time{}, time.Time{}); err != nil || total != 2 {
+		t.Errorf("expected current bucket count 2, got %d, %v", total, err)
+	}
+
+	if n, err := svc.MergeCounts(ctx, []string{"spring", "autumn"}, day1, now); err != nil || n != 4 {
+		t.Errorf("expected merged count 4, got %d, %v", n, err)
+	}
+
+	if n, err := svc.MergeCounts(context.Background(), []string{"spring"}, day1, now); err != nil || n != 1 {
+		t.Errorf("expected default tenant count 1, got %d, %v", n, err)
+	}
+}
+
+func TestCounterService_Errors(t *testing.T) {
+	svc := NewCounterService(&mockCounterRepository{digests: map[string]map[string]bool{}}, mustRegistry(), time.Hour)
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		fn        func() error
+		expectErr error
+	}{
+		{"add invalid key", func() error {
+			return svc.Add(ctx, "a b", mustCreateHash("input", "a", hash.AlgorithmMD5))
+		}, cardinality.ErrInvalidKey},
+		{"count invalid key", func() error {
+			_, _, err := svc.CountUnique(ctx, "", now, now)
+			return err
+		}, cardinality.ErrInvalidKey},
+		{"count invalid range", func() error {
+			_, _, err := svc.CountUnique(ctx, "spring", now, now.Add(-2*time.Hour))
+			return err
+		}, cardinality.ErrInvalidRange},
+		{"count too many buckets", func() error {
+			_, _, err := svc.CountUnique(ctx, "spring", now.Add(-cardinality.MaxBuckets*time.Hour), now)
+			return err
+		}, cardinality.ErrTooManyBuckets},
+		{"merge no keys", func() error {
+			_, err := svc.MergeCounts(ctx, nil, now, now)
+			return err
+		}, cardinality.ErrNoKeys},
+		{"merge invalid key", func() error {
+			_, err := svc.MergeCounts(ctx, []string{"spring", "a:b"}, now, now)
+			return err
+		}, cardinality.ErrInvalidKey},
+		{"merge too many keys", func() error {
+			keys := make([]string, cardinality.MaxKeys+1)
+			for i := range keys {
+				keys[i] = "key" + strconv.Itoa(i)
+			}
+			_, err := svc.MergeCounts(ctx, keys, now, now)
+			return err
+		}, cardinality.ErrTooManyKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// DedupRequest represents lead identifier to deduplicate. Identifier is
+// hashed the same way CreateHash does, zero algorithm and normalization are
+// replaced with tenant defaults. Zero window never ends.
+type DedupRequest struct {
+	Namespace     string
+	Input         string
+	Algorithm     hash.Algorithm
+	Normalization hash.Normalization
+	Window        time.Duration
+	// Source is a tag of source lead comes from, used by Register only.
+	Source string
+}
+
+// DedupService serves leads deduplication by hashed identifiers. Contains
+// hash service and implementation of leads repository.
+//
+// Leads are kept per caller tenant and namespace, only hashes of identifiers
+// are stored. Identifiers are hashed by HashService.Digest, so they are
+// neither cached nor logged. Server pepper version current on the first
+// registration is pinned by namespace, so leads survive pepper rotation.
+type DedupService struct {
+	hashSvc  *HashService
+	leadRepo dedup.Repository
+	now      func() time.Time
+}
+
+// NewDedupService creates new instance of dedup service.
+func NewDedupService(hashSvc *HashService, leadRepo dedup.Repository) *DedupService {
+	return &DedupService{
+		hashSvc:  hashSvc,
+		leadRepo: leadRepo,
+		now:      time.Now,
+	}
+}
+
+// SeenBefore reports whether lead was registered within window and returns
+// the registered lead if so. Lead is not registered.
+func (s *DedupService) SeenBefore(ctx context.Context, req DedupRequest) (*dedup.Lead, bool, error) {
+	if err := validateDedupRequest(req); err != nil {
+		return nil, false, err
+	}
+
+	tenant := s.hashSvc.tenant(ctx).Name()
+	version, err := s.leadRepo.PepperVersion(ctx, tenant, req.Namespace)
+	if errors.Is(err, dedup.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("find pepper version: %w", err)
+	}
+
+	key, err := s.key(ctx, req, version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lead, err := s.leadRepo.Find(ctx, tenant, req.Namespace, key)
+	if errors.Is(err, dedup.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("find lead: %w", err)
+	}
+
+	if !lead.Within(req.Window, s.now()) {
+		return nil, false, nil
+	}
+
+	return lead, true, nil
+}
+
+// Register registers lead unless it was registered within window. Check and
+// registration are atomic, so only one of concurrent callers gets the lead
+// as new. Returns lead first seen within window and whether it is new.
+func (s *DedupService) Register(ctx context.Context, req DedupRequest) (*dedup.Lead, bool, error) {
+	if err := validateDedupRequest(req); err != nil {
+		return nil, false, err
+	}
+
+	if err := dedup.ValidateSource(req.Source); err != nil {
+		return nil, false, err
+	}
+
+	current, err := s.hashSvc.PepperVersion("")
+	if err != nil {
+		return nil, false, err
+	}
+
+	tenant := s.hashSvc.tenant(ctx).Name()
+	version, err := s.leadRepo.PinPepperVersion(ctx, tenant, req.Namespace, current)
+	if err != nil {
+		return nil, false, fmt.Errorf("pin pepper version: %w", err)
+	}
+
+	key, err := s.key(ctx, req, version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lead := dedup.NewLead(key, req.Source, s.now().Truncate(time.Millisecond))
+
+	first, isNew, err := s.leadRepo.Register(ctx, tenant, req.Namespace, lead, req.Window)
+	if err != nil {
+		return nil, false, fmt.Errorf("register lead: %w", err)
+	}
+
+	return first, isNew, nil
+}
+
+// key hashes lead identifier with server pepper of given version.
+func (s *DedupService) key(ctx context.Context, req DedupRequest, pepperVersion string) (string, error) {
+	h, err := s.hashSvc.Digest(ctx, req.Input, req.Algorithm, req.Normalization, hash.Params{PepperVersion: pepperVersion})
+	if err != nil {
+		return "", err
+	}
+
+	return h.Hashed(), nil
+}
+
+func validateDedupRequest(req DedupRequest) error {
+	if err := dedup.ValidateNamespace(req.Namespace); err != nil {
+		return err
+	}
+
+	return dedup.ValidateWindow(req.Window)
+}
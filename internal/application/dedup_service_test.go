@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+type mockLeadRepository struct {
+	mu      sync.Mutex
+	leads   map[string]*dedup.Lead
+	peppers map[string]string
+}
+
+func (m *mockLeadRepository) Register(_ context.Context, tenant, namespace string, lead *dedup.Lead, window time.Duration) (*dedup.Lead, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := tenant + "/" + namespace + "/" + lead.Key()
+	if seen, ok := m.leads[k]; ok && seen.Within(window, lead.FirstSeen()) {
+		return seen, false, nil
+	}
+	m.leads[k] = lead
+	return lead, true, nil
+}
+
+func (m *mockLeadRepository) Find(_ context.Context, tenant, namespace, key string) (*dedup.Lead, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lead, ok := m.leads[tenant+"/"+namespace+"/"+key]
+	if !ok {
+		return nil, dedup.ErrNotFound
+	}
+	return lead, nil
+}
+
+func (m *mockLeadRepository) PinPepperVersion(_ context.Context, tenant, namespace, version string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := tenant + "/" + namespace
+	if pinned, ok := m.peppers[k]; ok {
+		return pinned, nil
+	}
+	m.peppers[k] = version
+	return version, nil
+}
+
+func (m *mockLeadRepository) PepperVersion(_ context.Context, tenant, namespace string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	version, ok := m.peppers[tenant+"/"+namespace]
+	if !ok {
+		return "", dedup.ErrNotFound
+	}
+	return version, nil
+}
+
+func TestDedupService(t *testing.T) {
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			t.Error("expected identifiers not to be looked up in hash cache")
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			t.Error("expected identifiers not to be cached")
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(input string) string { return "hashed:" + input }},
+	}
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{
+		Algorithm:     hash.AlgorithmMD5,
+		Normalization: hash.NormalizationLower,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usageRepo := &mockUsageRepository{counts: map[string]int64{}}
+	hashSvc := NewHashService(repo, usageRepo, mustRegistry(sales), nil, hashers, nil, nil)
+
+	leadRepo := &mockLeadRepository{leads: map[string]*dedup.Lead{}, peppers: map[string]string{}}
+	svc := NewDedupService(hashSvc, leadRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	window := 30 * 24 * time.Hour
+
+	if _, seen, err := svc.SeenBefore(ctx, DedupRequest{Namespace: "leads", Input: "Foo@Example.com", Window: window}); err != nil || seen {
+		t.Fatalf("expected unseen lead, got %v, %v", seen, err)
+	}
+
+	lead, isNew, err := svc.Register(ctx, DedupRequest{Namespace: "leads", Input: "Foo@Example.com", Window: window, Source: "web"})
+	if err != nil || !isNew {
+		t.Fatalf("expected new lead, got %v, %v", isNew, err)
+	}
+	if lead.Key() != "hashed:foo@example.com" || lead.Source() != "web" || !lead.FirstSeen().Equal(now) {
+		t.Errorf("unexpected lead %+v", lead)
+	}
+
+	now = now.Add(24 * time.Hour)
+	lead, isNew, err = svc.Register(ctx, DedupRequest{Namespace: "leads", Input: "foo@example.com ", Window: window, Source: "crm"})
+	if err != nil || isNew {
+		t.Fatalf("expected duplicate lead, got %v, %v", isNew, err)
+	}
+	if lead.Source() != "web" || !lead.FirstSeen().Equal(now.Add(-24*time.Hour)) {
+		t.Errorf("expected lead first seen at web, got %q at %v", lead.Source(), lead.FirstSeen())
+	}
+
+	if _, seen, err := svc.SeenBefore(ctx, DedupRequest{Namespace: "leads", Input: "foo@example.com", Window: window}); err != nil || !seen {
+		t.Errorf("expected seen lead, got %v, %v", seen, err)
+	}
+	if _, seen, err := svc.SeenBefore(ctx, DedupRequest{Namespace: "leads", Input: "foo@example.com", Window: time.Hour}); err != nil || seen {
+		t.Errorf("expected lead seen before window, got %v, %v", seen, err)
+	}
+	if _, seen, err := svc.SeenBefore(ctx, DedupRequest{Namespace: "events", Input: "foo@example.com"}); err != nil || seen {
+		t.Errorf("expected lead unseen in other namespace, got %v, %v", seen, err)
+	}
+	if _, seen, err := svc.SeenBefore(context.Background(), DedupRequest{Namespace: "leads", Input: "foo@example.com", Algorithm: hash.AlgorithmMD5}); err != nil || seen {
+		t.Errorf("expected lead unseen by other tenant, got %v, %v", seen, err)
+	}
+
+	now = now.Add(window)
+	if _, isNew, err := svc.Register(ctx, DedupRequest{Namespace: "leads", Input: "foo@example.com", Window: window, Source: "crm"}); err != nil || !isNew {
+		t.Errorf("expected lead new again after window, got %v, %v", isNew, err)
+	}
+
+	if len(usageRepo.counts) != 0 {
+		t.Errorf("expected no quota used, got %v", usageRepo.counts)
+	}
+}
+
+func TestDedupService_PepperRotation(t *testing.T) {
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmSHA256: newSHA256Hasher()}
+	leadRepo := &mockLeadRepository{leads: map[string]*dedup.Lead{}, peppers: map[string]string{}}
+	ctx := context.Background()
+
+	newService := func(current string) *DedupService {
+		peppers, err := hash.NewPeppers(current, map[string]string{"v1": "old", "v2": "new"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashSvc := NewHashService(&mockRepository{}, &mockUsageRepository{}, mustRegistry(), peppers, hashers, nil, nil)
+		return NewDedupService(hashSvc, leadRepo)
+	}
+
+	req := DedupRequest{Namespace: "leads", Input: "foo@example.com", Algorithm: hash.AlgorithmSHA256}
+
+	before := newService("v1")
+	if _, isNew, err := before.Register(ctx, req); err != nil || !isNew {
+		t.Fatalf("expected new lead, got %v, %v", isNew, err)
+	}
+
+	after := newService("v2")
+	if _, seen, err := after.SeenBefore(ctx, req); err != nil || !seen {
+		t.Errorf("expected lead seen after pepper rotation, got %v, %v", seen, err)
+	}
+	if _, isNew, err := after.Register(ctx, req); err != nil || isNew {
+		t.Errorf("expected duplicate lead after pepper rotation, got %v, %v", isNew, err)
+	}
+
+	req.Namespace = "events"
+	if _, isNew, err := after.Register(ctx, req); err != nil || !isNew {
+		t.Fatalf("expected new lead, got %v, %v", isNew, err)
+	}
+	if leadRepo.peppers["/events"] != "v2" {
+		t.Errorf("expected current pepper version pinned by new namespace, got %q", leadRepo.peppers["/events"])
+	}
+}
+
+func TestDedupService_PepperEnabled(t *testing.T) {
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmSHA256: newSHA256Hasher()}
+	leadRepo := &mockLeadRepository{leads: map[string]*dedup.Lead{}, peppers: map[string]string{}}
+	ctx := context.Background()
+	req := DedupRequest{Namespace: "leads", Input: "foo@example.com", Algorithm: hash.AlgorithmSHA256}
+
+	disabled := NewDedupService(NewHashService(&mockRepository{}, &mockUsageRepository{}, mustRegistry(), nil, hashers, nil, nil), leadRepo)
+	if _, isNew, err := disabled.Register(ctx, req); err != nil || !isNew {
+		t.Fatalf("expected new lead, got %v, %v", isNew, err)
+	}
+	if leadRepo.peppers["/leads"] != hash.PepperNone {
+		t.Errorf("expected %q pinned without pepper, got %q", hash.PepperNone, leadRepo.peppers["/leads"])
+	}
+
+	peppers, err := hash.NewPeppers("v1", map[string]string{"v1": "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled := NewDedupService(NewHashService(&mockRepository{}, &mockUsageRepository{}, mustRegistry(), peppers, hashers, nil, nil), leadRepo)
+	if _, seen, err := enabled.SeenBefore(ctx, req); err != nil || !seen {
+		t.Errorf("expected lead seen after pepper is enabled, got %v, %v", seen, err)
+	}
+	if _, isNew, err := enabled.Register(ctx, req); err != nil || isNew {
+		t.Errorf("expected duplicate lead after pepper is enabled, got %v, %v", isNew, err)
+	}
+}
+
+func TestDedupService_Validation(t *testing.T) {
+	hashSvc := NewHashService(&mockRepository{}, &mockUsageRepository{}, mustRegistry(), nil, nil, nil, nil)
+	svc := NewDedupService(hashSvc, &mockLeadRepository{})
+
+	tests := []struct {
+		name      string
+		req       DedupRequest
+		expectErr error
+	}{
+		{"invalid namespace", DedupRequest{Namespace: "leads:web", Input: "foo"}, dedup.ErrInvalidNamespace},
+		{"negative window", DedupRequest{Namespace: "leads", Input: "foo", Window: -time.Hour}, dedup.ErrNegativeWindow},
+		{"source too long", DedupRequest{Namespace: "leads", Input: "foo", Source: string(make([]byte, 129))}, dedup.ErrSourceTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := svc.Register(context.Background(), tt.req); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
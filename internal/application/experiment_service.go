@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/experiment"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// ExperimentService serves assignment of units to experiment variants.
+// Contains experiments and hashers supported by application.
+type ExperimentService struct {
+	experiments *experiment.Experiments
+	hashers     map[hash.Algorithm]hash.Hasher
+}
+
+// NewExperimentService creates new instance of experiment service. Every
+// experiment algorithm must be supported by hashers.
+func NewExperimentService(experiments *experiment.Experiments, hashers map[hash.Algorithm]hash.Hasher) (*ExperimentService, error) {
+	for _, e := range experiments.All() {
+		if _, ok := hashers[e.Algorithm()]; !ok {
+			return nil, fmt.Errorf("experiment %q: %w %q", e.Name(), hash.ErrUnsupportedAlgorithm, e.Algorithm())
+		}
+	}
+
+	return &ExperimentService{
+		experiments: experiments,
+		hashers:     hashers,
+	}, nil
+}
+
+// Assign assigns unit to variants of named experiments in the same order.
+// Empty names mean every experiment.
+func (s *ExperimentService) Assign(_ context.Context, unitID string, names []string) ([]experiment.Assignment, error) {
+	if unitID == "" {
+		return nil, experiment.ErrEmptyUnit
+	}
+
+	exps := s.experiments.All()
+	if len(names) > 0 {
+		exps = make([]*experiment.Experiment, len(names))
+		for i, name := range names {
+			e, err := s.experiments.Find(name)
+			if err != nil {
+				return nil, err
+			}
+			exps[i] = e
+		}
+	}
+
+	assignments := make([]experiment.Assignment, len(exps))
+	for i, e := range exps {
+		a, err := e.Assign(s.hashers[e.Algorithm()], unitID)
+		if err != nil {
+			return nil, fmt.Errorf("assign to %q: %w", e.Name(), err)
+		}
+		assignments[i] = a
+	}
+
+	return assignments, nil
+}
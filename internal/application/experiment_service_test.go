@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/experiment"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func mustExperiments(t *testing.T, alg hash.Algorithm, names ...string) *experiment.Experiments {
+	t.Helper()
+
+	exps := make([]*experiment.Experiment, len(names))
+	for i, name := range names {
+		e, err := experiment.NewExperiment(name, name+"-v1", alg, []experiment.Variant{
+			{Name: "control", Weight: 1},
+			{Name: "treatment", Weight: 1},
+		}, experiment.Allocation{Traffic: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		exps[i] = e
+	}
+
+	r, err := experiment.NewExperiments(exps...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestExperimentService_Assign(t *testing.T) {
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmSHA256: newSHA256Hasher()}
+	svc, err := NewExperimentService(mustExperiments(t, hash.AlgorithmSHA256, "hero", "form"), hashers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		unit      string
+		names     []string
+		expect    []string
+		expectErr error
+	}{
+		{"every experiment", "lead-1", nil, []string{"hero", "form"}, nil},
+		{"selected experiments", "lead-1", []string{"form", "hero"}, []string{"form", "hero"}, nil},
+		{"unknown experiment", "lead-1", []string{"missing"}, nil, experiment.ErrUnknownExperiment},
+		{"empty unit", "", nil, nil, experiment.ErrEmptyUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignments, err := svc.Assign(ctx, tt.unit, tt.names)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if len(assignments) != len(tt.expect) {
+				t.Fatalf("expected %d assignments, got %d", len(tt.expect), len(assignments))
+			}
+			for i, a := range assignments {
+				if a.Experiment != tt.expect[i] || a.Status != experiment.StatusAssigned || a.Variant == "" {
+					t.Errorf("unexpected assignment %+v", a)
+				}
+			}
+		})
+	}
+}
+
+func TestNewExperimentService_UnsupportedAlgorithm(t *testing.T) {
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmSHA256: newSHA256Hasher()}
+	if _, err := NewExperimentService(mustExperiments(t, hash.AlgorithmMD5, "hero"), hashers); !errors.Is(err, hash.ErrUnsupportedAlgorithm) {
+		t.Errorf("expected error %v, got %v", hash.ErrUnsupportedAlgorithm, err)
+	}
+}
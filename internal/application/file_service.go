@@ -0,0 +1,245 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+const (
+	// fileBatchSize is a number of records hashed before they are written.
+	fileBatchSize = 256
+	// maxReportErrors is a maximum number of row errors kept in report.
+	maxReportErrors = 1000
+	// defaultFileConcurrency is a default number of values hashed at once.
+	defaultFileConcurrency = 8
+)
+
+// FileReport represents outcome of bulk file hashing.
+type FileReport struct {
+	// Rows is a number of read rows including failed ones.
+	Rows int64 `json:"rows"`
+	// FailedRows is a number of rows with at least one error.
+	FailedRows int64 `json:"failed_rows"`
+	// Errors are the first row errors.
+	Errors []RowError `json:"errors,omitempty"`
+	// PepperVersion is a version of server pepper values were hashed with.
+	PepperVersion string `json:"pepper_version,omitempty"`
+}
+
+// RowError represents error of a single row. Column is empty if the whole
+// row is malformed.
+type RowError struct {
+	Row    int64  `json:"row"`
+	Column string `json:"column,omitempty"`
+	Err    string `json:"error"`
+}
+
+// FileService serves bulk files hashing. Contains hash service and codecs of
+// supported file formats.
+type FileService struct {
+	hashSvc     *HashService
+	codecs      map[record.Format]record.Codec
+	concurrency int
+}
+
+// NewFileService creates new instance of file service hashing up to
+// concurrency values at once.
+func NewFileService(hashSvc *HashService, codecs map[record.Format]record.Codec, concurrency int) *FileService {
+	if concurrency <= 0 {
+		concurrency = defaultFileConcurrency
+	}
+
+	return &FileService{
+		hashSvc:     hashSvc,
+		codecs:      codecs,
+		concurrency: concurrency,
+	}
+}
+
+// HashFile reads records of given format from in, hashes columns according
+// to spec with given salt and pepper version and writes records to out
+// preserving other columns. Pepper version is resolved once, so all values
+// are hashed with the same pepper.
+//
+// Row errors don't abort hashing: malformed rows are skipped and values
+// failed to hash are cleared, both are reported. Empty values are kept as
+// is. Other errors, e.g. exceeded quota, abort hashing.
+func (s *FileService) HashFile(ctx context.Context, format record.Format, spec *record.Spec, params hash.Params, in io.Reader, out io.Writer) (*FileReport, error) {
+	codec, ok := s.codecs[format]
+	if !ok {
+		return nil, record.ErrUnsupportedFormat
+	}
+
+	if err := hash.ValidateSalt(params.Salt); err != nil {
+		return nil, err
+	}
+
+	version, err := s.hashSvc.PepperVersion(params.PepperVersion)
+	if err != nil {
+		return nil, err
+	}
+	params.PepperVersion = version
+
+	r, err := codec.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("new reader: %w", err)
+	}
+
+	if cols := r.Columns(); cols != nil {
+		if err := checkColumns(cols, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := codec.NewWriter(out, r.Columns())
+	if err != nil {
+		return nil, fmt.Errorf("new writer: %w", err)
+	}
+
+	report := &FileReport{PepperVersion: version}
+	for {
+		batch, eof, err := s.readBatch(r, report)
+		if err != nil {
+			return report, err
+		}
+
+		if err := s.hashBatch(ctx, batch, spec, params, report); err != nil {
+			return report, err
+		}
+
+		for _, row := range batch {
+			if err := w.Write(row.rec); err != nil {
+				return report, fmt.Errorf("write row %d: %w", row.n, err)
+			}
+		}
+
+		if eof {
+			break
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return report, fmt.Errorf("flush: %w", err)
+	}
+
+	return report, nil
+}
+
+type fileRow struct {
+	n   int64
+	rec record.Record
+}
+
+// readBatch reads up to fileBatchSize records. Malformed records are
+// reported and skipped.
+func (s *FileService) readBatch(r record.Reader, report *FileReport) ([]fileRow, bool, error) {
+	batch := make([]fileRow, 0, fileBatchSize)
+	for len(batch) < fileBatchSize {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return batch, true, nil
+		}
+
+		report.Rows++
+		if errors.Is(err, record.ErrMalformed) {
+			report.FailedRows++
+			report.add(RowError{Row: report.Rows, Err: err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("read row %d: %w", report.Rows, err)
+		}
+
+		batch = append(batch, fileRow{n: report.Rows, rec: rec})
+	}
+
+	return batch, false, nil
+}
+
+// hashBatch hashes columns of every record with bounded concurrency.
+func (s *FileService) hashBatch(ctx context.Context, batch []fileRow, spec *record.Spec, params hash.Params, report *FileReport) error {
+	type cell struct {
+		row   int
+		rule  *record.Rule
+		value string
+		hash  string
+		err   error
+	}
+
+	var cells []*cell
+	for i, row := range batch {
+		for _, rule := range spec.Rules() {
+			if v, ok := row.rec.Get(rule.Column()); ok && v != "" {
+				cells = append(cells, &cell{row: i, rule: rule, value: v})
+			}
+		}
+	}
+
+	var (
+		sem = make(chan struct{}, s.concurrency)
+		wg  sync.WaitGroup
+	)
+	for _, c := range cells {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			h, err := s.hashSvc.CreateHash(ctx, c.value, c.rule.Algorithm(), c.rule.Normalization(), params)
+			if err != nil {
+				c.err = err
+				return
+			}
+			c.hash = h.Hashed()
+		}()
+	}
+	wg.Wait()
+
+	failed := make(map[int]struct{})
+	for _, c := range cells {
+		rec := batch[c.row].rec
+		switch {
+		case c.err == nil:
+			rec.Set(c.rule.Column(), c.hash)
+		case errors.Is(c.err, hash.ErrEmptyInput), errors.Is(c.err, ErrAlgorithmRequired):
+			rec.Set(c.rule.Column(), "")
+			failed[c.row] = struct{}{}
+			report.add(RowError{Row: batch[c.row].n, Column: c.rule.Column(), Err: c.err.Error()})
+		default:
+			return fmt.Errorf("hash row %d column %q: %w", batch[c.row].n, c.rule.Column(), c.err)
+		}
+	}
+	report.FailedRows += int64(len(failed))
+
+	return nil
+}
+
+func (r *FileReport) add(e RowError) {
+	if len(r.Errors) < maxReportErrors {
+		r.Errors = append(r.Errors, e)
+	}
+}
+
+func checkColumns(columns []string, spec *record.Spec) error {
+	known := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		known[c] = struct{}{}
+	}
+
+	for _, rule := range spec.Rules() {
+		if _, ok := known[rule.Column()]; !ok {
+			return fmt.Errorf("%w: %q", record.ErrMissingColumn, rule.Column())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package application
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// mockCodec reads comma separated lines with header, lines starting with "!"
+// are malformed.
+type mockCodec struct{}
+
+func (mockCodec) NewReader(r io.Reader) (record.Reader, error) {
+	s := bufio.NewScanner(r)
+	if !s.Scan() {
+		return &mockReader{s: s}, nil
+	}
+	return &mockReader{s: s, header: strings.Split(s.Text(), ",")}, nil
+}
+
+func (mockCodec) NewWriter(w io.Writer, columns []string) (record.Writer, error) {
+	fmt.Fprintln(w, strings.Join(columns, ","))
+	return &mockWriter{w: w, columns: columns}, nil
+}
+
+type mockReader struct {
+	s      *bufio.Scanner
+	header []string
+}
+
+func (r *mockReader) Columns() []string { return r.header }
+
+func (r *mockReader) Read() (record.Record, error) {
+	if !r.s.Scan() {
+		return nil, io.EOF
+	}
+	if strings.HasPrefix(r.s.Text(), "!") {
+		return nil, record.ErrMalformed
+	}
+
+	rec := mockRecord{}
+	for i, v := range strings.Split(r.s.Text(), ",") {
+		rec[r.header[i]] = v
+	}
+	return rec, nil
+}
+
+type mockRecord map[string]string
+
+func (r mockRecord) Get(column string) (string, bool) {
+	v, ok := r[column]
+	return v, ok
+}
+
+func (r mockRecord) Set(column, value string) { r[column] = value }
+
+type mockWriter struct {
+	w       io.Writer
+	columns []string
+}
+
+func (w *mockWriter) Write(r record.Record) error {
+	values := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		values[i], _ = r.Get(c)
+	}
+	_, err := fmt.Fprintln(w.w, strings.Join(values, ","))
+	return err
+}
+
+func (w *mockWriter) Flush() error { return nil }
+
+func newTestFileService(tenants *tenant.Registry) *FileService {
+	hashRepo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error { return nil },
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5:    &mockHasher{hashFunc: func(input string) string { return "md5(" + input + ")" }},
+		hash.AlgorithmSHA256: &mockHasher{hashFunc: func(input string) string { return "sha(" + input + ")" }},
+	}
+
+	hashSvc := NewHashService(hashRepo, &mockUsageRepository{counts: map[string]int64{}}, tenants, nil, hashers, nil, nil)
+
+	return NewFileService(hashSvc, map[record.Format]record.Codec{record.FormatCSV: mockCodec{}}, 4)
+}
+
+func mustSpec(t *testing.T, rules ...string) *record.Spec {
+	t.Helper()
+
+	parsed := make([]*record.Rule, len(rules))
+	for i, r := range rules {
+		rule, err := record.ParseRule(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed[i] = rule
+	}
+
+	spec, err := record.NewSpec(parsed...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func TestFileService_HashFile(t *testing.T) {
+	svc := newTestFileService(mustRegistry())
+	spec := mustSpec(t, "email=sha256:lower", "phone=md5:digits")
+
+	in := "email,phone,first_name\n" +
+		" A@x.io ,+1 (555) 12,Ann\n" +
+		"!broken\n" +
+		"b@x.io,n/a,Bob\n" +
+		",,Eve\n"
+
+	var out bytes.Buffer
+	report, err := svc.HashFile(context.Background(), record.FormatCSV, spec, hash.Params{}, strings.NewReader(in), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := "email,phone,first_name\n" +
+		"sha(a@x.io),md5(155512),Ann\n" +
+		"sha(b@x.io),,Bob\n" +
+		",,Eve\n"
+	if out.String() != expect {
+		t.Errorf("expected %q, got %q", expect, out.String())
+	}
+
+	expectReport := &FileReport{
+		Rows:       4,
+		FailedRows: 2,
+		Errors: []RowError{
+			{Row: 2, Err: record.ErrMalformed.Error()},
+			{Row: 3, Column: "phone", Err: hash.ErrEmptyInput.Error()},
+		},
+		PepperVersion: hash.PepperNone,
+	}
+	if !reflect.DeepEqual(report, expectReport) {
+		t.Errorf("expected report %+v, got %+v", expectReport, report)
+	}
+}
+
+func TestFileService_HashFile_Errors(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{DailyQuota: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		format    record.Format
+		spec      *record.Spec
+		ctx       context.Context
+		expectErr error
+	}{
+		{"unsupported format", record.FormatNDJSON, mustSpec(t, "email=md5"), context.Background(), record.ErrUnsupportedFormat},
+		{"missing column", record.FormatCSV, mustSpec(t, "email=md5", "zip=md5"), context.Background(), record.ErrMissingColumn},
+		{"quota exceeded", record.FormatCSV, mustSpec(t, "email=md5", "phone=md5"), identity.NewContext(context.Background(), mustIdentity(t, "importer")), tenant.ErrQuotaExceeded},
+	}
+
+	svc := newTestFileService(mustRegistry(sales))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := "email,phone\na,1\n"
+			if _, err := svc.HashFile(tt.ctx, tt.format, tt.spec, hash.Params{}, strings.NewReader(in), io.Discard); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// FilterService serves membership filters of hashed items, e.g. suppression
+// lists. Contains hash service and implementation of filters repository.
+//
+// Filters are kept per caller tenant. Items are hashed by HashService.Digest
+// before they are added or looked up, so inputs are neither held by filters
+// nor cached. Server pepper version current on creation is pinned by filter,
+// so filters survive pepper rotation, but they must be queried with the same
+// algorithm and normalization they were built with. Modifications are
+// atomic updates of filters repository, so concurrent writers don't lose
+// each other items.
+type FilterService struct {
+	hashSvc    *HashService
+	filterRepo filter.Repository
+}
+
+// NewFilterService creates new instance of filter service.
+func NewFilterService(hashSvc *HashService, filterRepo filter.Repository) *FilterService {
+	return &FilterService{
+		hashSvc:    hashSvc,
+		filterRepo: filterRepo,
+	}
+}
+
+// Create creates empty filter of given kind sized for capacity items at
+// false positive rate.
+func (s *FilterService) Create(ctx context.Context, name string, kind filter.Kind, capacity uint64, fpRate float64) (filter.Filter, error) {
+	if err := filter.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	version, err := s.hashSvc.PepperVersion("")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := filter.New(kind, capacity, fpRate, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.filterRepo.Create(ctx, s.hashSvc.tenant(ctx).Name(), name, f); err != nil {
+		return nil, fmt.Errorf("create filter: %w", err)
+	}
+
+	return f, nil
+}
+
+// Filter returns filter by name.
+func (s *FilterService) Filter(ctx context.Context, name string) (filter.Filter, error) {
+	if err := filter.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	return s.filterRepo.Find(ctx, s.hashSvc.tenant(ctx).Name(), name)
+}
+
+// Add hashes inputs and adds them to filter. Items added before filter is
+// full are kept.
+func (s *FilterService) Add(ctx context.Context, name string, inputs []string, alg hash.Algorithm, norm hash.Normalization) (filter.Filter, error) {
+	return s.modify(ctx, name, inputs, alg, norm, func(f filter.Filter, item string) error {
+		return f.Add(item)
+	})
+}
+
+// Remove hashes inputs and deletes them from filter. Only cuckoo filters
+// support deletion. Inputs never added are skipped.
+func (s *FilterService) Remove(ctx context.Context, name string, inputs []string, alg hash.Algorithm, norm hash.Normalization) (filter.Filter, error) {
+	return s.modify(ctx, name, inputs, alg, norm, func(f filter.Filter, item string) error {
+		_, err := f.Delete(item)
+		return err
+	})
+}
+
+// MightContain hashes inputs and reports whether every of them might have
+// been added to filter.
+func (s *FilterService) MightContain(ctx context.Context, name string, inputs []string, alg hash.Algorithm, norm hash.Normalization) ([]bool, error) {
+	f, err := s.Filter(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.hashInputs(ctx, f, inputs, alg, norm)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(items))
+	for i, item := range items {
+		found[i] = f.MightContain(item)
+	}
+
+	return found, nil
+}
+
+// Export returns filter in binary format, see filter.Unmarshal.
+func (s *FilterService) Export(ctx context.Context, name string) ([]byte, error) {
+	f, err := s.Filter(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.MarshalBinary()
+}
+
+// Import saves filter in binary format by name replacing existing one.
+func (s *FilterService) Import(ctx context.Context, name string, data []byte) (filter.Filter, error) {
+	if err := filter.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	f, err := filter.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.filterRepo.Save(ctx, s.hashSvc.tenant(ctx).Name(), name, f); err != nil {
+		return nil, fmt.Errorf("save filter: %w", err)
+	}
+
+	return f, nil
+}
+
+func (s *FilterService) modify(
+	ctx context.Context,
+	name string,
+	inputs []string,
+	alg hash.Algorithm,
+	norm hash.Normalization,
+	fn func(f filter.Filter, item string) error,
+) (filter.Filter, error) {
+	if err := filter.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	// Items are hashed once per pepper version, since update is retried
+	// on concurrent changes and filter may be replaced by import meanwhile.
+	var (
+		items   []string
+		version string
+		hashed  bool
+		fnErr   error
+	)
+	f, err := s.filterRepo.Update(ctx, s.hashSvc.tenant(ctx).Name(), name, func(f filter.Filter) error {
+		if !hashed || version != f.PepperVersion() {
+			var err error
+			if items, err = s.hashInputs(ctx, f, inputs, alg, norm); err != nil {
+				return err
+			}
+			version, hashed = f.PepperVersion(), true
+		}
+
+		// Items applied before failure are kept.
+		fnErr = nil
+		for _, item := range items {
+			if fnErr = fn(f, item); fnErr != nil {
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fnErr != nil {
+		return nil, fnErr
+	}
+
+	return f, nil
+}
+
+// hashInputs hashes inputs with server pepper version pinned by filter.
+func (s *FilterService) hashInputs(ctx context.Context, f filter.Filter, inputs []string, alg hash.Algorithm, norm hash.Normalization) ([]string, error) {
+	params := hash.Params{PepperVersion: f.PepperVersion()}
+
+	items := make([]string, len(inputs))
+	for i, input := range inputs {
+		h, err := s.hashSvc.Digest(ctx, input, alg, norm, params)
+		if err != nil {
+			return nil, fmt.Errorf("hash input %d: %w", i, err)
+		}
+		items[i] = h.Hashed()
+	}
+
+	return items, nil
+}
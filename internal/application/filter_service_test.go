@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+type mockFilterRepository struct {
+	mu      sync.Mutex
+	filters map[string][]byte
+}
+
+func (m *mockFilterRepository) Save(_ context.Context, tenant, name string, f filter.Filter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	m.filters[tenant+"/"+name] = data
+	return nil
+}
+
+func (m *mockFilterRepository) Find(_ context.Context, tenant, name string) (filter.Filter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.filters[tenant+"/"+name]
+	if !ok {
+		return nil, filter.ErrNotFound
+	}
+	return filter.Unmarshal(data)
+}
+
+func (m *mockFilterRepository) Create(ctx context.Context, tenant, name string, f filter.Filter) error {
+	if _, err := m.Find(ctx, tenant, name); err == nil {
+		return filter.ErrExists
+	}
+	return m.Save(ctx, tenant, name, f)
+}
+
+func (m *mockFilterRepository) Update(_ context.Context, tenant, name string, fn func(filter.Filter) error) (filter.Filter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.filters[tenant+"/"+name]
+	if !ok {
+		return nil, filter.ErrNotFound
+	}
+	f, err := filter.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(f); err != nil {
+		return nil, err
+	}
+	if data, err = f.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	m.filters[tenant+"/"+name] = data
+	return f, nil
+}
+
+func newFilterService(t *testing.T) (*FilterService, context.Context) {
+	t.Helper()
+
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(input string) string { return "hashed:" + input }},
+	}
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{
+		Algorithm:     hash.AlgorithmMD5,
+		Normalization: hash.NormalizationLower,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashSvc := NewHashService(repo, &mockUsageRepository{counts: map[string]int64{}}, mustRegistry(sales), nil, hashers, nil, nil)
+
+	svc := NewFilterService(hashSvc, &mockFilterRepository{filters: map[string][]byte{}})
+	return svc, identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+}
+
+func TestFilterService(t *testing.T) {
+	svc, ctx := newFilterService(t)
+
+	for _, kind := range []filter.Kind{filter.KindBloom, filter.KindCuckoo} {
+		t.Run(kind.String(), func(t *testing.T) {
+			name := "dnc-" + kind.String()
+			if _, err := svc.Create(ctx, name, kind, 100, 0.01); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := svc.Create(ctx, name, kind, 100, 0.01); !errors.Is(err, filter.ErrExists) {
+				t.Errorf("expected error %v, got %v", filter.ErrExists, err)
+			}
+
+			f, err := svc.Add(ctx, name, []string{"Foo@Example.com", "bar@example.com"}, 0, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if f.Count() != 2 {
+				t.Errorf("expected 2 items, got %d", f.Count())
+			}
+
+			found, err := svc.MightContain(ctx, name, []string{" foo@example.com", "baz@example.com"}, 0, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(found, []bool{true, false}) {
+				t.Errorf("expected normalized input found, got %v", found)
+			}
+
+			_, err = svc.Remove(ctx, name, []string{"foo@example.com"}, 0, 0)
+			if kind == filter.KindBloom {
+				if !errors.Is(err, filter.ErrDeleteUnsupported) {
+					t.Errorf("expected error %v, got %v", filter.ErrDeleteUnsupported, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			found, err = svc.MightContain(ctx, name, []string{"foo@example.com", "bar@example.com"}, 0, 0)
+			if err != nil || !reflect.DeepEqual(found, []bool{false, true}) {
+				t.Errorf("expected removed input not found, got %v, %v", found, err)
+			}
+		})
+	}
+
+	if _, err := svc.Filter(context.Background(), "dnc-bloom"); !errors.Is(err, filter.ErrNotFound) {
+		t.Errorf("expected filter of other tenant not found, got %v", err)
+	}
+}
+
+func TestFilterService_ExportImport(t *testing.T) {
+	svc, ctx := newFilterService(t)
+
+	if _, err := svc.Create(ctx, "dnc", filter.KindCuckoo, 100, 0.01); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Add(ctx, "dnc", []string{"foo@example.com"}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := svc.Export(ctx, "dnc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.Import(ctx, "dnc-copy", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found, err := svc.MightContain(ctx, "dnc-copy", []string{"foo@example.com"}, 0, 0)
+	if err != nil || !found[0] {
+		t.Errorf("expected imported filter to contain input, got %v, %v", found, err)
+	}
+
+	if _, err := svc.Import(ctx, "dnc-copy", data[1:]); !errors.Is(err, filter.ErrMalformed) {
+		t.Errorf("expected error %v, got %v", filter.ErrMalformed, err)
+	}
+}
+
+func TestFilterService_Errors(t *testing.T) {
+	svc, ctx := newFilterService(t)
+	if _, err := svc.Create(ctx, "dnc", filter.KindBloom, 100, 0.01); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		fn        func() error
+		expectErr error
+	}{
+		{"invalid name", func() error {
+			_, err := svc.Create(ctx, "dnc/1", filter.KindBloom, 100, 0.01)
+			return err
+		}, filter.ErrInvalidName},
+		{"invalid rate", func() error {
+			_, err := svc.Create(ctx, "dnc-2", filter.KindBloom, 100, 0)
+			return err
+		}, filter.ErrInvalidFPRate},
+		{"unknown filter", func() error {
+			_, err := svc.Add(ctx, "unknown", []string{"foo"}, 0, 0)
+			return err
+		}, filter.ErrNotFound},
+		{"empty input", func() error {
+			_, err := svc.MightContain(ctx, "dnc", []string{"foo", strings.Repeat(" ", 3)}, 0, 0)
+			return err
+		}, hash.ErrEmptyInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestFilterService_PepperRotation(t *testing.T) {
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			t.Error("expected items not to be looked up in hash cache")
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			t.Error("expected items not to be cached")
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmSHA256: newSHA256Hasher()}
+	filterRepo := &mockFilterRepository{filters: map[string][]byte{}}
+	ctx := context.Background()
+
+	// Empty current version disables pepper.
+	newService := func(current string) *FilterService {
+		var peppers *hash.Peppers
+		if current != "" {
+			var err error
+			if peppers, err = hash.NewPeppers(current, map[string]string{"v1": "old", "v2": "new"}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		hashSvc := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), peppers, hashers, nil, nil)
+		return NewFilterService(hashSvc, filterRepo)
+	}
+
+	disabled := newService("")
+	f, err := disabled.Create(ctx, "legacy", filter.KindBloom, 100, 0.01)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.PepperVersion() != hash.PepperNone {
+		t.Errorf("expected %q pinned without pepper, got %q", hash.PepperNone, f.PepperVersion())
+	}
+	if _, err := disabled.Add(ctx, "legacy", []string{"foo@example.com"}, hash.AlgorithmSHA256, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	before := newService("v1")
+	f, err = before.Create(ctx, "dnc", filter.KindBloom, 100, 0.01)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.PepperVersion() != "v1" {
+		t.Errorf("expected current pepper version pinned, got %q", f.PepperVersion())
+	}
+	if _, err := before.Add(ctx, "dnc", []string{"foo@example.com"}, hash.AlgorithmSHA256, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	after := newService("v2")
+	found, err := after.MightContain(ctx, "dnc", []string{"foo@example.com"}, hash.AlgorithmSHA256, 0)
+	if err != nil || !found[0] {
+		t.Errorf("expected item found after pepper rotation, got %v, %v", found, err)
+	}
+
+	found, err = after.MightContain(ctx, "legacy", []string{"foo@example.com"}, hash.AlgorithmSHA256, 0)
+	if err != nil || !found[0] {
+		t.Errorf("expected item found after pepper is enabled, got %v, %v", found, err)
+	}
+}
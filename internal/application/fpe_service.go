@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+)
+
+// FPEService serves format-preserving encryption business logic. Contains
+// schemes by name and set of callers permitted to decrypt.
+type FPEService struct {
+	schemes    map[string]*fpe.Scheme
+	decrypters *identity.Allowlist
+}
+
+// NewFPEService creates new instance of FPE service with given schemes.
+// Decrypters are callers permitted to decrypt, nil allowlist permits no one.
+func NewFPEService(decrypters *identity.Allowlist, schemes ...*fpe.Scheme) (*FPEService, error) {
+	byName := make(map[string]*fpe.Scheme, len(schemes))
+	for _, s := range schemes {
+		if _, ok := byName[s.Name()]; ok {
+			return nil, fmt.Errorf("%w %q", fpe.ErrDuplicateScheme, s.Name())
+		}
+		byName[s.Name()] = s
+	}
+
+	return &FPEService{
+		schemes:    byName,
+		decrypters: decrypters,
+	}, nil
+}
+
+// Encrypt encrypts value by named scheme. Empty tweak means scheme default.
+func (s *FPEService) Encrypt(_ context.Context, scheme, value string, tweak []byte) (string, error) {
+	sc, err := s.scheme(scheme)
+	if err != nil {
+		return "", err
+	}
+
+	return sc.Encrypt(value, tweak)
+}
+
+// Decrypt decrypts value encrypted by named scheme with the same tweak. Only
+// callers listed as decrypters are permitted.
+func (s *FPEService) Decrypt(ctx context.Context, scheme, value string, tweak []byte) (string, error) {
+	if id, _ := identity.FromContext(ctx); !s.decrypters.Permits(id) {
+		return "", fpe.ErrForbidden
+	}
+
+	sc, err := s.scheme(scheme)
+	if err != nil {
+		return "", err
+	}
+
+	return sc.Decrypt(value, tweak)
+}
+
+func (s *FPEService) scheme(name string) (*fpe.Scheme, error) {
+	sc, ok := s.schemes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", fpe.ErrUnknownScheme, name)
+	}
+
+	return sc, nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+)
+
+// mockFPECipher reverses numeral strings.
+type mockFPECipher struct{}
+
+func (mockFPECipher) Encrypt(_ []byte, x []uint16) ([]uint16, error) {
+	y := make([]uint16, len(x))
+	for i, d := range x {
+		y[len(x)-1-i] = d
+	}
+	return y, nil
+}
+
+func (c mockFPECipher) Decrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	return c.Encrypt(tweak, x)
+}
+
+func TestFPEService(t *testing.T) {
+	phone := mustScheme(t, "phone")
+
+	if _, err := NewFPEService(nil, phone, mustScheme(t, "phone")); !errors.Is(err, fpe.ErrDuplicateScheme) {
+		t.Fatalf("expected error %v, got %v", fpe.ErrDuplicateScheme, err)
+	}
+
+	svc, err := NewFPEService(mustAllowlist(t, "apikey:billing"), phone, mustScheme(t, "id"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.Encrypt(context.Background(), "phone", "+7 123-45", nil)
+	if err != nil || got != "+5 432-17" {
+		t.Errorf("expected %q, got %q, %v", "+5 432-17", got, err)
+	}
+
+	encrypted := got
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		expect    string
+		expectErr error
+	}{
+		{"decrypter", identity.NewContext(context.Background(), mustIdentity(t, "billing")), "+7 123-45", nil},
+		{"other caller", identity.NewContext(context.Background(), mustIdentity(t, "importer")), "", fpe.ErrForbidden},
+		{"certificate named as decrypter", identity.NewContext(context.Background(), mustCertificateIdentity(t, "billing")), "", fpe.ErrForbidden},
+		{"anonymous", context.Background(), "", fpe.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.Decrypt(tt.ctx, "phone", encrypted, nil)
+			if !errors.Is(err, tt.expectErr) || got != tt.expect {
+				t.Errorf("expected %q, %v, got %q, %v", tt.expect, tt.expectErr, got, err)
+			}
+		})
+	}
+
+	if _, err := svc.Encrypt(context.Background(), "email", "foo", nil); !errors.Is(err, fpe.ErrUnknownScheme) {
+		t.Errorf("expected error %v, got %v", fpe.ErrUnknownScheme, err)
+	}
+}
+
+func mustScheme(t *testing.T, name string) *fpe.Scheme {
+	t.Helper()
+
+	alphabet, err := fpe.RadixAlphabet(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := fpe.NewScheme(name, fpe.ModeFF1, alphabet, mockFPECipher{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
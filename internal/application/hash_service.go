@@ -4,50 +4,242 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
 )
 
+// ErrAlgorithmRequired is returned when neither request nor caller tenant
+// specify hash algorithm.
+var ErrAlgorithmRequired = errors.New("hash algorithm is required")
+
+// HashLog is a contract of issued hashes logs.
+type HashLog interface {
+	// Append appends hash issued to tenant.
+	Append(ctx context.Context, tenant string, h *hash.Hash) error
+}
+
 // HashService serves hash business logic. Contains implementation of hash
-// repository and map of hashers.
+// repository, tenant usage repository, tenants registry, server peppers, map
+// of hashers, documents canonicalizer and issued hashes log.
 type HashService struct {
-	hashRepo hash.Repository
-	hashers  map[hash.Algorithm]hash.Hasher
+	hashRepo      hash.Repository
+	usageRepo     tenant.UsageRepository
+	tenants       *tenant.Registry
+	peppers       *hash.Peppers
+	hashers       map[hash.Algorithm]hash.Hasher
+	canonicalizer hash.Canonicalizer
+	hashLog       HashLog
+	now           func() time.Time
 }
 
-// NewHashService creates new instance of hash service.
-func NewHashService(hashRepo hash.Repository, hashers map[hash.Algorithm]hash.Hasher) *HashService {
+// NewHashService creates new instance of hash service. Nil peppers disable
+// server pepper, nil log disables logging of issued hashes.
+func NewHashService(
+	hashRepo hash.Repository,
+	usageRepo tenant.UsageRepository,
+	tenants *tenant.Registry,
+	peppers *hash.Peppers,
+	hashers map[hash.Algorithm]hash.Hasher,
+	canonicalizer hash.Canonicalizer,
+	hashLog HashLog,
+) *HashService {
 	return &HashService{
-		hashRepo: hashRepo,
-		hashers:  hashers,
+		hashRepo:      hashRepo,
+		usageRepo:     usageRepo,
+		tenants:       tenants,
+		peppers:       peppers,
+		hashers:       hashers,
+		canonicalizer: canonicalizer,
+		hashLog:       hashLog,
+		now:           time.Now,
 	}
 }
 
 // CreateHash creates hash of provided string by given algorithm.
 //
-// Uses a cache-first approach. Only if hash string not found in cache will
-// create a new one.
-func (s *HashService) CreateHash(ctx context.Context, input string, alg hash.Algorithm) (*hash.Hash, error) {
-	h, err := s.hashRepo.FindByInput(ctx, input, alg)
+// Caller tenant is resolved from identity stored in ctx. Zero algorithm and
+// normalization are replaced with tenant defaults, input is normalized and
+// hashed keyed by server and tenant peppers with salt, see hash.Digest. Empty
+// pepper version in params means current server pepper, previous versions
+// reproduce hashes made before rotation. Returned hash carries salt and
+// pepper version it was made with. Every call is counted against tenant daily
+// quota and, if log is set, every issued hash is appended to it.
+//
+// Uses a cache-first approach. Only if hash string not found in tenant cache
+// will create a new one.
+func (s *HashService) CreateHash(ctx context.Context, input string, alg hash.Algorithm, norm hash.Normalization, params hash.Params) (*hash.Hash, error) {
+	t := s.tenant(ctx)
+
+	req, err := s.resolve(t, input, alg, norm, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.countUsage(ctx, t); err != nil {
+		return nil, err
+	}
+
+	h, err := s.hashRepo.FindByInput(ctx, t.Name(), req.input, req.alg, req.params)
 	if err == nil {
+		if err := s.appendLog(ctx, t, h); err != nil {
+			return nil, err
+		}
 		return h, nil
 	}
 
-	hasher, ok := s.hashers[alg]
+	h, err = s.digest(t, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hashRepo.Save(ctx, t.Name(), h, t.TTL()); err != nil {
+		return nil, fmt.Errorf("save hash for %q: %w", req.input, err)
+	}
+
+	if err := s.appendLog(ctx, t, h); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Digest hashes input the same way CreateHash does, but hash isn't cached,
+// logged or counted against tenant quota, so inputs are never stored. It's
+// meant for services keeping hashes of their own, e.g. filters, which should
+// pin pepper version in params, so their hashes survive pepper rotation.
+func (s *HashService) Digest(ctx context.Context, input string, alg hash.Algorithm, norm hash.Normalization, params hash.Params) (*hash.Hash, error) {
+	t := s.tenant(ctx)
+
+	req, err := s.resolve(t, input, alg, norm, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.digest(t, req)
+}
+
+// hashRequest represents hash request with tenant defaults applied and
+// server pepper resolved.
+type hashRequest struct {
+	input  string
+	alg    hash.Algorithm
+	params hash.Params
+	pepper string
+}
+
+func (s *HashService) resolve(t *tenant.Tenant, input string, alg hash.Algorithm, norm hash.Normalization, params hash.Params) (hashRequest, error) {
+	if err := hash.ValidateSalt(params.Salt); err != nil {
+		return hashRequest{}, err
+	}
+
+	version, pepper, err := s.peppers.Resolve(params.PepperVersion)
+	if err != nil {
+		return hashRequest{}, err
+	}
+	params.PepperVersion = version
+
+	if alg == 0 {
+		alg = t.Algorithm()
+	}
+	if alg == 0 {
+		return hashRequest{}, ErrAlgorithmRequired
+	}
+
+	if norm == 0 {
+		norm = t.Normalization()
+	}
+
+	input = norm.Apply(input)
+	if input == "" {
+		return hashRequest{}, hash.ErrEmptyInput
+	}
+
+	return hashRequest{
+		input:  input,
+		alg:    alg,
+		params: params,
+		pepper: pepper,
+	}, nil
+}
+
+func (s *HashService) digest(t *tenant.Tenant, req hashRequest) (*hash.Hash, error) {
+	hasher, ok := s.hashers[req.alg]
 	if !ok {
-		return nil, fmt.Errorf("hasher for algorithm %v not registered", alg)
+		return nil, fmt.Errorf("hasher for algorithm %v not registered", req.alg)
 	}
 
-	hashed := hasher.Hash(input)
-	h, err = hash.New(input, hashed, alg)
+	hashed := hash.Digest(hasher, req.pepper, t.Pepper(), req.params.Salt, req.input)
+	h, err := hash.NewWithParams(req.input, hashed, req.alg, req.params)
 	if err != nil {
 		return nil, fmt.Errorf("new hash: %w", err)
 	}
 
-	if err = s.hashRepo.Save(ctx, h); err != nil {
-		return nil, fmt.Errorf("save hash for %q: %w", input, err)
+	return h, nil
+}
+
+// CreateDocumentHash creates hash of structured document canonical form with
+// selected fields only, so documents differing in keys order or formatting
+// get equal hashes. Normalization is not applied, otherwise it's the same as
+// CreateHash.
+func (s *HashService) CreateDocumentHash(ctx context.Context, doc string, fields hash.Fields, alg hash.Algorithm, params hash.Params) (*hash.Hash, error) {
+	canonical, err := s.canonicalizer.Canonicalize(doc, fields)
+	if err != nil {
+		return nil, err
 	}
 
-	return h, nil
+	return s.CreateHash(ctx, canonical, alg, hash.NormalizationNone, params)
+}
+
+// PepperVersion resolves server pepper version to be pinned. Empty version
+// means current one, which is hash.PepperNone if server pepper is disabled.
+func (s *HashService) PepperVersion(version string) (string, error) {
+	return s.peppers.Pin(version)
+}
+
+func (s *HashService) tenant(ctx context.Context) *tenant.Tenant {
+	return callerTenant(ctx, s.tenants)
+}
+
+// callerTenant resolves tenant of caller identity stored in ctx.
+func callerTenant(ctx context.Context, tenants *tenant.Registry) *tenant.Tenant {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return tenants.Default()
+	}
+
+	return tenants.ForClient(id.Subject())
+}
+
+func (s *HashService) appendLog(ctx context.Context, t *tenant.Tenant, h *hash.Hash) error {
+	if s.hashLog == nil {
+		return nil
+	}
+
+	if err := s.hashLog.Append(ctx, t.Name(), h); err != nil {
+		return fmt.Errorf("append to hash log: %w", err)
+	}
+
+	return nil
+}
+
+func (s *HashService) countUsage(ctx context.Context, t *tenant.Tenant) error {
+	if t.Name() == "" {
+		return nil
+	}
+
+	n, err := s.usageRepo.Increment(ctx, t.Name(), s.now())
+	if err != nil {
+		return fmt.Errorf("increment %q usage: %w", t.Name(), err)
+	}
+
+	if t.DailyQuota() > 0 && n > t.DailyQuota() {
+		return tenant.ErrQuotaExceeded
+	}
+
+	return nil
 }
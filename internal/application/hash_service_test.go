@@ -3,39 +3,67 @@ package application
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
 )
 
 type mockRepository struct {
-	findByInputFunc func(ctx context.Context, input string, alg hash.Algorithm) (*hash.Hash, error)
-	saveFunc        func(ctx context.Context, h *hash.Hash) error
+	findByInputFunc func(ctx context.Context, namespace, input string, alg hash.Algorithm, params hash.Params) (*hash.Hash, error)
+	saveFunc        func(ctx context.Context, namespace string, h *hash.Hash, ttl time.Duration) error
 }
 
-func (m *mockRepository) FindByInput(ctx context.Context, input string, alg hash.Algorithm) (*hash.Hash, error) {
-	return m.findByInputFunc(ctx, input, alg)
+func (m *mockRepository) FindByInput(ctx context.Context, namespace, input string, alg hash.Algorithm, params hash.Params) (*hash.Hash, error) {
+	return m.findByInputFunc(ctx, namespace, input, alg, params)
 }
 
-func (m *mockRepository) Save(ctx context.Context, h *hash.Hash) error {
-	return m.saveFunc(ctx, h)
+func (m *mockRepository) Save(ctx context.Context, namespace string, h *hash.Hash, ttl time.Duration) error {
+	return m.saveFunc(ctx, namespace, h, ttl)
+}
+
+type mockUsageRepository struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (m *mockUsageRepository) Increment(_ context.Context, tenant string, _ time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[tenant]++
+	return m.counts[tenant], nil
+}
+
+func (m *mockUsageRepository) Usage(_ context.Context, tenant string, _ time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[tenant], nil
 }
 
 type mockHasher struct {
 	hashFunc func(input string) string
+	macFunc  func(key []byte, input string) string
 }
 
 func (m *mockHasher) Hash(input string) string {
 	return m.hashFunc(input)
 }
 
+func (m *mockHasher) MAC(key []byte, input string) string {
+	return m.macFunc(key, input)
+}
+
 func TestNewHashService(t *testing.T) {
 	repo := &mockRepository{}
 	hashers := map[hash.Algorithm]hash.Hasher{
 		hash.AlgorithmMD5: &mockHasher{},
 	}
 
-	service := NewHashService(repo, hashers)
+	service := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), nil, hashers, nil, nil)
 
 	if service.hashRepo != repo {
 		t.Error("repo not set")
@@ -99,10 +127,10 @@ func TestHashService_CreateHash(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &mockRepository{
-				findByInputFunc: func(_ context.Context, _ string, _ hash.Algorithm) (*hash.Hash, error) {
+				findByInputFunc: func(_ context.Context, _, _ string, _ hash.Algorithm, _ hash.Params) (*hash.Hash, error) {
 					return tt.repoFindResult, tt.repoFindError
 				},
-				saveFunc: func(_ context.Context, _ *hash.Hash) error {
+				saveFunc: func(_ context.Context, _ string, _ *hash.Hash, _ time.Duration) error {
 					return tt.repoSaveError
 				},
 			}
@@ -116,8 +144,8 @@ func TestHashService_CreateHash(t *testing.T) {
 				}
 			}
 
-			service := NewHashService(repo, hashers)
-			result, err := service.CreateHash(context.Background(), tt.input, tt.alg)
+			service := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), nil, hashers, nil, nil)
+			result, err := service.CreateHash(context.Background(), tt.input, tt.alg, 0, hash.Params{})
 
 			if (err != nil) != tt.expectError {
 				t.Errorf("expected error: %v, got: %v", tt.expectError, err)
@@ -131,6 +159,310 @@ func TestHashService_CreateHash(t *testing.T) {
 	}
 }
 
+func TestHashService_CreateHash_Tenant(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{
+		Algorithm:     hash.AlgorithmSHA256,
+		Normalization: hash.NormalizationLower,
+		Pepper:        "pepper:",
+		TTL:           time.Hour,
+		DailyQuota:    2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		gotNamespace string
+		gotTTL       time.Duration
+		gotKey       []byte
+		gotHasherIn  string
+	)
+	repo := &mockRepository{
+		findByInputFunc: func(_ context.Context, namespace, _ string, _ hash.Algorithm, _ hash.Params) (*hash.Hash, error) {
+			gotNamespace = namespace
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(_ context.Context, _ string, _ *hash.Hash, ttl time.Duration) error {
+			gotTTL = ttl
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmSHA256: &mockHasher{macFunc: func(key []byte, input string) string {
+			gotKey, gotHasherIn = key, input
+			return "hashed"
+		}},
+	}
+
+	service := NewHashService(repo, &mockUsageRepository{counts: map[string]int64{}}, mustRegistry(sales), nil, hashers, nil, nil)
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+
+	h, err := service.CreateHash(ctx, " Foo@Example.com ", 0, 0, hash.Params{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if h.Algorithm() != hash.AlgorithmSHA256 {
+		t.Errorf("expected tenant default algorithm, got %v", h.Algorithm())
+	}
+
+	if h.Input() != "foo@example.com" {
+		t.Errorf("expected normalized input, got %q", h.Input())
+	}
+
+	if !strings.Contains(string(gotKey), "pepper:") || !strings.HasSuffix(gotHasherIn, "foo@example.com") {
+		t.Errorf("expected input keyed by pepper, got %q keyed by %q", gotHasherIn, gotKey)
+	}
+
+	if gotNamespace != "sales" || gotTTL != time.Hour {
+		t.Errorf("expected sales namespace with 1h TTL, got %q with %v", gotNamespace, gotTTL)
+	}
+
+	if _, err := service.CreateHash(ctx, "bar@example.com", 0, 0, hash.Params{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := service.CreateHash(ctx, "baz@example.com", 0, 0, hash.Params{}); !errors.Is(err, tenant.ErrQuotaExceeded) {
+		t.Errorf("expected %v, got %v", tenant.ErrQuotaExceeded, err)
+	}
+
+	if _, err := service.CreateHash(context.Background(), "foo", 0, 0, hash.Params{}); !errors.Is(err, ErrAlgorithmRequired) {
+		t.Errorf("expected %v for default tenant, got %v", ErrAlgorithmRequired, err)
+	}
+
+	if _, err := service.CreateHash(ctx, "  ", 0, 0, hash.Params{}); !errors.Is(err, hash.ErrEmptyInput) {
+		t.Errorf("expected %v for blank input, got %v", hash.ErrEmptyInput, err)
+	}
+}
+
+func TestHashService_CreateHash_Params(t *testing.T) {
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmSHA256: newSHA256Hasher(),
+	}
+
+	peppers, err := hash.NewPeppers("v2", map[string]string{"v1": "old:", "v2": "new:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		input         string
+		params        hash.Params
+		expectVersion string
+		expectErr     error
+	}{
+		{"current pepper", "foo", hash.Params{}, "v2", nil},
+		{"previous pepper", "foo", hash.Params{PepperVersion: "v1"}, "v1", nil},
+		{"salt", "foo", hash.Params{Salt: "s:"}, "v2", nil},
+		{"salt shifted into input", "s:foo", hash.Params{}, "v2", nil},
+		{"salt overlapping input", "bc", hash.Params{Salt: "a"}, "v2", nil},
+		{"input overlapping salt", "c", hash.Params{Salt: "ab"}, "v2", nil},
+		{"unknown pepper", "foo", hash.Params{PepperVersion: "v3"}, "", hash.ErrUnknownPepperVersion},
+		{"long salt", "foo", hash.Params{Salt: strings.Repeat("s", 257)}, "", hash.ErrSaltTooLong},
+	}
+
+	service := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), peppers, hashers, nil, nil)
+	seen := make(map[string]string)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := service.CreateHash(context.Background(), tt.input, hash.AlgorithmSHA256, 0, tt.params)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if h.PepperVersion() != tt.expectVersion || h.Salt() != tt.params.Salt {
+				t.Errorf("expected params %q/%q, got %+v", tt.params.Salt, tt.expectVersion, h.Params())
+			}
+
+			if other, ok := seen[h.Hashed()]; ok {
+				t.Errorf("expected hash different from %q one", other)
+			}
+			seen[h.Hashed()] = tt.name
+		})
+	}
+}
+
+func TestHashService_CreateHash_Unkeyed(t *testing.T) {
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmSHA256: newSHA256Hasher(),
+	}
+
+	service := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), nil, hashers, nil, nil)
+
+	h, err := service.CreateHash(context.Background(), "hello", hash.AlgorithmSHA256, 0, hash.Params{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if h.Hashed() != expected {
+		t.Errorf("expected plain SHA256 %q without pepper and salt, got %q", expected, h.Hashed())
+	}
+}
+
+type mockCanonicalizer struct{}
+
+func (mockCanonicalizer) Canonicalize(doc string, fields hash.Fields) (string, error) {
+	if doc == "" {
+		return "", hash.ErrMalformedDocument
+	}
+	return strings.ToUpper(doc) + strings.Join(fields.Include, ","), nil
+}
+
+func TestHashService_CreateDocumentHash(t *testing.T) {
+	var gotHasherIn string
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmSHA256: &mockHasher{hashFunc: func(input string) string {
+			gotHasherIn = input
+			return "hashed"
+		}},
+	}
+
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{
+		Algorithm:     hash.AlgorithmSHA256,
+		Normalization: hash.NormalizationDigits,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewHashService(repo, &mockUsageRepository{counts: map[string]int64{}}, mustRegistry(sales), nil, hashers, mockCanonicalizer{}, nil)
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+
+	h, err := service.CreateDocumentHash(ctx, `{"a":1}`, hash.Fields{Include: []string{"a"}}, 0, hash.Params{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotHasherIn != `{"A":1}a` || h.Input() != `{"A":1}a` {
+		t.Errorf("expected canonical input without tenant normalization, got %q", gotHasherIn)
+	}
+
+	if _, err := service.CreateDocumentHash(ctx, "", hash.Fields{}, 0, hash.Params{}); !errors.Is(err, hash.ErrMalformedDocument) {
+		t.Errorf("expected error %v, got %v", hash.ErrMalformedDocument, err)
+	}
+}
+
+type mockHashLog struct {
+	tenants []string
+	err     error
+}
+
+func (m *mockHashLog) Append(_ context.Context, tenant string, _ *hash.Hash) error {
+	m.tenants = append(m.tenants, tenant)
+	return m.err
+}
+
+func TestHashService_CreateHash_Log(t *testing.T) {
+	cached := false
+	repo := &mockRepository{
+		findByInputFunc: func(_ context.Context, _, input string, alg hash.Algorithm, _ hash.Params) (*hash.Hash, error) {
+			if cached {
+				return mustCreateHash(input, "hashed", alg), nil
+			}
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			cached = true
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(string) string { return "hashed" }},
+	}
+
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashLog := &mockHashLog{}
+	service := NewHashService(repo, &mockUsageRepository{counts: map[string]int64{}}, mustRegistry(sales), nil, hashers, nil, hashLog)
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+
+	// Both new and cached hashes are logged.
+	for range 2 {
+		if _, err := service.CreateHash(ctx, "foo", hash.AlgorithmMD5, hash.NormalizationNone, hash.Params{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(hashLog.tenants) != 2 || hashLog.tenants[0] != "sales" {
+		t.Errorf("expected 2 hashes logged for sales, got %q", hashLog.tenants)
+	}
+
+	hashLog.err = errors.New("disk is full")
+	if h, err := service.CreateHash(ctx, "foo", hash.AlgorithmMD5, hash.NormalizationNone, hash.Params{}); !errors.Is(err, hashLog.err) || h != nil {
+		t.Errorf("expected error %v, got %v, %v", hashLog.err, h, err)
+	}
+}
+
+func mustRegistry(tenants ...*tenant.Tenant) *tenant.Registry {
+	r, err := tenant.NewRegistry(tenant.Default(), tenants...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func mustIdentity(t *testing.T, subject string) *identity.Identity {
+	t.Helper()
+
+	id, err := identity.New(subject, identity.SourceAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func mustAllowlist(t *testing.T, entries ...string) *identity.Allowlist {
+	t.Helper()
+
+	a, err := identity.ParseAllowlist(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func mustCertificateIdentity(t *testing.T, subject string) *identity.Identity {
+	t.Helper()
+
+	id, err := identity.New(subject, identity.SourceCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
 func mustCreateHash(input, hashed string, alg hash.Algorithm) *hash.Hash {
 	h, err := hash.New(input, hashed, alg)
 	if err != nil {
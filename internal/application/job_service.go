@@ -0,0 +1,714 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// JobOptions represents job processing limits. Zero values are replaced with
+// defaults.
+type JobOptions struct {
+	// Workers is a number of jobs processed at the same time.
+	Workers int
+	// Concurrency is a number of rows of a single job hashed at the same time.
+	Concurrency int
+	// ChunkSize is a number of rows read, hashed and stored at once. Progress
+	// is persisted after every chunk.
+	ChunkSize int
+	// MaxRows is a maximum number of rows of a single job, zero means no limit.
+	MaxRows int64
+	// PollInterval is how often watched jobs are checked for changes.
+	PollInterval time.Duration
+	// LeaseTTL is how long job lease outlives its last renewal. Unfinished
+	// jobs are looked up for free leases as often.
+	LeaseTTL time.Duration
+}
+
+// Default job options.
+const (
+	defaultJobWorkers      = 2
+	defaultJobConcurrency  = 8
+	defaultJobChunkSize    = 500
+	defaultJobPollInterval = 500 * time.Millisecond
+	defaultJobLeaseTTL     = 30 * time.Second
+)
+
+// JobService serves asynchronous hash jobs. Contains implementation of job
+// repository and hash service used to hash job rows.
+//
+// Jobs are processed by a bounded pool of workers. Worker holds job lease
+// while processing job, so replicas sharing job store never process the
+// same job at once. Unfinished jobs are looked up on start and then
+// periodically, so jobs of stopped replicas are resumed from the latest
+// stored result once their leases expire.
+type JobService struct {
+	jobRepo  job.Repository
+	hashSvc  *HashService
+	opts     JobOptions
+	log      *slog.Logger
+	now      func() time.Time
+	instance string
+
+	// stateMu serializes read-modify-write of job state, so cancellation
+	// isn't overwritten by worker progress.
+	stateMu sync.Mutex
+
+	mu      sync.Mutex
+	queue   []string
+	pending map[string]struct{}
+	running map[string]context.CancelFunc
+	notify  chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewJobService creates new instance of job service.
+func NewJobService(jobRepo job.Repository, hashSvc *HashService, opts JobOptions, log *slog.Logger) *JobService {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultJobWorkers
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultJobConcurrency
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultJobChunkSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultJobPollInterval
+	}
+	if opts.LeaseTTL <= 0 {
+		opts.LeaseTTL = defaultJobLeaseTTL
+	}
+
+	return &JobService{
+		jobRepo:  jobRepo,
+		hashSvc:  hashSvc,
+		opts:     opts,
+		log:      log,
+		now:      time.Now,
+		instance: rand.Text(),
+		pending:  make(map[string]struct{}),
+		running:  make(map[string]context.CancelFunc),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Start queues unfinished jobs and starts workers. Jobs interrupted during
+// upload can't be resumed and are failed.
+func (s *JobService) Start(ctx context.Context) error {
+	jobs, err := s.jobRepo.FindUnfinished(ctx)
+	if err != nil {
+		return fmt.Errorf("find unfinished jobs: %w", err)
+	}
+
+	for _, j := range jobs {
+		if j.Status() != job.StatusUploading {
+			s.enqueue(j.ID())
+			continue
+		}
+
+		if err := j.Fail("upload interrupted", s.now()); err != nil {
+			return fmt.Errorf("fail job %q: %w", j.ID(), err)
+		}
+		if err := s.jobRepo.Save(ctx, j); err != nil {
+			return fmt.Errorf("save job %q: %w", j.ID(), err)
+		}
+	}
+
+	if len(jobs) > 0 {
+		s.log.Info("unfinished jobs found", slog.Int("count", len(jobs)))
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for range s.opts.Workers {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.work(runCtx)
+		}()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.reclaim(runCtx)
+	}()
+
+	return nil
+}
+
+// Stop stops workers and waits until they exit. Interrupted jobs stay
+// running, their leases are released, so they are resumed by another
+// replica or on the next start.
+func (s *JobService) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// CreateJob creates new job in uploading status owned by caller identity
+// stored in ctx. Requested pepper version, current one by default, is pinned
+// to job.
+func (s *JobService) CreateJob(ctx context.Context, settings job.Settings) (*job.Job, error) {
+	if err := hash.ValidateSalt(settings.Params.Salt); err != nil {
+		return nil, err
+	}
+
+	version, err := s.hashSvc.PepperVersion(settings.Params.PepperVersion)
+	if err != nil {
+		return nil, err
+	}
+	settings.Params.PepperVersion = version
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, _ := identity.FromContext(ctx)
+	j, err := job.New(id, owner, settings, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("new job: %w", err)
+	}
+
+	if err := s.jobRepo.Save(ctx, j); err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+
+	return j, nil
+}
+
+// UploadRows appends rows to job in uploading status.
+func (s *JobService) UploadRows(ctx context.Context, id string, rows []string) error {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	j, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := j.AddRows(int64(len(rows)), s.opts.MaxRows, s.now()); err != nil {
+		return err
+	}
+
+	if err := s.jobRepo.AppendRows(ctx, id, rows); err != nil {
+		return fmt.Errorf("append rows: %w", err)
+	}
+
+	if err := s.jobRepo.Save(ctx, j); err != nil {
+		return fmt.Errorf("save job: %w", err)
+	}
+
+	return nil
+}
+
+// CommitJob finishes job upload and queues job for processing.
+func (s *JobService) CommitJob(ctx context.Context, id string) (*job.Job, error) {
+	j, err := s.update(ctx, id, func(j *job.Job) error {
+		return j.Commit(s.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.enqueue(id)
+
+	return j, nil
+}
+
+// GetJob returns job accessible by caller.
+func (s *JobService) GetJob(ctx context.Context, id string) (*job.Job, error) {
+	return s.find(ctx, id)
+}
+
+// CancelJob cancels unfinished job and stops its processing.
+func (s *JobService) CancelJob(ctx context.Context, id string) (*job.Job, error) {
+	j, err := s.update(ctx, id, func(j *job.Job) error {
+		return j.Cancel(s.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if cancel, ok := s.running[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return j, nil
+}
+
+// WatchJob calls fn with job state every time it changes until job
+// finishes, fn returns an error or ctx is done.
+func (s *JobService) WatchJob(ctx context.Context, id string, fn func(*job.Job) error) error {
+	var updatedAt time.Time
+	for {
+		j, err := s.find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !j.UpdatedAt().Equal(updatedAt) {
+			updatedAt = j.UpdatedAt()
+			if err := fn(j); err != nil {
+				return err
+			}
+		}
+
+		if j.Status().Terminal() {
+			return nil
+		}
+
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// Results calls fn with every job result starting from offset in rows
+// order. Results of running job are streamed as they are stored until job
+// finishes.
+func (s *JobService) Results(ctx context.Context, id string, offset int64, fn func(index int64, r job.Result) error) error {
+	for {
+		j, err := s.find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for {
+			results, err := s.jobRepo.Results(ctx, id, offset, int64(s.opts.ChunkSize))
+			if err != nil {
+				return fmt.Errorf("get results: %w", err)
+			}
+
+			for _, r := range results {
+				if err := fn(offset, r); err != nil {
+					return err
+				}
+				offset++
+			}
+
+			if len(results) < s.opts.ChunkSize {
+				break
+			}
+		}
+
+		if j.Status().Terminal() {
+			return nil
+		}
+
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *JobService) work(ctx context.Context) {
+	for {
+		id, ok := s.next(ctx)
+		if !ok {
+			return
+		}
+
+		if err := s.withLease(ctx, id, func(ctx context.Context) {
+			if err := s.run(ctx, id); err != nil {
+				s.log.Error("failed to run job", slog.String("job", id), slog.String("error", err.Error()))
+				if _, err := s.update(ctx, id, func(j *job.Job) error {
+					return j.Fail(err.Error(), s.now())
+				}); err != nil && !errors.Is(err, job.ErrInvalidTransition) {
+					s.log.Error("failed to fail job", slog.String("job", id), slog.String("error", err.Error()))
+				}
+			}
+		}); err != nil {
+			s.log.Error("failed to lease job", slog.String("job", id), slog.String("error", err.Error()))
+		}
+
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}
+}
+
+// withLease calls fn while job lease is held by service, renewing lease
+// every third of its TTL. Context passed to fn is canceled if lease is lost.
+// Job leased by another replica is skipped.
+func (s *JobService) withLease(ctx context.Context, id string, fn func(ctx context.Context)) error {
+	acquired, err := s.jobRepo.AcquireLease(ctx, id, s.instance, s.opts.LeaseTTL)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+
+	defer func() {
+		if err := s.jobRepo.ReleaseLease(context.WithoutCancel(ctx), id, s.instance); err != nil {
+			s.log.Error("failed to release job lease", slog.String("job", id), slog.String("error", err.Error()))
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+
+		t := time.NewTicker(s.opts.LeaseTTL / 3)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			acquired, err := s.jobRepo.AcquireLease(ctx, id, s.instance, s.opts.LeaseTTL)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.log.Error("failed to renew job lease", slog.String("job", id), slog.String("error", err.Error()))
+				}
+				continue
+			}
+			if !acquired {
+				s.log.Warn("job lease lost", slog.String("job", id))
+				cancel()
+				return
+			}
+		}
+	}()
+
+	fn(ctx)
+	cancel()
+	<-renewed
+
+	return nil
+}
+
+// reclaim queues unfinished jobs every lease TTL, so jobs left by stopped
+// replicas are resumed once their leases expire.
+func (s *JobService) reclaim(ctx context.Context) {
+	t := time.NewTicker(s.opts.LeaseTTL)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		jobs, err := s.jobRepo.FindUnfinished(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error("failed to find unfinished jobs", slog.String("error", err.Error()))
+			}
+			continue
+		}
+
+		for _, j := range jobs {
+			if j.Status() != job.StatusUploading {
+				s.enqueue(j.ID())
+			}
+		}
+	}
+}
+
+// run processes job until it finishes. Job canceled or interrupted by stop
+// isn't considered failed.
+func (s *JobService) run(ctx context.Context, id string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mu.Lock()
+	s.running[id] = cancel
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, id)
+		s.mu.Unlock()
+	}()
+
+	if err := s.process(ctx, id); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// process hashes job rows chunk by chunk starting after the latest stored
+// result.
+func (s *JobService) process(ctx context.Context, id string) error {
+	j, err := s.jobRepo.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("find job: %w", err)
+	}
+
+	processed, failed, err := s.resumePoint(ctx, j)
+	if err != nil {
+		return err
+	}
+
+	j, err = s.progress(ctx, id, processed, failed)
+	if err != nil || j == nil {
+		return err
+	}
+
+	hashCtx := ctx
+	if owner := j.Owner(); owner != nil {
+		hashCtx = identity.NewContext(ctx, owner)
+	}
+
+	for processed < j.Total() {
+		rows, err := s.jobRepo.Rows(ctx, id, processed, int64(s.opts.ChunkSize))
+		if err != nil {
+			return fmt.Errorf("get rows: %w", err)
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("rows after %d are missing", processed)
+		}
+
+		results, hashErr := s.hashRows(hashCtx, rows, j.Settings())
+		if err := s.jobRepo.AppendResults(ctx, id, results); err != nil {
+			return fmt.Errorf("append results: %w", err)
+		}
+
+		for _, r := range results {
+			if r.Error != "" {
+				failed++
+			}
+		}
+		processed += int64(len(results))
+
+		if hashErr != nil {
+			if _, err := s.progress(ctx, id, processed, failed); err != nil {
+				return err
+			}
+			return hashErr
+		}
+
+		if j, err = s.progress(ctx, id, processed, failed); err != nil || j == nil {
+			return err
+		}
+	}
+
+	_, err = s.update(ctx, id, func(j *job.Job) error {
+		return j.Succeed(s.now())
+	})
+
+	return err
+}
+
+// resumePoint returns number of processed and failed rows according to
+// stored results, which may be ahead of job state if process stopped right
+// after results were stored.
+func (s *JobService) resumePoint(ctx context.Context, j *job.Job) (int64, int64, error) {
+	processed, err := s.jobRepo.CountResults(ctx, j.ID())
+	if err != nil {
+		return 0, 0, fmt.Errorf("count results: %w", err)
+	}
+
+	failed := j.Failed()
+	if processed <= j.Processed() {
+		return processed, min(failed, processed), nil
+	}
+
+	results, err := s.jobRepo.Results(ctx, j.ID(), j.Processed(), processed-j.Processed())
+	if err != nil {
+		return 0, 0, fmt.Errorf("get results: %w", err)
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	return processed, failed, nil
+}
+
+// progress starts job if needed and records progress. Returns nil job if job
+// was finished meanwhile, e.g. canceled.
+func (s *JobService) progress(ctx context.Context, id string, processed, failed int64) (*job.Job, error) {
+	j, err := s.update(ctx, id, func(j *job.Job) error {
+		if err := j.Start(s.now()); err != nil {
+			return err
+		}
+		return j.Progress(processed, failed, s.now())
+	})
+	if errors.Is(err, job.ErrInvalidTransition) {
+		return nil, nil
+	}
+
+	return j, err
+}
+
+// hashRows hashes rows with bounded concurrency. Rows rejected by hash
+// service are recorded as failed results. Any other error stops hashing and
+// is returned along with results of rows preceding the failed one.
+func (s *JobService) hashRows(ctx context.Context, rows []string, settings job.Settings) ([]job.Result, error) {
+	var (
+		results = make([]job.Result, len(rows))
+		errs    = make([]error, len(rows))
+		sem     = make(chan struct{}, s.opts.Concurrency)
+		wg      sync.WaitGroup
+	)
+
+	for i, row := range rows {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			h, err := s.hashSvc.CreateHash(ctx, row, settings.Algorithm, settings.Normalization, settings.Params)
+			switch {
+			case err == nil:
+				results[i] = job.Result{Hash: h.Hashed()}
+			case errors.Is(err, hash.ErrEmptyInput), errors.Is(err, ErrAlgorithmRequired):
+				results[i] = job.Result{Error: err.Error()}
+			default:
+				errs[i] = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, tenant.ErrQuotaExceeded) {
+			return results[:i], err
+		}
+		return results[:i], fmt.Errorf("hash row %d: %w", i, err)
+	}
+
+	return results, nil
+}
+
+func (s *JobService) update(ctx context.Context, id string, fn func(*job.Job) error) (*job.Job, error) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	j, err := s.jobRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller, ok := identity.FromContext(ctx); ok && !j.OwnedBy(caller) {
+		return nil, job.ErrNotFound
+	}
+
+	if err := fn(j); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.Save(ctx, j); err != nil {
+		return nil, fmt.Errorf("save job: %w", err)
+	}
+
+	return j, nil
+}
+
+// find finds job accessible by caller. Jobs of other callers are reported as
+// not found.
+func (s *JobService) find(ctx context.Context, id string) (*job.Job, error) {
+	j, err := s.jobRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, _ := identity.FromContext(ctx)
+	if !j.OwnedBy(caller) {
+		return nil, job.ErrNotFound
+	}
+
+	return j, nil
+}
+
+// enqueue queues job unless it is already queued or being processed.
+func (s *JobService) enqueue(id string) {
+	s.mu.Lock()
+	if _, ok := s.pending[id]; ok {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[id] = struct{}{}
+	s.queue = append(s.queue, id)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *JobService) next(ctx context.Context) (string, bool) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			id := s.queue[0]
+			s.queue = s.queue[1:]
+			more := len(s.queue) > 0
+			s.mu.Unlock()
+
+			if more {
+				select {
+				case s.notify <- struct{}{}:
+				default:
+				}
+			}
+			return id, true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return "", false
+		case <-s.notify:
+		}
+	}
+}
+
+func (s *JobService) wait(ctx context.Context) error {
+	t := time.NewTimer(s.opts.PollInterval)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate job id: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
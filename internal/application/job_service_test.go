@@ -0,0 +1,378 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+type mockJobRepository struct {
+	mu      sync.Mutex
+	jobs    map[string]job.Snapshot
+	rows    map[string][]string
+	results map[string][]job.Result
+	leases  map[string]mockLease
+}
+
+type mockLease struct {
+	owner  string
+	expiry time.Time
+}
+
+func newMockJobRepository() *mockJobRepository {
+	return &mockJobRepository{
+		jobs:    make(map[string]job.Snapshot),
+		rows:    make(map[string][]string),
+		results: make(map[string][]job.Result),
+		leases:  make(map[string]mockLease),
+	}
+}
+
+func (m *mockJobRepository) Save(_ context.Context, j *job.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[j.ID()] = j.Snapshot()
+	return nil
+}
+
+func (m *mockJobRepository) Find(_ context.Context, id string) (*job.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.jobs[id]
+	if !ok {
+		return nil, job.ErrNotFound
+	}
+	return job.FromSnapshot(s)
+}
+
+func (m *mockJobRepository) FindUnfinished(_ context.Context) ([]*job.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jobs []*job.Job
+	for _, s := range m.jobs {
+		if !s.Status.Terminal() {
+			j, _ := job.FromSnapshot(s)
+			jobs = append(jobs, j)
+		}
+	}
+	return jobs, nil
+}
+
+func (m *mockJobRepository) AppendRows(_ context.Context, id string, rows []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows[id] = append(m.rows[id], rows...)
+	return nil
+}
+
+func (m *mockJobRepository) Rows(_ context.Context, id string, offset, limit int64) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return window(m.rows[id], offset, limit), nil
+}
+
+func (m *mockJobRepository) AppendResults(_ context.Context, id string, results []job.Result) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[id] = append(m.results[id], results...)
+	return nil
+}
+
+func (m *mockJobRepository) Results(_ context.Context, id string, offset, limit int64) ([]job.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return window(m.results[id], offset, limit), nil
+}
+
+func (m *mockJobRepository) CountResults(_ context.Context, id string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.results[id])), nil
+}
+
+func (m *mockJobRepository) AcquireLease(_ context.Context, id, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[id]; ok && l.owner != owner && time.Now().Before(l.expiry) {
+		return false, nil
+	}
+	m.leases[id] = mockLease{owner: owner, expiry: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (m *mockJobRepository) ReleaseLease(_ context.Context, id, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.leases[id].owner == owner {
+		delete(m.leases, id)
+	}
+	return nil
+}
+
+func window[T any](s []T, offset, limit int64) []T {
+	if offset >= int64(len(s)) {
+		return nil
+	}
+	return append([]T(nil), s[offset:min(offset+limit, int64(len(s)))]...)
+}
+
+func newTestJobService(t *testing.T, repo job.Repository, tenants *tenant.Registry, calls *atomic.Int64) *JobService {
+	t.Helper()
+
+	hashRepo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error { return nil },
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(input string) string {
+			calls.Add(1)
+			return "h(" + input + ")"
+		}},
+	}
+
+	hashSvc := NewHashService(hashRepo, &mockUsageRepository{counts: map[string]int64{}}, tenants, nil, hashers, nil, nil)
+	svc := NewJobService(repo, hashSvc, JobOptions{ChunkSize: 2, Concurrency: 2, MaxRows: 10, PollInterval: time.Millisecond, LeaseTTL: 30 * time.Millisecond}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(svc.Stop)
+
+	return svc
+}
+
+func TestJobService(t *testing.T) {
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	var calls atomic.Int64
+	svc := newTestJobService(t, newMockJobRepository(), mustRegistry(), &calls)
+
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	j, err := svc.CreateJob(ctx, job.Settings{Algorithm: hash.AlgorithmMD5, Normalization: hash.NormalizationTrim})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.UploadRows(ctx, j.ID(), []string{"a", " "}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.UploadRows(ctx, j.ID(), []string{" b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.UploadRows(ctx, j.ID(), make([]string, 8)); !errors.Is(err, job.ErrTooManyRows) {
+		t.Errorf("expected %v, got %v", job.ErrTooManyRows, err)
+	}
+
+	if _, err := svc.CommitJob(ctx, j.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var last *job.Job
+	if err := svc.WatchJob(ctx, j.ID(), func(j *job.Job) error {
+		last = j
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if last.Status() != job.StatusSucceeded || last.Processed() != 3 || last.Failed() != 1 {
+		t.Errorf("unexpected job state %+v", last.Snapshot())
+	}
+
+	var got []job.Result
+	if err := svc.Results(ctx, j.ID(), 1, func(i int64, r job.Result) error {
+		if i != int64(len(got))+1 {
+			t.Errorf("expected index %d, got %d", len(got)+1, i)
+		}
+		got = append(got, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := []job.Result{{Error: hash.ErrEmptyInput.Error()}, {Hash: "h(b)"}}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %v, got %v", expect, got)
+	}
+
+	other := identity.NewContext(context.Background(), mustIdentity(t, "exporter"))
+	if _, err := svc.GetJob(other, j.ID()); !errors.Is(err, job.ErrNotFound) {
+		t.Errorf("expected job of other caller to be hidden, got %v", err)
+	}
+	if _, err := svc.CancelJob(other, j.ID()); !errors.Is(err, job.ErrNotFound) {
+		t.Errorf("expected job of other caller to be hidden, got %v", err)
+	}
+}
+
+func TestJobService_Resume(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockJobRepository()
+	now := time.Now()
+
+	running, _ := job.New("running", nil, job.Settings{Algorithm: hash.AlgorithmMD5}, now)
+	_ = running.AddRows(4, 0, now)
+	_ = running.Commit(now)
+	_ = running.Start(now)
+	_ = running.Progress(1, 0, now)
+	_ = repo.Save(ctx, running)
+	_ = repo.AppendRows(ctx, "running", []string{"a", "", "c", "d"})
+	// Second result was stored but progress wasn't saved before restart.
+	_ = repo.AppendResults(ctx, "running", []job.Result{{Hash: "h(a)"}, {Error: "empty"}})
+
+	uploading, _ := job.New("uploading", nil, job.Settings{}, now)
+	_ = repo.Save(ctx, uploading)
+
+	canceled, _ := job.New("canceled", nil, job.Settings{Algorithm: hash.AlgorithmMD5}, now)
+	_ = canceled.AddRows(1, 0, now)
+	_ = canceled.Commit(now)
+	_ = repo.Save(ctx, canceled)
+	_ = repo.AppendRows(ctx, "canceled", []string{"x"})
+
+	var calls atomic.Int64
+	svc := newTestJobService(t, repo, mustRegistry(), &calls)
+
+	if _, err := svc.CancelJob(ctx, "canceled"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.WatchJob(ctx, "running", func(*job.Job) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	j, _ := svc.GetJob(ctx, "running")
+	if j.Status() != job.StatusSucceeded || j.Processed() != 4 || j.Failed() != 1 {
+		t.Errorf("unexpected resumed job state %+v", j.Snapshot())
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected only remaining 2 rows to be hashed, got %d", n)
+	}
+
+	if j, _ := svc.GetJob(ctx, "uploading"); j.Status() != job.StatusFailed {
+		t.Errorf("expected interrupted upload to fail, got %v", j.Status())
+	}
+
+	if j, _ := svc.GetJob(ctx, "canceled"); j.Status() != job.StatusCanceled || len(repo.results["canceled"]) != 0 {
+		t.Errorf("expected canceled job not to be processed, got %+v", j.Snapshot())
+	}
+}
+
+func TestJobService_Replicas(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockJobRepository()
+	now := time.Now()
+
+	pending, _ := job.New("pending", nil, job.Settings{Algorithm: hash.AlgorithmMD5}, now)
+	_ = pending.AddRows(6, 0, now)
+	_ = pending.Commit(now)
+	_ = repo.Save(ctx, pending)
+	_ = repo.AppendRows(ctx, "pending", []string{"a", "b", "c", "d", "e", "f"})
+
+	var calls atomic.Int64
+	replicas := []*JobService{
+		newTestJobService(t, repo, mustRegistry(), &calls),
+		newTestJobService(t, repo, mustRegistry(), &calls),
+	}
+	for _, svc := range replicas {
+		if err := svc.Start(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := replicas[0].WatchJob(ctx, "pending", func(*job.Job) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if j, _ := replicas[0].GetJob(ctx, "pending"); j.Status() != job.StatusSucceeded {
+		t.Errorf("expected job to succeed, got %+v", j.Snapshot())
+	}
+
+	if n := calls.Load(); n != 6 {
+		t.Errorf("expected every row to be hashed once, got %d hashes", n)
+	}
+}
+
+func TestJobService_Leased(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockJobRepository()
+	now := time.Now()
+
+	pending, _ := job.New("pending", nil, job.Settings{Algorithm: hash.AlgorithmMD5}, now)
+	_ = pending.AddRows(1, 0, now)
+	_ = pending.Commit(now)
+	_ = repo.Save(ctx, pending)
+	_ = repo.AppendRows(ctx, "pending", []string{"a"})
+	_, _ = repo.AcquireLease(ctx, "pending", "other", time.Hour)
+
+	var calls atomic.Int64
+	svc := newTestJobService(t, repo, mustRegistry(), &calls)
+	if err := svc.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if j, _ := svc.GetJob(ctx, "pending"); j.Status() != job.StatusPending || calls.Load() != 0 {
+		t.Fatalf("expected job leased by other replica not to be processed, got %+v", j.Snapshot())
+	}
+
+	// Lease of stopped replica is gone, job is reclaimed.
+	_ = repo.ReleaseLease(ctx, "pending", "other")
+
+	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := svc.WatchJob(watchCtx, "pending", func(*job.Job) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if j, _ := svc.GetJob(ctx, "pending"); j.Status() != job.StatusSucceeded || calls.Load() != 1 {
+		t.Errorf("expected reclaimed job to succeed, got %+v", j.Snapshot())
+	}
+
+	svc.Stop()
+	if acquired, _ := repo.AcquireLease(ctx, "pending", "other", time.Hour); !acquired {
+		t.Error("expected lease to be released after job finished")
+	}
+}
+
+func TestJobService_QuotaExceeded(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{DailyQuota: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	var calls atomic.Int64
+	svc := newTestJobService(t, newMockJobRepository(), mustRegistry(sales), &calls)
+	_ = svc.Start(context.Background())
+
+	j, _ := svc.CreateJob(ctx, job.Settings{Algorithm: hash.AlgorithmMD5})
+	_ = svc.UploadRows(ctx, j.ID(), []string{"a", "b", "c", "d"})
+	if _, err := svc.CommitJob(ctx, j.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.WatchJob(ctx, j.ID(), func(*job.Job) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	j, _ = svc.GetJob(ctx, j.ID())
+	if j.Status() != job.StatusFailed || j.Error() != tenant.ErrQuotaExceeded.Error() || j.Processed() != 2 {
+		t.Errorf("unexpected job state %+v", j.Snapshot())
+	}
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/kdf"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// KDFService serves key derivation business logic. Contains key deriver,
+// set of callers permitted to derive keys and tenants registry. Derived keys
+// are secrets, so they are never saved, unlike hashes.
+//
+// HKDF keys are bound to caller tenant or, for callers of default tenant, to
+// caller subject, so no caller derives keys of another one.
+type KDFService struct {
+	deriver  kdf.Deriver
+	derivers *identity.Allowlist
+	tenants  *tenant.Registry
+}
+
+// NewKDFService creates new instance of KDF service. Derivers are callers
+// permitted to derive keys, nil allowlist permits no one.
+func NewKDFService(deriver kdf.Deriver, derivers *identity.Allowlist, tenants *tenant.Registry) *KDFService {
+	return &KDFService{
+		deriver:  deriver,
+		derivers: derivers,
+		tenants:  tenants,
+	}
+}
+
+// HKDF derives key of length bytes from master key with HKDF and info bound
+// to caller scope. Zero algorithm means SHA256.
+func (s *KDFService) HKDF(ctx context.Context, alg hash.Algorithm, salt []byte, info string, length int) ([]byte, error) {
+	id, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kdf.ValidateHKDF(salt, info, length); err != nil {
+		return nil, err
+	}
+
+	return s.deriver.HKDF(kdfAlgorithm(alg), salt, kdf.BindInfo(s.scope(ctx, id), info), length)
+}
+
+// PBKDF2 derives key of length bytes from password with PBKDF2. Zero
+// algorithm means SHA256.
+func (s *KDFService) PBKDF2(ctx context.Context, alg hash.Algorithm, password string, salt []byte, iterations, length int) ([]byte, error) {
+	if _, err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	alg = kdfAlgorithm(alg)
+	if err := kdf.ValidatePBKDF2(alg, password, salt, iterations, length); err != nil {
+		return nil, err
+	}
+
+	return s.deriver.PBKDF2(alg, password, salt, iterations, length)
+}
+
+func (s *KDFService) authorize(ctx context.Context) (*identity.Identity, error) {
+	id, _ := identity.FromContext(ctx)
+	if !s.derivers.Permits(id) {
+		return nil, kdf.ErrForbidden
+	}
+
+	return id, nil
+}
+
+// scope returns derivation scope of caller, its tenant or its source and
+// subject if caller belongs to default tenant.
+func (s *KDFService) scope(ctx context.Context, id *identity.Identity) string {
+	if name := callerTenant(ctx, s.tenants).Name(); name != "" {
+		return "tenant:" + name
+	}
+
+	return "client:" + id.Source().String() + ":" + id.Subject()
+}
+
+func kdfAlgorithm(alg hash.Algorithm) hash.Algorithm {
+	if alg == 0 {
+		return hash.AlgorithmSHA256
+	}
+
+	return alg
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/kdf"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// mockDeriver derives keys describing its arguments.
+type mockDeriver struct{}
+
+func (mockDeriver) HKDF(alg hash.Algorithm, salt []byte, info string, length int) ([]byte, error) {
+	return fmt.Appendf(nil, "hkdf %s %s %q %d", alg, salt, info, length), nil
+}
+
+func (mockDeriver) PBKDF2(alg hash.Algorithm, password string, salt []byte, iterations, length int) ([]byte, error) {
+	return fmt.Appendf(nil, "pbkdf2 %s %s %s %d %d", alg, password, salt, iterations, length), nil
+}
+
+func TestKDFService_HKDF(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"billing"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewKDFService(mockDeriver{}, mustAllowlist(t, "apikey:billing"), mustRegistry(sales))
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "billing"))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		alg         hash.Algorithm
+		length      int
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "permitted caller",
+			ctx:      ctx,
+			alg:      hash.AlgorithmMD5,
+			length:   32,
+			expected: `hkdf md5 salt "\x00\x00\x00\ftenant:salesdb" 32`,
+		},
+		{
+			name:     "default algorithm",
+			ctx:      ctx,
+			length:   16,
+			expected: `hkdf sha256 salt "\x00\x00\x00\ftenant:salesdb" 16`,
+		},
+		{
+			name:        "anonymous caller",
+			ctx:         context.Background(),
+			length:      32,
+			expectedErr: kdf.ErrForbidden,
+		},
+		{
+			name:        "other caller",
+			ctx:         identity.NewContext(context.Background(), mustIdentity(t, "importer")),
+			length:      32,
+			expectedErr: kdf.ErrForbidden,
+		},
+		{
+			name:        "invalid length",
+			ctx:         ctx,
+			length:      kdf.MaxLength + 1,
+			expectedErr: kdf.ErrInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := svc.HKDF(tt.ctx, tt.alg, []byte("salt"), "db", tt.length)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if string(key) != tt.expected {
+				t.Errorf("expected key %q, got %q", tt.expected, key)
+			}
+		})
+	}
+}
+
+func TestKDFService_HKDF_CallerIsolation(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"billing", "crm"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	marketing, err := tenant.New("marketing", []string{"ads"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewKDFService(mockDeriver{}, mustAllowlist(t,
+		"apikey:billing", "apikey:crm", "apikey:ads", "apikey:importer", "apikey:exporter", "certificate:importer",
+	), mustRegistry(sales, marketing))
+
+	derive := func(subject, info string) string {
+		t.Helper()
+		key, err := svc.HKDF(identity.NewContext(context.Background(), mustIdentity(t, subject)), 0, nil, info, 32)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return string(key)
+	}
+
+	if derive("billing", "db") != derive("crm", "db") {
+		t.Error("expected callers of the same tenant to derive equal keys")
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		info    string
+	}{
+		{"other tenant", "ads", "db"},
+		{"other tenant forging scope", "ads", kdf.BindInfo("tenant:sales", "db")},
+		{"default tenant caller", "importer", "db"},
+		{"default tenant caller forging scope", "importer", "\x00\x00\x00\x0ctenant:salesdb"},
+	}
+
+	victim := derive("billing", "db")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if derive(tt.subject, tt.info) == victim {
+				t.Errorf("expected %s not to reproduce key of another tenant", tt.subject)
+			}
+		})
+	}
+
+	if derive("importer", "db") == derive("exporter", "db") {
+		t.Error("expected default tenant callers to derive keys of their own")
+	}
+
+	key, err := svc.HKDF(identity.NewContext(context.Background(), mustCertificateIdentity(t, "importer")), 0, nil, "db", 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(key) == derive("importer", "db") {
+		t.Error("expected certificate and API key of the same name to derive keys of their own")
+	}
+}
+
+func TestKDFService_PBKDF2(t *testing.T) {
+	svc := NewKDFService(mockDeriver{}, mustAllowlist(t, "apikey:billing"), mustRegistry())
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "billing"))
+
+	key, err := svc.PBKDF2(ctx, 0, "secret", []byte("salt"), kdf.MinIterations, 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "pbkdf2 sha256 secret salt 1000 32"; string(key) != expected {
+		t.Errorf("expected key %q, got %q", expected, key)
+	}
+
+	if _, err := svc.PBKDF2(ctx, 0, "secret", nil, 1, 32); !errors.Is(err, kdf.ErrInvalidIterations) {
+		t.Errorf("expected error %v, got %v", kdf.ErrInvalidIterations, err)
+	}
+
+	if _, err := svc.PBKDF2(context.Background(), 0, "secret", nil, kdf.MinIterations, 32); !errors.Is(err, kdf.ErrForbidden) {
+		t.Errorf("expected error %v, got %v", kdf.ErrForbidden, err)
+	}
+
+	certificate := identity.NewContext(context.Background(), mustCertificateIdentity(t, "billing"))
+	if _, err := svc.PBKDF2(certificate, 0, "secret", nil, kdf.MinIterations, 32); !errors.Is(err, kdf.ErrForbidden) {
+		t.Errorf("expected certificate named as deriver to get %v, got %v", kdf.ErrForbidden, err)
+	}
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/merkle"
+)
+
+// MerkleService serves Merkle trees business logic. Contains map of hashers.
+type MerkleService struct {
+	hashers map[hash.Algorithm]hash.Hasher
+}
+
+// NewMerkleService creates new instance of Merkle service.
+func NewMerkleService(hashers map[hash.Algorithm]hash.Hasher) *MerkleService {
+	return &MerkleService{
+		hashers: hashers,
+	}
+}
+
+// BuildTree builds Merkle tree over leaves by given algorithm.
+func (s *MerkleService) BuildTree(_ context.Context, leaves []string, alg hash.Algorithm) (*merkle.Tree, error) {
+	if len(leaves) == 0 {
+		return nil, merkle.ErrEmptyTree
+	}
+
+	hasher, err := s.hasher(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	return merkle.NewTree(hasher, leaves)
+}
+
+// VerifyInclusion reports whether leaf is at index of tree of given size and
+// root by its audit path. Malformed proofs are reported as errors.
+func (s *MerkleService) VerifyInclusion(_ context.Context, alg hash.Algorithm, leaf string, index, size int64, path []string, root string) (bool, error) {
+	hasher, err := s.hasher(alg)
+	if err != nil {
+		return false, err
+	}
+
+	err = merkle.VerifyInclusion(hasher, leaf, index, size, path, root)
+	if errors.Is(err, merkle.ErrInvalidProof) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *MerkleService) hasher(alg hash.Algorithm) (hash.Hasher, error) {
+	if alg == 0 {
+		return nil, ErrAlgorithmRequired
+	}
+
+	hasher, ok := s.hashers[alg]
+	if !ok {
+		return nil, fmt.Errorf("hasher for algorithm %v not registered", alg)
+	}
+
+	return hasher, nil
+}
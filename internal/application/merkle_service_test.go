@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestMerkleService(t *testing.T) {
+	svc := NewMerkleService(map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(input string) string { return fmt.Sprintf("%x", md5.Sum([]byte(input))) }},
+	})
+	ctx := context.Background()
+	leaves := []string{"a@example.com", "b@example.com", "c@example.com"}
+
+	tree, err := svc.BuildTree(ctx, leaves, hash.AlgorithmMD5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, err := tree.Proof(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		alg    hash.Algorithm
+		leaf   string
+		expect bool
+		err    error
+	}{
+		{"included", hash.AlgorithmMD5, leaves[2], true, nil},
+		{"not included", hash.AlgorithmMD5, "d@example.com", false, nil},
+		{"no algorithm", 0, leaves[2], false, ErrAlgorithmRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.VerifyInclusion(ctx, tt.alg, tt.leaf, 2, tree.Size(), path, tree.Root())
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+
+	if _, err := svc.BuildTree(ctx, leaves, hash.AlgorithmSHA256); err == nil {
+		t.Error("expected error for unregistered hasher")
+	}
+}
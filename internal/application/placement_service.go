@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/placement"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+const (
+	// maxNodeSetSaveAttempts is a maximum number of attempts to save node
+	// set changed concurrently.
+	maxNodeSetSaveAttempts = 16
+	// maxCachedNodeSets is a maximum number of built node sets kept.
+	maxCachedNodeSets = 1024
+)
+
+// PlacementService serves placement of keys on named node sets by
+// consistent hashing. Contains implementation of node sets repository and
+// tenants registry.
+//
+// Node sets are kept per caller tenant. Node set changes are saved only if
+// node set wasn't changed since it was found, so concurrent writers of any
+// replica don't lose each other nodes. Changes report estimated fraction of
+// keys moved to other nodes for given replicas count, 1 if it's zero. Built
+// node sets are cached by name and version, so rings aren't rebuilt on every
+// lookup.
+type PlacementService struct {
+	setRepo placement.Repository
+	tenants *tenant.Registry
+
+	mu   sync.Mutex
+	sets map[nodeSetCacheKey]cachedNodeSet
+}
+
+type nodeSetCacheKey struct {
+	tenant string
+	name   string
+}
+
+type cachedNodeSet struct {
+	set     *placement.NodeSet
+	version uint64
+}
+
+// NewPlacementService creates new instance of placement service.
+func NewPlacementService(setRepo placement.Repository, tenants *tenant.Registry) *PlacementService {
+	return &PlacementService{
+		setRepo: setRepo,
+		tenants: tenants,
+		sets:    map[nodeSetCacheKey]cachedNodeSet{},
+	}
+}
+
+// Register registers node set replacing registered one of the same name.
+// Returns fraction of keys moved from replaced node set, zero if there was
+// none.
+func (s *PlacementService) Register(ctx context.Context, name string, kind placement.Kind, nodes []string, virtualNodes, replicas int) (*placement.NodeSet, float64, error) {
+	set, err := placement.NewNodeSet(name, kind, nodes, virtualNodes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	t := callerTenant(ctx, s.tenants).Name()
+	for range maxNodeSetSaveAttempts {
+		prev, version, err := s.setRepo.Find(ctx, t, name)
+		if err != nil && !errors.Is(err, placement.ErrNotFound) {
+			return nil, 0, fmt.Errorf("find node set: %w", err)
+		}
+
+		saved, err := s.setRepo.Save(ctx, t, set, version)
+		if errors.Is(err, placement.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("save node set: %w", err)
+		}
+		s.cache(t, set, saved)
+
+		if prev == nil {
+			return set, 0, nil
+		}
+		return set, placement.Moved(prev, set, max(replicas, 1)), nil
+	}
+
+	return nil, 0, placement.ErrConflict
+}
+
+// NodeSet returns node set by name.
+func (s *PlacementService) NodeSet(ctx context.Context, name string) (*placement.NodeSet, error) {
+	if err := placement.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	t := callerTenant(ctx, s.tenants).Name()
+	version, err := s.setRepo.Version(ctx, t, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cached, ok := s.sets[nodeSetCacheKey{tenant: t, name: name}]
+	s.mu.Unlock()
+	if ok && cached.version == version {
+		return cached.set, nil
+	}
+
+	set, version, err := s.setRepo.Find(ctx, t, name)
+	if err != nil {
+		return nil, err
+	}
+	s.cache(t, set, version)
+
+	return set, nil
+}
+
+// AddNodes appends nodes to node set. Returns fraction of moved keys.
+func (s *PlacementService) AddNodes(ctx context.Context, name string, nodes []string, replicas int) (*placement.NodeSet, float64, error) {
+	return s.update(ctx, name, replicas, func(set *placement.NodeSet) (*placement.NodeSet, error) {
+		return set.Add(nodes...)
+	})
+}
+
+// RemoveNodes removes nodes from node set. Returns fraction of moved keys.
+func (s *PlacementService) RemoveNodes(ctx context.Context, name string, nodes []string, replicas int) (*placement.NodeSet, float64, error) {
+	return s.update(ctx, name, replicas, func(set *placement.NodeSet) (*placement.NodeSet, error) {
+		return set.Remove(nodes...)
+	})
+}
+
+// Locate returns replicas nodes of every key in the same order, 1 if
+// replicas is zero.
+func (s *PlacementService) Locate(ctx context.Context, name string, keys []string, replicas int) ([][]string, error) {
+	set, err := s.NodeSet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	placed := make([][]string, len(keys))
+	for i, key := range keys {
+		if placed[i], err = set.Locate(key, replicas); err != nil {
+			return nil, err
+		}
+	}
+
+	return placed, nil
+}
+
+func (s *PlacementService) update(ctx context.Context, name string, replicas int, fn func(*placement.NodeSet) (*placement.NodeSet, error)) (*placement.NodeSet, float64, error) {
+	if err := placement.ValidateName(name); err != nil {
+		return nil, 0, err
+	}
+
+	t := callerTenant(ctx, s.tenants).Name()
+	for range maxNodeSetSaveAttempts {
+		prev, version, err := s.setRepo.Find(ctx, t, name)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		set, err := fn(prev)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		saved, err := s.setRepo.Save(ctx, t, set, version)
+		if errors.Is(err, placement.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("save node set: %w", err)
+		}
+		s.cache(t, set, saved)
+
+		return set, placement.Moved(prev, set, max(replicas, 1)), nil
+	}
+
+	return nil, 0, placement.ErrConflict
+}
+
+// cache keeps built node set of given version. Cache is emptied once it's
+// full, since node sets are rebuilt on demand.
+func (s *PlacementService) cache(tenant string, set *placement.NodeSet, version uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := nodeSetCacheKey{tenant: tenant, name: set.Name()}
+	if cached, ok := s.sets[key]; ok && cached.version > version {
+		return
+	}
+
+	if len(s.sets) >= maxCachedNodeSets {
+		clear(s.sets)
+	}
+	s.sets[key] = cachedNodeSet{set: set, version: version}
+}
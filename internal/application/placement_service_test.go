@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/placement"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+type mockNodeSetRepository struct {
+	mu       sync.Mutex
+	sets     map[string]*placement.NodeSet
+	versions map[string]uint64
+	finds    int
+}
+
+func (m *mockNodeSetRepository) Save(_ context.Context, tenant string, set *placement.NodeSet, version uint64) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := tenant + "/" + set.Name()
+	if m.versions[k] != version {
+		return 0, placement.ErrConflict
+	}
+	m.sets[k] = set
+	m.versions[k]++
+	return m.versions[k], nil
+}
+
+func (m *mockNodeSetRepository) Find(_ context.Context, tenant, name string) (*placement.NodeSet, uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finds++
+	set, ok := m.sets[tenant+"/"+name]
+	if !ok {
+		return nil, 0, placement.ErrNotFound
+	}
+	return set, m.versions[tenant+"/"+name], nil
+}
+
+func (m *mockNodeSetRepository) Version(_ context.Context, tenant, name string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	version, ok := m.versions[tenant+"/"+name]
+	if !ok {
+		return 0, placement.ErrNotFound
+	}
+	return version, nil
+}
+
+func newMockNodeSetRepository() *mockNodeSetRepository {
+	return &mockNodeSetRepository{sets: map[string]*placement.NodeSet{}, versions: map[string]uint64{}}
+}
+
+func TestPlacementService(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewPlacementService(newMockNodeSetRepository(), mustRegistry(sales))
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+
+	set, moved, err := svc.Register(ctx, "shards", placement.KindRing, []string{"a", "b", "c"}, 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if set.VirtualNodes() != placement.DefaultVirtualNodes || moved != 0 {
+		t.Errorf("expected new node set with default virtual nodes, got %d, %v", set.VirtualNodes(), moved)
+	}
+
+	if _, err := svc.NodeSet(context.Background(), "shards"); !errors.Is(err, placement.ErrNotFound) {
+		t.Errorf("expected node sets to be isolated by tenant, got %v", err)
+	}
+
+	placed, err := svc.Locate(ctx, "shards", []string{"lead-1", "lead-2"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(placed) != 2 || len(placed[0]) != 1 || len(placed[1]) != 1 {
+		t.Fatalf("expected single node of every key, got %v", placed)
+	}
+
+	set, moved, err = svc.AddNodes(ctx, "shards", []string{"d"}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(set.Nodes()) != 4 || moved <= 0 || moved > 0.5 {
+		t.Errorf("expected 4 nodes and part of keys moved, got %v, %v", set.Nodes(), moved)
+	}
+
+	if _, moved, err = svc.RemoveNodes(ctx, "shards", []string{"d"}, 1); err != nil || moved <= 0 {
+		t.Errorf("expected keys to move back, got %v, %v", moved, err)
+	}
+
+	if _, moved, err = svc.Register(ctx, "shards", placement.KindRing, []string{"a", "b", "c"}, 0, 1); err != nil || moved != 0 {
+		t.Errorf("expected re-registering the same nodes to move nothing, got %v, %v", moved, err)
+	}
+}
+
+func TestPlacementService_Errors(t *testing.T) {
+	svc := NewPlacementService(newMockNodeSetRepository(), mustRegistry())
+	ctx := context.Background()
+
+	if _, _, err := svc.Register(ctx, "shards", placement.KindJump, []string{"a", "b"}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		fn        func() error
+		expectErr error
+	}{
+		{"invalid name", func() error {
+			_, err := svc.NodeSet(ctx, "")
+			return err
+		}, placement.ErrInvalidName},
+		{"add to missing", func() error {
+			_, _, err := svc.AddNodes(ctx, "missing", []string{"c"}, 1)
+			return err
+		}, placement.ErrNotFound},
+		{"add duplicate", func() error {
+			_, _, err := svc.AddNodes(ctx, "shards", []string{"a"}, 1)
+			return err
+		}, placement.ErrDuplicateNode},
+		{"remove unknown", func() error {
+			_, _, err := svc.RemoveNodes(ctx, "shards", []string{"c"}, 1)
+			return err
+		}, placement.ErrUnknownNode},
+		{"too many replicas", func() error {
+			_, err := svc.Locate(ctx, "shards", []string{"lead"}, 3)
+			return err
+		}, placement.ErrInvalidReplicas},
+		{"empty key", func() error {
+			_, err := svc.Locate(ctx, "shards", []string{""}, 1)
+			return err
+		}, placement.ErrEmptyKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestPlacementService_Replicas(t *testing.T) {
+	repo := newMockNodeSetRepository()
+	a := NewPlacementService(repo, mustRegistry())
+	b := NewPlacementService(repo, mustRegistry())
+	ctx := context.Background()
+
+	if _, _, err := a.Register(ctx, "shards", placement.KindRing, []string{"a", "b"}, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	finds := repo.finds
+	for range 10 {
+		if _, err := a.Locate(ctx, "shards", []string{"lead"}, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if repo.finds != finds {
+		t.Errorf("expected cached node set to be located on, got %d finds", repo.finds-finds)
+	}
+
+	var wg sync.WaitGroup
+	for _, node := range []string{"c", "d", "e", "f"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := b.AddNodes(ctx, "shards", []string{node}, 1); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	set, err := a.NodeSet(ctx, "shards")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(set.Nodes()) != 6 {
+		t.Errorf("expected nodes added by another replica, got %v", set.Nodes())
+	}
+}
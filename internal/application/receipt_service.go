@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+)
+
+// ReceiptService serves signed hash receipts. Contains receipts signer and
+// published verification keys.
+type ReceiptService struct {
+	signer receipt.Signer
+	keys   []receipt.Key
+	now    func() time.Time
+}
+
+// NewReceiptService creates new instance of receipt service. Keys should
+// include signer key and previous keys receipts were signed with.
+func NewReceiptService(signer receipt.Signer, keys []receipt.Key) *ReceiptService {
+	return &ReceiptService{
+		signer: signer,
+		keys:   keys,
+		now:    time.Now,
+	}
+}
+
+// Issue issues signed receipt of hash bound to caller request id.
+func (s *ReceiptService) Issue(_ context.Context, h *hash.Hash, requestID string) (string, error) {
+	r := &receipt.Receipt{
+		Algorithm: h.Algorithm(),
+		Digest:    h.Hashed(),
+		IssuedAt:  s.now(),
+		RequestID: requestID,
+	}
+
+	return r.Sign(s.signer)
+}
+
+// Keys returns published verification keys.
+func (s *ReceiptService) Keys(_ context.Context) []receipt.Key {
+	return s.keys
+}
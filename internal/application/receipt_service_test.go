@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+)
+
+func TestReceiptService(t *testing.T) {
+	ctx := context.Background()
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	keys := []receipt.Key{{ID: "key-1", PublicKey: key.Public().(ed25519.PublicKey)}}
+
+	svc := NewReceiptService(&mockSigner{key: key}, keys)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	h := mustCreateHash("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5)
+	token, err := svc.Issue(ctx, h, "req-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := receipt.Verify(token, svc.Keys(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := receipt.Receipt{
+		Algorithm: hash.AlgorithmMD5,
+		Digest:    h.Hashed(),
+		IssuedAt:  now,
+		KeyID:     "key-1",
+		RequestID: "req-1",
+	}
+	if got.Algorithm != expect.Algorithm || got.Digest != expect.Digest || !got.IssuedAt.Equal(expect.IssuedAt) ||
+		got.KeyID != expect.KeyID || got.RequestID != expect.RequestID {
+		t.Errorf("expected %+v, got %+v", expect, got)
+	}
+
+	if _, err := svc.Issue(ctx, h, strings.Repeat("x", 200)); !errors.Is(err, receipt.ErrRequestIDTooLong) {
+		t.Errorf("expected error %v, got %v", receipt.ErrRequestIDTooLong, err)
+	}
+}
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+// RecordService serves structured records hashing by named recipes. Contains
+// hash service and recipes.
+type RecordService struct {
+	hashSvc *HashService
+	recipes *record.Recipes
+}
+
+// NewRecordService creates new instance of record service.
+func NewRecordService(hashSvc *HashService, recipes *record.Recipes) *RecordService {
+	return &RecordService{
+		hashSvc: hashSvc,
+		recipes: recipes,
+	}
+}
+
+// HashRecord hashes composite key of record fields built by named recipe.
+// Fields are normalized by recipe, so tenant normalization is not applied.
+func (s *RecordService) HashRecord(ctx context.Context, recipeName string, fields map[string]string, params hash.Params) (*hash.Hash, error) {
+	recipe, err := s.recipes.Find(recipeName)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := recipe.Compose(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.hashSvc.CreateHash(ctx, key, recipe.Algorithm(), hash.NormalizationNone, params)
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+func TestRecordService_HashRecord(t *testing.T) {
+	var gotHasherIn string
+	repo := &mockRepository{
+		findByInputFunc: func(context.Context, string, string, hash.Algorithm, hash.Params) (*hash.Hash, error) {
+			return nil, errors.New("not found")
+		},
+		saveFunc: func(context.Context, string, *hash.Hash, time.Duration) error {
+			return nil
+		},
+	}
+	hashers := map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5: &mockHasher{hashFunc: func(input string) string {
+			gotHasherIn = input
+			return "hashed"
+		}},
+	}
+	hashSvc := NewHashService(repo, &mockUsageRepository{}, mustRegistry(), nil, hashers, nil, nil)
+
+	lead, err := record.NewRecipe("lead", []record.RecipeField{
+		{Name: "email", Normalization: hash.NormalizationLower},
+		{Name: "phone", Normalization: hash.NormalizationDigits},
+	}, record.EncodingSeparator, "|", hash.AlgorithmMD5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipes, err := record.NewRecipes(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewRecordService(hashSvc, recipes)
+
+	tests := []struct {
+		name      string
+		recipe    string
+		fields    map[string]string
+		expectIn  string
+		expectErr error
+	}{
+		{"composite", "lead", map[string]string{"email": "Foo@Example.com ", "phone": "+7 999"}, "foo@example.com|7999", nil},
+		{"unknown recipe", "event", nil, "", record.ErrUnknownRecipe},
+		{"missing field", "lead", map[string]string{"email": "foo"}, "", record.ErrMissingField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHasherIn = ""
+
+			h, err := svc.HashRecord(context.Background(), tt.recipe, tt.fields, hash.Params{})
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if gotHasherIn != tt.expectIn || h.Algorithm() != hash.AlgorithmMD5 {
+				t.Errorf("expected %q hashed with MD5, got %q with %v", tt.expectIn, gotHasherIn, h.Algorithm())
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+const (
+	// defaultMatches is a number of matches returned if limit is not set.
+	defaultMatches = 10
+	// maxMatches is a maximum number of matches returned at once.
+	maxMatches = 100
+)
+
+// SimilarityService serves near-duplicate search by locality-sensitive
+// signatures. Contains indexes by name, implementation of signatures
+// repository and tenants registry.
+//
+// Items are kept per caller tenant, only their signatures are stored.
+type SimilarityService struct {
+	indexes map[string]*similarity.Index
+	sigRepo similarity.Repository
+	tenants *tenant.Registry
+}
+
+// NewSimilarityService creates new instance of similarity service with given
+// indexes.
+func NewSimilarityService(sigRepo similarity.Repository, tenants *tenant.Registry, indexes ...*similarity.Index) (*SimilarityService, error) {
+	byName := make(map[string]*similarity.Index, len(indexes))
+	for _, idx := range indexes {
+		if _, ok := byName[idx.Name()]; ok {
+			return nil, fmt.Errorf("%w %q", similarity.ErrDuplicateIndex, idx.Name())
+		}
+		byName[idx.Name()] = idx
+	}
+
+	return &SimilarityService{
+		indexes: byName,
+		sigRepo: sigRepo,
+		tenants: tenants,
+	}, nil
+}
+
+// Add adds text signature to named index by item id. Adding the same id
+// again replaces its signature.
+func (s *SimilarityService) Add(ctx context.Context, index, id, text string) error {
+	idx, err := s.index(index)
+	if err != nil {
+		return err
+	}
+
+	if err := similarity.ValidateID(id); err != nil {
+		return err
+	}
+
+	sig, bands, err := idx.Sketch(text)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sigRepo.Save(ctx, callerTenant(ctx, s.tenants).Name(), idx.Name(), id, sig, bands); err != nil {
+		return fmt.Errorf("save signature: %w", err)
+	}
+
+	return nil
+}
+
+// FindSimilar finds items of named index similar to text at least by
+// threshold, most similar first. Only items sharing LSH band with text are
+// candidates, so items of low similarity may be missed. Limit is capped at
+// 100 matches, zero limit means 10.
+func (s *SimilarityService) FindSimilar(ctx context.Context, index, text string, threshold float64, limit int) ([]similarity.Match, error) {
+	idx, err := s.index(index)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := similarity.ValidateThreshold(threshold); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultMatches
+	}
+	limit = min(limit, maxMatches)
+
+	sig, bands, err := idx.Sketch(text)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.sigRepo.Candidates(ctx, callerTenant(ctx, s.tenants).Name(), idx.Name(), bands)
+	if err != nil {
+		return nil, fmt.Errorf("find candidates: %w", err)
+	}
+
+	matches := make([]similarity.Match, 0, len(candidates))
+	for id, candidate := range candidates {
+		if sim := idx.Similarity(sig, candidate); sim >= threshold {
+			matches = append(matches, similarity.Match{ID: id, Similarity: sim})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b similarity.Match) int {
+		if c := cmp.Compare(b.Similarity, a.Similarity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return matches[:min(limit, len(matches))], nil
+}
+
+func (s *SimilarityService) index(name string) (*similarity.Index, error) {
+	idx, ok := s.indexes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", similarity.ErrUnknownIndex, name)
+	}
+
+	return idx, nil
+}
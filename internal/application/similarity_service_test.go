@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+)
+
+// mockSketcher signs every token, so similarity is exact Jaccard similarity
+// and every token is a band.
+type mockSketcher struct{}
+
+func (mockSketcher) Sketch(tokens []string) similarity.Signature {
+	sig := make(similarity.Signature, 0, len(tokens))
+	for _, t := range tokens {
+		h := fnv.New64a()
+		h.Write([]byte(t))
+		if v := h.Sum64(); !slices.Contains(sig, v) {
+			sig = append(sig, v)
+		}
+	}
+	return sig
+}
+
+func (mockSketcher) Similarity(a, b similarity.Signature) float64 {
+	var common int
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func (mockSketcher) Bands(sig similarity.Signature) []uint64 { return sig }
+
+type mockSignatureRepository struct {
+	sigs    map[string]similarity.Signature
+	buckets map[string][]string
+}
+
+func (m *mockSignatureRepository) Save(_ context.Context, tenant, index, id string, sig similarity.Signature, bands []uint64) error {
+	m.sigs[tenant+"/"+index+"/"+id] = sig
+	for _, b := range bands {
+		k := tenant + "/" + index + "/" + strconv.FormatUint(b, 16)
+		m.buckets[k] = append(m.buckets[k], id)
+	}
+	return nil
+}
+
+func (m *mockSignatureRepository) Candidates(_ context.Context, tenant, index string, bands []uint64) (map[string]similarity.Signature, error) {
+	found := make(map[string]similarity.Signature)
+	for _, b := range bands {
+		for _, id := range m.buckets[tenant+"/"+index+"/"+strconv.FormatUint(b, 16)] {
+			found[id] = m.sigs[tenant+"/"+index+"/"+id]
+		}
+	}
+	return found, nil
+}
+
+func TestSimilarityService(t *testing.T) {
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := similarity.NewIndex("leads", similarity.AlgorithmMinHash, mockSketcher{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &mockSignatureRepository{sigs: map[string]similarity.Signature{}, buckets: map[string][]string{}}
+	svc, err := NewSimilarityService(repo, mustRegistry(sales), idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	for id, text := range map[string]string{
+		"1": "John Smith, 12 Main Street",
+		"2": "Jon Smith 12 Main St",
+		"3": "Mary Jones, 7 Oak Avenue",
+	} {
+		if err := svc.Add(ctx, "leads", id, text); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		threshold float64
+		limit     int
+		expect    []similarity.Match
+	}{
+		{"ordered by similarity", "john smith 12 main st", 0, 0, []similarity.Match{{ID: "1", Similarity: 4.0 / 6}, {ID: "2", Similarity: 4.0 / 6}}},
+		{"threshold", "John Smith, 12 Main Street", 0.7, 0, []similarity.Match{{ID: "1", Similarity: 1}}},
+		{"limit", "John Smith, 12 Main Street", 0, 1, []similarity.Match{{ID: "1", Similarity: 1}}},
+		{"no candidates", "Peter Brown", 0, 0, []similarity.Match{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.FindSimilar(ctx, "leads", tt.text, tt.threshold, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+
+	got, err := svc.FindSimilar(context.Background(), "leads", "John Smith, 12 Main Street", 0, 0)
+	if err != nil || len(got) != 0 {
+		t.Errorf("expected no matches of other tenant, got %v, %v", got, err)
+	}
+}
+
+func TestSimilarityService_Errors(t *testing.T) {
+	idx, err := similarity.NewIndex("leads", similarity.AlgorithmMinHash, mockSketcher{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewSimilarityService(nil, mustRegistry(), idx, idx); !errors.Is(err, similarity.ErrDuplicateIndex) {
+		t.Errorf("expected error %v, got %v", similarity.ErrDuplicateIndex, err)
+	}
+
+	svc, err := NewSimilarityService(&mockSignatureRepository{}, mustRegistry(), idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		fn        func() error
+		expectErr error
+	}{
+		{"unknown index", func() error { return svc.Add(context.Background(), "events", "1", "foo") }, similarity.ErrUnknownIndex},
+		{"empty id", func() error { return svc.Add(context.Background(), "leads", "", "foo") }, similarity.ErrInvalidID},
+		{"empty text", func() error { return svc.Add(context.Background(), "leads", "1", "...") }, similarity.ErrEmptyText},
+		{"invalid threshold", func() error {
+			_, err := svc.FindSimilar(context.Background(), "leads", "foo", 2, 0)
+			return err
+		}, similarity.ErrInvalidThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
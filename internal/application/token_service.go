@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+)
+
+// TokenService serves reversible tokenization business logic. Contains
+// implementation of token repository, token cipher and set of callers
+// permitted to detokenize.
+type TokenService struct {
+	tokenRepo    token.Repository
+	cipher       token.Cipher
+	detokenizers *identity.Allowlist
+}
+
+// NewTokenService creates new instance of token service. Detokenizers are
+// callers permitted to detokenize, nil allowlist permits no one.
+func NewTokenService(tokenRepo token.Repository, cipher token.Cipher, detokenizers *identity.Allowlist) *TokenService {
+	return &TokenService{
+		tokenRepo:    tokenRepo,
+		cipher:       cipher,
+		detokenizers: detokenizers,
+	}
+}
+
+// Tokenize returns token of value. Equal values get equal tokens, so tokens
+// can be joined on like hashes. Encrypted value is saved on first
+// tokenization only.
+func (s *TokenService) Tokenize(ctx context.Context, value string) (string, error) {
+	if err := token.ValidateValue(value); err != nil {
+		return "", err
+	}
+
+	tok := s.cipher.Token(value)
+
+	_, err := s.tokenRepo.Find(ctx, tok)
+	if err == nil {
+		return tok, nil
+	}
+	if !errors.Is(err, token.ErrNotFound) {
+		return "", fmt.Errorf("find token: %w", err)
+	}
+
+	sealed, err := s.cipher.Seal(tok, value)
+	if err != nil {
+		return "", fmt.Errorf("seal value: %w", err)
+	}
+
+	if err := s.tokenRepo.Save(ctx, tok, sealed); err != nil {
+		return "", fmt.Errorf("save token: %w", err)
+	}
+
+	return tok, nil
+}
+
+// Detokenize returns original value of token. Only callers listed as
+// detokenizers are permitted.
+func (s *TokenService) Detokenize(ctx context.Context, tok string) (string, error) {
+	if id, _ := identity.FromContext(ctx); !s.detokenizers.Permits(id) {
+		return "", token.ErrForbidden
+	}
+
+	if err := token.ValidateToken(tok); err != nil {
+		return "", err
+	}
+
+	sealed, err := s.tokenRepo.Find(ctx, tok)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := s.cipher.Open(tok, sealed)
+	if err != nil {
+		return "", fmt.Errorf("open token: %w", err)
+	}
+
+	return value, nil
+}
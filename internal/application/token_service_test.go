@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+)
+
+type mockTokenRepository struct {
+	sealed map[string][]byte
+	saves  int
+}
+
+func (m *mockTokenRepository) Save(_ context.Context, tok string, sealed []byte) error {
+	m.saves++
+	if _, ok := m.sealed[tok]; !ok {
+		m.sealed[tok] = sealed
+	}
+	return nil
+}
+
+func (m *mockTokenRepository) Find(_ context.Context, tok string) ([]byte, error) {
+	sealed, ok := m.sealed[tok]
+	if !ok {
+		return nil, token.ErrNotFound
+	}
+	return sealed, nil
+}
+
+// mockCipher derives token from upper-cased value and seals value reversed.
+type mockCipher struct{}
+
+func (mockCipher) Token(value string) string { return token.Prefix + strings.ToUpper(value) }
+
+func (mockCipher) Seal(_, value string) ([]byte, error) { return []byte(reverse(value)), nil }
+
+func (mockCipher) Open(_ string, sealed []byte) (string, error) { return reverse(string(sealed)), nil }
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func TestTokenService_Tokenize(t *testing.T) {
+	repo := &mockTokenRepository{sealed: map[string][]byte{}}
+	svc := NewTokenService(repo, mockCipher{}, nil)
+
+	tok, err := svc.Tokenize(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tok != "tok_FOO" || string(repo.sealed[tok]) != "oof" {
+		t.Errorf("expected sealed tok_FOO, got %q with %q", tok, repo.sealed[tok])
+	}
+
+	again, err := svc.Tokenize(context.Background(), "foo")
+	if err != nil || again != tok {
+		t.Errorf("expected the same token, got %q, %v", again, err)
+	}
+
+	if repo.saves != 1 {
+		t.Errorf("expected value to be saved once, got %d saves", repo.saves)
+	}
+
+	if _, err := svc.Tokenize(context.Background(), ""); !errors.Is(err, token.ErrEmptyValue) {
+		t.Errorf("expected error %v, got %v", token.ErrEmptyValue, err)
+	}
+}
+
+func TestTokenService_Detokenize(t *testing.T) {
+	repo := &mockTokenRepository{sealed: map[string][]byte{"tok_FOO": []byte("oof")}}
+	svc := NewTokenService(repo, mockCipher{}, mustAllowlist(t, "apikey:billing"))
+
+	billing := identity.NewContext(context.Background(), mustIdentity(t, "billing"))
+	importer := identity.NewContext(context.Background(), mustIdentity(t, "importer"))
+	certificate := identity.NewContext(context.Background(), mustCertificateIdentity(t, "billing"))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		token       string
+		expectValue string
+		expectErr   error
+	}{
+		{"permitted", billing, "tok_FOO", "foo", nil},
+		{"not permitted", importer, "tok_FOO", "", token.ErrForbidden},
+		{"certificate named as detokenizer", certificate, "tok_FOO", "", token.ErrForbidden},
+		{"anonymous", context.Background(), "tok_FOO", "", token.ErrForbidden},
+		{"unknown", billing, "tok_BAR", "", token.ErrNotFound},
+		{"malformed", billing, "FOO", "", token.ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := svc.Detokenize(tt.ctx, tt.token)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if value != tt.expectValue {
+				t.Errorf("expected value %q, got %q", tt.expectValue, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/merkle"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+)
+
+// Transparency log service errors.
+var (
+	// ErrTreeHeadMismatch is returned when stored tree head doesn't match
+	// stored entries, i.e. log was tampered with or truncated.
+	ErrTreeHeadMismatch = errors.New("signed tree head doesn't match log entries")
+	// ErrLogStopped is returned when hash is appended to stopped log.
+	ErrLogStopped = errors.New("transparency log is stopped")
+)
+
+// TransparencyLogOptions represents transparency log settings. Zero values
+// are replaced with defaults.
+type TransparencyLogOptions struct {
+	// Tenants are names of tenants whose hashes are logged, empty list means
+	// every tenant.
+	Tenants []string
+	// SignInterval is how often tree head is signed if log has grown.
+	SignInterval time.Duration
+}
+
+// Default transparency log options.
+const (
+	defaultSignInterval = time.Minute
+	// logLoadBatch is a number of entries read at once when tree is rebuilt.
+	logLoadBatch = 10_000
+	// maxLogEntries is a maximum number of entries returned at once.
+	maxLogEntries = 1000
+	// maxAppendBatch is a maximum number of entries stored at once.
+	maxAppendBatch = 512
+)
+
+// TransparencyLogService serves append-only log of issued hashes. Contains
+// implementation of log repository, tree heads signer, hasher of log tree and
+// tenants registry.
+//
+// Log tree is kept in memory and rebuilt from stored entries when service
+// starts. Concurrent appends are stored in batches by single committer, so
+// they share repository writes. Tree head is signed periodically and when
+// service stops. Entries
+// content is returned to callers of tenant hash was issued to only, others
+// get leaves with commitments.
+type TransparencyLogService struct {
+	logRepo translog.Repository
+	signer  translog.Signer
+	hasher  hash.Hasher
+	tenants *tenant.Registry
+	opts    TransparencyLogOptions
+	log     *slog.Logger
+	now     func() time.Time
+
+	mu   sync.Mutex
+	tree *merkle.Tree
+	head *translog.TreeHead
+
+	// appendMu guards appends channel from being closed while sent to.
+	appendMu sync.RWMutex
+	appends  chan appendRequest
+	stopped  bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTransparencyLogService creates new instance of transparency log
+// service.
+func NewTransparencyLogService(
+	logRepo translog.Repository,
+	signer translog.Signer,
+	hasher hash.Hasher,
+	tenants *tenant.Registry,
+	opts TransparencyLogOptions,
+	log *slog.Logger,
+) *TransparencyLogService {
+	if opts.SignInterval <= 0 {
+		opts.SignInterval = defaultSignInterval
+	}
+
+	return &TransparencyLogService{
+		logRepo: logRepo,
+		signer:  signer,
+		hasher:  hasher,
+		tenants: tenants,
+		opts:    opts,
+		log:     log,
+		now:     time.Now,
+		appends: make(chan appendRequest, maxAppendBatch),
+	}
+}
+
+// appendRequest represents hash waiting to be appended to log.
+type appendRequest struct {
+	tenant   string
+	h        *hash.Hash
+	issuedAt time.Time
+	done     chan error
+}
+
+// Start rebuilds log tree from stored entries, checks it against the latest
+// signed tree head and starts signing tree heads.
+func (s *TransparencyLogService) Start(ctx context.Context) error {
+	tree, err := merkle.NewTree(s.hasher, nil)
+	if err != nil {
+		return fmt.Errorf("new tree: %w", err)
+	}
+
+	for {
+		entries, err := s.logRepo.Entries(ctx, tree.Size(), logLoadBatch)
+		if err != nil {
+			return fmt.Errorf("load entries: %w", err)
+		}
+
+		for _, e := range entries {
+			if err := tree.Append(e.Leaf()); err != nil {
+				return fmt.Errorf("append entry %d: %w", e.Index(), err)
+			}
+		}
+
+		if len(entries) < logLoadBatch {
+			break
+		}
+	}
+
+	head, err := s.logRepo.LatestHead(ctx)
+	if err != nil && !errors.Is(err, translog.ErrNoTreeHead) {
+		return fmt.Errorf("load tree head: %w", err)
+	}
+
+	if head != nil {
+		root, err := tree.RootAt(head.Size)
+		if err != nil || root != head.Root {
+			return fmt.Errorf("%w: head of size %d, %d entries", ErrTreeHeadMismatch, head.Size, tree.Size())
+		}
+	}
+
+	s.mu.Lock()
+	s.tree = tree
+	s.head = head
+	s.mu.Unlock()
+
+	s.log.Info("transparency log loaded", slog.Int64("size", tree.Size()))
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.signPeriodically(runCtx)
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.commitAppends()
+	}()
+
+	return nil
+}
+
+// Stop stores pending entries, stops signing tree heads and signs the final
+// one.
+func (s *TransparencyLogService) Stop() {
+	s.appendMu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.appends)
+	}
+	s.appendMu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+
+	if err := s.SignTreeHead(context.Background()); err != nil {
+		s.log.Error("sign tree head", slog.String("error", err.Error()))
+	}
+}
+
+// Append appends hash issued to tenant to log and waits until it's stored.
+// Hashes of tenants not selected by options are skipped.
+func (s *TransparencyLogService) Append(ctx context.Context, tenant string, h *hash.Hash) error {
+	if len(s.opts.Tenants) > 0 && !slices.Contains(s.opts.Tenants, tenant) {
+		return nil
+	}
+
+	req := appendRequest{
+		tenant:   tenant,
+		h:        h,
+		issuedAt: s.now(),
+		done:     make(chan error, 1),
+	}
+
+	if err := s.enqueue(ctx, req); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *TransparencyLogService) enqueue(ctx context.Context, req appendRequest) error {
+	s.appendMu.RLock()
+	defer s.appendMu.RUnlock()
+
+	if s.stopped {
+		return ErrLogStopped
+	}
+
+	select {
+	case s.appends <- req:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// commitAppends stores pending appends in batches until appends channel is
+// closed.
+func (s *TransparencyLogService) commitAppends() {
+	for req := range s.appends {
+		batch := []appendRequest{req}
+	drain:
+		for len(batch) < maxAppendBatch {
+			select {
+			case req, ok := <-s.appends:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, req)
+			default:
+				break drain
+			}
+		}
+
+		err := s.commit(batch)
+		for _, req := range batch {
+			req.done <- err
+		}
+	}
+}
+
+// commit stores batch of appends and appends their leaves to tree. Only
+// committer appends to tree, so entry indexes are taken from its size before
+// entries are stored.
+func (s *TransparencyLogService) commit(batch []appendRequest) error {
+	s.mu.Lock()
+	size := s.tree.Size()
+	s.mu.Unlock()
+
+	nonces := make([]byte, len(batch)*translog.NonceSize)
+	if _, err := rand.Read(nonces); err != nil {
+		return fmt.Errorf("read nonces: %w", err)
+	}
+
+	entries := make([]*translog.Entry, len(batch))
+	for i, req := range batch {
+		nonce := nonces[i*translog.NonceSize : (i+1)*translog.NonceSize]
+		e, err := translog.NewEntry(size+int64(i), req.tenant, req.h, req.issuedAt, nonce)
+		if err != nil {
+			return fmt.Errorf("new entry: %w", err)
+		}
+		entries[i] = e
+	}
+
+	if err := s.logRepo.Append(context.Background(), entries); err != nil {
+		return fmt.Errorf("append entries: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entries {
+		if err := s.tree.Append(e.Leaf()); err != nil {
+			return fmt.Errorf("append leaf: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SignTreeHead signs head of current tree if log has grown since the latest
+// signed head.
+func (s *TransparencyLogService) SignTreeHead(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size := s.tree.Size()
+	if size == 0 || (s.head != nil && s.head.Size == size) {
+		return nil
+	}
+
+	head := &translog.TreeHead{
+		Size:      size,
+		Root:      s.tree.Root(),
+		Timestamp: s.now().UTC().Truncate(time.Millisecond),
+	}
+	if err := head.Sign(s.signer); err != nil {
+		return err
+	}
+
+	if err := s.logRepo.SaveHead(ctx, head); err != nil {
+		return fmt.Errorf("save tree head: %w", err)
+	}
+	s.head = head
+
+	return nil
+}
+
+// TreeHead returns the latest signed tree head.
+func (s *TransparencyLogService) TreeHead(_ context.Context) (*translog.TreeHead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.head == nil {
+		return nil, translog.ErrNoTreeHead
+	}
+
+	return s.head, nil
+}
+
+// Entries returns up to limit entries starting from offset. Limit is capped
+// at 1000 entries. Entries of other tenants are redacted.
+func (s *TransparencyLogService) Entries(ctx context.Context, offset, limit int64) ([]*translog.Entry, error) {
+	if offset < 0 {
+		return nil, merkle.ErrIndexOutOfRange
+	}
+	if limit <= 0 || limit > maxLogEntries {
+		limit = maxLogEntries
+	}
+
+	entries, err := s.logRepo.Entries(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, e := range entries {
+		entries[i] = s.scope(ctx, e)
+	}
+
+	return entries, nil
+}
+
+// InclusionProof represents audit path of log entry in tree of given size.
+type InclusionProof struct {
+	Entry     *translog.Entry
+	TreeSize  int64
+	AuditPath []string
+}
+
+// InclusionProof returns entry at index and its audit path in tree of given
+// size. Zero size means size of the latest signed tree head. Entry of other
+// tenant is redacted.
+func (s *TransparencyLogService) InclusionProof(ctx context.Context, index, size int64) (*InclusionProof, error) {
+	s.mu.Lock()
+	size, err := s.resolveSize(size)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	path, err := s.tree.InclusionProof(index, size)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.logRepo.Entries(ctx, index, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, merkle.ErrIndexOutOfRange
+	}
+
+	return &InclusionProof{
+		Entry:     s.scope(ctx, entries[0]),
+		TreeSize:  size,
+		AuditPath: path,
+	}, nil
+}
+
+// ConsistencyProof represents proof that tree of first size is a prefix of
+// tree of second size.
+type ConsistencyProof struct {
+	First  int64
+	Second int64
+	Proof  []string
+}
+
+// ConsistencyProof returns proof that tree of first size is a prefix of tree
+// of second size. Zero second size means size of the latest signed tree head.
+func (s *TransparencyLogService) ConsistencyProof(_ context.Context, first, second int64) (*ConsistencyProof, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	second, err := s.resolveSize(second)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.tree.ConsistencyProof(first, second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsistencyProof{
+		First:  first,
+		Second: second,
+		Proof:  proof,
+	}, nil
+}
+
+// scope redacts entry unless it was issued to caller tenant. Entries of
+// default tenant are shared by unrelated callers, so they are always
+// redacted.
+func (s *TransparencyLogService) scope(ctx context.Context, e *translog.Entry) *translog.Entry {
+	owner := callerTenant(ctx, s.tenants).Name()
+	if owner == "" || e.Tenant() != owner {
+		return e.Redacted()
+	}
+
+	return e
+}
+
+func (s *TransparencyLogService) resolveSize(size int64) (int64, error) {
+	if size != 0 {
+		return size, nil
+	}
+
+	if s.head == nil {
+		return 0, translog.ErrNoTreeHead
+	}
+
+	return s.head.Size, nil
+}
+
+func (s *TransparencyLogService) signPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.SignInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SignTreeHead(ctx); err != nil {
+				s.log.Error("sign tree head", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
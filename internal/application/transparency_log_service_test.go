@@ -0,0 +1,342 @@
+package application
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/merkle"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+)
+
+type mockLogRepository struct {
+	mu      sync.Mutex
+	entries []*translog.Entry
+	heads   []*translog.TreeHead
+	appends int
+	// block blocks the first append until closed.
+	block chan struct{}
+}
+
+func (m *mockLogRepository) Append(_ context.Context, entries []*translog.Entry) error {
+	m.mu.Lock()
+	m.appends++
+	first := m.appends == 1
+	m.mu.Unlock()
+
+	if first && m.block != nil {
+		<-m.block
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *mockLogRepository) Entries(_ context.Context, offset, limit int64) ([]*translog.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if offset >= int64(len(m.entries)) {
+		return nil, nil
+	}
+	return m.entries[offset:min(offset+limit, int64(len(m.entries)))], nil
+}
+
+func (m *mockLogRepository) SaveHead(_ context.Context, head *translog.TreeHead) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.heads = append(m.heads, head)
+	return nil
+}
+
+func (m *mockLogRepository) LatestHead(_ context.Context) (*translog.TreeHead, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.heads) == 0 {
+		return nil, translog.ErrNoTreeHead
+	}
+	return m.heads[len(m.heads)-1], nil
+}
+
+type mockSigner struct {
+	key ed25519.PrivateKey
+}
+
+func (m *mockSigner) KeyID() string { return "key-1" }
+
+func (m *mockSigner) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(m.key, message), nil
+}
+
+func newSHA256Hasher() hash.Hasher {
+	return &mockHasher{
+		hashFunc: func(input string) string { return fmt.Sprintf("%x", sha256.Sum256([]byte(input))) },
+		macFunc: func(key []byte, input string) string {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(input))
+			return fmt.Sprintf("%x", mac.Sum(nil))
+		},
+	}
+}
+
+func TestTransparencyLogService(t *testing.T) {
+	ctx := context.Background()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sales, err := tenant.New("sales", []string{"importer"}, tenant.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenants := mustRegistry(sales)
+
+	repo := &mockLogRepository{}
+	newService := func() *TransparencyLogService {
+		svc := NewTransparencyLogService(repo, &mockSigner{key: key}, newSHA256Hasher(), tenants, TransparencyLogOptions{
+			Tenants:      []string{"sales"},
+			SignInterval: time.Hour,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if err := svc.Start(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return svc
+	}
+
+	svc := newService()
+	if _, err := svc.TreeHead(ctx); !errors.Is(err, translog.ErrNoTreeHead) {
+		t.Errorf("expected error %v, got %v", translog.ErrNoTreeHead, err)
+	}
+
+	h := mustCreateHash("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5)
+	for _, tenant := range []string{"sales", "marketing", "sales", "sales"} {
+		if err := svc.Append(ctx, tenant, h); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(repo.entries) != 3 {
+		t.Fatalf("expected 3 entries of selected tenant, got %d", len(repo.entries))
+	}
+
+	if err := svc.SignTreeHead(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, err := svc.TreeHead(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyHead(t, pub, first)
+
+	// Log is rebuilt from repository after restart.
+	svc.Stop()
+	svc = newService()
+	defer svc.Stop()
+
+	if err := svc.Append(ctx, "sales", h); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.SignTreeHead(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.TreeHead(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyHead(t, pub, second)
+
+	if first.Size != 3 || second.Size != 4 || len(repo.heads) != 2 {
+		t.Fatalf("expected heads of size 3 and 4, got %d and %d", first.Size, second.Size)
+	}
+
+	consistency, err := svc.ConsistencyProof(ctx, first.Size, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := merkle.VerifyConsistency(newSHA256Hasher(), first.Size, consistency.Second, consistency.Proof, first.Root, second.Root); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	salesCtx := identity.NewContext(ctx, mustIdentity(t, "importer"))
+	inclusion, err := svc.InclusionProof(salesCtx, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inclusion.Entry.Index() != 1 || inclusion.Entry.Hash() != h.Hashed() || inclusion.TreeSize != second.Size {
+		t.Errorf("unexpected entry %d of tree %d", inclusion.Entry.Index(), inclusion.TreeSize)
+	}
+	if err := merkle.VerifyInclusion(newSHA256Hasher(), inclusion.Entry.Leaf(), 1, inclusion.TreeSize, inclusion.AuditPath, second.Root); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.InclusionProof(ctx, 4, 0); !errors.Is(err, merkle.ErrIndexOutOfRange) {
+		t.Errorf("expected error %v, got %v", merkle.ErrIndexOutOfRange, err)
+	}
+
+	entries, err := svc.Entries(salesCtx, 2, 0)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d, %v", len(entries), err)
+	}
+	if entries[0].Hash() != h.Hashed() || entries[0].Preimage() == "" {
+		t.Errorf("expected entry content for its tenant, got %+v", entries[0])
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"other tenant", identity.NewContext(ctx, mustIdentity(t, "outsider"))},
+		{"anonymous", ctx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := svc.Entries(tt.ctx, 0, 0)
+			if err != nil || len(entries) != 4 {
+				t.Fatalf("expected 4 entries, got %d, %v", len(entries), err)
+			}
+			for _, e := range entries {
+				if e.Hash() != "" || e.Tenant() != "" || e.Preimage() != "" || e.Commitment() == "" {
+					t.Errorf("expected redacted entry, got %+v", e)
+				}
+			}
+
+			inclusion, err := svc.InclusionProof(tt.ctx, 1, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if inclusion.Entry.Hash() != "" {
+				t.Errorf("expected redacted entry, got %+v", inclusion.Entry)
+			}
+			if err := merkle.VerifyInclusion(newSHA256Hasher(), inclusion.Entry.Leaf(), 1, inclusion.TreeSize, inclusion.AuditPath, second.Root); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTransparencyLogService_Start_Tampered(t *testing.T) {
+	ctx := context.Background()
+	h := mustCreateHash("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5)
+
+	e, err := translog.NewEntry(0, "sales", h, time.Now(), make([]byte, translog.NonceSize))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := &mockLogRepository{
+		entries: []*translog.Entry{e},
+		heads:   []*translog.TreeHead{{Size: 2, Root: "aa"}},
+	}
+
+	svc := NewTransparencyLogService(repo, &mockSigner{}, newSHA256Hasher(), mustRegistry(), TransparencyLogOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := svc.Start(ctx); !errors.Is(err, ErrTreeHeadMismatch) {
+		t.Errorf("expected error %v, got %v", ErrTreeHeadMismatch, err)
+	}
+}
+
+func TestTransparencyLogService_Append_Batches(t *testing.T) {
+	ctx := context.Background()
+	h := mustCreateHash("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := &mockLogRepository{block: make(chan struct{})}
+	svc := NewTransparencyLogService(repo, &mockSigner{key: key}, newSHA256Hasher(), mustRegistry(), TransparencyLogOptions{
+		SignInterval: time.Hour,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := svc.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const n = 50
+	errs := make(chan error, n)
+	appendHash := func() { errs <- svc.Append(ctx, "sales", h) }
+
+	// The first append blocks in repository, the rest queue behind it.
+	go appendHash()
+	for {
+		repo.mu.Lock()
+		appends := repo.appends
+		repo.mu.Unlock()
+		if appends == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for range n - 1 {
+		go appendHash()
+	}
+	for len(svc.appends) < n-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(repo.block)
+
+	for range n {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if repo.appends != 2 {
+		t.Errorf("expected 2 repository appends, got %d", repo.appends)
+	}
+
+	tree, err := merkle.NewTree(newSHA256Hasher(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, e := range repo.entries {
+		if e.Index() != int64(i) {
+			t.Fatalf("expected entry %d, got %d", i, e.Index())
+		}
+		if err := tree.Append(e.Leaf()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := svc.SignTreeHead(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	head, err := svc.TreeHead(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if head.Size != n || head.Root != tree.Root() {
+		t.Errorf("expected head of %d entries with root %s, got %d, %s", n, tree.Root(), head.Size, head.Root)
+	}
+
+	svc.Stop()
+	if err := svc.Append(ctx, "sales", h); !errors.Is(err, ErrLogStopped) {
+		t.Errorf("expected error %v, got %v", ErrLogStopped, err)
+	}
+}
+
+func verifyHead(t *testing.T, pub ed25519.PublicKey, head *translog.TreeHead) {
+	t.Helper()
+
+	data, err := head.SignedData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if head.KeyID != "key-1" || !ed25519.Verify(pub, data, head.Signature) {
+		t.Errorf("tree head of size %d has invalid signature", head.Size)
+	}
+}
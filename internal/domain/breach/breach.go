@@ -0,0 +1,146 @@
+package breach
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Breach domain errors.
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported breach algorithm")
+	ErrInvalidPrefix        = errors.New("prefix must be 5 hex characters")
+	ErrMalformedLine        = errors.New("malformed corpus line")
+	ErrNotIngested          = errors.New("corpus is not ingested")
+	ErrMalformedStore       = errors.New("malformed corpus store")
+)
+
+const (
+	// PrefixSize is a number of hex characters of hash prefix, so every
+	// prefix is shared by about a million of hashes.
+	PrefixSize = 5
+	// Prefixes is a number of distinct prefixes.
+	Prefixes = 1 << (PrefixSize * 4)
+)
+
+// Repository is a contract that corpus stores should implement.
+type Repository interface {
+	// Range returns entries of hashes of given algorithm starting with
+	// uppercase prefix, sorted by suffix. Returns ErrNotIngested if there
+	// is no corpus of algorithm.
+	Range(ctx context.Context, alg Algorithm, prefix string) ([]Entry, error)
+}
+
+// Entry represents hash of breach corpus. Suffix is uppercase hex hash
+// without prefix, Count is a number of times hash appears in breaches. Count
+// of padding entries is zero.
+type Entry struct {
+	Suffix string
+	Count  uint64
+}
+
+// Algorithm represents algorithm corpus hashes are made with.
+type Algorithm int8
+
+// Supported breach algorithms.
+const (
+	AlgorithmSHA1 Algorithm = iota + 1
+	AlgorithmNTLM
+	AlgorithmSHA256
+)
+
+// String strings algorithm numeric constant.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmSHA1:
+		return "sha1"
+	case AlgorithmNTLM:
+		return "ntlm"
+	case AlgorithmSHA256:
+		return "sha256"
+	default:
+		return ""
+	}
+}
+
+// Size returns hash size in bytes, zero if algorithm is unsupported.
+func (a Algorithm) Size() int {
+	switch a {
+	case AlgorithmSHA1:
+		return 20
+	case AlgorithmNTLM:
+		return 16
+	case AlgorithmSHA256:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// ParseAlgorithm parses algorithm by its name.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch name {
+	case "sha1":
+		return AlgorithmSHA1, nil
+	case "ntlm":
+		return AlgorithmNTLM, nil
+	case "sha256":
+		return AlgorithmSHA256, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedAlgorithm, name)
+	}
+}
+
+// ValidatePrefix validates hash prefix and returns it in uppercase.
+func ValidatePrefix(prefix string) (string, error) {
+	if len(prefix) != PrefixSize {
+		return "", ErrInvalidPrefix
+	}
+
+	for _, r := range prefix {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+		default:
+			return "", ErrInvalidPrefix
+		}
+	}
+
+	return strings.ToUpper(prefix), nil
+}
+
+// ParseLine parses corpus line "HASH[:COUNT]" of hex hash of algorithm and
+// optional count, 1 if it's omitted.
+func ParseLine(alg Algorithm, line string) ([]byte, uint64, error) {
+	hexHash, countStr, hasCount := strings.Cut(strings.TrimSpace(line), ":")
+
+	if len(hexHash) != 2*alg.Size() {
+		return nil, 0, fmt.Errorf("%w: hash must be %d hex characters", ErrMalformedLine, 2*alg.Size())
+	}
+
+	digest, err := hex.DecodeString(hexHash)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrMalformedLine, err)
+	}
+
+	count := uint64(1)
+	if hasCount {
+		if count, err = strconv.ParseUint(countStr, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", ErrMalformedLine, err)
+		}
+	}
+
+	return digest, count, nil
+}
+
+// PrefixIndex returns index of hash prefix within [0, Prefixes).
+func PrefixIndex(digest []byte) int {
+	return int(digest[0])<<12 | int(digest[1])<<4 | int(digest[2])>>4
+}
+
+// Suffix returns uppercase hex hash without prefix.
+func Suffix(digest []byte) string {
+	return strings.ToUpper(hex.EncodeToString(digest))[PrefixSize:]
+}
@@ -0,0 +1,120 @@
+package breach
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidatePrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		expect    string
+		expectErr error
+	}{
+		{"uppercase", "5BAA6", "5BAA6", nil},
+		{"lowercase", "5baa6", "5BAA6", nil},
+		{"short", "5BAA", "", ErrInvalidPrefix},
+		{"long", "5BAA61", "", ErrInvalidPrefix},
+		{"not hex", "5BAG6", "", ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidatePrefix(tt.prefix)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	// SHA-1 of "password".
+	const sha1Password = "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+	tests := []struct {
+		name        string
+		alg         Algorithm
+		line        string
+		expectCount uint64
+		expectErr   error
+	}{
+		{"with count", AlgorithmSHA1, sha1Password + ":10434004\r\n", 10434004, nil},
+		{"without count", AlgorithmSHA1, strings.ToLower(sha1Password), 1, nil},
+		{"ntlm", AlgorithmNTLM, "8846F7EAEE8FB117AD06BDD830B7586C:3", 3, nil},
+		{"wrong size", AlgorithmSHA256, sha1Password, 0, ErrMalformedLine},
+		{"not hex", AlgorithmNTLM, "8846F7EAEE8FB117AD06BDD830B7586Z", 0, ErrMalformedLine},
+		{"bad count", AlgorithmSHA1, sha1Password + ":many", 0, ErrMalformedLine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest, count, err := ParseLine(tt.alg, tt.line)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if count != tt.expectCount || len(digest) != tt.alg.Size() {
+				t.Errorf("expected %d bytes hash with count %d, got %d bytes, %d", tt.alg.Size(), tt.expectCount, len(digest), count)
+			}
+		})
+	}
+}
+
+func TestPrefixIndexAndSuffix(t *testing.T) {
+	digest, _, err := ParseLine(AlgorithmSHA1, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := PrefixIndex(digest); got != 0x5BAA6 {
+		t.Errorf("expected prefix index %x, got %x", 0x5BAA6, got)
+	}
+
+	if got := Suffix(digest); got != "1E4C9B93F3F0682250B6CF8331B7EE68FD8" {
+		t.Errorf("unexpected suffix %s", got)
+	}
+}
+
+func TestPad(t *testing.T) {
+	entries := []Entry{
+		{Suffix: "1E4C9B93F3F0682250B6CF8331B7EE68FD8", Count: 10},
+		{Suffix: "0018A45C4D1DEF81644B54AB7F969B88D65", Count: 1},
+	}
+
+	padded := Pad(AlgorithmSHA1, slices.Clone(entries))
+	if len(padded) < MinPadded || len(padded) > MaxPadded {
+		t.Fatalf("expected %d to %d entries, got %d", MinPadded, MaxPadded, len(padded))
+	}
+
+	if !slices.IsSortedFunc(padded, func(a, b Entry) int { return strings.Compare(a.Suffix, b.Suffix) }) {
+		t.Error("expected padded entries to be sorted by suffix")
+	}
+
+	var real int
+	for _, e := range padded {
+		if len(e.Suffix) != 2*AlgorithmSHA1.Size()-PrefixSize {
+			t.Fatalf("unexpected suffix size %q", e.Suffix)
+		}
+		if e.Count > 0 {
+			real++
+		}
+	}
+	if real != len(entries) {
+		t.Errorf("expected %d real entries, got %d", len(entries), real)
+	}
+
+	large := make([]Entry, MaxPadded+1)
+	if got := Pad(AlgorithmSHA1, large); len(got) != len(large) {
+		t.Errorf("expected large range not to be padded, got %d entries", len(got))
+	}
+}
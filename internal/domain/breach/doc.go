@@ -0,0 +1,2 @@
+// Package breach provides a domain breach corpus definitions.
+package breach
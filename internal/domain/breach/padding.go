@@ -0,0 +1,56 @@
+package breach
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+const (
+	// MinPadded and MaxPadded bound number of entries of padded range, so
+	// response size doesn't reveal prefix queried.
+	MinPadded = 800
+	MaxPadded = 1000
+)
+
+// Pad adds entries of random suffixes with zero count until there are
+// random number of entries between MinPadded and MaxPadded and sorts all
+// entries by suffix. Ranges of more than MaxPadded entries are not padded.
+func Pad(alg Algorithm, entries []Entry) []Entry {
+	target := MinPadded + randIntN(MaxPadded-MinPadded+1)
+	if len(entries) >= target {
+		return entries
+	}
+
+	seen := make(map[string]struct{}, target)
+	for _, e := range entries {
+		seen[e.Suffix] = struct{}{}
+	}
+
+	padded := slices.Grow(entries, target-len(entries))
+	buf := make([]byte, alg.Size())
+	for len(padded) < target {
+		rand.Read(buf)
+		suffix := strings.ToUpper(hex.EncodeToString(buf))[PrefixSize:]
+		if _, ok := seen[suffix]; ok {
+			continue
+		}
+		seen[suffix] = struct{}{}
+		padded = append(padded, Entry{Suffix: suffix})
+	}
+
+	slices.SortFunc(padded, func(a, b Entry) int {
+		return strings.Compare(a.Suffix, b.Suffix)
+	})
+
+	return padded
+}
+
+// randIntN returns uniform random number within [0, n) of crypto/rand.
+func randIntN(n int) int {
+	var buf [8]byte
+	rand.Read(buf[:])
+	return int(binary.BigEndian.Uint64(buf[:]) % uint64(n))
+}
@@ -0,0 +1,107 @@
+package cardinality
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Cardinality domain errors.
+var (
+	ErrInvalidKey     = errors.New("counter key must be 1 to 64 letters, digits, '.', '-' or '_'")
+	ErrNoKeys         = errors.New("at least one counter key is required")
+	ErrTooManyKeys    = errors.New("too many counter keys")
+	ErrInvalidRange   = errors.New("range end is before start")
+	ErrTooManyBuckets = errors.New("range spans too many buckets")
+	ErrInvalidBucket  = errors.New("bucket size must be positive")
+)
+
+const (
+	// maxKeySize is a maximum counter key size in bytes.
+	maxKeySize = 64
+	// MaxBuckets is a maximum number of buckets counted at once.
+	MaxBuckets = 1000
+	// MaxKeys is a maximum number of keys counted at once.
+	MaxKeys = 16
+)
+
+// Repository is a contract that counter repositories should implement.
+// Counters are kept per tenant, key and time bucket.
+type Repository interface {
+	// Add adds digest to counter bucket.
+	Add(ctx context.Context, tenant, key string, bucket time.Time, digest string) error
+
+	// Count estimates number of unique digests added to any of buckets of
+	// any of keys.
+	Count(ctx context.Context, tenant string, keys []string, buckets []time.Time) (uint64, error)
+
+	// CountBuckets estimates number of unique digests added to every bucket
+	// of key, counts are in buckets order.
+	CountBuckets(ctx context.Context, tenant, key string, buckets []time.Time) ([]uint64, error)
+}
+
+// ValidateKey validates counter key.
+func ValidateKey(key string) error {
+	if key == "" || len(key) > maxKeySize {
+		return ErrInvalidKey
+	}
+
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
+
+// ValidateKeys validates keys counted at once.
+func ValidateKeys(keys []string) error {
+	if len(keys) == 0 {
+		return ErrNoKeys
+	}
+
+	if len(keys) > MaxKeys {
+		return ErrTooManyKeys
+	}
+
+	for _, key := range keys {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Bucket returns start of bucket of given size t belongs to. Buckets are
+// aligned to Unix epoch in UTC.
+func Bucket(t time.Time, size time.Duration) time.Time {
+	return t.UTC().Truncate(size)
+}
+
+// Buckets returns starts of buckets of given size overlapping range from
+// start to end inclusive.
+func Buckets(start, end time.Time, size time.Duration) ([]time.Time, error) {
+	if size <= 0 {
+		return nil, ErrInvalidBucket
+	}
+
+	if end.Before(start) {
+		return nil, ErrInvalidRange
+	}
+
+	first, last := Bucket(start, size), Bucket(end, size)
+	if last.Sub(first)/size >= MaxBuckets {
+		return nil, ErrTooManyBuckets
+	}
+
+	var buckets []time.Time
+	for b := first; !b.After(last); b = b.Add(size) {
+		buckets = append(buckets, b)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,135 @@
+package cardinality
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		expectErr error
+	}{
+		{"valid", "campaign.spring-2026_v2", nil},
+		{"empty", "", ErrInvalidKey},
+		{"separator", "campaign:spring", ErrInvalidKey},
+		{"too long", strings.Repeat("a", maxKeySize+1), ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateKey(tt.key); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		expectErr error
+	}{
+		{"valid", []string{"spring", "autumn"}, nil},
+		{"max keys", distinctKeys(MaxKeys), nil},
+		{"no keys", nil, ErrNoKeys},
+		{"too many", distinctKeys(MaxKeys + 1), ErrTooManyKeys},
+		{"invalid key", []string{"spring", "a:b"}, ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateKeys(tt.keys); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func distinctKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = strings.Repeat("k", i+1)
+	}
+	return keys
+}
+
+func TestBuckets(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		size      time.Duration
+		expect    int
+		expectErr error
+	}{
+		{"same bucket", start, start.Add(time.Hour), day, 1, nil},
+		{"partial buckets", start, start.Add(2 * day), day, 3, nil},
+		{"end before start", start, start.Add(-time.Hour), day, 0, ErrInvalidRange},
+		{"zero size", start, start, 0, 0, ErrInvalidBucket},
+		{"too many", start, start.Add(MaxBuckets * time.Hour), time.Hour, 0, ErrTooManyBuckets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, err := Buckets(tt.start, tt.end, tt.size)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if len(buckets) != tt.expect {
+				t.Fatalf("expected %d buckets, got %d", tt.expect, len(buckets))
+			}
+
+			if len(buckets) > 0 && !buckets[0].Equal(Bucket(tt.start, tt.size)) {
+				t.Errorf("expected first bucket %v, got %v", Bucket(tt.start, tt.size), buckets[0])
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 100_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			var h HyperLogLog
+			for i := range n {
+				item := strconv.Itoa(i)
+				h.Add(item)
+				h.Add(item)
+			}
+
+			if err := relErr(h.Estimate(), n); err > 0.03 {
+				t.Errorf("expected estimate close to %d, got %d", n, h.Estimate())
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	var a, b HyperLogLog
+	for i := range 30_000 {
+		a.Add(strconv.Itoa(i))
+		b.Add(strconv.Itoa(i + 20_000))
+	}
+
+	a.Merge(&b)
+	if err := relErr(a.Estimate(), 50_000); err > 0.03 {
+		t.Errorf("expected union estimate close to 50000, got %d", a.Estimate())
+	}
+}
+
+func relErr(estimate uint64, n int) float64 {
+	if n == 0 {
+		return float64(estimate)
+	}
+	return math.Abs(float64(estimate)-float64(n)) / float64(n)
+}
@@ -0,0 +1,2 @@
+// Package cardinality provides a domain unique counting definitions.
+package cardinality
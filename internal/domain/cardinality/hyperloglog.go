@@ -0,0 +1,67 @@
+package cardinality
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+const (
+	// precision is a number of hash bits selecting register, the same as
+	// Redis uses. Standard error is 1.04/sqrt(2^precision), about 0.81%.
+	precision = 14
+	// registers is a number of sketch registers.
+	registers = 1 << precision
+)
+
+// HyperLogLog represents HyperLogLog sketch estimating number of unique
+// items. Zero value is an empty sketch.
+type HyperLogLog struct {
+	registers [registers]uint8
+}
+
+// Add adds item to sketch.
+func (h *HyperLogLog) Add(item string) {
+	x := mixhash.String(item)
+	idx := x >> (64 - precision)
+	// Sentinel bit bounds rank if remaining bits are zeros.
+	rank := uint8(bits.LeadingZeros64(x<<precision|1<<(precision-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Merge merges other sketch into h, so h estimates union of both.
+func (h *HyperLogLog) Merge(other *HyperLogLog) {
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+}
+
+// Estimate returns estimated number of unique items. Small cardinalities are
+// estimated by linear counting.
+func (h *HyperLogLog) Estimate() uint64 {
+	const m = float64(registers)
+
+	var (
+		sum   float64
+		zeros int
+	)
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
@@ -0,0 +1,111 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Dedup domain errors.
+var (
+	ErrInvalidNamespace = errors.New("namespace must be 1 to 64 letters, digits, '.', '-' or '_'")
+	ErrSourceTooLong    = errors.New("source is too long")
+	ErrNegativeWindow   = errors.New("window cannot be negative")
+	ErrNotFound         = errors.New("lead not found")
+)
+
+const (
+	// maxNamespaceSize is a maximum namespace size in bytes.
+	maxNamespaceSize = 64
+	// maxSourceSize is a maximum source tag size in bytes.
+	maxSourceSize = 128
+)
+
+// Lead represents hashed lead identifier registered in namespace with time
+// and source it was first seen at.
+type Lead struct {
+	key       string
+	source    string
+	firstSeen time.Time
+}
+
+// NewLead creates new instance of lead.
+func NewLead(key, source string, firstSeen time.Time) *Lead {
+	return &Lead{
+		key:       key,
+		source:    source,
+		firstSeen: firstSeen,
+	}
+}
+
+// Key returns hashed lead identifier.
+func (l *Lead) Key() string { return l.key }
+
+// Source returns tag of source lead was first seen at.
+func (l *Lead) Source() string { return l.source }
+
+// FirstSeen returns time lead was first seen.
+func (l *Lead) FirstSeen() time.Time { return l.firstSeen }
+
+// Within reports whether lead was first seen within window before now. Zero
+// window never ends.
+func (l *Lead) Within(window time.Duration, now time.Time) bool {
+	return window == 0 || now.Sub(l.firstSeen) < window
+}
+
+// Repository is a contract that lead repositories should implement. Leads
+// are kept per tenant and namespace for retention after they are first seen,
+// which doesn't depend on windows leads are registered with.
+type Repository interface {
+	// Register atomically saves lead unless lead with the same key was
+	// first seen within window, replacing lead first seen before it.
+	// Returns lead first seen within window and whether it is the provided
+	// one.
+	Register(ctx context.Context, tenant, namespace string, lead *Lead, window time.Duration) (*Lead, bool, error)
+
+	// Find finds lead by key.
+	Find(ctx context.Context, tenant, namespace, key string) (*Lead, error)
+
+	// PinPepperVersion saves server pepper version leads of namespace are
+	// hashed with unless one is already saved. Returns saved version.
+	PinPepperVersion(ctx context.Context, tenant, namespace, version string) (string, error)
+
+	// PepperVersion finds server pepper version pinned by namespace. Returns
+	// ErrNotFound if namespace has no leads.
+	PepperVersion(ctx context.Context, tenant, namespace string) (string, error)
+}
+
+// ValidateNamespace validates leads namespace.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" || len(namespace) > maxNamespaceSize {
+		return ErrInvalidNamespace
+	}
+
+	for _, r := range namespace {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return ErrInvalidNamespace
+		}
+	}
+
+	return nil
+}
+
+// ValidateSource validates lead source tag.
+func ValidateSource(source string) error {
+	if len(source) > maxSourceSize {
+		return ErrSourceTooLong
+	}
+
+	return nil
+}
+
+// ValidateWindow validates dedup window.
+func ValidateWindow(window time.Duration) error {
+	if window < 0 {
+		return ErrNegativeWindow
+	}
+
+	return nil
+}
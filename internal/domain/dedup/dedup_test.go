@@ -0,0 +1,64 @@
+package dedup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		expectErr error
+	}{
+		{"valid", "leads.web-form_v2", nil},
+		{"empty", "", ErrInvalidNamespace},
+		{"separator", "leads:web", ErrInvalidNamespace},
+		{"space", "web leads", ErrInvalidNamespace},
+		{"too long", strings.Repeat("a", maxNamespaceSize+1), ErrInvalidNamespace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNamespace(tt.namespace); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSource(t *testing.T) {
+	if err := ValidateSource(""); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := ValidateSource(strings.Repeat("a", maxSourceSize+1)); !errors.Is(err, ErrSourceTooLong) {
+		t.Errorf("expected error %v, got %v", ErrSourceTooLong, err)
+	}
+}
+
+func TestLead_Within(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	lead := NewLead("key", "web", now.Add(-10*24*time.Hour))
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		expect bool
+	}{
+		{"no window", 0, true},
+		{"within", 30 * 24 * time.Hour, true},
+		{"expired", 7 * 24 * time.Hour, false},
+		{"boundary", 10 * 24 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lead.Within(tt.window, now); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
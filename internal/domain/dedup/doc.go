@@ -0,0 +1,2 @@
+// Package dedup provides a domain dedup definitions.
+package dedup
@@ -0,0 +1,2 @@
+// Package experiment provides a domain experiment definitions.
+package experiment
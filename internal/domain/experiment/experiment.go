@@ -0,0 +1,297 @@
+package experiment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Experiment domain errors.
+var (
+	ErrEmptyName           = errors.New("experiment name cannot be empty")
+	ErrEmptySalt           = errors.New("experiment salt cannot be empty")
+	ErrEmptyUnit           = errors.New("unit id cannot be empty")
+	ErrNoVariants          = errors.New("at least one variant is required")
+	ErrInvalidVariant      = errors.New("variant must have name and positive weight")
+	ErrDuplicateVariant    = errors.New("variant already defined")
+	ErrInvalidTraffic      = errors.New("traffic must be within (0, 1] and fit layer")
+	ErrInvalidHoldout      = errors.New("holdout must be within [0, 1)")
+	ErrDuplicateExperiment = errors.New("experiment already defined")
+	ErrOverlappingTraffic  = errors.New("experiment traffic overlaps other experiment of layer")
+	ErrUnknownExperiment   = errors.New("unknown experiment")
+	ErrMalformedDigest     = errors.New("malformed digest")
+)
+
+// Buckets is a number of buckets units are mapped to, so traffic, holdout
+// and variants are allocated with 0.01% granularity.
+const Buckets = 10_000
+
+// Status represents a way unit is assigned to experiment.
+type Status int8
+
+// Assignment statuses.
+const (
+	// StatusAssigned means unit is assigned to experiment variant.
+	StatusAssigned Status = iota + 1
+	// StatusHoldout means unit is in experiment traffic, but is held out of
+	// every variant.
+	StatusHoldout
+	// StatusExcluded means unit is out of experiment traffic.
+	StatusExcluded
+)
+
+// String strings status numeric constant.
+func (s Status) String() string {
+	switch s {
+	case StatusAssigned:
+		return "assigned"
+	case StatusHoldout:
+		return "holdout"
+	case StatusExcluded:
+		return "excluded"
+	default:
+		return ""
+	}
+}
+
+// Variant represents experiment variant. Units are allocated to variants
+// proportionally to their weights.
+type Variant struct {
+	Name   string
+	Weight uint32
+}
+
+// Allocation represents experiment traffic allocation, shares are fractions
+// of units. Experiments of the same layer are mutually exclusive, every one
+// takes Traffic share of layer units starting at Offset. Experiment without
+// layer takes Traffic share of all units. Holdout share of experiment units
+// is held out of every variant.
+type Allocation struct {
+	Layer   string
+	Offset  float64
+	Traffic float64
+	Holdout float64
+}
+
+// Assignment represents result of unit assignment to experiment. Bucket is
+// unit bucket of experiment, variant is set only if unit is assigned.
+type Assignment struct {
+	Experiment string
+	Status     Status
+	Variant    string
+	Bucket     uint32
+}
+
+// Experiment represents named experiment splitting units between variants
+// by hash of experiment salt and unit id.
+//
+// Assignments depend only on algorithm, salt, layer and allocation, so they
+// are stable across releases. Changing salt reshuffles units, growing
+// traffic or holdout keeps assignments of units already in experiment or
+// holdout.
+type Experiment struct {
+	name     string
+	salt     string
+	alg      hash.Algorithm
+	variants []Variant
+	weights  uint64
+	layer    string
+	start    uint32
+	end      uint32
+	holdout  uint32
+}
+
+// NewExperiment creates new experiment instance.
+func NewExperiment(name, salt string, alg hash.Algorithm, variants []Variant, alloc Allocation) (*Experiment, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	if salt == "" {
+		return nil, ErrEmptySalt
+	}
+
+	if len(variants) == 0 {
+		return nil, ErrNoVariants
+	}
+
+	var weights uint64
+	seen := make(map[string]struct{}, len(variants))
+	for _, v := range variants {
+		if v.Name == "" || v.Weight == 0 {
+			return nil, ErrInvalidVariant
+		}
+		if _, ok := seen[v.Name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateVariant, v.Name)
+		}
+		seen[v.Name] = struct{}{}
+		weights += uint64(v.Weight)
+	}
+
+	if alloc.Traffic <= 0 || alloc.Offset < 0 || alloc.Offset+alloc.Traffic > 1 {
+		return nil, ErrInvalidTraffic
+	}
+
+	if alloc.Holdout < 0 || alloc.Holdout >= 1 {
+		return nil, ErrInvalidHoldout
+	}
+
+	start := toBuckets(alloc.Offset)
+	end := toBuckets(alloc.Offset + alloc.Traffic)
+	if start == end {
+		return nil, ErrInvalidTraffic
+	}
+
+	return &Experiment{
+		name:     name,
+		salt:     salt,
+		alg:      alg,
+		variants: variants,
+		weights:  weights,
+		layer:    alloc.Layer,
+		start:    start,
+		end:      end,
+		holdout:  toBuckets(alloc.Holdout),
+	}, nil
+}
+
+// Name returns experiment name.
+func (e *Experiment) Name() string { return e.name }
+
+// Algorithm returns algorithm units are hashed with.
+func (e *Experiment) Algorithm() hash.Algorithm { return e.alg }
+
+// Layer returns experiment layer, empty if experiment has no layer.
+func (e *Experiment) Layer() string { return e.layer }
+
+// Assign assigns unit to experiment variant. Hasher must implement
+// experiment algorithm.
+//
+// Unit takes part in experiment if its traffic bucket, hash of layer name
+// or, without layer, of experiment salt, is within experiment traffic. Unit
+// bucket, hash of experiment salt, selects holdout or variant. Both are
+// independent, so traffic changes don't move units between variants.
+func (e *Experiment) Assign(hasher hash.Hasher, unitID string) (Assignment, error) {
+	if unitID == "" {
+		return Assignment{}, ErrEmptyUnit
+	}
+
+	bucket, err := Bucket(hasher, e.salt, unitID)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	a := Assignment{
+		Experiment: e.name,
+		Status:     StatusExcluded,
+		Bucket:     bucket,
+	}
+
+	trafficSalt := "traffic:" + e.salt
+	if e.layer != "" {
+		trafficSalt = "layer:" + e.layer
+	}
+
+	traffic, err := Bucket(hasher, trafficSalt, unitID)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	if traffic < e.start || traffic >= e.end {
+		return a, nil
+	}
+
+	if bucket < e.holdout {
+		a.Status = StatusHoldout
+		return a, nil
+	}
+
+	// Buckets after holdout are split between variants by cumulative weight.
+	pos := uint64(bucket-e.holdout) * e.weights
+	size := uint64(Buckets - e.holdout)
+
+	var cum uint64
+	for _, v := range e.variants {
+		cum += uint64(v.Weight)
+		if pos < cum*size {
+			a.Status = StatusAssigned
+			a.Variant = v.Name
+			break
+		}
+	}
+
+	return a, nil
+}
+
+// overlaps reports whether experiments share layer units.
+func (e *Experiment) overlaps(other *Experiment) bool {
+	return e.layer != "" && e.layer == other.layer && e.start < other.end && other.start < e.end
+}
+
+// Bucket maps unit to bucket by the first 64 bits of hex digest of salt and
+// unit id joined by colon. The mapping must never change, otherwise running
+// experiments are reshuffled.
+func Bucket(hasher hash.Hasher, salt, unitID string) (uint32, error) {
+	digest := hasher.Hash(salt + ":" + unitID)
+	if len(digest) < 16 {
+		return 0, ErrMalformedDigest
+	}
+
+	x, err := strconv.ParseUint(digest[:16], 16, 64)
+	if err != nil {
+		return 0, ErrMalformedDigest
+	}
+
+	return uint32(x % Buckets), nil
+}
+
+func toBuckets(share float64) uint32 {
+	return uint32(math.Round(share * Buckets))
+}
+
+// Experiments represents experiments by name.
+type Experiments struct {
+	experiments []*Experiment
+	byName      map[string]*Experiment
+}
+
+// NewExperiments creates new experiments instance. Experiment names must be
+// unique and experiments of the same layer must not overlap.
+func NewExperiments(experiments ...*Experiment) (*Experiments, error) {
+	byName := make(map[string]*Experiment, len(experiments))
+	for i, e := range experiments {
+		if _, ok := byName[e.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateExperiment, e.name)
+		}
+		byName[e.name] = e
+
+		for _, other := range experiments[:i] {
+			if e.overlaps(other) {
+				return nil, fmt.Errorf("%w: %q and %q", ErrOverlappingTraffic, other.name, e.name)
+			}
+		}
+	}
+
+	return &Experiments{
+		experiments: experiments,
+		byName:      byName,
+	}, nil
+}
+
+// Find finds experiment by name.
+func (e *Experiments) Find(name string) (*Experiment, error) {
+	exp, ok := e.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownExperiment, name)
+	}
+
+	return exp, nil
+}
+
+// All returns every experiment in definition order.
+func (e *Experiments) All() []*Experiment {
+	return e.experiments
+}
@@ -0,0 +1,207 @@
+package experiment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Hash(input string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
+}
+
+func (sha256Hasher) MAC(key []byte, input string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+type constHasher string
+
+func (h constHasher) Hash(string) string { return string(h) }
+
+func (h constHasher) MAC([]byte, string) string { return string(h) }
+
+var abVariants = []Variant{{Name: "control", Weight: 1}, {Name: "treatment", Weight: 1}}
+
+func mustExperiment(t *testing.T, name string, variants []Variant, alloc Allocation) *Experiment {
+	t.Helper()
+
+	e, err := NewExperiment(name, name+"-v1", hash.AlgorithmSHA256, variants, alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestNewExperiment(t *testing.T) {
+	tests := []struct {
+		name      string
+		expName   string
+		salt      string
+		variants  []Variant
+		alloc     Allocation
+		expectErr error
+	}{
+		{"valid", "hero", "hero-v1", abVariants, Allocation{Traffic: 1}, nil},
+		{"valid layer", "hero", "hero-v1", abVariants, Allocation{Layer: "landing", Offset: 0.5, Traffic: 0.5, Holdout: 0.1}, nil},
+		{"empty name", "", "hero-v1", abVariants, Allocation{Traffic: 1}, ErrEmptyName},
+		{"empty salt", "hero", "", abVariants, Allocation{Traffic: 1}, ErrEmptySalt},
+		{"no variants", "hero", "hero-v1", nil, Allocation{Traffic: 1}, ErrNoVariants},
+		{"zero weight", "hero", "hero-v1", []Variant{{Name: "control"}}, Allocation{Traffic: 1}, ErrInvalidVariant},
+		{"duplicate variant", "hero", "hero-v1", []Variant{{Name: "a", Weight: 1}, {Name: "a", Weight: 1}}, Allocation{Traffic: 1}, ErrDuplicateVariant},
+		{"zero traffic", "hero", "hero-v1", abVariants, Allocation{}, ErrInvalidTraffic},
+		{"tiny traffic", "hero", "hero-v1", abVariants, Allocation{Traffic: 0.00001}, ErrInvalidTraffic},
+		{"traffic beyond layer", "hero", "hero-v1", abVariants, Allocation{Offset: 0.6, Traffic: 0.5}, ErrInvalidTraffic},
+		{"full holdout", "hero", "hero-v1", abVariants, Allocation{Traffic: 1, Holdout: 1}, ErrInvalidHoldout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewExperiment(tt.expName, tt.salt, hash.AlgorithmSHA256, tt.variants, tt.alloc); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+// TestBucket locks bucket mapping, changing it reshuffles running
+// experiments.
+func TestBucket(t *testing.T) {
+	tests := []struct {
+		unit   string
+		expect uint32
+	}{
+		{"lead-1", 2724},
+		{"lead-2", 595},
+		{"lead-42", 8707},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			b, err := Bucket(sha256Hasher{}, "hero-v1", tt.unit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b != tt.expect {
+				t.Errorf("expected bucket %d, got %d", tt.expect, b)
+			}
+		})
+	}
+
+	if _, err := Bucket(constHasher("not-a-hex-digest"), "hero-v1", "lead-1"); !errors.Is(err, ErrMalformedDigest) {
+		t.Errorf("expected error %v, got %v", ErrMalformedDigest, err)
+	}
+}
+
+func TestExperiment_Assign(t *testing.T) {
+	e := mustExperiment(t, "hero", []Variant{{Name: "control", Weight: 1}, {Name: "treatment", Weight: 3}}, Allocation{Traffic: 0.5, Holdout: 0.2})
+
+	const units = 100_000
+	counts := map[string]int{}
+	for i := range units {
+		a, err := e.Assign(sha256Hasher{}, strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		again, _ := e.Assign(sha256Hasher{}, strconv.Itoa(i))
+		if again != a {
+			t.Fatalf("expected stable assignment %+v, got %+v", a, again)
+		}
+
+		if a.Status == StatusAssigned {
+			counts[a.Variant]++
+		} else {
+			counts[a.Status.String()]++
+		}
+	}
+
+	expect := map[string]float64{
+		"excluded":  0.5,
+		"holdout":   0.5 * 0.2,
+		"control":   0.5 * 0.8 * 0.25,
+		"treatment": 0.5 * 0.8 * 0.75,
+	}
+	for k, share := range expect {
+		if got := float64(counts[k]) / units; math.Abs(got-share) > 0.01 {
+			t.Errorf("expected %s share %.3f, got %.3f", k, share, got)
+		}
+	}
+
+	if _, err := e.Assign(sha256Hasher{}, ""); !errors.Is(err, ErrEmptyUnit) {
+		t.Errorf("expected error %v, got %v", ErrEmptyUnit, err)
+	}
+}
+
+func TestExperiment_Assign_TrafficGrowth(t *testing.T) {
+	small := mustExperiment(t, "hero", abVariants, Allocation{Traffic: 0.1})
+	large := mustExperiment(t, "hero", abVariants, Allocation{Traffic: 0.5})
+
+	for i := range 10_000 {
+		unit := strconv.Itoa(i)
+		before, _ := small.Assign(sha256Hasher{}, unit)
+		after, _ := large.Assign(sha256Hasher{}, unit)
+		if before.Status == StatusAssigned && after != before {
+			t.Fatalf("expected unit %s to keep assignment %+v, got %+v", unit, before, after)
+		}
+	}
+}
+
+func TestExperiment_Assign_Layer(t *testing.T) {
+	a := mustExperiment(t, "hero", abVariants, Allocation{Layer: "landing", Traffic: 0.5})
+	b := mustExperiment(t, "form", abVariants, Allocation{Layer: "landing", Offset: 0.5, Traffic: 0.5})
+
+	for i := range 10_000 {
+		unit := strconv.Itoa(i)
+		ra, _ := a.Assign(sha256Hasher{}, unit)
+		rb, _ := b.Assign(sha256Hasher{}, unit)
+		if (ra.Status == StatusAssigned) == (rb.Status == StatusAssigned) {
+			t.Fatalf("expected unit %s in exactly one layer experiment, got %+v and %+v", unit, ra, rb)
+		}
+	}
+}
+
+func TestNewExperiments(t *testing.T) {
+	hero := mustExperiment(t, "hero", abVariants, Allocation{Layer: "landing", Traffic: 0.5})
+	form := mustExperiment(t, "form", abVariants, Allocation{Layer: "landing", Offset: 0.5, Traffic: 0.5})
+	overlapping := mustExperiment(t, "cta", abVariants, Allocation{Layer: "landing", Offset: 0.4, Traffic: 0.2})
+	unlayered := mustExperiment(t, "price", abVariants, Allocation{Traffic: 1})
+
+	tests := []struct {
+		name        string
+		experiments []*Experiment
+		expectErr   error
+	}{
+		{"valid", []*Experiment{hero, form, unlayered}, nil},
+		{"duplicate", []*Experiment{hero, hero}, ErrDuplicateExperiment},
+		{"overlapping", []*Experiment{hero, form, overlapping}, ErrOverlappingTraffic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exps, err := NewExperiments(tt.experiments...)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if _, err := exps.Find("hero"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if _, err := exps.Find("missing"); !errors.Is(err, ErrUnknownExperiment) {
+				t.Errorf("expected error %v, got %v", ErrUnknownExperiment, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package filter
+
+import (
+	"math"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+// Bloom is a Bloom filter. Items set k bits of m chosen by double hashing,
+// so items can't be deleted.
+type Bloom struct {
+	capacity uint64
+	fpRate   float64
+	count    uint64
+	// pepperVersion is a server pepper version items are hashed with.
+	pepperVersion string
+	hashes        uint8
+	bits          []uint64
+}
+
+// NewBloom creates new empty Bloom filter with optimal number of bits and
+// hashes for capacity items at false positive rate.
+func NewBloom(capacity uint64, fpRate float64) (*Bloom, error) {
+	if err := validateSize(capacity, fpRate); err != nil {
+		return nil, err
+	}
+
+	m := math.Ceil(-float64(capacity) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	words := uint64(math.Ceil(m / 64))
+	if err := validateEncodedSize(1 + 8 + words*8); err != nil {
+		return nil, err
+	}
+	k := math.Round(float64(words*64) / float64(capacity) * math.Ln2)
+
+	return &Bloom{
+		capacity: capacity,
+		fpRate:   fpRate,
+		hashes:   uint8(min(max(k, 1), math.MaxUint8)),
+		bits:     make([]uint64, words),
+	}, nil
+}
+
+// Kind returns KindBloom.
+func (b *Bloom) Kind() Kind { return KindBloom }
+
+// Capacity returns number of items filter is sized for.
+func (b *Bloom) Capacity() uint64 { return b.capacity }
+
+// FPRate returns false positive rate at capacity.
+func (b *Bloom) FPRate() float64 { return b.fpRate }
+
+// Count returns number of added items, duplicates included.
+func (b *Bloom) Count() uint64 { return b.count }
+
+// PepperVersion returns server pepper version items are hashed with.
+func (b *Bloom) PepperVersion() string { return b.pepperVersion }
+
+// Add sets item bits.
+func (b *Bloom) Add(item string) error {
+	b.positions(item, func(i uint64) bool {
+		b.bits[i/64] |= 1 << (i % 64)
+		return true
+	})
+	b.count++
+
+	return nil
+}
+
+// MightContain reports whether every item bit is set.
+func (b *Bloom) MightContain(item string) bool {
+	return b.positions(item, func(i uint64) bool {
+		return b.bits[i/64]&(1<<(i%64)) != 0
+	})
+}
+
+// Delete returns ErrDeleteUnsupported, since bits are shared by items.
+func (b *Bloom) Delete(string) (bool, error) {
+	return false, ErrDeleteUnsupported
+}
+
+// positions calls fn with every item bit position until it returns false.
+func (b *Bloom) positions(item string, fn func(uint64) bool) bool {
+	h1, h2 := mixhash.String128(item)
+	m := uint64(len(b.bits)) * 64
+	for i := range uint64(b.hashes) {
+		if !fn((h1 + i*h2) % m) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+const (
+	// bucketSize is a number of fingerprints in cuckoo filter bucket.
+	bucketSize = 4
+	// maxKicks is a maximum number of fingerprints relocated on insertion.
+	maxKicks = 500
+	// cuckooLoad is a load factor cuckoo filter is sized for.
+	cuckooLoad = 0.95
+)
+
+// Cuckoo is a cuckoo filter. Items are stored as fingerprints in one of two
+// buckets, so they can be deleted. Fingerprint that couldn't be placed is
+// kept aside and filter refuses new items until deletion makes room.
+type Cuckoo struct {
+	capacity uint64
+	fpRate   float64
+	count    uint64
+	// pepperVersion is a server pepper version items are hashed with.
+	pepperVersion string
+	// fpBytes is a fingerprint size in bytes.
+	fpBytes uint8
+	// slots are fingerprints of bucketSize slots of every bucket, zero is an
+	// empty slot.
+	slots  []uint32
+	victim victim
+	rnd    uint64
+}
+
+// victim represents fingerprint left without slot.
+type victim struct {
+	used        bool
+	index       uint64
+	fingerprint uint32
+}
+
+// NewCuckoo creates new empty cuckoo filter with fingerprint size for false
+// positive rate and number of buckets for capacity items.
+func NewCuckoo(capacity uint64, fpRate float64) (*Cuckoo, error) {
+	if err := validateSize(capacity, fpRate); err != nil {
+		return nil, err
+	}
+
+	fpBits := math.Ceil(math.Log2(2 * bucketSize / fpRate))
+	fpBytes := uint8(min(max(math.Ceil(fpBits/8), 1), 4))
+
+	buckets := uint64(math.Ceil(float64(capacity) / bucketSize / cuckooLoad))
+	if buckets&(buckets-1) != 0 {
+		buckets = 1 << bits.Len64(buckets)
+	}
+	if err := validateEncodedSize(1 + 8 + 1 + 8 + uint64(fpBytes)*(1+buckets*bucketSize)); err != nil {
+		return nil, err
+	}
+
+	return newCuckoo(capacity, fpRate, fpBytes, buckets), nil
+}
+
+func newCuckoo(capacity uint64, fpRate float64, fpBytes uint8, buckets uint64) *Cuckoo {
+	return &Cuckoo{
+		capacity: capacity,
+		fpRate:   fpRate,
+		fpBytes:  fpBytes,
+		slots:    make([]uint32, buckets*bucketSize),
+		rnd:      mixhash.Mix(capacity),
+	}
+}
+
+// Kind returns KindCuckoo.
+func (c *Cuckoo) Kind() Kind { return KindCuckoo }
+
+// Capacity returns number of items filter is sized for.
+func (c *Cuckoo) Capacity() uint64 { return c.capacity }
+
+// FPRate returns false positive rate at capacity.
+func (c *Cuckoo) FPRate() float64 { return c.fpRate }
+
+// Count returns number of added items, duplicates included.
+func (c *Cuckoo) Count() uint64 { return c.count }
+
+// PepperVersion returns server pepper version items are hashed with.
+func (c *Cuckoo) PepperVersion() string { return c.pepperVersion }
+
+// Add stores item fingerprint in one of its buckets relocating other
+// fingerprints if both are full. Returns ErrFull if filter has no room.
+func (c *Cuckoo) Add(item string) error {
+	if c.victim.used {
+		return ErrFull
+	}
+
+	i1, fp := c.locate(item)
+	i2 := c.altIndex(i1, fp)
+	if c.insert(i1, fp) || c.insert(i2, fp) {
+		c.count++
+		return nil
+	}
+
+	i := i1
+	if c.random()&1 == 1 {
+		i = i2
+	}
+	for range maxKicks {
+		slot := i*bucketSize + c.random()%bucketSize
+		fp, c.slots[slot] = c.slots[slot], fp
+		i = c.altIndex(i, fp)
+		if c.insert(i, fp) {
+			c.count++
+			return nil
+		}
+	}
+
+	c.victim = victim{used: true, index: i, fingerprint: fp}
+	c.count++
+
+	return nil
+}
+
+// MightContain reports whether item fingerprint is in one of its buckets.
+func (c *Cuckoo) MightContain(item string) bool {
+	i1, fp := c.locate(item)
+	i2 := c.altIndex(i1, fp)
+
+	if c.victim.used && c.victim.fingerprint == fp && (c.victim.index == i1 || c.victim.index == i2) {
+		return true
+	}
+
+	return c.find(i1, fp) >= 0 || c.find(i2, fp) >= 0
+}
+
+// Delete deletes item fingerprint from one of its buckets.
+func (c *Cuckoo) Delete(item string) (bool, error) {
+	i1, fp := c.locate(item)
+	i2 := c.altIndex(i1, fp)
+
+	if c.victim.used && c.victim.fingerprint == fp && (c.victim.index == i1 || c.victim.index == i2) {
+		c.victim = victim{}
+		c.count--
+		return true, nil
+	}
+
+	slot := c.find(i1, fp)
+	if slot < 0 {
+		slot = c.find(i2, fp)
+	}
+	if slot < 0 {
+		return false, nil
+	}
+
+	c.slots[slot] = 0
+	c.count--
+
+	if v := c.victim; v.used && (c.insert(v.index, v.fingerprint) || c.insert(c.altIndex(v.index, v.fingerprint), v.fingerprint)) {
+		c.victim = victim{}
+	}
+
+	return true, nil
+}
+
+func (c *Cuckoo) buckets() uint64 { return uint64(len(c.slots)) / bucketSize }
+
+// locate returns item bucket index and fingerprint. Zero fingerprint marks
+// empty slot, so it's replaced with one.
+func (c *Cuckoo) locate(item string) (uint64, uint32) {
+	h1, h2 := mixhash.String128(item)
+
+	fp := uint32(h2 & (1<<(8*uint64(c.fpBytes)) - 1))
+	if fp == 0 {
+		fp = 1
+	}
+
+	return h1 & (c.buckets() - 1), fp
+}
+
+// altIndex returns the other bucket of fingerprint. It's an involution, so
+// the original bucket is the alternate one of alternate bucket.
+func (c *Cuckoo) altIndex(i uint64, fp uint32) uint64 {
+	return (i ^ mixhash.Mix(uint64(fp))) & (c.buckets() - 1)
+}
+
+func (c *Cuckoo) insert(i uint64, fp uint32) bool {
+	for slot := i * bucketSize; slot < (i+1)*bucketSize; slot++ {
+		if c.slots[slot] == 0 {
+			c.slots[slot] = fp
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Cuckoo) find(i uint64, fp uint32) int {
+	for slot := i * bucketSize; slot < (i+1)*bucketSize; slot++ {
+		if c.slots[slot] == fp {
+			return int(slot)
+		}
+	}
+
+	return -1
+}
+
+// random returns next xorshift64 number, used to choose relocated
+// fingerprints.
+func (c *Cuckoo) random() uint64 {
+	c.rnd ^= c.rnd << 13
+	c.rnd ^= c.rnd >> 7
+	c.rnd ^= c.rnd << 17
+	return c.rnd
+}
@@ -0,0 +1,2 @@
+// Package filter provides a domain filter definitions.
+package filter
@@ -0,0 +1,189 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Filter domain errors.
+var (
+	ErrInvalidName       = errors.New("filter name must be 1 to 64 letters, digits, '.', '-' or '_'")
+	ErrInvalidCapacity   = errors.New("capacity must be from 1 to 1000000000")
+	ErrInvalidFPRate     = errors.New("false positive rate must be greater than 0 and less than 1")
+	ErrUnsupportedKind   = errors.New("unsupported filter kind")
+	ErrDeleteUnsupported = errors.New("filter doesn't support deletion")
+	ErrFull              = errors.New("filter is full")
+	ErrMalformed         = errors.New("malformed filter data")
+	ErrNotFound          = errors.New("filter not found")
+	ErrExists            = errors.New("filter already exists")
+	ErrTooLarge          = errors.New("filter exceeds 256 MiB, lower capacity or raise false positive rate")
+
+	ErrPepperVersionTooLong = errors.New("pepper version is too long")
+)
+
+// MaxSize is a maximum size of filter in binary format, it keeps filters
+// within Redis value limit.
+const MaxSize = 256 << 20
+
+const (
+	// maxNameSize is a maximum filter name size in bytes.
+	maxNameSize = 64
+	// maxCapacity is a maximum number of items filter is sized for.
+	maxCapacity = 1_000_000_000
+	// maxPepperVersionSize is a maximum pepper version size in bytes.
+	maxPepperVersionSize = 255
+)
+
+// Filter is a contract of probabilistic sets of items. Added items are
+// always reported as contained, other items are falsely reported as contained
+// at about configured rate once filter holds capacity items.
+type Filter interface {
+	// Kind returns filter kind.
+	Kind() Kind
+
+	// Capacity returns number of items filter is sized for.
+	Capacity() uint64
+
+	// FPRate returns false positive rate at capacity.
+	FPRate() float64
+
+	// Count returns number of added items, duplicates included.
+	Count() uint64
+
+	// PepperVersion returns server pepper version items are hashed with.
+	PepperVersion() string
+
+	// Add adds item to filter.
+	Add(item string) error
+
+	// MightContain reports whether item might have been added.
+	MightContain(item string) bool
+
+	// Delete deletes item added before and reports whether it was found.
+	// Deleting item never added may delete another one with equal
+	// fingerprint.
+	Delete(item string) (bool, error)
+
+	// MarshalBinary encodes filter in binary format, see Unmarshal.
+	MarshalBinary() ([]byte, error)
+}
+
+// Repository is a contract that filter repositories should implement.
+// Filters are kept per tenant.
+type Repository interface {
+	// Save saves filter by name replacing saved one.
+	Save(ctx context.Context, tenant, name string, f Filter) error
+
+	// Find finds filter by name.
+	Find(ctx context.Context, tenant, name string) (Filter, error)
+
+	// Create saves filter by name unless filter with the same name exists,
+	// ErrExists is returned then.
+	Create(ctx context.Context, tenant, name string, f Filter) error
+
+	// Update atomically finds filter by name, calls fn with it and saves it
+	// unless fn fails. fn may be called again if filter was changed
+	// concurrently.
+	Update(ctx context.Context, tenant, name string, fn func(Filter) error) (Filter, error)
+}
+
+// Kind represents filter kind.
+type Kind int8
+
+// Supported kinds.
+const (
+	KindBloom Kind = iota + 1
+	KindCuckoo
+)
+
+// String strings kind numeric constant.
+func (k Kind) String() string {
+	switch k {
+	case KindBloom:
+		return "bloom"
+	case KindCuckoo:
+		return "cuckoo"
+	default:
+		return ""
+	}
+}
+
+// ParseKind parses kind by its name.
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "bloom":
+		return KindBloom, nil
+	case "cuckoo":
+		return KindCuckoo, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedKind, name)
+	}
+}
+
+// New creates new empty filter of given kind sized for capacity items at
+// false positive rate. Items are hashed with server pepper of given version,
+// which is pinned, so filter survives pepper rotation.
+func New(kind Kind, capacity uint64, fpRate float64, pepperVersion string) (Filter, error) {
+	if len(pepperVersion) > maxPepperVersionSize {
+		return nil, ErrPepperVersionTooLong
+	}
+
+	switch kind {
+	case KindBloom:
+		b, err := NewBloom(capacity, fpRate)
+		if err != nil {
+			return nil, err
+		}
+		b.pepperVersion = pepperVersion
+		return b, nil
+	case KindCuckoo:
+		c, err := NewCuckoo(capacity, fpRate)
+		if err != nil {
+			return nil, err
+		}
+		c.pepperVersion = pepperVersion
+		return c, nil
+	default:
+		return nil, ErrUnsupportedKind
+	}
+}
+
+// ValidateName validates filter name.
+func ValidateName(name string) error {
+	if name == "" || len(name) > maxNameSize {
+		return ErrInvalidName
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return ErrInvalidName
+		}
+	}
+
+	return nil
+}
+
+// validateEncodedSize validates filter with body of given size fits MaxSize
+// in binary format whatever its pepper version is.
+func validateEncodedSize(bodySize uint64) error {
+	if bodySize > MaxSize-headerSize-1-maxPepperVersionSize-checksumSize {
+		return ErrTooLarge
+	}
+
+	return nil
+}
+
+func validateSize(capacity uint64, fpRate float64) error {
+	if capacity == 0 || capacity > maxCapacity {
+		return ErrInvalidCapacity
+	}
+
+	if !(fpRate > 0 && fpRate < 1) {
+		return ErrInvalidFPRate
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      Kind
+		capacity  uint64
+		fpRate    float64
+		expectErr error
+	}{
+		{"bloom", KindBloom, 1000, 0.01, nil},
+		{"cuckoo", KindCuckoo, 1000, 0.001, nil},
+		{"zero capacity", KindBloom, 0, 0.01, ErrInvalidCapacity},
+		{"too large capacity", KindCuckoo, maxCapacity + 1, 0.01, ErrInvalidCapacity},
+		{"bloom over max size", KindBloom, maxCapacity, 0.01, ErrTooLarge},
+		{"cuckoo over max size", KindCuckoo, maxCapacity, 0.01, ErrTooLarge},
+		{"zero rate", KindBloom, 1000, 0, ErrInvalidFPRate},
+		{"rate of one", KindCuckoo, 1000, 1, ErrInvalidFPRate},
+		{"unknown kind", 0, 1000, 0.01, ErrUnsupportedKind},
+	}
+
+	if _, err := New(KindBloom, 1000, 0.01, strings.Repeat("v", 256)); !errors.Is(err, ErrPepperVersionTooLong) {
+		t.Errorf("expected error %v, got %v", ErrPepperVersionTooLong, err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := New(tt.kind, tt.capacity, tt.fpRate, "")
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil && f != nil {
+				t.Errorf("expected nil filter on error, got %v", f)
+			}
+		})
+	}
+}
+
+func TestFilter_FPRate(t *testing.T) {
+	const n = 10_000
+
+	for _, kind := range []Kind{KindBloom, KindCuckoo} {
+		for _, rate := range []float64{0.01, 0.001} {
+			t.Run(fmt.Sprintf("%v %v", kind, rate), func(t *testing.T) {
+				f, err := New(kind, n, rate, "")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for i := range n {
+					if err := f.Add(item(i)); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+
+				for i := range n {
+					if !f.MightContain(item(i)) {
+						t.Fatalf("false negative for %q", item(i))
+					}
+				}
+
+				var fp int
+				for i := n; i < 11*n; i++ {
+					if f.MightContain(item(i)) {
+						fp++
+					}
+				}
+				if got := float64(fp) / (10 * n); got > 2*rate {
+					t.Errorf("expected false positive rate about %v, got %v", rate, got)
+				}
+
+				if f.Count() != n {
+					t.Errorf("expected count %d, got %d", n, f.Count())
+				}
+			})
+		}
+	}
+}
+
+func TestBloom_Delete(t *testing.T) {
+	b, err := NewBloom(10, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := b.Delete("foo"); !errors.Is(err, ErrDeleteUnsupported) {
+		t.Errorf("expected error %v, got %v", ErrDeleteUnsupported, err)
+	}
+}
+
+func TestCuckoo_Delete(t *testing.T) {
+	c, err := NewCuckoo(100, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 100 {
+		if err := c.Add(item(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := range 50 {
+		if ok, err := c.Delete(item(i)); err != nil || !ok {
+			t.Fatalf("expected %q deleted, got %v, %v", item(i), ok, err)
+		}
+	}
+
+	for i := range 50 {
+		if c.MightContain(item(i)) {
+			t.Errorf("expected deleted %q not contained", item(i))
+		}
+	}
+	for i := 50; i < 100; i++ {
+		if !c.MightContain(item(i)) {
+			t.Errorf("expected %q contained", item(i))
+		}
+	}
+
+	if ok, err := c.Delete(item(0)); err != nil || ok {
+		t.Errorf("expected deleted item not found, got %v, %v", ok, err)
+	}
+	if c.Count() != 50 {
+		t.Errorf("expected count 50, got %d", c.Count())
+	}
+}
+
+func TestCuckoo_Full(t *testing.T) {
+	c, err := NewCuckoo(8, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var added int
+	for ; added < 1000; added++ {
+		if err := c.Add(item(added)); errors.Is(err, ErrFull) {
+			break
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if added < 8 || added == 1000 {
+		t.Fatalf("expected filter full after capacity, added %d", added)
+	}
+
+	for i := range added {
+		if !c.MightContain(item(i)) {
+			t.Fatalf("false negative for %q", item(i))
+		}
+	}
+
+	// Deletion makes room for fingerprint kept aside.
+	for i := range added / 2 {
+		if _, err := c.Delete(item(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Add(item(0)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i := added / 2; i < added; i++ {
+		if !c.MightContain(item(i)) {
+			t.Fatalf("false negative for %q", item(i))
+		}
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, kind := range []Kind{KindBloom, KindCuckoo} {
+		t.Run(kind.String(), func(t *testing.T) {
+			f, err := New(kind, 1000, 0.01, "2026-10")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range 500 {
+				if err := f.Add(item(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Kind() != kind || got.Capacity() != 1000 || got.FPRate() != 0.01 || got.Count() != 500 || got.PepperVersion() != "2026-10" {
+				t.Errorf("unexpected filter %v of %d items with pepper %q", got.Kind(), got.Count(), got.PepperVersion())
+			}
+			for i := range 500 {
+				if !got.MightContain(item(i)) {
+					t.Fatalf("false negative for %q", item(i))
+				}
+			}
+
+			again, err := got.MarshalBinary()
+			if err != nil || !bytes.Equal(data, again) {
+				t.Errorf("expected stable encoding, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMarshalBinary_Format(t *testing.T) {
+	b, err := NewBloom(1, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []byte{
+		'H', 'F', 'L', 'T', 2, 1,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0x3f, 0xe0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0,
+		44,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(data[:len(data)-checksumSize], expect) {
+		t.Errorf("expected %x, got %x", expect, data[:len(data)-checksumSize])
+	}
+}
+
+func TestUnmarshal_Version1(t *testing.T) {
+	data := []byte{
+		'H', 'F', 'L', 'T', 1, 1,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0x3f, 0xe0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		44,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+
+	f, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.Kind() != KindBloom || f.Capacity() != 1 || f.PepperVersion() != "" {
+		t.Errorf("unexpected filter %v of capacity %d with pepper %q", f.Kind(), f.Capacity(), f.PepperVersion())
+	}
+}
+
+func TestUnmarshal_Malformed(t *testing.T) {
+	c, err := NewCuckoo(100, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)/2] ^= 1
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated", data[:len(data)-10]},
+		{"corrupted", corrupted},
+		{"not a filter", []byte(strings.Repeat("x", 64))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Unmarshal(tt.data); !errors.Is(err, ErrMalformed) {
+				t.Errorf("expected error %v, got %v", ErrMalformed, err)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    string
+		expectErr error
+	}{
+		{"valid", "do-not-contact_v2", nil},
+		{"empty", "", ErrInvalidName},
+		{"path", "../secrets", ErrInvalidName},
+		{"too long", strings.Repeat("a", maxNameSize+1), ErrInvalidName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateName(tt.filter); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func item(i int) string {
+	return fmt.Sprintf("%064x", i)
+}
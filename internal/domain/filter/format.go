@@ -0,0 +1,233 @@
+package filter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"math/bits"
+)
+
+// Binary format of filters. Integers are big-endian.
+//
+//	magic         4 bytes "HFLT"
+//	version       uint8, 2
+//	kind          uint8, 1 is Bloom, 2 is cuckoo
+//	capacity      uint64
+//	fp rate       float64, IEEE 754
+//	count         uint64
+//	pepper        uint8 size and server pepper version items are hashed with
+//	body          kind specific
+//	checksum      uint32, CRC-32 IEEE of all preceding bytes
+//
+// Bloom body:
+//
+//	hashes        uint8, number of hash functions k
+//	words         uint64, number of 64-bit words of bits, m is 64 * words
+//	bits          words uint64, bit i is bit i % 64 of word i / 64
+//
+// Cuckoo body:
+//
+//	fp size       uint8, fingerprint size in bytes f, 1 to 4
+//	buckets       uint64, number of buckets, power of two
+//	victim        uint8 1 if fingerprint is kept aside, uint64 its bucket and
+//	              f bytes fingerprint
+//	slots         4 * buckets f bytes fingerprints, zero is an empty slot
+//
+// Items are hashed by FNV-1a 128, h1 and h2 are SplitMix64 finalizers of the
+// first and the second 8 bytes of hash. Bloom item bits are (h1 + i * h2) mod m for i from 0 to k.
+// Cuckoo item bucket is h1 mod buckets, fingerprint is the lowest f bytes of
+// h2 or 1 if they are zero and alternate bucket is bucket xor SplitMix64
+// finalizer of fingerprint mod buckets.
+//
+// Version 1 has no pepper version, such filters are hashed with current
+// server pepper.
+const (
+	formatMagic   = "HFLT"
+	formatVersion = 2
+	// headerSize is a size of magic, version, kind, capacity, fp rate and
+	// count.
+	headerSize = 4 + 1 + 1 + 8 + 8 + 8
+	// checksumSize is a size of checksum trailer.
+	checksumSize = 4
+)
+
+// MarshalBinary encodes Bloom filter in binary format.
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, headerSize+1+len(b.pepperVersion)+1+8+len(b.bits)*8+checksumSize), b)
+	data = append(data, b.hashes)
+	data = binary.BigEndian.AppendUint64(data, uint64(len(b.bits)))
+	for _, w := range b.bits {
+		data = binary.BigEndian.AppendUint64(data, w)
+	}
+
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// MarshalBinary encodes cuckoo filter in binary format.
+func (c *Cuckoo) MarshalBinary() ([]byte, error) {
+	f := int(c.fpBytes)
+	data := appendHeader(make([]byte, 0, headerSize+1+len(c.pepperVersion)+1+8+1+8+f+len(c.slots)*f+checksumSize), c)
+	data = append(data, c.fpBytes)
+	data = binary.BigEndian.AppendUint64(data, c.buckets())
+
+	var used byte
+	if c.victim.used {
+		used = 1
+	}
+	data = append(data, used)
+	data = binary.BigEndian.AppendUint64(data, c.victim.index)
+	data = appendFingerprint(data, c.victim.fingerprint, f)
+
+	for _, fp := range c.slots {
+		data = appendFingerprint(data, fp, f)
+	}
+
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// Unmarshal decodes filter encoded in binary format.
+func Unmarshal(data []byte) (Filter, error) {
+	if len(data) > MaxSize {
+		return nil, ErrTooLarge
+	}
+	if len(data) < headerSize+checksumSize {
+		return nil, fmt.Errorf("%w: too short", ErrMalformed)
+	}
+
+	payload, sum := data[:len(data)-checksumSize], data[len(data)-checksumSize:]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(sum) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrMalformed)
+	}
+
+	if string(payload[:4]) != formatMagic {
+		return nil, fmt.Errorf("%w: not a filter", ErrMalformed)
+	}
+	version := payload[4]
+	if version != 1 && version != formatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrMalformed, version)
+	}
+
+	kind := Kind(payload[5])
+	capacity := binary.BigEndian.Uint64(payload[6:])
+	fpRate := math.Float64frombits(binary.BigEndian.Uint64(payload[14:]))
+	count := binary.BigEndian.Uint64(payload[22:])
+	if err := validateSize(capacity, fpRate); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	body := payload[headerSize:]
+	var pepperVersion string
+	if version == formatVersion {
+		if len(body) < 1 || len(body) < 1+int(body[0]) {
+			return nil, fmt.Errorf("%w: truncated pepper version", ErrMalformed)
+		}
+		pepperVersion, body = string(body[1:1+body[0]]), body[1+body[0]:]
+	}
+
+	switch kind {
+	case KindBloom:
+		b, err := unmarshalBloom(body, capacity, fpRate, count)
+		if err != nil {
+			return nil, err
+		}
+		b.pepperVersion = pepperVersion
+		return b, nil
+	case KindCuckoo:
+		c, err := unmarshalCuckoo(body, capacity, fpRate, count)
+		if err != nil {
+			return nil, err
+		}
+		c.pepperVersion = pepperVersion
+		return c, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, ErrUnsupportedKind)
+	}
+}
+
+func unmarshalBloom(body []byte, capacity uint64, fpRate float64, count uint64) (*Bloom, error) {
+	if len(body) < 1+8 {
+		return nil, fmt.Errorf("%w: truncated bloom filter", ErrMalformed)
+	}
+
+	hashes := body[0]
+	words := binary.BigEndian.Uint64(body[1:])
+	body = body[9:]
+	if hashes == 0 || words == 0 || words != uint64(len(body))/8 || len(body)%8 != 0 {
+		return nil, fmt.Errorf("%w: bloom filter size mismatch", ErrMalformed)
+	}
+
+	b := &Bloom{
+		capacity: capacity,
+		fpRate:   fpRate,
+		count:    count,
+		hashes:   hashes,
+		bits:     make([]uint64, words),
+	}
+	for i := range b.bits {
+		b.bits[i] = binary.BigEndian.Uint64(body[i*8:])
+	}
+
+	return b, nil
+}
+
+func unmarshalCuckoo(body []byte, capacity uint64, fpRate float64, count uint64) (*Cuckoo, error) {
+	if len(body) < 1+8+1+8 {
+		return nil, fmt.Errorf("%w: truncated cuckoo filter", ErrMalformed)
+	}
+
+	fpBytes := body[0]
+	buckets := binary.BigEndian.Uint64(body[1:])
+	if fpBytes == 0 || fpBytes > 4 || buckets == 0 || bits.OnesCount64(buckets) != 1 {
+		return nil, fmt.Errorf("%w: invalid cuckoo filter parameters", ErrMalformed)
+	}
+
+	f := uint64(fpBytes)
+	rest := uint64(len(body)) - 1 - 8 - 1 - 8
+	if rest < f || (rest-f)/f/bucketSize != buckets || (rest-f)%(f*bucketSize) != 0 {
+		return nil, fmt.Errorf("%w: cuckoo filter size mismatch", ErrMalformed)
+	}
+
+	c := newCuckoo(capacity, fpRate, fpBytes, buckets)
+	c.count = count
+
+	if body[9] == 1 {
+		c.victim = victim{
+			used:        true,
+			index:       binary.BigEndian.Uint64(body[10:]) & (buckets - 1),
+			fingerprint: readFingerprint(body[18:], int(f)),
+		}
+	}
+
+	slots := body[18+f:]
+	for i := range c.slots {
+		c.slots[i] = readFingerprint(slots[uint64(i)*f:], int(f))
+	}
+
+	return c, nil
+}
+
+func appendHeader(data []byte, f Filter) []byte {
+	data = append(data, formatMagic...)
+	data = append(data, formatVersion, byte(f.Kind()))
+	data = binary.BigEndian.AppendUint64(data, f.Capacity())
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(f.FPRate()))
+	data = binary.BigEndian.AppendUint64(data, f.Count())
+	data = append(data, byte(len(f.PepperVersion())))
+	return append(data, f.PepperVersion()...)
+}
+
+func appendFingerprint(data []byte, fp uint32, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		data = append(data, byte(fp>>(8*i)))
+	}
+	return data
+}
+
+func readFingerprint(data []byte, size int) uint32 {
+	var fp uint32
+	for i := range size {
+		fp = fp<<8 | uint32(data[i])
+	}
+	return fp
+}
@@ -0,0 +1,51 @@
+package fpe
+
+// radixDigits are characters of alphabets defined by radix only.
+const radixDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// maxRadix is a maximum radix of NIST SP 800-38G.
+const maxRadix = 1 << 16
+
+// Alphabet represents characters values are encrypted over. Numeral of a
+// character is its position in alphabet.
+type Alphabet struct {
+	chars []rune
+	index map[rune]uint16
+}
+
+// NewAlphabet creates new alphabet of given characters.
+func NewAlphabet(chars string) (*Alphabet, error) {
+	runes := []rune(chars)
+	if len(runes) < 2 || len(runes) > maxRadix {
+		return nil, ErrInvalidAlphabet
+	}
+
+	index := make(map[rune]uint16, len(runes))
+	for i, r := range runes {
+		if _, ok := index[r]; ok {
+			return nil, ErrInvalidAlphabet
+		}
+		index[r] = uint16(i)
+	}
+
+	return &Alphabet{
+		chars: runes,
+		index: index,
+	}, nil
+}
+
+// RadixAlphabet creates new alphabet of first radix characters of digits,
+// lowercase and uppercase latin letters.
+func RadixAlphabet(radix int) (*Alphabet, error) {
+	if radix < 2 || radix > len(radixDigits) {
+		return nil, ErrInvalidRadix
+	}
+
+	return NewAlphabet(radixDigits[:radix])
+}
+
+// Radix returns number of alphabet characters.
+func (a *Alphabet) Radix() int { return len(a.chars) }
+
+// String returns alphabet characters.
+func (a *Alphabet) String() string { return string(a.chars) }
@@ -0,0 +1,2 @@
+// Package fpe provides a domain format-preserving encryption definitions.
+package fpe
@@ -0,0 +1,66 @@
+package fpe
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FPE domain errors.
+var (
+	ErrEmptyName          = errors.New("scheme name cannot be empty")
+	ErrEmptyValue         = errors.New("value cannot be empty")
+	ErrUnknownScheme      = errors.New("unknown fpe scheme")
+	ErrDuplicateScheme    = errors.New("duplicate fpe scheme")
+	ErrUnsupportedMode    = errors.New("unsupported fpe mode")
+	ErrInvalidAlphabet    = errors.New("alphabet must have from 2 to 65536 unique characters")
+	ErrInvalidRadix       = errors.New("alphabet radix must be from 2 to 62")
+	ErrInvalidCipherRadix = errors.New("cipher radix must be from 2 to 65536")
+	ErrInvalidTweak       = errors.New("invalid tweak")
+	ErrInvalidLength      = errors.New("invalid number of alphabet characters in value")
+	ErrNumeralOutOfBounds = errors.New("numeral is out of radix bounds")
+	ErrForbidden          = errors.New("fpe decryption is not permitted")
+)
+
+// Cipher is a contract that format-preserving ciphers should implement.
+// Values are numeral strings, every numeral is less than cipher radix, and
+// ciphertext has the same length as plaintext.
+type Cipher interface {
+	// Encrypt encrypts numeral string with given tweak.
+	Encrypt(tweak []byte, x []uint16) ([]uint16, error)
+
+	// Decrypt decrypts numeral string encrypted with given tweak.
+	Decrypt(tweak []byte, x []uint16) ([]uint16, error)
+}
+
+// Mode represents format-preserving encryption mode of NIST SP 800-38G.
+type Mode int8
+
+// Supported modes.
+const (
+	ModeFF1 Mode = iota + 1
+	ModeFF31
+)
+
+// String strings mode numeric constant.
+func (m Mode) String() string {
+	switch m {
+	case ModeFF1:
+		return "ff1"
+	case ModeFF31:
+		return "ff3-1"
+	default:
+		return ""
+	}
+}
+
+// ParseMode parses mode by its name.
+func ParseMode(name string) (Mode, error) {
+	switch name {
+	case "ff1":
+		return ModeFF1, nil
+	case "ff3-1":
+		return ModeFF31, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedMode, name)
+	}
+}
@@ -0,0 +1,87 @@
+package fpe
+
+// Scheme represents named format-preserving encryption settings.
+//
+// Only characters of scheme alphabet are encrypted, others are kept in
+// place, so "+1 (555) 010-9999" stays a phone number with digits alphabet.
+type Scheme struct {
+	name     string
+	mode     Mode
+	alphabet *Alphabet
+	cipher   Cipher
+	tweak    []byte
+}
+
+// NewScheme creates new scheme instance. Cipher must be made for alphabet
+// radix, tweak is used when callers don't provide one.
+func NewScheme(name string, mode Mode, alphabet *Alphabet, cipher Cipher, tweak []byte) (*Scheme, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	if mode != ModeFF1 && mode != ModeFF31 {
+		return nil, ErrUnsupportedMode
+	}
+
+	return &Scheme{
+		name:     name,
+		mode:     mode,
+		alphabet: alphabet,
+		cipher:   cipher,
+		tweak:    tweak,
+	}, nil
+}
+
+// Name returns scheme name.
+func (s *Scheme) Name() string { return s.name }
+
+// Mode returns scheme mode.
+func (s *Scheme) Mode() Mode { return s.mode }
+
+// Alphabet returns scheme alphabet.
+func (s *Scheme) Alphabet() *Alphabet { return s.alphabet }
+
+// Encrypt encrypts alphabet characters of value. Empty tweak means scheme
+// default.
+func (s *Scheme) Encrypt(value string, tweak []byte) (string, error) {
+	return s.transform(value, tweak, s.cipher.Encrypt)
+}
+
+// Decrypt decrypts value encrypted with the same tweak.
+func (s *Scheme) Decrypt(value string, tweak []byte) (string, error) {
+	return s.transform(value, tweak, s.cipher.Decrypt)
+}
+
+func (s *Scheme) transform(value string, tweak []byte, fn func([]byte, []uint16) ([]uint16, error)) (string, error) {
+	if value == "" {
+		return "", ErrEmptyValue
+	}
+
+	if len(tweak) == 0 {
+		tweak = s.tweak
+	}
+
+	runes := []rune(value)
+
+	var (
+		positions []int
+		x         []uint16
+	)
+	for i, r := range runes {
+		if d, ok := s.alphabet.index[r]; ok {
+			positions = append(positions, i)
+			x = append(x, d)
+		}
+	}
+
+	y, err := fn(tweak, x)
+	if err != nil {
+		return "", err
+	}
+
+	for j, i := range positions {
+		runes[i] = s.alphabet.chars[y[j]]
+	}
+
+	return string(runes), nil
+}
@@ -0,0 +1,146 @@
+package fpe
+
+import (
+	"errors"
+	"testing"
+)
+
+// mockCipher adds tweak length to every numeral.
+type mockCipher struct{ radix int }
+
+func (c mockCipher) Encrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	return c.shift(x, len(tweak)), nil
+}
+
+func (c mockCipher) Decrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	return c.shift(x, c.radix-len(tweak)%c.radix), nil
+}
+
+func (c mockCipher) shift(x []uint16, n int) []uint16 {
+	y := make([]uint16, len(x))
+	for i, d := range x {
+		y[i] = uint16((int(d) + n) % c.radix)
+	}
+	return y
+}
+
+func TestScheme(t *testing.T) {
+	alphabet, err := RadixAlphabet(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, err := NewScheme("phone", ModeFF1, alphabet, mockCipher{radix: 10}, []byte{0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		value  string
+		tweak  []byte
+		expect string
+	}{
+		{"default tweak", "+1 (555) 010-9999", nil, "+2 (666) 121-0000"},
+		{"request tweak", "5550109", []byte{1, 2}, "7772321"},
+		{"no alphabet characters", "тел", nil, "тел"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Encrypt(tt.value, tt.tweak)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+
+			back, err := s.Decrypt(got, tt.tweak)
+			if err != nil || back != tt.value {
+				t.Errorf("expected %q back, got %q, %v", tt.value, back, err)
+			}
+		})
+	}
+
+	if _, err := s.Encrypt("", nil); !errors.Is(err, ErrEmptyValue) {
+		t.Errorf("expected error %v, got %v", ErrEmptyValue, err)
+	}
+}
+
+func TestNewScheme(t *testing.T) {
+	alphabet, _ := RadixAlphabet(10)
+
+	tests := []struct {
+		name      string
+		scheme    string
+		mode      Mode
+		expectErr error
+	}{
+		{"valid", "phone", ModeFF31, nil},
+		{"empty name", "", ModeFF1, ErrEmptyName},
+		{"unsupported mode", "phone", Mode(99), ErrUnsupportedMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScheme(tt.scheme, tt.mode, alphabet, mockCipher{radix: 10}, nil); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestNewAlphabet(t *testing.T) {
+	tests := []struct {
+		name        string
+		chars       string
+		expectRadix int
+		expectErr   error
+	}{
+		{"digits", "0123456789", 10, nil},
+		{"unicode", "абв", 3, nil},
+		{"single", "0", 0, ErrInvalidAlphabet},
+		{"duplicate", "0120", 0, ErrInvalidAlphabet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAlphabet(tt.chars)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err == nil && a.Radix() != tt.expectRadix {
+				t.Errorf("expected radix %d, got %d", tt.expectRadix, a.Radix())
+			}
+		})
+	}
+
+	if _, err := RadixAlphabet(63); !errors.Is(err, ErrInvalidRadix) {
+		t.Errorf("expected error %v, got %v", ErrInvalidRadix, err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		expect    Mode
+		expectErr error
+	}{
+		{"ff1", ModeFF1, nil},
+		{"ff3-1", ModeFF31, nil},
+		{"ff3", 0, ErrUnsupportedMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMode(tt.name)
+			if !errors.Is(err, tt.expectErr) || m != tt.expect {
+				t.Errorf("expected %v, %v, got %v, %v", tt.expect, tt.expectErr, m, err)
+			}
+			if err == nil && m.String() != tt.name {
+				t.Errorf("expected name %q, got %q", tt.name, m.String())
+			}
+		})
+	}
+}
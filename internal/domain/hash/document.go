@@ -0,0 +1,46 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+)
+
+// Document domain errors.
+var (
+	ErrMalformedDocument = errors.New("malformed document")
+	ErrInvalidFieldPath  = errors.New("invalid field path")
+)
+
+// Fields represents selection of document fields. Paths are dot-separated
+// object keys, e.g. "address.zip". Empty Include means every field, excluded
+// fields are removed after inclusion.
+type Fields struct {
+	Include []string
+	Exclude []string
+}
+
+// Empty reports whether no fields are selected, so the whole document is
+// kept.
+func (f Fields) Empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Canonicalizer is a contract that document canonicalizers should implement.
+type Canonicalizer interface {
+	// Canonicalize returns canonical form of document with selected fields
+	// only. Equal documents have equal canonical forms regardless of keys
+	// order, whitespace and numbers formatting.
+	Canonicalize(doc string, fields Fields) (string, error)
+}
+
+// SplitFieldPath splits field path to keys.
+func SplitFieldPath(path string) ([]string, error) {
+	keys := strings.Split(path, ".")
+	for _, k := range keys {
+		if k == "" {
+			return nil, ErrInvalidFieldPath
+		}
+	}
+
+	return keys, nil
+}
@@ -1,23 +1,47 @@
 package hash
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Hash domain errors.
 var (
 	ErrEmptyInput           = errors.New("input string cannot be empty")
 	ErrEmptyHash            = errors.New("hashed string cannot be empty")
 	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+
+	ErrUnsupportedNormalization = errors.New("unsupported normalization")
+	ErrSaltTooLong              = errors.New("salt is too long")
 )
 
+// maxSaltSize is a maximum salt size in bytes.
+const maxSaltSize = 256
+
+// Params represents optional hashing parameters. Salt is provided by caller,
+// pepper version identifies server pepper input was mixed with. Empty values
+// mean no salt and no server pepper.
+type Params struct {
+	Salt          string
+	PepperVersion string
+}
+
 // Hash represents hash domain entity.
 type Hash struct {
 	input  string
 	hashed string
 	alg    Algorithm
+	params Params
 }
 
-// New creates new hash instance.
+// New creates new hash instance without salt and server pepper.
 func New(input, hashed string, alg Algorithm) (*Hash, error) {
+	return NewWithParams(input, hashed, alg, Params{})
+}
+
+// NewWithParams creates new hash instance made with given salt and server
+// pepper version.
+func NewWithParams(input, hashed string, alg Algorithm, params Params) (*Hash, error) {
 	if input == "" {
 		return nil, ErrEmptyInput
 	}
@@ -30,10 +54,15 @@ func New(input, hashed string, alg Algorithm) (*Hash, error) {
 		return nil, ErrUnsupportedAlgorithm
 	}
 
+	if err := ValidateSalt(params.Salt); err != nil {
+		return nil, err
+	}
+
 	return &Hash{
 		input:  input,
 		hashed: hashed,
 		alg:    alg,
+		params: params,
 	}, nil
 }
 
@@ -46,6 +75,24 @@ func (h *Hash) Algorithm() Algorithm { return h.alg }
 // Input returns string from which hash was build.
 func (h *Hash) Input() string { return h.input }
 
+// Salt returns salt input was mixed with.
+func (h *Hash) Salt() string { return h.params.Salt }
+
+// PepperVersion returns version of server pepper input was mixed with.
+func (h *Hash) PepperVersion() string { return h.params.PepperVersion }
+
+// Params returns salt and pepper version hash was made with.
+func (h *Hash) Params() Params { return h.params }
+
+// ValidateSalt validates salt provided by caller.
+func ValidateSalt(salt string) error {
+	if len(salt) > maxSaltSize {
+		return ErrSaltTooLong
+	}
+
+	return nil
+}
+
 func isValidAlgorithm(alg Algorithm) bool {
 	return alg == AlgorithmMD5 || alg == AlgorithmSHA256
 }
@@ -70,3 +117,27 @@ func (a Algorithm) String() string {
 		return ""
 	}
 }
+
+// Size returns algorithm digest size in bytes, zero for unsupported one.
+func (a Algorithm) Size() int {
+	switch a {
+	case AlgorithmMD5:
+		return 16
+	case AlgorithmSHA256:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// ParseAlgorithm parses algorithm name as returned by Algorithm.String.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "md5":
+		return AlgorithmMD5, nil
+	case "sha256":
+		return AlgorithmSHA256, nil
+	default:
+		return 0, ErrUnsupportedAlgorithm
+	}
+}
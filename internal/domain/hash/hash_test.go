@@ -1,6 +1,8 @@
 package hash
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -80,6 +82,24 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithParams(t *testing.T) {
+	params := Params{Salt: "campaign-42", PepperVersion: "v1"}
+
+	h, err := NewWithParams("test", "hash", AlgorithmMD5, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if h.Params() != params || h.Salt() != "campaign-42" || h.PepperVersion() != "v1" {
+		t.Errorf("expected params %+v, got %+v", params, h.Params())
+	}
+
+	_, err = NewWithParams("test", "hash", AlgorithmMD5, Params{Salt: strings.Repeat("s", maxSaltSize+1)})
+	if !errors.Is(err, ErrSaltTooLong) {
+		t.Errorf("expected error %v, got %v", ErrSaltTooLong, err)
+	}
+}
+
 func TestIsValidAlgorithm(t *testing.T) {
 	tests := []struct {
 		name     string
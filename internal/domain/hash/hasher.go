@@ -1,6 +1,32 @@
 package hash
 
+import "encoding/binary"
+
 // Hasher represents contract that different hash creators should implement.
 type Hasher interface {
 	Hash(input string) string
+
+	// MAC returns hex-encoded HMAC of input keyed by key.
+	MAC(key []byte, input string) string
+}
+
+// Digest hashes input mixed with server pepper, tenant pepper and salt. Input
+// is hashed as is if all of them are empty, otherwise digest is HMAC keyed by
+// both peppers over salt followed by input. Peppers and salt are length
+// prefixed, so no part can be shifted into another, e.g. salt "ab" with input
+// "c" and salt "a" with input "bc" get different digests.
+func Digest(h Hasher, serverPepper, tenantPepper, salt, input string) string {
+	if serverPepper == "" && tenantPepper == "" && salt == "" {
+		return h.Hash(input)
+	}
+
+	key := appendPrefixed(nil, serverPepper)
+	key = appendPrefixed(key, tenantPepper)
+
+	return h.MAC(key, string(appendPrefixed(nil, salt))+input)
+}
+
+func appendPrefixed(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
 }
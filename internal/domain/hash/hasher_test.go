@@ -0,0 +1,52 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+)
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Hash(input string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
+}
+
+func (sha256Hasher) MAC(key []byte, input string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+func TestDigest(t *testing.T) {
+	if got, expected := Digest(sha256Hasher{}, "", "", "", "hello"), (sha256Hasher{}).Hash("hello"); got != expected {
+		t.Errorf("expected plain hash %q without peppers and salt, got %q", expected, got)
+	}
+
+	tests := []struct {
+		name         string
+		serverPepper string
+		tenantPepper string
+		salt         string
+		input        string
+	}{
+		{name: "salt", salt: "ab", input: "c"},
+		{name: "salt shifted into input", salt: "a", input: "bc"},
+		{name: "salt and input concatenated", input: "abc"},
+		{name: "server pepper", serverPepper: "p", input: "abc"},
+		{name: "tenant pepper", tenantPepper: "p", input: "abc"},
+		{name: "server pepper shifted into tenant pepper", serverPepper: "p", tenantPepper: "q", input: "abc"},
+		{name: "peppers concatenated", serverPepper: "pq", input: "abc"},
+		{name: "pepper shifted into salt", serverPepper: "p", salt: "q", input: "abc"},
+	}
+
+	seen := make(map[string]string)
+	for _, tt := range tests {
+		got := Digest(sha256Hasher{}, tt.serverPepper, tt.tenantPepper, tt.salt, tt.input)
+		if other, ok := seen[got]; ok {
+			t.Errorf("%s: expected digest different from %q one", tt.name, other)
+		}
+		seen[got] = tt.name
+	}
+}
@@ -0,0 +1,69 @@
+package hash
+
+import "strings"
+
+// Normalization represents input normalization applied before hashing.
+type Normalization int8
+
+// Supported normalizations. Zero value means no normalization.
+const (
+	NormalizationNone Normalization = iota + 1
+	NormalizationTrim
+	NormalizationLower
+	NormalizationDigits
+)
+
+// Apply normalizes input string.
+//
+// Trim removes leading and trailing whitespace, lower additionally lowercases
+// input and digits keeps only decimal digits, e.g. for phone numbers.
+func (n Normalization) Apply(input string) string {
+	switch n {
+	case NormalizationTrim:
+		return strings.TrimSpace(input)
+	case NormalizationLower:
+		return strings.ToLower(strings.TrimSpace(input))
+	case NormalizationDigits:
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, input)
+	default:
+		return input
+	}
+}
+
+// String strings normalization numeric constant.
+func (n Normalization) String() string {
+	switch n {
+	case NormalizationNone:
+		return "none"
+	case NormalizationTrim:
+		return "trim"
+	case NormalizationLower:
+		return "lower"
+	case NormalizationDigits:
+		return "digits"
+	default:
+		return ""
+	}
+}
+
+// ParseNormalization parses normalization name. Empty name means no
+// normalization.
+func ParseNormalization(name string) (Normalization, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return NormalizationNone, nil
+	case "trim":
+		return NormalizationTrim, nil
+	case "lower":
+		return NormalizationLower, nil
+	case "digits":
+		return NormalizationDigits, nil
+	default:
+		return 0, ErrUnsupportedNormalization
+	}
+}
@@ -0,0 +1,78 @@
+package hash
+
+import "testing"
+
+func TestNormalization_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		norm   Normalization
+		input  string
+		expect string
+	}{
+		{"zero value", 0, " Foo@Example.com ", " Foo@Example.com "},
+		{"none", NormalizationNone, " Foo@Example.com ", " Foo@Example.com "},
+		{"trim", NormalizationTrim, "\t Foo@Example.com \n", "Foo@Example.com"},
+		{"lower", NormalizationLower, " Foo@Example.com ", "foo@example.com"},
+		{"digits", NormalizationDigits, "+7 (912) 345-67-89", "79123456789"},
+		{"digits unicode", NormalizationDigits, "٣12", "12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.norm.Apply(tt.input); got != tt.expect {
+				t.Errorf("%v.Apply(%q) expect %q, got %q", tt.norm, tt.input, tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseNormalization(t *testing.T) {
+	tests := []struct {
+		in          string
+		expect      Normalization
+		expectedErr error
+	}{
+		{"", NormalizationNone, nil},
+		{"none", NormalizationNone, nil},
+		{"Trim", NormalizationTrim, nil},
+		{"lower", NormalizationLower, nil},
+		{"digits", NormalizationDigits, nil},
+		{"upper", 0, ErrUnsupportedNormalization},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNormalization(tt.in)
+		if err != tt.expectedErr {
+			t.Errorf("ParseNormalization(%q) expected error %v, got %v", tt.in, tt.expectedErr, err)
+			continue
+		}
+
+		if got != tt.expect {
+			t.Errorf("ParseNormalization(%q) expect %v, got %v", tt.in, tt.expect, got)
+		}
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		in          string
+		expect      Algorithm
+		expectedErr error
+	}{
+		{"md5", AlgorithmMD5, nil},
+		{"SHA256", AlgorithmSHA256, nil},
+		{"sha1", 0, ErrUnsupportedAlgorithm},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAlgorithm(tt.in)
+		if err != tt.expectedErr {
+			t.Errorf("ParseAlgorithm(%q) expected error %v, got %v", tt.in, tt.expectedErr, err)
+			continue
+		}
+
+		if got != tt.expect {
+			t.Errorf("ParseAlgorithm(%q) expect %v, got %v", tt.in, tt.expect, got)
+		}
+	}
+}
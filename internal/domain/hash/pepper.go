@@ -0,0 +1,97 @@
+package hash
+
+import "errors"
+
+// Pepper domain errors.
+var (
+	ErrEmptyPepper          = errors.New("pepper cannot be empty")
+	ErrUnknownPepperVersion = errors.New("unknown pepper version")
+	ErrReservedPepper       = errors.New(`pepper version "none" is reserved`)
+)
+
+// PepperNone is a pepper version of hashes made without server pepper. It's
+// pinned instead of empty version, which means current one, so hashes made
+// later stay unpeppered after pepper is enabled.
+const PepperNone = "none"
+
+// Peppers represents versioned server-held peppers. New hashes are made with
+// current pepper, previous versions are kept to reproduce hashes made before
+// rotation.
+//
+// Nil peppers mean no server pepper.
+type Peppers struct {
+	current string
+	secrets map[string]string
+}
+
+// NewPeppers creates new peppers instance with given current version and
+// secrets by version.
+func NewPeppers(current string, secrets map[string]string) (*Peppers, error) {
+	for version, secret := range secrets {
+		if version == "" || secret == "" {
+			return nil, ErrEmptyPepper
+		}
+		if version == PepperNone {
+			return nil, ErrReservedPepper
+		}
+	}
+
+	if _, ok := secrets[current]; !ok {
+		return nil, ErrUnknownPepperVersion
+	}
+
+	return &Peppers{
+		current: current,
+		secrets: secrets,
+	}, nil
+}
+
+// Current returns current pepper version.
+func (p *Peppers) Current() string {
+	if p == nil {
+		return ""
+	}
+
+	return p.current
+}
+
+// Resolve returns version and secret of requested pepper. Empty version means
+// current one, PepperNone means no pepper and resolves to empty version.
+func (p *Peppers) Resolve(version string) (string, string, error) {
+	if version == PepperNone {
+		return "", "", nil
+	}
+
+	if p == nil {
+		if version != "" {
+			return "", "", ErrUnknownPepperVersion
+		}
+		return "", "", nil
+	}
+
+	if version == "" {
+		version = p.current
+	}
+
+	secret, ok := p.secrets[version]
+	if !ok {
+		return "", "", ErrUnknownPepperVersion
+	}
+
+	return version, secret, nil
+}
+
+// Pin resolves version of requested pepper to be saved with data hashed
+// later. Returns PepperNone if there is no pepper.
+func (p *Peppers) Pin(version string) (string, error) {
+	version, _, err := p.Resolve(version)
+	if err != nil {
+		return "", err
+	}
+
+	if version == "" {
+		return PepperNone, nil
+	}
+
+	return version, nil
+}
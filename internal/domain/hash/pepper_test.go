@@ -0,0 +1,96 @@
+package hash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPeppers_Resolve(t *testing.T) {
+	p, err := NewPeppers("v2", map[string]string{"v1": "first", "v2": "second"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		peppers       *Peppers
+		version       string
+		expectVersion string
+		expectSecret  string
+		expectErr     error
+	}{
+		{"current", p, "", "v2", "second", nil},
+		{"previous", p, "v1", "v1", "first", nil},
+		{"unknown", p, "v3", "", "", ErrUnknownPepperVersion},
+		{"disabled", nil, "", "", "", nil},
+		{"disabled with version", nil, "v1", "", "", ErrUnknownPepperVersion},
+		{"none", p, PepperNone, "", "", nil},
+		{"disabled none", nil, PepperNone, "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, secret, err := tt.peppers.Resolve(tt.version)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if version != tt.expectVersion || secret != tt.expectSecret {
+				t.Errorf("expected %q/%q, got %q/%q", tt.expectVersion, tt.expectSecret, version, secret)
+			}
+		})
+	}
+}
+
+func TestPeppers_Pin(t *testing.T) {
+	p, err := NewPeppers("v2", map[string]string{"v1": "first", "v2": "second"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		peppers   *Peppers
+		version   string
+		expect    string
+		expectErr error
+	}{
+		{"current", p, "", "v2", nil},
+		{"previous", p, "v1", "v1", nil},
+		{"none", p, PepperNone, PepperNone, nil},
+		{"disabled", nil, "", PepperNone, nil},
+		{"unknown", p, "v3", "", ErrUnknownPepperVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := tt.peppers.Pin(tt.version)
+			if !errors.Is(err, tt.expectErr) || version != tt.expect {
+				t.Errorf("expected %q, %v, got %q, %v", tt.expect, tt.expectErr, version, err)
+			}
+		})
+	}
+}
+
+func TestNewPeppers(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		secrets   map[string]string
+		expectErr error
+	}{
+		{"valid", "v1", map[string]string{"v1": "s"}, nil},
+		{"empty secret", "v1", map[string]string{"v1": ""}, ErrEmptyPepper},
+		{"empty version", "v1", map[string]string{"v1": "s", "": "s"}, ErrEmptyPepper},
+		{"unknown current", "v2", map[string]string{"v1": "s"}, ErrUnknownPepperVersion},
+		{"reserved version", "v1", map[string]string{"v1": "s", PepperNone: "s"}, ErrReservedPepper},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPeppers(tt.current, tt.secrets); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,19 @@
 package hash
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository is a contract that hash repositories should implement.
+//
+// Hashes are isolated by namespace, so equal inputs stored in different
+// namespaces never collide. Empty namespace is shared.
 type Repository interface {
-	// Save saves hash.
-	Save(context.Context, *Hash) error
+	// Save saves hash for given TTL. Zero TTL means repository default.
+	Save(ctx context.Context, namespace string, h *Hash, ttl time.Duration) error
 
-	// FindByInput finds hash by input string and algorithm.
-	FindByInput(ctx context.Context, input string, alg Algorithm) (*Hash, error)
+	// FindByInput finds hash by input string, algorithm, salt and pepper
+	// version.
+	FindByInput(ctx context.Context, namespace, input string, alg Algorithm, params Params) (*Hash, error)
 }
@@ -0,0 +1,52 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Allowlist represents callers permitted to do privileged operations, e.g.
+// detokenize. Callers are matched by source and subject together, so API
+// key client and certificate of the same name are never confused. Callers
+// identified by peer address only are never permitted.
+type Allowlist struct {
+	entries map[entry]struct{}
+}
+
+type entry struct {
+	source  Source
+	subject string
+}
+
+// ParseAllowlist parses allowlist of "source:subject" entries, where source
+// is "apikey" or "certificate", e.g. "apikey:billing".
+func ParseAllowlist(entries []string) (*Allowlist, error) {
+	a := &Allowlist{entries: make(map[entry]struct{}, len(entries))}
+	for _, e := range entries {
+		src, subject, ok := strings.Cut(e, ":")
+		if !ok || subject == "" {
+			return nil, fmt.Errorf("%w, got %q", ErrInvalidEntry, e)
+		}
+
+		switch src {
+		case SourceAPIKey.String():
+			a.entries[entry{source: SourceAPIKey, subject: subject}] = struct{}{}
+		case SourceCertificate.String():
+			a.entries[entry{source: SourceCertificate, subject: subject}] = struct{}{}
+		default:
+			return nil, fmt.Errorf("%w, got %q", ErrInvalidEntry, e)
+		}
+	}
+
+	return a, nil
+}
+
+// Permits reports whether caller is listed. Nil allowlist permits no one.
+func (a *Allowlist) Permits(id *Identity) bool {
+	if a == nil || id == nil {
+		return false
+	}
+
+	_, ok := a.entries[entry{source: id.Source(), subject: id.Subject()}]
+	return ok
+}
@@ -0,0 +1,69 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAllowlist(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []string
+		expectedErr error
+	}{
+		{name: "empty"},
+		{name: "api key and certificate", entries: []string{"apikey:billing", "certificate:CN=billing,O=leadgen"}},
+		{name: "no source", entries: []string{"billing"}, expectedErr: ErrInvalidEntry},
+		{name: "empty subject", entries: []string{"apikey:"}, expectedErr: ErrInvalidEntry},
+		{name: "peer", entries: []string{"peer:10.0.0.1"}, expectedErr: ErrInvalidEntry},
+		{name: "unknown source", entries: []string{"token:billing"}, expectedErr: ErrInvalidEntry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAllowlist(tt.entries)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAllowlist_Permits(t *testing.T) {
+	a, err := ParseAllowlist([]string{"apikey:billing", "certificate:CN=crm"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		src     Source
+		expect  bool
+	}{
+		{"listed api key", "billing", SourceAPIKey, true},
+		{"listed certificate", "CN=crm", SourceCertificate, true},
+		{"certificate named as api key", "billing", SourceCertificate, false},
+		{"api key named as certificate", "CN=crm", SourceAPIKey, false},
+		{"peer named as api key", "billing", SourcePeer, false},
+		{"unlisted api key", "ads", SourceAPIKey, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := New(tt.subject, tt.src)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := a.Permits(id); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+
+	var none *Allowlist
+	if none.Permits(&Identity{subject: "billing", source: SourceAPIKey}) {
+		t.Error("expected nil allowlist to permit no one")
+	}
+}
@@ -0,0 +1,2 @@
+// Package identity provides a domain caller identity definitions.
+package identity
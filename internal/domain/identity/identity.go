@@ -0,0 +1,83 @@
+package identity
+
+import (
+	"context"
+	"errors"
+)
+
+// Identity domain errors.
+var (
+	ErrEmptySubject      = errors.New("subject cannot be empty")
+	ErrUnsupportedSource = errors.New("unsupported identity source")
+	ErrInvalidEntry      = errors.New("allowlist entry must be apikey:<subject> or certificate:<subject>")
+)
+
+// Identity represents authenticated caller of the service.
+type Identity struct {
+	subject string
+	source  Source
+}
+
+// New creates new identity instance.
+func New(subject string, src Source) (*Identity, error) {
+	if subject == "" {
+		return nil, ErrEmptySubject
+	}
+
+	if !isValidSource(src) {
+		return nil, ErrUnsupportedSource
+	}
+
+	return &Identity{
+		subject: subject,
+		source:  src,
+	}, nil
+}
+
+// Subject returns caller subject, e.g. API key client name, client
+// certificate subject or peer address.
+func (i *Identity) Subject() string { return i.subject }
+
+// Source returns a way caller was identified by.
+func (i *Identity) Source() Source { return i.source }
+
+func isValidSource(src Source) bool {
+	return src == SourceCertificate || src == SourcePeer || src == SourceAPIKey
+}
+
+// Source represents a way caller was identified by.
+type Source int8
+
+// Supported identity sources.
+const (
+	SourceCertificate Source = iota + 1
+	SourcePeer
+	SourceAPIKey
+)
+
+// String strings source numeric constant.
+func (s Source) String() string {
+	switch s {
+	case SourceCertificate:
+		return "certificate"
+	case SourcePeer:
+		return "peer"
+	case SourceAPIKey:
+		return "apikey"
+	default:
+		return ""
+	}
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying provided identity.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns identity stored in ctx, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(ctxKey{}).(*Identity)
+	return id, ok
+}
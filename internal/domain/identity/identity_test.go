@@ -0,0 +1,84 @@
+package identity
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		subject     string
+		src         Source
+		expectedErr error
+	}{
+		{
+			name:    "certificate subject",
+			subject: "CN=importer,O=leadgen",
+			src:     SourceCertificate,
+		},
+		{
+			name:    "peer address",
+			subject: "10.0.0.1",
+			src:     SourcePeer,
+		},
+		{
+			name:        "empty subject",
+			subject:     "",
+			src:         SourcePeer,
+			expectedErr: ErrEmptySubject,
+		},
+		{
+			name:        "invalid source",
+			subject:     "10.0.0.1",
+			src:         Source(99),
+			expectedErr: ErrUnsupportedSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := New(tt.subject, tt.src)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if id.Subject() != tt.subject {
+				t.Errorf("expected subject %q, got %q", tt.subject, id.Subject())
+			}
+
+			if id.Source() != tt.src {
+				t.Errorf("expected source %v, got %v", tt.src, id.Source())
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("expected no identity in empty context")
+	}
+
+	id, err := New("10.0.0.1", SourcePeer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := FromContext(NewContext(context.Background(), id))
+	if !ok {
+		t.Fatal("expected identity in context")
+	}
+
+	if got != id {
+		t.Errorf("expected %v, got %v", id, got)
+	}
+}
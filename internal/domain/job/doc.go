@@ -0,0 +1,2 @@
+// Package job provides a domain asynchronous hash job definitions.
+package job
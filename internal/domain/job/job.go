@@ -0,0 +1,210 @@
+package job
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+)
+
+// Job domain errors.
+var (
+	ErrEmptyID           = errors.New("job id cannot be empty")
+	ErrNotFound          = errors.New("job not found")
+	ErrInvalidTransition = errors.New("invalid job status transition")
+	ErrTooManyRows       = errors.New("job rows limit exceeded")
+	ErrInvalidSnapshot   = errors.New("invalid job snapshot")
+)
+
+// Settings represents hashing settings applied to every job row. Zero
+// algorithm and normalization mean owner tenant defaults. Pepper version is
+// pinned when job is created, so all rows are hashed with the same pepper.
+type Settings struct {
+	Algorithm     hash.Algorithm
+	Normalization hash.Normalization
+	Params        hash.Params
+}
+
+// Job represents asynchronous hashing of a large number of rows.
+//
+// Job is created in uploading status, rows are appended until upload is
+// committed and job becomes pending. Workers run pending jobs and record
+// progress until job succeeds, fails or is canceled.
+type Job struct {
+	id          string
+	owner       string
+	ownerSource identity.Source
+	settings    Settings
+	status      Status
+	total       int64
+	processed   int64
+	failed      int64
+	errMsg      string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// New creates new job instance in uploading status owned by given caller.
+// Owner is nil for anonymous callers.
+func New(id string, owner *identity.Identity, settings Settings, now time.Time) (*Job, error) {
+	if id == "" {
+		return nil, ErrEmptyID
+	}
+
+	j := &Job{
+		id:        id,
+		settings:  settings,
+		status:    StatusUploading,
+		createdAt: now,
+		updatedAt: now,
+	}
+
+	if owner != nil {
+		j.owner = owner.Subject()
+		j.ownerSource = owner.Source()
+	}
+
+	return j, nil
+}
+
+// ID returns job identifier.
+func (j *Job) ID() string { return j.id }
+
+// Owner returns identity of caller submitted job, nil for anonymous callers.
+func (j *Job) Owner() *identity.Identity {
+	id, err := identity.New(j.owner, j.ownerSource)
+	if err != nil {
+		return nil
+	}
+
+	return id
+}
+
+// OwnedBy reports whether job is accessible by caller. Jobs of anonymous
+// callers are accessible by anonymous callers only.
+func (j *Job) OwnedBy(caller *identity.Identity) bool {
+	if caller == nil {
+		return j.owner == ""
+	}
+
+	return j.owner == caller.Subject() && j.ownerSource == caller.Source()
+}
+
+// Settings returns job hashing settings.
+func (j *Job) Settings() Settings { return j.settings }
+
+// Status returns job status.
+func (j *Job) Status() Status { return j.status }
+
+// Total returns number of uploaded rows.
+func (j *Job) Total() int64 { return j.total }
+
+// Processed returns number of processed rows including failed ones.
+func (j *Job) Processed() int64 { return j.processed }
+
+// Failed returns number of rows failed to hash.
+func (j *Job) Failed() int64 { return j.failed }
+
+// Error returns reason of job failure.
+func (j *Job) Error() string { return j.errMsg }
+
+// CreatedAt returns job creation time.
+func (j *Job) CreatedAt() time.Time { return j.createdAt }
+
+// UpdatedAt returns time of the latest job change.
+func (j *Job) UpdatedAt() time.Time { return j.updatedAt }
+
+// AddRows records uploaded rows. Total number of rows can't exceed limit,
+// zero limit means no limit.
+func (j *Job) AddRows(n, limit int64, now time.Time) error {
+	if j.status != StatusUploading {
+		return ErrInvalidTransition
+	}
+
+	if limit > 0 && j.total+n > limit {
+		return ErrTooManyRows
+	}
+
+	j.total += n
+	j.updatedAt = now
+
+	return nil
+}
+
+// Commit finishes upload and makes job pending.
+func (j *Job) Commit(now time.Time) error {
+	return j.transition(StatusUploading, StatusPending, now)
+}
+
+// Start marks pending job as running. Running job may be started again when
+// it is resumed after restart.
+func (j *Job) Start(now time.Time) error {
+	if j.status == StatusRunning {
+		j.updatedAt = now
+		return nil
+	}
+
+	return j.transition(StatusPending, StatusRunning, now)
+}
+
+// Progress records processed rows of running job.
+func (j *Job) Progress(processed, failed int64, now time.Time) error {
+	if j.status != StatusRunning {
+		return ErrInvalidTransition
+	}
+
+	j.processed = processed
+	j.failed = failed
+	j.updatedAt = now
+
+	return nil
+}
+
+// Succeed marks running job as succeeded.
+func (j *Job) Succeed(now time.Time) error {
+	return j.transition(StatusRunning, StatusSucceeded, now)
+}
+
+// Fail marks unfinished job as failed with given reason.
+func (j *Job) Fail(reason string, now time.Time) error {
+	if j.status.Terminal() {
+		return ErrInvalidTransition
+	}
+
+	j.status = StatusFailed
+	j.errMsg = reason
+	j.updatedAt = now
+
+	return nil
+}
+
+// Cancel marks unfinished job as canceled.
+func (j *Job) Cancel(now time.Time) error {
+	if j.status.Terminal() {
+		return ErrInvalidTransition
+	}
+
+	j.status = StatusCanceled
+	j.updatedAt = now
+
+	return nil
+}
+
+func (j *Job) transition(from, to Status, now time.Time) error {
+	if j.status != from {
+		return ErrInvalidTransition
+	}
+
+	j.status = to
+	j.updatedAt = now
+
+	return nil
+}
+
+// Result represents outcome of a single row. Error is set instead of hash if
+// row failed.
+type Result struct {
+	Hash  string `json:"hash,omitempty"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,156 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+)
+
+func TestNew(t *testing.T) {
+	owner, err := identity.New("importer", identity.SourceAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		owner     *identity.Identity
+		expectErr error
+	}{
+		{"valid", "job-1", owner, nil},
+		{"anonymous", "job-1", nil, nil},
+		{"empty id", "", owner, ErrEmptyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := New(tt.id, tt.owner, Settings{Algorithm: hash.AlgorithmSHA256}, time.Now())
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if j.Status() != StatusUploading {
+				t.Errorf("expected status %v, got %v", StatusUploading, j.Status())
+			}
+
+			if !j.OwnedBy(tt.owner) {
+				t.Error("expected job to be owned by its owner")
+			}
+		})
+	}
+}
+
+func TestJob_OwnedBy(t *testing.T) {
+	owner, _ := identity.New("importer", identity.SourceAPIKey)
+	sameName, _ := identity.New("importer", identity.SourcePeer)
+	other, _ := identity.New("exporter", identity.SourceAPIKey)
+
+	j, _ := New("job-1", owner, Settings{}, time.Now())
+	anon, _ := New("job-2", nil, Settings{}, time.Now())
+
+	tests := []struct {
+		name   string
+		job    *Job
+		caller *identity.Identity
+		expect bool
+	}{
+		{"owner", j, owner, true},
+		{"other source", j, sameName, false},
+		{"other subject", j, other, false},
+		{"anonymous caller", j, nil, false},
+		{"anonymous job", anon, owner, false},
+		{"anonymous both", anon, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.OwnedBy(tt.caller); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestJob_Lifecycle(t *testing.T) {
+	now := time.Now()
+	j, _ := New("job-1", nil, Settings{}, now)
+
+	if err := j.AddRows(3, 5, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := j.AddRows(3, 5, now); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("expected %v, got %v", ErrTooManyRows, err)
+	}
+	if err := j.Start(now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected start of uploading job to fail, got %v", err)
+	}
+	if err := j.Commit(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := j.AddRows(1, 0, now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected adding rows to committed job to fail, got %v", err)
+	}
+	if err := j.Start(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := j.Start(now); err != nil {
+		t.Errorf("expected resume of running job, got %v", err)
+	}
+	if err := j.Progress(3, 1, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := j.Succeed(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := j.Cancel(now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected cancel of finished job to fail, got %v", err)
+	}
+
+	if j.Total() != 3 || j.Processed() != 3 || j.Failed() != 1 || j.Status() != StatusSucceeded {
+		t.Errorf("unexpected job state %+v", j.Snapshot())
+	}
+}
+
+func TestFromSnapshot(t *testing.T) {
+	owner, _ := identity.New("importer", identity.SourceAPIKey)
+	j, _ := New("job-1", owner, Settings{Algorithm: hash.AlgorithmMD5, Normalization: hash.NormalizationTrim}, time.Now())
+	_ = j.AddRows(10, 0, time.Now())
+
+	restored, err := FromSnapshot(j.Snapshot())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if restored.Snapshot() != j.Snapshot() {
+		t.Errorf("expected %+v, got %+v", j.Snapshot(), restored.Snapshot())
+	}
+
+	if !restored.OwnedBy(owner) {
+		t.Error("expected restored job to keep owner")
+	}
+
+	tests := []struct {
+		name      string
+		snapshot  Snapshot
+		expectErr error
+	}{
+		{"empty id", Snapshot{Status: StatusPending}, ErrEmptyID},
+		{"unknown status", Snapshot{ID: "job-1"}, ErrInvalidSnapshot},
+		{"processed over total", Snapshot{ID: "job-1", Status: StatusRunning, Processed: 2, Total: 1}, ErrInvalidSnapshot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromSnapshot(tt.snapshot); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
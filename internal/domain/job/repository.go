@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is a contract that job repositories should implement. Rows and
+// results are addressed by zero-based row index.
+type Repository interface {
+	// Save creates or updates job state.
+	Save(ctx context.Context, j *Job) error
+
+	// Find finds job by identifier. Returns ErrNotFound if job doesn't exist.
+	Find(ctx context.Context, id string) (*Job, error)
+
+	// FindUnfinished finds jobs which are not in terminal status.
+	FindUnfinished(ctx context.Context) ([]*Job, error)
+
+	// AppendRows appends uploaded rows.
+	AppendRows(ctx context.Context, id string, rows []string) error
+
+	// Rows returns up to limit rows starting from offset.
+	Rows(ctx context.Context, id string, offset, limit int64) ([]string, error)
+
+	// AppendResults appends results of rows following already stored ones.
+	AppendResults(ctx context.Context, id string, results []Result) error
+
+	// Results returns up to limit results starting from offset.
+	Results(ctx context.Context, id string, offset, limit int64) ([]Result, error)
+
+	// CountResults returns number of stored results.
+	CountResults(ctx context.Context, id string) (int64, error)
+
+	// AcquireLease acquires or extends lease of job processing by owner for
+	// ttl. Returns false if unexpired lease is held by another owner.
+	AcquireLease(ctx context.Context, id, owner string, ttl time.Duration) (bool, error)
+
+	// ReleaseLease releases lease held by owner. Lease held by another owner
+	// is left intact.
+	ReleaseLease(ctx context.Context, id, owner string) error
+}
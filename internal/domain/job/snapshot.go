@@ -0,0 +1,75 @@
+package job
+
+import (
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+)
+
+// Snapshot represents job state stored by repositories.
+type Snapshot struct {
+	ID            string             `json:"id"`
+	Owner         string             `json:"owner,omitempty"`
+	OwnerSource   identity.Source    `json:"owner_source,omitempty"`
+	Algorithm     hash.Algorithm     `json:"algorithm,omitempty"`
+	Normalization hash.Normalization `json:"normalization,omitempty"`
+	Salt          string             `json:"salt,omitempty"`
+	PepperVersion string             `json:"pepper_version,omitempty"`
+	Status        Status             `json:"status"`
+	Total         int64              `json:"total"`
+	Processed     int64              `json:"processed"`
+	Failed        int64              `json:"failed"`
+	Error         string             `json:"error,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// Snapshot returns job state.
+func (j *Job) Snapshot() Snapshot {
+	return Snapshot{
+		ID:            j.id,
+		Owner:         j.owner,
+		OwnerSource:   j.ownerSource,
+		Algorithm:     j.settings.Algorithm,
+		Normalization: j.settings.Normalization,
+		Salt:          j.settings.Params.Salt,
+		PepperVersion: j.settings.Params.PepperVersion,
+		Status:        j.status,
+		Total:         j.total,
+		Processed:     j.processed,
+		Failed:        j.failed,
+		Error:         j.errMsg,
+		CreatedAt:     j.createdAt,
+		UpdatedAt:     j.updatedAt,
+	}
+}
+
+// FromSnapshot restores job from stored state.
+func FromSnapshot(s Snapshot) (*Job, error) {
+	if s.ID == "" {
+		return nil, ErrEmptyID
+	}
+
+	if s.Status.String() == "" || s.Processed > s.Total || s.Failed > s.Processed {
+		return nil, ErrInvalidSnapshot
+	}
+
+	return &Job{
+		id:          s.ID,
+		owner:       s.Owner,
+		ownerSource: s.OwnerSource,
+		settings: Settings{
+			Algorithm:     s.Algorithm,
+			Normalization: s.Normalization,
+			Params:        hash.Params{Salt: s.Salt, PepperVersion: s.PepperVersion},
+		},
+		status:    s.Status,
+		total:     s.Total,
+		processed: s.Processed,
+		failed:    s.Failed,
+		errMsg:    s.Error,
+		createdAt: s.CreatedAt,
+		updatedAt: s.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package job
+
+// Status represents job status.
+type Status int8
+
+// Job statuses.
+const (
+	StatusUploading Status = iota + 1
+	StatusPending
+	StatusRunning
+	StatusSucceeded
+	StatusFailed
+	StatusCanceled
+)
+
+// Terminal reports whether job can't change anymore.
+func (s Status) Terminal() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+// String strings status numeric constant.
+func (s Status) String() string {
+	switch s {
+	case StatusUploading:
+		return "uploading"
+	case StatusPending:
+		return "pending"
+	case StatusRunning:
+		return "running"
+	case StatusSucceeded:
+		return "succeeded"
+	case StatusFailed:
+		return "failed"
+	case StatusCanceled:
+		return "canceled"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,2 @@
+// Package kdf provides a domain key derivation definitions.
+package kdf
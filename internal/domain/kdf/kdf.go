@@ -0,0 +1,120 @@
+package kdf
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// KDF domain errors.
+var (
+	ErrForbidden         = errors.New("key derivation is not permitted")
+	ErrInvalidLength     = errors.New("invalid derived key length")
+	ErrInvalidIterations = errors.New("invalid number of iterations")
+	ErrTooMuchWork       = errors.New("too many iterations for derived key length")
+	ErrEmptyPassword     = errors.New("password cannot be empty")
+	ErrPasswordTooLong   = errors.New("password is too long")
+	ErrSaltTooLong       = errors.New("salt is too long")
+	ErrInfoTooLong       = errors.New("info is too long")
+)
+
+const (
+	// MaxLength is a maximum derived key length in bytes.
+	MaxLength = 1024
+
+	// MinIterations is a minimum number of PBKDF2 iterations.
+	MinIterations = 1000
+	// MaxIterations is a maximum number of PBKDF2 iterations.
+	MaxIterations = 600_000
+	// MaxWork is a maximum number of PBKDF2 iterations over all blocks of
+	// derived key, so single request can't hold CPU for long. Every block is
+	// a hash digest long.
+	MaxWork = 2 * MaxIterations
+
+	// maxSaltSize is a maximum salt size in bytes.
+	maxSaltSize = 1024
+	// maxInfoSize is a maximum info size in bytes.
+	maxInfoSize = 1024
+	// maxPasswordSize is a maximum password size in bytes.
+	maxPasswordSize = 1024
+)
+
+// Deriver is a contract that key derivers should implement. Hash function
+// is picked by algorithm.
+type Deriver interface {
+	// HKDF derives key of length bytes from master secret with HKDF.
+	HKDF(alg hash.Algorithm, salt []byte, info string, length int) ([]byte, error)
+
+	// PBKDF2 derives key of length bytes from password with PBKDF2.
+	PBKDF2(alg hash.Algorithm, password string, salt []byte, iterations, length int) ([]byte, error)
+}
+
+// BindInfo binds HKDF info to caller scope, e.g. tenant. Scope is prefixed
+// with its 4-byte big-endian length, so callers of different scopes never
+// derive equal keys whatever info they pass.
+func BindInfo(scope, info string) string {
+	b := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(scope)+len(info)), uint32(len(scope)))
+	b = append(b, scope...)
+	return string(append(b, info...))
+}
+
+// ValidateHKDF validates HKDF parameters.
+func ValidateHKDF(salt []byte, info string, length int) error {
+	if err := validateLength(length); err != nil {
+		return err
+	}
+
+	if len(salt) > maxSaltSize {
+		return ErrSaltTooLong
+	}
+
+	if len(info) > maxInfoSize {
+		return ErrInfoTooLong
+	}
+
+	return nil
+}
+
+// ValidatePBKDF2 validates PBKDF2 parameters of key derived with hash
+// function of algorithm.
+func ValidatePBKDF2(alg hash.Algorithm, password string, salt []byte, iterations, length int) error {
+	if err := validateLength(length); err != nil {
+		return err
+	}
+
+	if password == "" {
+		return ErrEmptyPassword
+	}
+
+	if len(password) > maxPasswordSize {
+		return ErrPasswordTooLong
+	}
+
+	if len(salt) > maxSaltSize {
+		return ErrSaltTooLong
+	}
+
+	if iterations < MinIterations || iterations > MaxIterations {
+		return ErrInvalidIterations
+	}
+
+	size := alg.Size()
+	if size == 0 {
+		return hash.ErrUnsupportedAlgorithm
+	}
+
+	if blocks := (length + size - 1) / size; iterations*blocks > MaxWork {
+		return ErrTooMuchWork
+	}
+
+	return nil
+}
+
+func validateLength(length int) error {
+	if length <= 0 || length > MaxLength {
+		return ErrInvalidLength
+	}
+
+	return nil
+}
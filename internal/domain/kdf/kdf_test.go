@@ -0,0 +1,188 @@
+package kdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestValidateHKDF(t *testing.T) {
+	tests := []struct {
+		name        string
+		salt        []byte
+		info        string
+		length      int
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			salt:   []byte("salt"),
+			info:   "tenant sales",
+			length: 32,
+		},
+		{
+			name:   "no salt and info",
+			length: MaxLength,
+		},
+		{
+			name:        "zero length",
+			expectedErr: ErrInvalidLength,
+		},
+		{
+			name:        "too long key",
+			length:      MaxLength + 1,
+			expectedErr: ErrInvalidLength,
+		},
+		{
+			name:        "too long salt",
+			salt:        make([]byte, maxSaltSize+1),
+			length:      32,
+			expectedErr: ErrSaltTooLong,
+		},
+		{
+			name:        "too long info",
+			info:        strings.Repeat("a", maxInfoSize+1),
+			length:      32,
+			expectedErr: ErrInfoTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHKDF(tt.salt, tt.info, tt.length)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidatePBKDF2(t *testing.T) {
+	tests := []struct {
+		name        string
+		alg         hash.Algorithm
+		password    string
+		salt        []byte
+		iterations  int
+		length      int
+		expectedErr error
+	}{
+		{
+			name:       "valid",
+			password:   "secret",
+			salt:       []byte("salt"),
+			iterations: MinIterations,
+			length:     32,
+		},
+		{
+			name:        "empty password",
+			iterations:  MinIterations,
+			length:      32,
+			expectedErr: ErrEmptyPassword,
+		},
+		{
+			name:        "too long password",
+			password:    strings.Repeat("a", maxPasswordSize+1),
+			iterations:  MinIterations,
+			length:      32,
+			expectedErr: ErrPasswordTooLong,
+		},
+		{
+			name:        "too few iterations",
+			password:    "secret",
+			iterations:  MinIterations - 1,
+			length:      32,
+			expectedErr: ErrInvalidIterations,
+		},
+		{
+			name:        "too many iterations",
+			password:    "secret",
+			iterations:  MaxIterations + 1,
+			length:      32,
+			expectedErr: ErrInvalidIterations,
+		},
+		{
+			name:        "invalid length",
+			password:    "secret",
+			iterations:  MinIterations,
+			expectedErr: ErrInvalidLength,
+		},
+		{
+			name:       "max iterations of two blocks",
+			password:   "secret",
+			iterations: MaxIterations,
+			length:     64,
+		},
+		{
+			name:        "max iterations of three blocks",
+			password:    "secret",
+			iterations:  MaxIterations,
+			length:      65,
+			expectedErr: ErrTooMuchWork,
+		},
+		{
+			name:       "min iterations of max length",
+			alg:        hash.AlgorithmMD5,
+			password:   "secret",
+			iterations: MinIterations,
+			length:     MaxLength,
+		},
+		{
+			name:        "max length of md5 blocks",
+			alg:         hash.AlgorithmMD5,
+			password:    "secret",
+			iterations:  MaxWork/(MaxLength/16) + 1,
+			length:      MaxLength,
+			expectedErr: ErrTooMuchWork,
+		},
+		{
+			name:        "unsupported algorithm",
+			alg:         hash.Algorithm(42),
+			password:    "secret",
+			iterations:  MinIterations,
+			length:      32,
+			expectedErr: hash.ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg := tt.alg
+			if alg == 0 {
+				alg = hash.AlgorithmSHA256
+			}
+
+			err := ValidatePBKDF2(alg, tt.password, tt.salt, tt.iterations, tt.length)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBindInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		scope  string
+		info   string
+		expect string
+	}{
+		{"scope and info", "tenant:sales", "db", "\x00\x00\x00\x0ctenant:salesdb"},
+		{"empty info", "tenant:sales", "", "\x00\x00\x00\x0ctenant:sales"},
+		{"empty scope", "", "db", "\x00\x00\x00\x00db"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BindInfo(tt.scope, tt.info); got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+
+	if BindInfo("tenant:a", "bc") == BindInfo("tenant:ab", "c") {
+		t.Error("expected scope boundary to be unambiguous")
+	}
+}
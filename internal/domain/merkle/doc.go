@@ -0,0 +1,2 @@
+// Package merkle provides a domain Merkle tree definitions.
+package merkle
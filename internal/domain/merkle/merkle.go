@@ -0,0 +1,415 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Merkle domain errors.
+var (
+	ErrEmptyTree       = errors.New("tree must have at least one leaf")
+	ErrIndexOutOfRange = errors.New("leaf index is out of tree range")
+	ErrSizeOutOfRange  = errors.New("tree size is out of range")
+	ErrMalformedHash   = errors.New("malformed tree hash")
+	ErrInvalidProof    = errors.New("invalid inclusion proof")
+	ErrInconsistent    = errors.New("invalid consistency proof")
+)
+
+// Domain separation prefixes of RFC 6962. Leaf hashes never collide with node
+// hashes, so an inner node can't be passed off as a leaf.
+const (
+	leafPrefix = "\x00"
+	nodePrefix = "\x01"
+)
+
+// Tree represents append-only RFC 6962 Merkle tree. Hashes are made by
+// hash.Hasher and hex-encoded. Roots and proofs are available for every
+// previous tree size.
+type Tree struct {
+	hasher hash.Hasher
+	// levels are tree levels from leaf hashes up to root. Node i of level k
+	// is a hash of up to 2^k leaves starting from leaf i*2^k, last node of
+	// odd level is promoted to the next level as is.
+	levels [][][]byte
+}
+
+// NewTree builds tree over leaves in given order.
+func NewTree(hasher hash.Hasher, leaves []string) (*Tree, error) {
+	t := &Tree{
+		hasher: hasher,
+		levels: [][][]byte{make([][]byte, 0, len(leaves))},
+	}
+
+	for _, leaf := range leaves {
+		if err := t.Append(leaf); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// Append appends leaf to tree.
+func (t *Tree) Append(leaf string) error {
+	node, err := hashLeaf(t.hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	i := len(t.levels[0])
+	for k := 0; ; k++ {
+		if k == len(t.levels) {
+			t.levels = append(t.levels, nil)
+		}
+
+		if i == len(t.levels[k]) {
+			t.levels[k] = append(t.levels[k], node)
+		} else {
+			t.levels[k][i] = node
+		}
+
+		level := t.levels[k]
+		if len(level) == 1 {
+			return nil
+		}
+
+		if i%2 == 1 {
+			if node, err = hashChildren(t.hasher, level[i-1], level[i]); err != nil {
+				return err
+			}
+		}
+		i /= 2
+	}
+}
+
+// Size returns number of tree leaves.
+func (t *Tree) Size() int64 { return int64(len(t.levels[0])) }
+
+// Root returns tree root hash.
+func (t *Tree) Root() string {
+	root, _ := t.RootAt(t.Size())
+	return root
+}
+
+// RootAt returns root hash of tree of the first size leaves. Root of empty
+// tree is a hash of empty string.
+func (t *Tree) RootAt(size int64) (string, error) {
+	if size < 0 || size > t.Size() {
+		return "", ErrSizeOutOfRange
+	}
+
+	if size == 0 {
+		root, err := decodeHash(t.hasher.Hash(""))
+		return hex.EncodeToString(root), err
+	}
+
+	root, err := t.subtree(0, size)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(root), nil
+}
+
+// LeafHash returns hash of leaf at index.
+func (t *Tree) LeafHash(index int64) (string, error) {
+	if index < 0 || index >= t.Size() {
+		return "", ErrIndexOutOfRange
+	}
+
+	return hex.EncodeToString(t.levels[0][index]), nil
+}
+
+// Proof returns audit path of leaf at index, from leaf sibling up to root.
+func (t *Tree) Proof(index int64) ([]string, error) {
+	return t.InclusionProof(index, t.Size())
+}
+
+// InclusionProof returns audit path of leaf at index in tree of the first
+// size leaves, see RFC 9162 section 2.1.3.1.
+func (t *Tree) InclusionProof(index, size int64) ([]string, error) {
+	if size <= 0 || size > t.Size() {
+		return nil, ErrSizeOutOfRange
+	}
+	if index < 0 || index >= size {
+		return nil, ErrIndexOutOfRange
+	}
+
+	path, err := t.inclusion(index, 0, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeHashes(path), nil
+}
+
+// ConsistencyProof returns proof that tree of the first size leaves is a
+// prefix of tree of the second size leaves, see RFC 9162 section 2.1.4.1.
+func (t *Tree) ConsistencyProof(first, second int64) ([]string, error) {
+	if first <= 0 || first > second || second > t.Size() {
+		return nil, ErrSizeOutOfRange
+	}
+
+	proof, err := t.consistency(first, 0, second, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeHashes(proof), nil
+}
+
+// subtree returns hash of leaves from lo to hi. Complete subtrees are stored
+// in levels, others are combined from them.
+func (t *Tree) subtree(lo, hi int64) ([]byte, error) {
+	n := hi - lo
+	if n&(n-1) == 0 && lo%n == 0 {
+		k := bits.TrailingZeros64(uint64(n))
+		return t.levels[k][lo>>k], nil
+	}
+
+	k := split(n)
+	left, err := t.subtree(lo, lo+k)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := t.subtree(lo+k, hi)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashChildren(t.hasher, left, right)
+}
+
+func (t *Tree) inclusion(m, lo, hi int64) ([][]byte, error) {
+	if hi-lo == 1 {
+		return nil, nil
+	}
+
+	var (
+		path    [][]byte
+		sibling []byte
+		err     error
+	)
+	if k := split(hi - lo); m < k {
+		if path, err = t.inclusion(m, lo, lo+k); err != nil {
+			return nil, err
+		}
+		sibling, err = t.subtree(lo+k, hi)
+	} else {
+		if path, err = t.inclusion(m-k, lo+k, hi); err != nil {
+			return nil, err
+		}
+		sibling, err = t.subtree(lo, lo+k)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(path, sibling), nil
+}
+
+func (t *Tree) consistency(m, lo, hi int64, complete bool) ([][]byte, error) {
+	if m == hi-lo {
+		if complete {
+			return nil, nil
+		}
+
+		node, err := t.subtree(lo, hi)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]byte{node}, nil
+	}
+
+	var (
+		proof [][]byte
+		node  []byte
+		err   error
+	)
+	if k := split(hi - lo); m <= k {
+		if proof, err = t.consistency(m, lo, lo+k, complete); err != nil {
+			return nil, err
+		}
+		node, err = t.subtree(lo+k, hi)
+	} else {
+		if proof, err = t.consistency(m-k, lo+k, hi, false); err != nil {
+			return nil, err
+		}
+		node, err = t.subtree(lo, lo+k)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, node), nil
+}
+
+// VerifyInclusion checks that leaf is at index of tree of given size and
+// root by its audit path, see RFC 9162 section 2.1.3.2. Returns
+// ErrInvalidProof if proof doesn't match root.
+func VerifyInclusion(hasher hash.Hasher, leaf string, index, size int64, path []string, root string) error {
+	if index < 0 || index >= size {
+		return ErrIndexOutOfRange
+	}
+
+	want, err := decodeHash(root)
+	if err != nil {
+		return err
+	}
+
+	r, err := hashLeaf(hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	fn, sn := index, size-1
+	for _, p := range path {
+		if sn == 0 {
+			return ErrInvalidProof
+		}
+
+		sibling, err := decodeHash(p)
+		if err != nil {
+			return err
+		}
+
+		if fn&1 == 1 || fn == sn {
+			if r, err = hashChildren(hasher, sibling, r); err != nil {
+				return err
+			}
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			if r, err = hashChildren(hasher, r, sibling); err != nil {
+				return err
+			}
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || !bytes.Equal(r, want) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// VerifyConsistency checks that tree of first size and root is a prefix of
+// tree of second size and root by consistency proof, see RFC 9162 section
+// 2.1.4.2. Returns ErrInconsistent if proof doesn't match roots.
+func VerifyConsistency(hasher hash.Hasher, first, second int64, proof []string, firstRoot, secondRoot string) error {
+	if first <= 0 || first > second {
+		return ErrSizeOutOfRange
+	}
+
+	firstHash, err := decodeHash(firstRoot)
+	if err != nil {
+		return err
+	}
+
+	secondHash, err := decodeHash(secondRoot)
+	if err != nil {
+		return err
+	}
+
+	if first == second {
+		if len(proof) != 0 || !bytes.Equal(firstHash, secondHash) {
+			return ErrInconsistent
+		}
+		return nil
+	}
+
+	path := make([][]byte, 0, len(proof)+1)
+	if first&(first-1) == 0 {
+		path = append(path, firstHash)
+	}
+	for _, p := range proof {
+		node, err := decodeHash(p)
+		if err != nil {
+			return err
+		}
+		path = append(path, node)
+	}
+	if len(path) == 0 {
+		return ErrInconsistent
+	}
+
+	fn, sn := first-1, second-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr, sr := path[0], path[0]
+	for _, c := range path[1:] {
+		if sn == 0 {
+			return ErrInconsistent
+		}
+
+		if fn&1 == 1 || fn == sn {
+			if fr, err = hashChildren(hasher, c, fr); err != nil {
+				return err
+			}
+			if sr, err = hashChildren(hasher, c, sr); err != nil {
+				return err
+			}
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			if sr, err = hashChildren(hasher, sr, c); err != nil {
+				return err
+			}
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || !bytes.Equal(fr, firstHash) || !bytes.Equal(sr, secondHash) {
+		return ErrInconsistent
+	}
+
+	return nil
+}
+
+// split returns the largest power of two less than n.
+func split(n int64) int64 {
+	return 1 << (bits.Len64(uint64(n-1)) - 1)
+}
+
+func hashLeaf(hasher hash.Hasher, leaf string) ([]byte, error) {
+	return decodeHash(hasher.Hash(leafPrefix + leaf))
+}
+
+func hashChildren(hasher hash.Hasher, left, right []byte) ([]byte, error) {
+	return decodeHash(hasher.Hash(nodePrefix + string(left) + string(right)))
+}
+
+func decodeHash(s string) ([]byte, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("%w %q", ErrMalformedHash, s)
+	}
+
+	return b, nil
+}
+
+func encodeHashes(hashes [][]byte) []string {
+	encoded := make([]string, len(hashes))
+	for i, h := range hashes {
+		encoded[i] = hex.EncodeToString(h)
+	}
+
+	return encoded
+}
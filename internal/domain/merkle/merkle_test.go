@@ -0,0 +1,324 @@
+package merkle
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Hash(input string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
+}
+
+func (sha256Hasher) MAC(key []byte, input string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+// testLeaves are leaves of RFC 6962 reference implementation tests.
+func testLeaves(t *testing.T) []string {
+	t.Helper()
+
+	encoded := []string{"", "00", "10", "2021", "3031", "40414243", "5051525354555657", "606162636465666768696a6b6c6d6e6f"}
+	leaves := make([]string, len(encoded))
+	for i, e := range encoded {
+		b, err := hex.DecodeString(e)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		leaves[i] = string(b)
+	}
+
+	return leaves
+}
+
+func TestNewTree_Root(t *testing.T) {
+	roots := []string{
+		"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+		"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+		"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+		"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+		"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+		"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+		"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+	}
+
+	leaves := testLeaves(t)
+	for i, root := range roots {
+		t.Run(fmt.Sprintf("size %d", i+1), func(t *testing.T) {
+			tree, err := NewTree(sha256Hasher{}, leaves[:i+1])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tree.Size() != int64(i+1) {
+				t.Errorf("expected size %d, got %d", i+1, tree.Size())
+			}
+			if tree.Root() != root {
+				t.Errorf("expected root %s, got %s", root, tree.Root())
+			}
+		})
+	}
+}
+
+func TestNewTree_Empty(t *testing.T) {
+	tree, err := NewTree(sha256Hasher{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expect = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if tree.Size() != 0 || tree.Root() != expect {
+		t.Errorf("expected empty tree with root %s, got size %d root %s", expect, tree.Size(), tree.Root())
+	}
+
+	if _, err := tree.Proof(0); !errors.Is(err, ErrSizeOutOfRange) {
+		t.Errorf("expected ErrSizeOutOfRange, got %v", err)
+	}
+}
+
+func TestTree_Append(t *testing.T) {
+	leaves := testLeaves(t)
+	tree, err := NewTree(sha256Hasher{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, leaf := range leaves {
+		if err := tree.Append(leaf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		built, err := NewTree(sha256Hasher{}, leaves[:i+1])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for size := int64(0); size <= int64(i+1); size++ {
+			got, err := tree.RootAt(size)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, err := built.RootAt(size)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != want {
+				t.Errorf("size %d of %d: expected root %s, got %s", size, i+1, want, got)
+			}
+		}
+	}
+
+	if _, err := tree.RootAt(int64(len(leaves) + 1)); !errors.Is(err, ErrSizeOutOfRange) {
+		t.Errorf("expected ErrSizeOutOfRange, got %v", err)
+	}
+}
+
+func TestTree_Proof(t *testing.T) {
+	tree, err := NewTree(sha256Hasher{}, testLeaves(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		index  int64
+		expect []string
+	}{
+		{"first leaf", 0, []string{
+			"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+		}},
+		{"middle leaf", 5, []string{
+			"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+			"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+			"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tree.Proof(tt.index)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.expect) {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+
+	if _, err := tree.Proof(8); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("expected ErrIndexOutOfRange, got %v", err)
+	}
+
+	got, err := tree.InclusionProof(2, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := []string{
+		"07506a85fd9dd2f120eb694f86011e5bb4662e5c415a62917033d4a9624487e7",
+		"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+		"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expect) {
+		t.Errorf("expected %v, got %v", expect, got)
+	}
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	leaves := testLeaves(t)
+	for size := 1; size <= len(leaves); size++ {
+		tree, err := NewTree(sha256Hasher{}, leaves[:size])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for i := range size {
+			path, err := tree.Proof(int64(i))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := VerifyInclusion(sha256Hasher{}, leaves[i], int64(i), int64(size), path, tree.Root()); err != nil {
+				t.Errorf("leaf %d of %d: unexpected error: %v", i, size, err)
+			}
+		}
+	}
+}
+
+func TestVerifyInclusion_Invalid(t *testing.T) {
+	leaves := testLeaves(t)
+	tree, err := NewTree(sha256Hasher{}, leaves[:7])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, err := tree.Proof(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		leaf   string
+		index  int64
+		size   int64
+		path   []string
+		root   string
+		expect error
+	}{
+		{"other leaf", leaves[5], 4, 7, path, tree.Root(), ErrInvalidProof},
+		{"other index", leaves[4], 5, 7, path, tree.Root(), ErrInvalidProof},
+		{"other size", leaves[4], 4, 5, path, tree.Root(), ErrInvalidProof},
+		{"short path", leaves[4], 4, 7, path[:1], tree.Root(), ErrInvalidProof},
+		{"long path", leaves[4], 4, 7, append(path, path[0]), tree.Root(), ErrInvalidProof},
+		{"other root", leaves[4], 4, 7, path, path[0], ErrInvalidProof},
+		{"index out of range", leaves[4], 7, 7, path, tree.Root(), ErrIndexOutOfRange},
+		{"malformed root", leaves[4], 4, 7, path, "xyz", ErrMalformedHash},
+		{"malformed path", leaves[4], 4, 7, []string{"xyz"}, tree.Root(), ErrMalformedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyInclusion(sha256Hasher{}, tt.leaf, tt.index, tt.size, tt.path, tt.root)
+			if !errors.Is(err, tt.expect) {
+				t.Errorf("expected %v, got %v", tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestTree_ConsistencyProof(t *testing.T) {
+	tree, err := NewTree(sha256Hasher{}, testLeaves(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		first, second int64
+		expect        []string
+	}{
+		{"same size", 1, 1, []string{}},
+		{"single leaf", 1, 8, []string{
+			"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+		}},
+		{"incomplete first", 6, 8, []string{
+			"0ebc5d3437fbe2db158b9f126a1d118e308181031d0a949f8dededebc558ef6a",
+			"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+			"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+		}},
+		{"incomplete second", 2, 5, []string{
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tree.ConsistencyProof(tt.first, tt.second)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.expect) {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+
+	if _, err := tree.ConsistencyProof(0, 8); !errors.Is(err, ErrSizeOutOfRange) {
+		t.Errorf("expected ErrSizeOutOfRange, got %v", err)
+	}
+	if _, err := tree.ConsistencyProof(3, 9); !errors.Is(err, ErrSizeOutOfRange) {
+		t.Errorf("expected ErrSizeOutOfRange, got %v", err)
+	}
+}
+
+func TestVerifyConsistency(t *testing.T) {
+	tree, err := NewTree(sha256Hasher{}, testLeaves(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for first := int64(1); first <= tree.Size(); first++ {
+		for second := first; second <= tree.Size(); second++ {
+			proof, err := tree.ConsistencyProof(first, second)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			firstRoot, _ := tree.RootAt(first)
+			secondRoot, _ := tree.RootAt(second)
+			if err := VerifyConsistency(sha256Hasher{}, first, second, proof, firstRoot, secondRoot); err != nil {
+				t.Errorf("%d to %d: unexpected error: %v", first, second, err)
+			}
+
+			if first == second {
+				continue
+			}
+
+			otherRoot, _ := tree.RootAt(first - 1)
+			if first == 1 {
+				otherRoot = secondRoot
+			}
+			if err := VerifyConsistency(sha256Hasher{}, first, second, proof, otherRoot, secondRoot); !errors.Is(err, ErrInconsistent) {
+				t.Errorf("%d to %d with other first root: expected ErrInconsistent, got %v", first, second, err)
+			}
+			if err := VerifyConsistency(sha256Hasher{}, first, second, proof[:len(proof)-1], firstRoot, secondRoot); !errors.Is(err, ErrInconsistent) {
+				t.Errorf("%d to %d with short proof: expected ErrInconsistent, got %v", first, second, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package mixhash provides stable non-cryptographic hashes shared by sketches,
+// filters and placement. Their outputs are persisted, so they must never
+// change.
+package mixhash
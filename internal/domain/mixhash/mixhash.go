@@ -0,0 +1,34 @@
+package mixhash
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Mix is a SplitMix64 finalizer, so every output bit depends on every input
+// bit.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// String returns FNV-1a 64 hash of s mixed by Mix. FNV-1a is stable across Go
+// versions, mixing spreads its weak low bits.
+func String(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return Mix(h.Sum64())
+}
+
+// String128 returns two independent 64-bit hashes of s, halves of its FNV-1a
+// 128 hash mixed by Mix.
+func String128(s string) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write([]byte(s))
+	sum := h.Sum(nil)
+	return Mix(binary.BigEndian.Uint64(sum[:8])), Mix(binary.BigEndian.Uint64(sum[8:]))
+}
@@ -0,0 +1,65 @@
+package mixhash
+
+import "testing"
+
+func TestMix(t *testing.T) {
+	tests := []struct {
+		name   string
+		x      uint64
+		expect uint64
+	}{
+		{"zero", 0, 0},
+		{"one", 1, 0x5692161d100b05e5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mix(tt.x); got != tt.expect {
+				t.Errorf("expected %#x, got %#x", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		expect uint64
+	}{
+		{"empty", "", 0xf52a15e9a9b5e89b},
+		{"single char", "a", 0x2c0bdbf481420f8},
+		{"node", "node-1", 0x13950d4ef307e655},
+		{"words", "hello world", 0x5cb585112be1151},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.s); got != tt.expect {
+				t.Errorf("expected %#x, got %#x", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestString128(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		expectA uint64
+		expectB uint64
+	}{
+		{"empty", "", 0x292417dcc0d778ab, 0xd2ece9d449824020},
+		{"single char", "a", 0xc2b8827221280a41, 0x94d69899baf50369},
+		{"node", "node-1", 0x2c89bb16090b6899, 0xff97bd58fb8c8b1e},
+		{"words", "hello world", 0xefba237fe67359ea, 0x8f38eb0c8d9c0a48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a, b := String128(tt.s); a != tt.expectA || b != tt.expectB {
+				t.Errorf("expected %#x, %#x, got %#x, %#x", tt.expectA, tt.expectB, a, b)
+			}
+		})
+	}
+}
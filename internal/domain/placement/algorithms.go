@@ -0,0 +1,110 @@
+package placement
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+// ring represents hash ring of virtual nodes points sorted by hash.
+type ring struct {
+	points []point
+	nodes  int
+}
+
+type point struct {
+	hash uint64
+	node string
+}
+
+// newRing places virtual nodes of every node on ring by hash of node and
+// virtual node index joined by '#'.
+func newRing(nodes []string, virtualNodes int) *ring {
+	points := make([]point, 0, len(nodes)*virtualNodes)
+	for _, n := range nodes {
+		for i := range virtualNodes {
+			points = append(points, point{hash: mixhash.String(n + "#" + strconv.Itoa(i)), node: n})
+		}
+	}
+
+	// Colliding points are ordered by node, so ring doesn't depend on nodes
+	// order.
+	slices.SortFunc(points, func(a, b point) int {
+		if c := cmp.Compare(a.hash, b.hash); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.node, b.node)
+	})
+
+	return &ring{
+		points: points,
+		nodes:  len(nodes),
+	}
+}
+
+// locate walks ring clockwise from key hash collecting distinct nodes.
+func (r *ring) locate(h uint64, replicas int) []string {
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+
+	nodes := make([]string, 0, replicas)
+	for j := 0; len(nodes) < replicas; j++ {
+		n := r.points[(i+j)%len(r.points)].node
+		if !slices.Contains(nodes, n) {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// rendezvous returns replicas nodes of highest weights, weight is a mix of
+// key and node hashes.
+func rendezvous(nodes []string, h uint64, replicas int) []string {
+	type scored struct {
+		node  string
+		score uint64
+	}
+
+	scores := make([]scored, len(nodes))
+	for i, n := range nodes {
+		scores[i] = scored{node: n, score: mixhash.Mix(h ^ mixhash.String(n))}
+	}
+
+	slices.SortFunc(scores, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.node, b.node)
+	})
+
+	top := make([]string, replicas)
+	for i := range top {
+		top[i] = scores[i].node
+	}
+
+	return top
+}
+
+// jump returns node of jump consistent hash bucket of key followed by the
+// next nodes in set order.
+//
+// See "A Fast, Minimal Memory, Consistent Hash Algorithm" by Lamping and
+// Veach.
+func jump(nodes []string, h uint64, replicas int) []string {
+	var b, j int64 = -1, 0
+	for j < int64(len(nodes)) {
+		b = j
+		h = h*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((h>>33)+1)))
+	}
+
+	placed := make([]string, replicas)
+	for i := range placed {
+		placed[i] = nodes[(int(b)+i)%len(nodes)]
+	}
+
+	return placed
+}
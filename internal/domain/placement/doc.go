@@ -0,0 +1,2 @@
+// Package placement provides a domain placement definitions.
+package placement
@@ -0,0 +1,296 @@
+package placement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+// Placement domain errors.
+var (
+	ErrInvalidName         = errors.New("node set name must be 1 to 64 letters, digits, '.', '-' or '_'")
+	ErrNoNodes             = errors.New("at least one node is required")
+	ErrTooManyNodes        = errors.New("too many nodes")
+	ErrEmptyNode           = errors.New("node cannot be empty")
+	ErrDuplicateNode       = errors.New("node already defined")
+	ErrUnknownNode         = errors.New("unknown node")
+	ErrInvalidVirtualNodes = errors.New("invalid number of virtual nodes")
+	ErrInvalidReplicas     = errors.New("replicas must be within number of nodes")
+	ErrEmptyKey            = errors.New("key cannot be empty")
+	ErrUnsupportedKind     = errors.New("unsupported placement kind")
+	ErrNotFound            = errors.New("node set not found")
+	ErrConflict            = errors.New("node set was changed concurrently")
+)
+
+const (
+	// maxNameSize is a maximum node set name size in bytes.
+	maxNameSize = 64
+	// MaxNodes is a maximum number of nodes in set.
+	MaxNodes = 256
+	// MaxVirtualNodes is a maximum number of ring points per node, so ring
+	// holds at most 64Ki points.
+	MaxVirtualNodes = 256
+	// DefaultVirtualNodes is a number of ring points per node used if it's
+	// not set.
+	DefaultVirtualNodes = 160
+	// movedSamples is a number of sample keys moved fraction is estimated
+	// by.
+	movedSamples = 10_000
+)
+
+// Repository is a contract that node set repositories should implement.
+// Node sets are kept per tenant. Every save increments node set version.
+type Repository interface {
+	// Save saves node set replacing saved one of given version, zero
+	// version is a node set never saved. Returns ErrConflict if saved
+	// version differs, new version otherwise.
+	Save(ctx context.Context, tenant string, set *NodeSet, version uint64) (uint64, error)
+
+	// Find finds node set by name with its version.
+	Find(ctx context.Context, tenant, name string) (*NodeSet, uint64, error)
+
+	// Version finds version of node set by name.
+	Version(ctx context.Context, tenant, name string) (uint64, error)
+}
+
+// Kind represents consistent hashing algorithm keys are placed by.
+type Kind int8
+
+// Supported placement kinds.
+const (
+	// KindRing places keys on hash ring with virtual nodes.
+	KindRing Kind = iota + 1
+	// KindRendezvous places keys on nodes of highest random weight.
+	KindRendezvous
+	// KindJump places keys by jump consistent hash. Only adding or removing
+	// the last nodes moves minimal number of keys.
+	KindJump
+)
+
+// String strings kind numeric constant.
+func (k Kind) String() string {
+	switch k {
+	case KindRing:
+		return "ring"
+	case KindRendezvous:
+		return "rendezvous"
+	case KindJump:
+		return "jump"
+	default:
+		return ""
+	}
+}
+
+// ParseKind parses kind by its name.
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "ring":
+		return KindRing, nil
+	case "rendezvous":
+		return KindRendezvous, nil
+	case "jump":
+		return KindJump, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedKind, name)
+	}
+}
+
+// NodeSet represents named ordered set of nodes keys are placed on.
+//
+// Placement depends only on kind, nodes, their order for jump hash and
+// virtual nodes for ring, so it's stable across releases and replicas.
+type NodeSet struct {
+	name         string
+	kind         Kind
+	nodes        []string
+	virtualNodes int
+	ring         *ring
+}
+
+// NewNodeSet creates new node set instance. Virtual nodes are used by ring
+// only, zero means DefaultVirtualNodes.
+func NewNodeSet(name string, kind Kind, nodes []string, virtualNodes int) (*NodeSet, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	if err := validateNodes(nodes); err != nil {
+		return nil, err
+	}
+
+	s := &NodeSet{
+		name:  name,
+		kind:  kind,
+		nodes: nodes,
+	}
+
+	switch kind {
+	case KindRing:
+		if virtualNodes == 0 {
+			virtualNodes = DefaultVirtualNodes
+		}
+		if virtualNodes < 0 || virtualNodes > MaxVirtualNodes {
+			return nil, ErrInvalidVirtualNodes
+		}
+		s.virtualNodes = virtualNodes
+		s.ring = newRing(nodes, virtualNodes)
+	case KindRendezvous, KindJump:
+	default:
+		return nil, ErrUnsupportedKind
+	}
+
+	return s, nil
+}
+
+// Name returns node set name.
+func (s *NodeSet) Name() string { return s.name }
+
+// Kind returns placement kind.
+func (s *NodeSet) Kind() Kind { return s.kind }
+
+// Nodes returns nodes in set order.
+func (s *NodeSet) Nodes() []string { return s.nodes }
+
+// VirtualNodes returns number of ring points per node, zero for other kinds.
+func (s *NodeSet) VirtualNodes() int { return s.virtualNodes }
+
+// Locate returns replicas distinct nodes key is placed on, the primary one
+// first.
+func (s *NodeSet) Locate(key string, replicas int) ([]string, error) {
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+
+	if replicas < 1 || replicas > len(s.nodes) {
+		return nil, ErrInvalidReplicas
+	}
+
+	return s.locate(key, replicas), nil
+}
+
+func (s *NodeSet) locate(key string, replicas int) []string {
+	h := mixhash.String(key)
+	switch s.kind {
+	case KindRing:
+		return s.ring.locate(h, replicas)
+	case KindRendezvous:
+		return rendezvous(s.nodes, h, replicas)
+	default:
+		return jump(s.nodes, h, replicas)
+	}
+}
+
+// WithNodes returns node set of the same name, kind and virtual nodes with
+// given nodes.
+func (s *NodeSet) WithNodes(nodes []string) (*NodeSet, error) {
+	return NewNodeSet(s.name, s.kind, nodes, s.virtualNodes)
+}
+
+// Add returns node set with nodes appended.
+func (s *NodeSet) Add(nodes ...string) (*NodeSet, error) {
+	return s.WithNodes(append(s.nodes[:len(s.nodes):len(s.nodes)], nodes...))
+}
+
+// Remove returns node set without nodes.
+func (s *NodeSet) Remove(nodes ...string) (*NodeSet, error) {
+	removed := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		removed[n] = true
+	}
+
+	kept := make([]string, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		if !removed[n] {
+			kept = append(kept, n)
+		}
+		delete(removed, n)
+	}
+
+	for n := range removed {
+		return nil, fmt.Errorf("%w %q", ErrUnknownNode, n)
+	}
+
+	return s.WithNodes(kept)
+}
+
+// Moved estimates fraction of keys placed on other nodes by to than by s.
+// Key moves if any of its replicas nodes differs. Fraction is estimated by
+// sample keys.
+func Moved(from, to *NodeSet, replicas int) float64 {
+	replicas = min(replicas, len(from.nodes), len(to.nodes))
+
+	var moved int
+	for i := range movedSamples {
+		key := "sample-" + strconv.Itoa(i)
+		if !sameNodes(from.locate(key, replicas), to.locate(key, replicas)) {
+			moved++
+		}
+	}
+
+	return float64(moved) / movedSamples
+}
+
+// ValidateName validates node set name.
+func ValidateName(name string) error {
+	if name == "" || len(name) > maxNameSize {
+		return ErrInvalidName
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return ErrInvalidName
+		}
+	}
+
+	return nil
+}
+
+func validateNodes(nodes []string) error {
+	if len(nodes) == 0 {
+		return ErrNoNodes
+	}
+
+	if len(nodes) > MaxNodes {
+		return ErrTooManyNodes
+	}
+
+	seen := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		if n == "" {
+			return ErrEmptyNode
+		}
+		if _, ok := seen[n]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateNode, n)
+		}
+		seen[n] = struct{}{}
+	}
+
+	return nil
+}
+
+// sameNodes reports whether a and b contain the same nodes in any order.
+func sameNodes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,197 @@
+package placement
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var kinds = []Kind{KindRing, KindRendezvous, KindJump}
+
+func testNodes(n int) []string {
+	nodes := make([]string, n)
+	for i := range nodes {
+		nodes[i] = "shard-" + strconv.Itoa(i)
+	}
+	return nodes
+}
+
+func mustNodeSet(t *testing.T, kind Kind, nodes []string) *NodeSet {
+	t.Helper()
+
+	s, err := NewNodeSet("shards", kind, nodes, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewNodeSet(t *testing.T) {
+	tests := []struct {
+		name         string
+		setName      string
+		kind         Kind
+		nodes        []string
+		virtualNodes int
+		expectErr    error
+	}{
+		{"valid ring", "shards", KindRing, testNodes(3), 0, nil},
+		{"valid jump", "shards", KindJump, testNodes(3), 0, nil},
+		{"invalid name", "shards:eu", KindRing, testNodes(3), 0, ErrInvalidName},
+		{"no nodes", "shards", KindRing, nil, 0, ErrNoNodes},
+		{"too many nodes", "shards", KindRendezvous, testNodes(MaxNodes + 1), 0, ErrTooManyNodes},
+		{"empty node", "shards", KindRing, []string{"a", ""}, 0, ErrEmptyNode},
+		{"duplicate node", "shards", KindRing, []string{"a", "a"}, 0, ErrDuplicateNode},
+		{"negative virtual nodes", "shards", KindRing, testNodes(3), -1, ErrInvalidVirtualNodes},
+		{"too many virtual nodes", "shards", KindRing, testNodes(3), MaxVirtualNodes + 1, ErrInvalidVirtualNodes},
+		{"unsupported kind", "shards", 0, testNodes(3), 0, ErrUnsupportedKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNodeSet(tt.setName, tt.kind, tt.nodes, tt.virtualNodes); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	for _, k := range kinds {
+		got, err := ParseKind(k.String())
+		if err != nil || got != k {
+			t.Errorf("expected %v, got %v, %v", k, got, err)
+		}
+	}
+
+	if _, err := ParseKind("modulo"); !errors.Is(err, ErrUnsupportedKind) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedKind, err)
+	}
+}
+
+func TestNodeSet_Locate(t *testing.T) {
+	const keys = 50_000
+	nodes := testNodes(10)
+
+	for _, kind := range kinds {
+		t.Run(kind.String(), func(t *testing.T) {
+			s := mustNodeSet(t, kind, nodes)
+
+			counts := map[string]int{}
+			for i := range keys {
+				placed, err := s.Locate("lead-"+strconv.Itoa(i), 3)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(placed) != 3 || placed[0] == placed[1] || placed[1] == placed[2] || placed[0] == placed[2] {
+					t.Fatalf("expected 3 distinct nodes, got %v", placed)
+				}
+				counts[placed[0]]++
+			}
+
+			for _, n := range nodes {
+				if share := float64(counts[n]) / keys; math.Abs(share-0.1) > 0.03 {
+					t.Errorf("expected node %s share about 0.1, got %.3f", n, share)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeSet_Locate_Errors(t *testing.T) {
+	s := mustNodeSet(t, KindRing, testNodes(3))
+
+	tests := []struct {
+		name      string
+		key       string
+		replicas  int
+		expectErr error
+	}{
+		{"empty key", "", 1, ErrEmptyKey},
+		{"zero replicas", "lead", 0, ErrInvalidReplicas},
+		{"too many replicas", "lead", 4, ErrInvalidReplicas},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Locate(tt.key, tt.replicas); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestNodeSet_Locate_Stable(t *testing.T) {
+	for _, kind := range kinds {
+		t.Run(kind.String(), func(t *testing.T) {
+			a := mustNodeSet(t, kind, testNodes(5))
+			b := mustNodeSet(t, kind, testNodes(5))
+
+			for i := range 1000 {
+				key := "lead-" + strconv.Itoa(i)
+				pa, _ := a.Locate(key, 2)
+				pb, _ := b.Locate(key, 2)
+				if strings.Join(pa, ",") != strings.Join(pb, ",") {
+					t.Fatalf("expected stable placement of %s, got %v and %v", key, pa, pb)
+				}
+			}
+		})
+	}
+}
+
+func TestMoved(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   Kind
+		update func(*NodeSet) (*NodeSet, error)
+		expect float64
+	}{
+		{"ring add", KindRing, func(s *NodeSet) (*NodeSet, error) { return s.Add("shard-10") }, 1.0 / 11},
+		{"ring remove", KindRing, func(s *NodeSet) (*NodeSet, error) { return s.Remove("shard-3") }, 1.0 / 10},
+		{"rendezvous add", KindRendezvous, func(s *NodeSet) (*NodeSet, error) { return s.Add("shard-10") }, 1.0 / 11},
+		{"rendezvous remove", KindRendezvous, func(s *NodeSet) (*NodeSet, error) { return s.Remove("shard-3") }, 1.0 / 10},
+		{"jump add", KindJump, func(s *NodeSet) (*NodeSet, error) { return s.Add("shard-10") }, 1.0 / 11},
+		{"jump remove last", KindJump, func(s *NodeSet) (*NodeSet, error) { return s.Remove("shard-9") }, 1.0 / 10},
+		{"jump remove middle", KindJump, func(s *NodeSet) (*NodeSet, error) { return s.Remove("shard-3") }, 0.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := mustNodeSet(t, tt.kind, testNodes(10))
+			to, err := tt.update(from)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if moved := Moved(from, to, 1); math.Abs(moved-tt.expect) > 0.1*tt.expect+0.01 {
+				t.Errorf("expected moved fraction about %.3f, got %.3f", tt.expect, moved)
+			}
+		})
+	}
+}
+
+func TestNodeSet_Remove(t *testing.T) {
+	s := mustNodeSet(t, KindRing, testNodes(3))
+
+	if _, err := s.Remove("shard-7"); !errors.Is(err, ErrUnknownNode) {
+		t.Errorf("expected error %v, got %v", ErrUnknownNode, err)
+	}
+
+	if _, err := s.Remove(testNodes(3)...); !errors.Is(err, ErrNoNodes) {
+		t.Errorf("expected error %v, got %v", ErrNoNodes, err)
+	}
+
+	removed, err := s.Remove("shard-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(removed.Nodes(), ","); got != "shard-0,shard-2" {
+		t.Errorf("expected remaining nodes shard-0,shard-2, got %s", got)
+	}
+	if len(s.Nodes()) != 3 {
+		t.Errorf("expected original node set to be kept, got %v", s.Nodes())
+	}
+}
@@ -0,0 +1,2 @@
+// Package ratelimit provides a domain rate limiting definitions.
+package ratelimit
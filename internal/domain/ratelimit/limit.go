@@ -0,0 +1,29 @@
+package ratelimit
+
+import "time"
+
+// Limit represents token bucket parameters. Rate is a number of tokens added
+// per second and Burst is a bucket capacity. Zero rate means no limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether limit doesn't restrict requests.
+func (l Limit) Unlimited() bool { return l.Rate <= 0 }
+
+// Capacity returns bucket capacity. Burst lower than one is treated as one,
+// so non-zero rate always lets some requests through.
+func (l Limit) Capacity() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+
+	return float64(l.Burst)
+}
+
+// Decision represents limiter verdict for a single request.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
@@ -0,0 +1,9 @@
+package ratelimit
+
+import "context"
+
+// Limiter represents contract that rate limiters should implement.
+type Limiter interface {
+	// Allow takes a token from bucket identified by key.
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
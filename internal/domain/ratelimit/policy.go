@@ -0,0 +1,43 @@
+package ratelimit
+
+// Policy resolves limit for a caller and RPC.
+//
+// The most specific limit wins: client method limit, then client limit, then
+// default method limit and finally default limit.
+type Policy struct {
+	defaultLimit Limit
+	methods      map[string]Limit
+	clients      map[string]ClientLimits
+}
+
+// ClientLimits represents limits for a single client.
+type ClientLimits struct {
+	Limit   Limit
+	Methods map[string]Limit
+}
+
+// NewPolicy creates new rate limiting policy.
+func NewPolicy(defaultLimit Limit, methods map[string]Limit, clients map[string]ClientLimits) *Policy {
+	return &Policy{
+		defaultLimit: defaultLimit,
+		methods:      methods,
+		clients:      clients,
+	}
+}
+
+// Limit returns limit for provided client subject and full RPC method name.
+func (p *Policy) Limit(subject, method string) Limit {
+	if c, ok := p.clients[subject]; ok {
+		if l, ok := c.Methods[method]; ok {
+			return l
+		}
+
+		return c.Limit
+	}
+
+	if l, ok := p.methods[method]; ok {
+		return l
+	}
+
+	return p.defaultLimit
+}
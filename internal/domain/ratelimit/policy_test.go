@@ -0,0 +1,52 @@
+package ratelimit
+
+import "testing"
+
+func TestPolicy_Limit(t *testing.T) {
+	const method = "/leadgen.hasher.v1.HasherService/Hash"
+
+	p := NewPolicy(
+		Limit{Rate: 100, Burst: 100},
+		map[string]Limit{method: {Rate: 50, Burst: 50}},
+		map[string]ClientLimits{
+			"importer": {
+				Limit:   Limit{Rate: 10, Burst: 10},
+				Methods: map[string]Limit{method: {Rate: 1, Burst: 1}},
+			},
+			"reporting": {
+				Limit: Limit{Rate: 20, Burst: 20},
+			},
+		},
+	)
+
+	tests := []struct {
+		name    string
+		subject string
+		method  string
+		expect  Limit
+	}{
+		{"client method", "importer", method, Limit{Rate: 1, Burst: 1}},
+		{"client default", "importer", "/other", Limit{Rate: 10, Burst: 10}},
+		{"client without method limits", "reporting", method, Limit{Rate: 20, Burst: 20}},
+		{"default method", "unknown", method, Limit{Rate: 50, Burst: 50}},
+		{"default", "unknown", "/other", Limit{Rate: 100, Burst: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Limit(tt.subject, tt.method); got != tt.expect {
+				t.Errorf("expected %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	if !(Limit{}).Unlimited() {
+		t.Error("expected zero limit to be unlimited")
+	}
+
+	if (Limit{Rate: 1}).Capacity() != 1 {
+		t.Error("expected capacity at least one")
+	}
+}
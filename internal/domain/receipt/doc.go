@@ -0,0 +1,2 @@
+// Package receipt provides a domain hash receipt definitions.
+package receipt
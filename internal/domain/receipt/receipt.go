@@ -0,0 +1,190 @@
+package receipt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Receipt domain errors.
+var (
+	ErrRequestIDTooLong = errors.New("request id is too long")
+	ErrMalformed        = errors.New("malformed receipt")
+	ErrUnknownKey       = errors.New("unknown receipt key")
+	ErrInvalidSignature = errors.New("invalid receipt signature")
+)
+
+const (
+	// Type is a JWS type of receipts.
+	Type = "hash-receipt+jws"
+	// SignatureAlgorithm is a JWS algorithm of receipts.
+	SignatureAlgorithm = "EdDSA"
+
+	// maxRequestIDSize is a maximum request id size in bytes.
+	maxRequestIDSize = 128
+)
+
+// Signer is a contract that receipt signers should implement.
+type Signer interface {
+	// KeyID returns identifier of signing key.
+	KeyID() string
+
+	// Sign signs message.
+	Sign(message []byte) ([]byte, error)
+}
+
+// Key represents receipt verification key.
+type Key struct {
+	ID        string
+	PublicKey ed25519.PublicKey
+}
+
+// Receipt represents attestation that service produced digest by algorithm
+// at given time. Request id is set by caller to bind receipt to its request.
+type Receipt struct {
+	Algorithm hash.Algorithm
+	Digest    string
+	IssuedAt  time.Time
+	KeyID     string
+	RequestID string
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+type claims struct {
+	Algorithm string `json:"hash_alg"`
+	Digest    string `json:"digest"`
+	IssuedAt  int64  `json:"iat"`
+	RequestID string `json:"rid,omitempty"`
+}
+
+// ValidateRequestID validates request id.
+func ValidateRequestID(id string) error {
+	if len(id) > maxRequestIDSize {
+		return ErrRequestIDTooLong
+	}
+
+	return nil
+}
+
+// Sign signs receipt by signer and returns it in JWS compact serialization.
+// Key id and issuance time truncated to seconds are set on receipt.
+func (r *Receipt) Sign(signer Signer) (string, error) {
+	if err := ValidateRequestID(r.RequestID); err != nil {
+		return "", err
+	}
+
+	r.KeyID = signer.KeyID()
+	r.IssuedAt = r.IssuedAt.Truncate(time.Second)
+
+	h, err := json.Marshal(header{
+		Algorithm: SignatureAlgorithm,
+		Type:      Type,
+		KeyID:     r.KeyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal header: %w", err)
+	}
+
+	c, err := json.Marshal(claims{
+		Algorithm: r.Algorithm.String(),
+		Digest:    r.Digest,
+		IssuedAt:  r.IssuedAt.Unix(),
+		RequestID: r.RequestID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+
+	signingInput := encode(h) + "." + encode(c)
+	sig, err := signer.Sign([]byte(signingInput))
+	if err != nil {
+		return "", fmt.Errorf("sign receipt: %w", err)
+	}
+
+	return signingInput + "." + encode(sig), nil
+}
+
+// Verify verifies receipt in JWS compact serialization by one of keys and
+// returns its content.
+func Verify(token string, keys []Key) (*Receipt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return nil, err
+	}
+	if h.Algorithm != SignatureAlgorithm || h.Type != Type {
+		return nil, fmt.Errorf("%w: unsupported type %q or algorithm %q", ErrMalformed, h.Type, h.Algorithm)
+	}
+
+	var pub ed25519.PublicKey
+	for _, k := range keys {
+		if k.ID == h.KeyID {
+			pub = k.PublicKey
+			break
+		}
+	}
+	if pub == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, h.KeyID)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if !ed25519.Verify(pub, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	var c claims
+	if err := decodeJSON(parts[1], &c); err != nil {
+		return nil, err
+	}
+
+	alg, err := hash.ParseAlgorithm(c.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	return &Receipt{
+		Algorithm: alg,
+		Digest:    c.Digest,
+		IssuedAt:  time.Unix(c.IssuedAt, 0),
+		KeyID:     h.KeyID,
+		RequestID: c.RequestID,
+	}, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJSON(part string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package receipt
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+type mockSigner struct {
+	key ed25519.PrivateKey
+}
+
+func (m mockSigner) KeyID() string { return "key-1" }
+
+func (m mockSigner) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(m.key, message), nil
+}
+
+func TestReceipt(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	keys := []Key{{ID: "key-1", PublicKey: key.Public().(ed25519.PublicKey)}}
+
+	r := &Receipt{
+		Algorithm: hash.AlgorithmSHA256,
+		Digest:    "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		IssuedAt:  time.Date(2026, 10, 19, 12, 0, 0, 500, time.UTC),
+		RequestID: "req-1",
+	}
+
+	token, err := r.Sign(mockSigner{key: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := Verify(token, keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.IssuedAt.Equal(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected issuance time truncated to seconds, got %v", got.IssuedAt)
+	}
+	if got.KeyID != "key-1" || got.Digest != r.Digest || got.RequestID != "req-1" || got.Algorithm != hash.AlgorithmSHA256 {
+		t.Errorf("expected %+v, got %+v", r, got)
+	}
+
+	parts := strings.Split(token, ".")
+	other, err := (&Receipt{Algorithm: hash.AlgorithmMD5, Digest: "00", IssuedAt: r.IssuedAt}).Sign(mockSigner{key: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherParts := strings.Split(other, ".")
+
+	tests := []struct {
+		name   string
+		token  string
+		keys   []Key
+		expect error
+	}{
+		{"swapped claims", parts[0] + "." + otherParts[1] + "." + parts[2], keys, ErrInvalidSignature},
+		{"unknown key", token, []Key{{ID: "key-2", PublicKey: keys[0].PublicKey}}, ErrUnknownKey},
+		{"truncated", parts[0] + "." + parts[1], keys, ErrMalformed},
+		{"malformed header", "e30." + parts[1] + "." + parts[2], keys, ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Verify(tt.token, tt.keys); !errors.Is(err, tt.expect) {
+				t.Errorf("expected error %v, got %v", tt.expect, err)
+			}
+		})
+	}
+
+	long := &Receipt{Algorithm: hash.AlgorithmMD5, Digest: "00", RequestID: strings.Repeat("x", 129)}
+	if _, err := long.Sign(mockSigner{key: key}); !errors.Is(err, ErrRequestIDTooLong) {
+		t.Errorf("expected error %v, got %v", ErrRequestIDTooLong, err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package record provides a domain bulk file records and column hashing rules
+// definitions.
+package record
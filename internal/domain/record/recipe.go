@@ -0,0 +1,191 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Recipe domain errors.
+var (
+	ErrEmptyRecipeName     = errors.New("recipe name cannot be empty")
+	ErrEmptyField          = errors.New("field cannot be empty")
+	ErrNoFields            = errors.New("at least one recipe field is required")
+	ErrDuplicateField      = errors.New("recipe field already defined")
+	ErrDuplicateRecipe     = errors.New("recipe already defined")
+	ErrUnknownRecipe       = errors.New("unknown recipe")
+	ErrMissingField        = errors.New("missing record field")
+	ErrEmptySeparator      = errors.New("separator cannot be empty")
+	ErrSeparatorInValue    = errors.New("field value contains separator")
+	ErrUnsupportedEncoding = errors.New("unsupported encoding")
+)
+
+// Encoding represents a way recipe fields are joined to a composite key.
+type Encoding int8
+
+// Supported encodings.
+const (
+	// EncodingSeparator joins values with separator. Values containing
+	// separator are rejected, so keys are never ambiguous.
+	EncodingSeparator Encoding = iota + 1
+	// EncodingLengthPrefix prefixes every value with its length in bytes,
+	// e.g. "3:foo5:12345", so any value is allowed.
+	EncodingLengthPrefix
+)
+
+// String strings encoding numeric constant.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingSeparator:
+		return "separator"
+	case EncodingLengthPrefix:
+		return "length-prefix"
+	default:
+		return ""
+	}
+}
+
+// ParseEncoding parses encoding by its name.
+func ParseEncoding(name string) (Encoding, error) {
+	switch name {
+	case "separator":
+		return EncodingSeparator, nil
+	case "length-prefix":
+		return EncodingLengthPrefix, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedEncoding, name)
+	}
+}
+
+// RecipeField represents a single field of composite key. Zero normalization
+// means none. Missing optional fields are encoded as empty values.
+type RecipeField struct {
+	Name          string
+	Normalization hash.Normalization
+	Optional      bool
+}
+
+// Recipe represents named composite key definition, e.g.
+// lower(email)|digits(phone)|zip. Zero algorithm means caller tenant default.
+type Recipe struct {
+	name      string
+	fields    []RecipeField
+	encoding  Encoding
+	separator string
+	alg       hash.Algorithm
+}
+
+// NewRecipe creates new recipe instance. Separator is required by separator
+// encoding only.
+func NewRecipe(name string, fields []RecipeField, encoding Encoding, separator string, alg hash.Algorithm) (*Recipe, error) {
+	if name == "" {
+		return nil, ErrEmptyRecipeName
+	}
+
+	if len(fields) == 0 {
+		return nil, ErrNoFields
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if f.Name == "" {
+			return nil, ErrEmptyField
+		}
+		if _, ok := seen[f.Name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateField, f.Name)
+		}
+		seen[f.Name] = struct{}{}
+	}
+
+	switch encoding {
+	case EncodingSeparator:
+		if separator == "" {
+			return nil, ErrEmptySeparator
+		}
+	case EncodingLengthPrefix:
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+
+	return &Recipe{
+		name:      name,
+		fields:    fields,
+		encoding:  encoding,
+		separator: separator,
+		alg:       alg,
+	}, nil
+}
+
+// Name returns recipe name.
+func (r *Recipe) Name() string { return r.name }
+
+// Fields returns recipe fields in key order.
+func (r *Recipe) Fields() []RecipeField { return r.fields }
+
+// Encoding returns recipe encoding.
+func (r *Recipe) Encoding() Encoding { return r.encoding }
+
+// Algorithm returns recipe hash algorithm.
+func (r *Recipe) Algorithm() hash.Algorithm { return r.alg }
+
+// Compose builds composite key of normalized field values in recipe order.
+// Fields not in recipe are ignored.
+func (r *Recipe) Compose(values map[string]string) (string, error) {
+	var sb strings.Builder
+	for i, f := range r.fields {
+		v, ok := values[f.Name]
+		if !ok && !f.Optional {
+			return "", fmt.Errorf("%w %q", ErrMissingField, f.Name)
+		}
+		v = f.Normalization.Apply(v)
+
+		switch r.encoding {
+		case EncodingSeparator:
+			if strings.Contains(v, r.separator) {
+				return "", fmt.Errorf("%w: %q", ErrSeparatorInValue, f.Name)
+			}
+			if i > 0 {
+				sb.WriteString(r.separator)
+			}
+		case EncodingLengthPrefix:
+			sb.WriteString(strconv.Itoa(len(v)))
+			sb.WriteByte(':')
+		}
+		sb.WriteString(v)
+	}
+
+	return sb.String(), nil
+}
+
+// Recipes represents recipes by name.
+type Recipes struct {
+	recipes map[string]*Recipe
+}
+
+// NewRecipes creates new recipes instance. Recipe names must be unique.
+func NewRecipes(recipes ...*Recipe) (*Recipes, error) {
+	byName := make(map[string]*Recipe, len(recipes))
+	for _, r := range recipes {
+		if _, ok := byName[r.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateRecipe, r.name)
+		}
+		byName[r.name] = r
+	}
+
+	return &Recipes{
+		recipes: byName,
+	}, nil
+}
+
+// Find finds recipe by name.
+func (r *Recipes) Find(name string) (*Recipe, error) {
+	recipe, ok := r.recipes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRecipe, name)
+	}
+
+	return recipe, nil
+}
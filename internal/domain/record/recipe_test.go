@@ -0,0 +1,98 @@
+package record
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestRecipe_Compose(t *testing.T) {
+	fields := []RecipeField{
+		{Name: "email", Normalization: hash.NormalizationLower},
+		{Name: "phone", Normalization: hash.NormalizationDigits},
+		{Name: "zip", Optional: true},
+	}
+	values := map[string]string{"email": " Foo@Example.com", "phone": "+7 (999) 123", "zip": "12|3", "ignored": "x"}
+
+	tests := []struct {
+		name      string
+		encoding  Encoding
+		values    map[string]string
+		expect    string
+		expectErr error
+	}{
+		{"length prefix", EncodingLengthPrefix, values, "15:foo@example.com7:79991234:12|3", nil},
+		{"separator in value", EncodingSeparator, values, "", ErrSeparatorInValue},
+		{"separator", EncodingSeparator, map[string]string{"email": "a@b.c", "phone": "1-2"}, "a@b.c|12|", nil},
+		{"missing required", EncodingLengthPrefix, map[string]string{"email": "a@b.c"}, "", ErrMissingField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRecipe("lead", fields, tt.encoding, "|", 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := r.Compose(tt.values)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestNewRecipe(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipe    string
+		fields    []RecipeField
+		encoding  Encoding
+		separator string
+		expectErr error
+	}{
+		{"valid", "lead", []RecipeField{{Name: "email"}}, EncodingSeparator, "|", nil},
+		{"empty name", "", []RecipeField{{Name: "email"}}, EncodingSeparator, "|", ErrEmptyRecipeName},
+		{"no fields", "lead", nil, EncodingSeparator, "|", ErrNoFields},
+		{"empty field", "lead", []RecipeField{{}}, EncodingSeparator, "|", ErrEmptyField},
+		{"duplicate field", "lead", []RecipeField{{Name: "a"}, {Name: "a"}}, EncodingSeparator, "|", ErrDuplicateField},
+		{"empty separator", "lead", []RecipeField{{Name: "email"}}, EncodingSeparator, "", ErrEmptySeparator},
+		{"unsupported encoding", "lead", []RecipeField{{Name: "email"}}, Encoding(99), "|", ErrUnsupportedEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRecipe(tt.recipe, tt.fields, tt.encoding, tt.separator, 0); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestRecipes(t *testing.T) {
+	lead, err := NewRecipe("lead", []RecipeField{{Name: "email"}}, EncodingLengthPrefix, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewRecipes(lead, lead); !errors.Is(err, ErrDuplicateRecipe) {
+		t.Errorf("expected error %v, got %v", ErrDuplicateRecipe, err)
+	}
+
+	recipes, err := NewRecipes(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := recipes.Find("lead"); err != nil || got != lead {
+		t.Errorf("expected lead recipe, got %v, %v", got, err)
+	}
+
+	if _, err := recipes.Find("event"); !errors.Is(err, ErrUnknownRecipe) {
+		t.Errorf("expected error %v, got %v", ErrUnknownRecipe, err)
+	}
+}
@@ -0,0 +1,87 @@
+package record
+
+import (
+	"errors"
+	"io"
+)
+
+// Record domain errors.
+var (
+	ErrMalformed         = errors.New("malformed record")
+	ErrMissingColumn     = errors.New("column is missing")
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+)
+
+// Record represents a single row of bulk file.
+type Record interface {
+	// Get returns column value. Reports false if record has no such column
+	// or its value is null.
+	Get(column string) (string, bool)
+
+	// Set replaces column value.
+	Set(column, value string)
+}
+
+// Reader reads records of bulk file.
+type Reader interface {
+	// Columns returns columns defined by file header, nil if format has no
+	// header.
+	Columns() []string
+
+	// Read returns next record or io.EOF if there are no records left.
+	// Errors wrapping ErrMalformed affect a single record only and reading
+	// may continue.
+	Read() (Record, error)
+}
+
+// Writer writes records of bulk file.
+type Writer interface {
+	// Write writes a record read by reader of the same format.
+	Write(r Record) error
+
+	// Flush writes buffered records.
+	Flush() error
+}
+
+// Codec creates readers and writers of a single file format.
+type Codec interface {
+	// NewReader creates reader of records from r.
+	NewReader(r io.Reader) (Reader, error)
+
+	// NewWriter creates writer of records to w. Columns are columns of
+	// reader records are read by.
+	NewWriter(w io.Writer, columns []string) (Writer, error)
+}
+
+// Format represents bulk file format.
+type Format int8
+
+// Supported file formats.
+const (
+	FormatCSV Format = iota + 1
+	FormatNDJSON
+)
+
+// String strings format numeric constant.
+func (f Format) String() string {
+	switch f {
+	case FormatCSV:
+		return "csv"
+	case FormatNDJSON:
+		return "ndjson"
+	default:
+		return ""
+	}
+}
+
+// ParseFormat parses format name as returned by Format.String.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	default:
+		return 0, ErrUnsupportedFormat
+	}
+}
@@ -0,0 +1,101 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Rule domain errors.
+var (
+	ErrEmptyColumn     = errors.New("column cannot be empty")
+	ErrDuplicateColumn = errors.New("column rule already defined")
+	ErrNoRules         = errors.New("at least one column rule is required")
+)
+
+// Rule represents hashing rule of a single column. Zero algorithm and
+// normalization mean caller tenant defaults.
+type Rule struct {
+	column string
+	alg    hash.Algorithm
+	norm   hash.Normalization
+}
+
+// NewRule creates new column rule instance.
+func NewRule(column string, alg hash.Algorithm, norm hash.Normalization) (*Rule, error) {
+	if column == "" {
+		return nil, ErrEmptyColumn
+	}
+
+	return &Rule{
+		column: column,
+		alg:    alg,
+		norm:   norm,
+	}, nil
+}
+
+// ParseRule parses rule in "column[=algorithm[:normalization]]" form, e.g.
+// "email=sha256:lower". Omitted algorithm or normalization mean caller tenant
+// defaults.
+func ParseRule(s string) (*Rule, error) {
+	column, settings, _ := strings.Cut(s, "=")
+	algName, normName, _ := strings.Cut(settings, ":")
+
+	var (
+		alg  hash.Algorithm
+		norm hash.Normalization
+		err  error
+	)
+	if algName != "" {
+		if alg, err = hash.ParseAlgorithm(algName); err != nil {
+			return nil, fmt.Errorf("column %q: %w", column, err)
+		}
+	}
+	if normName != "" {
+		if norm, err = hash.ParseNormalization(normName); err != nil {
+			return nil, fmt.Errorf("column %q: %w", column, err)
+		}
+	}
+
+	return NewRule(strings.TrimSpace(column), alg, norm)
+}
+
+// Column returns name of hashed column.
+func (r *Rule) Column() string { return r.column }
+
+// Algorithm returns column hash algorithm.
+func (r *Rule) Algorithm() hash.Algorithm { return r.alg }
+
+// Normalization returns column input normalization.
+func (r *Rule) Normalization() hash.Normalization { return r.norm }
+
+// Spec represents mapping of columns to hashing rules. Columns without rules
+// are kept as is.
+type Spec struct {
+	rules []*Rule
+}
+
+// NewSpec creates new mapping spec instance. Every column may have a single
+// rule only.
+func NewSpec(rules ...*Rule) (*Spec, error) {
+	if len(rules) == 0 {
+		return nil, ErrNoRules
+	}
+
+	seen := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		if _, ok := seen[r.column]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateColumn, r.column)
+		}
+		seen[r.column] = struct{}{}
+	}
+
+	return &Spec{
+		rules: rules,
+	}, nil
+}
+
+// Rules returns column rules in order they were defined.
+func (s *Spec) Rules() []*Rule { return s.rules }
@@ -0,0 +1,83 @@
+package record
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		in         string
+		expectCol  string
+		expectAlg  hash.Algorithm
+		expectNorm hash.Normalization
+		expectErr  error
+	}{
+		{"email=sha256:lower", "email", hash.AlgorithmSHA256, hash.NormalizationLower, nil},
+		{"phone=md5", "phone", hash.AlgorithmMD5, 0, nil},
+		{"phone=:digits", "phone", 0, hash.NormalizationDigits, nil},
+		{"first_name", "first_name", 0, 0, nil},
+		{"email=sha1", "", 0, 0, hash.ErrUnsupportedAlgorithm},
+		{"email=md5:upper", "", 0, 0, hash.ErrUnsupportedNormalization},
+		{"=md5", "", 0, 0, ErrEmptyColumn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			r, err := ParseRule(tt.in)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if r.Column() != tt.expectCol || r.Algorithm() != tt.expectAlg || r.Normalization() != tt.expectNorm {
+				t.Errorf("expected %s %v %v, got %s %v %v", tt.expectCol, tt.expectAlg, tt.expectNorm, r.Column(), r.Algorithm(), r.Normalization())
+			}
+		})
+	}
+}
+
+func TestNewSpec(t *testing.T) {
+	email, _ := NewRule("email", hash.AlgorithmSHA256, 0)
+	phone, _ := NewRule("phone", hash.AlgorithmSHA256, hash.NormalizationDigits)
+
+	tests := []struct {
+		name      string
+		rules     []*Rule
+		expectErr error
+	}{
+		{"valid", []*Rule{email, phone}, nil},
+		{"empty", nil, ErrNoRules},
+		{"duplicate", []*Rule{email, phone, email}, ErrDuplicateColumn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSpec(tt.rules...)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if err == nil && len(s.Rules()) != len(tt.rules) {
+				t.Errorf("expected %d rules, got %d", len(tt.rules), len(s.Rules()))
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{FormatCSV, FormatNDJSON} {
+		got, err := ParseFormat(f.String())
+		if err != nil || got != f {
+			t.Errorf("ParseFormat(%q) expected %v, got %v, %v", f.String(), f, got, err)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected %v, got %v", ErrUnsupportedFormat, err)
+	}
+}
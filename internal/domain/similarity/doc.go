@@ -0,0 +1,2 @@
+// Package similarity provides a domain similarity definitions.
+package similarity
@@ -0,0 +1,52 @@
+package similarity
+
+// Index represents named LSH index settings: signature algorithm and its
+// sketcher and size of tokens shingles.
+type Index struct {
+	name      string
+	algorithm Algorithm
+	sketcher  Sketcher
+	shingle   int
+}
+
+// NewIndex creates new index instance. Sketcher must implement algorithm,
+// zero shingle size means word tokens.
+func NewIndex(name string, alg Algorithm, sketcher Sketcher, shingle int) (*Index, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	if alg != AlgorithmMinHash && alg != AlgorithmSimHash {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return &Index{
+		name:      name,
+		algorithm: alg,
+		sketcher:  sketcher,
+		shingle:   shingle,
+	}, nil
+}
+
+// Name returns index name.
+func (i *Index) Name() string { return i.name }
+
+// Algorithm returns index signature algorithm.
+func (i *Index) Algorithm() Algorithm { return i.algorithm }
+
+// Sketch returns signature of text and its LSH bands.
+func (i *Index) Sketch(text string) (Signature, []uint64, error) {
+	tokens := Tokenize(text, i.shingle)
+	if len(tokens) == 0 {
+		return nil, nil, ErrEmptyText
+	}
+
+	sig := i.sketcher.Sketch(tokens)
+
+	return sig, i.sketcher.Bands(sig), nil
+}
+
+// Similarity estimates similarity of texts by their signatures.
+func (i *Index) Similarity(a, b Signature) float64 {
+	return i.sketcher.Similarity(a, b)
+}
@@ -0,0 +1,109 @@
+package similarity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Similarity domain errors.
+var (
+	ErrEmptyName            = errors.New("index name cannot be empty")
+	ErrEmptyText            = errors.New("text has no tokens")
+	ErrInvalidID            = errors.New("item id must be 1 to 128 bytes")
+	ErrInvalidThreshold     = errors.New("similarity threshold must be from 0 to 1")
+	ErrInvalidBands         = errors.New("bands must evenly divide signature")
+	ErrUnknownIndex         = errors.New("unknown similarity index")
+	ErrDuplicateIndex       = errors.New("duplicate similarity index")
+	ErrUnsupportedAlgorithm = errors.New("unsupported similarity algorithm")
+)
+
+// maxIDSize is a maximum item id size in bytes.
+const maxIDSize = 128
+
+// Signature represents locality-sensitive signature of tokens. Unlike
+// hashes, signatures of similar tokens have many equal components.
+type Signature []uint64
+
+// Sketcher is a contract that locality-sensitive signature algorithms should
+// implement.
+type Sketcher interface {
+	// Sketch returns signature of tokens.
+	Sketch(tokens []string) Signature
+
+	// Similarity estimates similarity of tokens by their signatures, from 0
+	// to 1.
+	Similarity(a, b Signature) float64
+
+	// Bands splits signature into LSH bands. Signatures of similar tokens
+	// have at least one equal band with high probability.
+	Bands(sig Signature) []uint64
+}
+
+// Repository is a contract that signature repositories should implement.
+// Signatures are kept per tenant and index.
+type Repository interface {
+	// Save saves item signature and adds item to buckets of its bands.
+	Save(ctx context.Context, tenant, index, id string, sig Signature, bands []uint64) error
+
+	// Candidates returns signatures of items sharing at least one bucket
+	// with given bands by item id.
+	Candidates(ctx context.Context, tenant, index string, bands []uint64) (map[string]Signature, error)
+}
+
+// Match represents item similar to searched text.
+type Match struct {
+	ID         string
+	Similarity float64
+}
+
+// Algorithm represents locality-sensitive signature algorithm.
+type Algorithm int8
+
+// Supported algorithms.
+const (
+	AlgorithmMinHash Algorithm = iota + 1
+	AlgorithmSimHash
+)
+
+// String strings algorithm numeric constant.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmMinHash:
+		return "minhash"
+	case AlgorithmSimHash:
+		return "simhash"
+	default:
+		return ""
+	}
+}
+
+// ParseAlgorithm parses algorithm by its name.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch name {
+	case "minhash":
+		return AlgorithmMinHash, nil
+	case "simhash":
+		return AlgorithmSimHash, nil
+	default:
+		return 0, fmt.Errorf("%w %q", ErrUnsupportedAlgorithm, name)
+	}
+}
+
+// ValidateID validates indexed item id.
+func ValidateID(id string) error {
+	if id == "" || len(id) > maxIDSize {
+		return ErrInvalidID
+	}
+
+	return nil
+}
+
+// ValidateThreshold validates minimum similarity of matches.
+func ValidateThreshold(threshold float64) error {
+	if threshold < 0 || threshold > 1 {
+		return ErrInvalidThreshold
+	}
+
+	return nil
+}
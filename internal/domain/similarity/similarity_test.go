@@ -0,0 +1,116 @@
+package similarity
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		shingle int
+		expect  []string
+	}{
+		{"words", "Jon Smith, 12 Main St.", 0, []string{"jon", "smith", "12", "main", "st"}},
+		{"shingles", "Jon  Smith", 3, []string{"jon", "on ", "n s", " sm", "smi", "mit", "ith"}},
+		{"shorter than shingle", "Jo", 3, []string{"jo"}},
+		{"unicode", "Ёж-Ёж", 0, []string{"ёж", "ёж"}},
+		{"no tokens", " ,.- ", 3, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.text, tt.shingle); !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, alg := range []Algorithm{AlgorithmMinHash, AlgorithmSimHash} {
+		got, err := ParseAlgorithm(alg.String())
+		if err != nil || got != alg {
+			t.Errorf("expected %v, got %v, %v", alg, got, err)
+		}
+	}
+
+	if _, err := ParseAlgorithm("md5"); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedAlgorithm, err)
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		expectErr error
+	}{
+		{"valid", "lead-42", nil},
+		{"empty", "", ErrInvalidID},
+		{"too long", strings.Repeat("a", maxIDSize+1), ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateID(tt.id); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		expectErr error
+	}{
+		{"zero", 0, nil},
+		{"one", 1, nil},
+		{"negative", -0.1, ErrInvalidThreshold},
+		{"above one", 1.1, ErrInvalidThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateThreshold(tt.threshold); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+type mockSketcher struct{}
+
+func (mockSketcher) Sketch(tokens []string) Signature { return Signature{uint64(len(tokens))} }
+
+func (mockSketcher) Similarity(a, b Signature) float64 { return 1 }
+
+func (mockSketcher) Bands(sig Signature) []uint64 { return sig }
+
+func TestIndex_Sketch(t *testing.T) {
+	if _, err := NewIndex("", AlgorithmMinHash, mockSketcher{}, 3); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("expected error %v, got %v", ErrEmptyName, err)
+	}
+
+	idx, err := NewIndex("leads", AlgorithmMinHash, mockSketcher{}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sig, bands, err := idx.Sketch("Jon Smith")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sig, Signature{7}) || !reflect.DeepEqual(bands, []uint64{7}) {
+		t.Errorf("unexpected signature %v and bands %v", sig, bands)
+	}
+
+	if _, _, err := idx.Sketch("!!!"); !errors.Is(err, ErrEmptyText) {
+		t.Errorf("expected error %v, got %v", ErrEmptyText, err)
+	}
+}
@@ -0,0 +1,31 @@
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits text into tokens. Text is lowercased and everything but
+// letters and digits is treated as a space. Zero shingle size means words,
+// otherwise tokens are character shingles of given size, so misspelled or
+// abbreviated words still share most tokens.
+func Tokenize(text string, shingle int) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if shingle <= 0 || len(words) == 0 {
+		return words
+	}
+
+	runes := []rune(strings.Join(words, " "))
+	if len(runes) <= shingle {
+		return []string{string(runes)}
+	}
+
+	tokens := make([]string, 0, len(runes)-shingle+1)
+	for i := 0; i+shingle <= len(runes); i++ {
+		tokens = append(tokens, string(runes[i:i+shingle]))
+	}
+
+	return tokens
+}
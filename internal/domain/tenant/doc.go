@@ -0,0 +1,2 @@
+// Package tenant provides a domain tenant definitions.
+package tenant
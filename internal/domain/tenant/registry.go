@@ -0,0 +1,48 @@
+package tenant
+
+import "fmt"
+
+// Registry resolves tenants by caller subject.
+type Registry struct {
+	def      *Tenant
+	byClient map[string]*Tenant
+}
+
+// NewRegistry creates new registry of provided tenants with def tenant used
+// for unknown clients. Tenant names must be unique and every client can belong
+// to a single tenant.
+func NewRegistry(def *Tenant, tenants ...*Tenant) (*Registry, error) {
+	names := make(map[string]struct{}, len(tenants))
+	byClient := map[string]*Tenant{}
+	for _, t := range tenants {
+		if _, ok := names[t.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateTenant, t.name)
+		}
+		names[t.name] = struct{}{}
+
+		for _, c := range t.clients {
+			if _, ok := byClient[c]; ok {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateClient, c)
+			}
+			byClient[c] = t
+		}
+	}
+
+	return &Registry{
+		def:      def,
+		byClient: byClient,
+	}, nil
+}
+
+// ForClient returns tenant of client with provided subject. Default tenant is
+// returned for unknown clients.
+func (r *Registry) ForClient(subject string) *Tenant {
+	if t, ok := r.byClient[subject]; ok {
+		return t
+	}
+
+	return r.def
+}
+
+// Default returns tenant used for unknown clients.
+func (r *Registry) Default() *Tenant { return r.def }
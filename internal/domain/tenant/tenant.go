@@ -0,0 +1,123 @@
+package tenant
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Tenant domain errors.
+var (
+	ErrEmptyName        = errors.New("tenant name cannot be empty")
+	ErrInvalidName      = errors.New("tenant name must be up to 64 letters, digits, '-' or '_'")
+	ErrDuplicateTenant  = errors.New("duplicate tenant")
+	ErrDuplicateClient  = errors.New("client bound to several tenants")
+	ErrNegativeQuota    = errors.New("quota cannot be negative")
+	ErrQuotaExceeded    = errors.New("tenant quota exceeded")
+	ErrInvalidAlgorithm = errors.New("invalid default algorithm")
+)
+
+// maxNameSize is a maximum tenant name size in bytes.
+const maxNameSize = 64
+
+// Settings represents tenant hashing settings.
+//
+// Zero algorithm means caller must always specify it, zero normalization
+// means input is hashed as is. Zero TTL means repository default and zero
+// daily quota means no quota.
+type Settings struct {
+	Algorithm     hash.Algorithm
+	Normalization hash.Normalization
+	Pepper        string
+	TTL           time.Duration
+	DailyQuota    int64
+}
+
+// Tenant represents business unit sharing the service. Tenant cached values,
+// secrets and usage are isolated from other tenants.
+type Tenant struct {
+	name     string
+	clients  []string
+	settings Settings
+}
+
+// New creates new tenant instance with given name, client subjects and
+// settings.
+func New(name string, clients []string, settings Settings) (*Tenant, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	if settings.Algorithm != 0 && settings.Algorithm.String() == "" {
+		return nil, ErrInvalidAlgorithm
+	}
+
+	if settings.DailyQuota < 0 {
+		return nil, ErrNegativeQuota
+	}
+
+	return &Tenant{
+		name:     name,
+		clients:  clients,
+		settings: settings,
+	}, nil
+}
+
+// Default returns tenant used for callers not bound to any tenant. It has
+// empty name and zero settings.
+func Default() *Tenant { return &Tenant{} }
+
+// NewDefault creates default tenant with given settings. Default tenant has
+// empty name, so its hashes are stored in shared namespace and usage isn't
+// counted.
+func NewDefault(settings Settings) (*Tenant, error) {
+	if settings.Algorithm != 0 && settings.Algorithm.String() == "" {
+		return nil, ErrInvalidAlgorithm
+	}
+
+	return &Tenant{settings: settings}, nil
+}
+
+// Name returns tenant name. Name is empty for default tenant.
+func (t *Tenant) Name() string { return t.name }
+
+// Clients returns subjects of callers bound to tenant.
+func (t *Tenant) Clients() []string { return t.clients }
+
+// Algorithm returns algorithm used if request doesn't specify one.
+func (t *Tenant) Algorithm() hash.Algorithm { return t.settings.Algorithm }
+
+// Normalization returns normalization used if request doesn't specify one.
+func (t *Tenant) Normalization() hash.Normalization { return t.settings.Normalization }
+
+// Pepper returns tenant secret mixed into every hashed input.
+func (t *Tenant) Pepper() string { return t.settings.Pepper }
+
+// TTL returns cached hashes lifetime.
+func (t *Tenant) TTL() time.Duration { return t.settings.TTL }
+
+// DailyQuota returns maximum number of hashes per UTC day.
+func (t *Tenant) DailyQuota() int64 { return t.settings.DailyQuota }
+
+// validateName validates tenant name. Name is a part of storage keys, so it
+// can't contain separators making keys of different tenants collide.
+func validateName(name string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+
+	if len(name) > maxNameSize {
+		return ErrInvalidName
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return ErrInvalidName
+		}
+	}
+
+	return nil
+}
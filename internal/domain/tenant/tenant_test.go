@@ -0,0 +1,137 @@
+package tenant
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		tenant      string
+		settings    Settings
+		expectedErr error
+	}{
+		{
+			name:     "valid",
+			tenant:   "sales",
+			settings: Settings{Algorithm: hash.AlgorithmSHA256, DailyQuota: 10},
+		},
+		{
+			name:     "no default algorithm",
+			tenant:   "sales",
+			settings: Settings{},
+		},
+		{
+			name:        "empty name",
+			tenant:      "",
+			expectedErr: ErrEmptyName,
+		},
+		{
+			name:        "separator in name",
+			tenant:      "sales:eu",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "space in name",
+			tenant:      "sales eu",
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:        "too long name",
+			tenant:      strings.Repeat("a", maxNameSize+1),
+			expectedErr: ErrInvalidName,
+		},
+		{
+			name:     "dashes and underscores",
+			tenant:   "sales-eu_2",
+			settings: Settings{},
+		},
+		{
+			name:        "invalid algorithm",
+			tenant:      "sales",
+			settings:    Settings{Algorithm: hash.Algorithm(99)},
+			expectedErr: ErrInvalidAlgorithm,
+		},
+		{
+			name:        "negative quota",
+			tenant:      "sales",
+			settings:    Settings{DailyQuota: -1},
+			expectedErr: ErrNegativeQuota,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tnt, err := New(tt.tenant, nil, tt.settings)
+			if err != tt.expectedErr {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if err == nil && tnt.Name() != tt.tenant {
+				t.Errorf("expected name %q, got %q", tt.tenant, tnt.Name())
+			}
+		})
+	}
+}
+
+func TestRegistry_ForClient(t *testing.T) {
+	sales := mustNew(t, "sales", []string{"importer", "CN=crm"})
+	marketing := mustNew(t, "marketing", []string{"campaigns"})
+
+	r, err := NewRegistry(Default(), sales, marketing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := r.ForClient("CN=crm"); got != sales {
+		t.Errorf("expected sales tenant, got %q", got.Name())
+	}
+
+	if got := r.ForClient("campaigns"); got != marketing {
+		t.Errorf("expected marketing tenant, got %q", got.Name())
+	}
+
+	if got := r.ForClient("10.0.0.1"); got.Name() != "" {
+		t.Errorf("expected default tenant, got %q", got.Name())
+	}
+}
+
+func TestNewRegistry_Duplicates(t *testing.T) {
+	if _, err := NewRegistry(Default(), mustNew(t, "sales", nil), mustNew(t, "sales", nil)); !errors.Is(err, ErrDuplicateTenant) {
+		t.Errorf("expected %v, got %v", ErrDuplicateTenant, err)
+	}
+
+	if _, err := NewRegistry(Default(), mustNew(t, "sales", []string{"a"}), mustNew(t, "marketing", []string{"a"})); !errors.Is(err, ErrDuplicateClient) {
+		t.Errorf("expected %v, got %v", ErrDuplicateClient, err)
+	}
+}
+
+func TestNewDefault(t *testing.T) {
+	def, err := NewDefault(Settings{Algorithm: hash.AlgorithmMD5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if def.Name() != "" || def.Algorithm() != hash.AlgorithmMD5 {
+		t.Errorf("expected unnamed md5 tenant, got %q %v", def.Name(), def.Algorithm())
+	}
+
+	if _, err := NewDefault(Settings{Algorithm: hash.Algorithm(99)}); err != ErrInvalidAlgorithm {
+		t.Errorf("expected %v, got %v", ErrInvalidAlgorithm, err)
+	}
+}
+
+func mustNew(t *testing.T, name string, clients []string) *Tenant {
+	t.Helper()
+
+	tnt, err := New(name, clients, Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tnt
+}
@@ -0,0 +1,17 @@
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+// UsageRepository is a contract that tenant usage counters repositories
+// should implement.
+type UsageRepository interface {
+	// Increment increments tenant counter for UTC day of provided time and
+	// returns new value.
+	Increment(ctx context.Context, tenant string, at time.Time) (int64, error)
+
+	// Usage returns tenant counter for UTC day of provided time.
+	Usage(ctx context.Context, tenant string, at time.Time) (int64, error)
+}
@@ -0,0 +1,2 @@
+// Package token provides a domain token definitions.
+package token
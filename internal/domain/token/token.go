@@ -0,0 +1,71 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Token domain errors.
+var (
+	ErrEmptyValue     = errors.New("value cannot be empty")
+	ErrValueTooLong   = errors.New("value is too long")
+	ErrMalformedToken = errors.New("malformed token")
+	ErrNotFound       = errors.New("token not found")
+	ErrForbidden      = errors.New("detokenization is not permitted")
+)
+
+const (
+	// Prefix is a prefix of every token, so tokens can't be confused with
+	// hashes or original values.
+	Prefix = "tok_"
+
+	// maxValueSize is a maximum tokenized value size in bytes.
+	maxValueSize = 4096
+	// maxTokenSize is a maximum token size in bytes.
+	maxTokenSize = 128
+)
+
+// Cipher is a contract that token ciphers should implement.
+type Cipher interface {
+	// Token derives token of value. Equal values always get equal tokens,
+	// token doesn't reveal value without the key.
+	Token(value string) string
+
+	// Seal encrypts value bound to its token.
+	Seal(token, value string) ([]byte, error)
+
+	// Open decrypts value sealed for token.
+	Open(token string, sealed []byte) (string, error)
+}
+
+// Repository is a contract that token repositories should implement.
+type Repository interface {
+	// Save saves sealed value of token. Already saved token is kept as is.
+	Save(ctx context.Context, token string, sealed []byte) error
+
+	// Find finds sealed value of token.
+	Find(ctx context.Context, token string) ([]byte, error)
+}
+
+// ValidateValue validates value to tokenize.
+func ValidateValue(value string) error {
+	if value == "" {
+		return ErrEmptyValue
+	}
+
+	if len(value) > maxValueSize {
+		return ErrValueTooLong
+	}
+
+	return nil
+}
+
+// ValidateToken validates token to detokenize.
+func ValidateToken(token string) error {
+	if len(token) <= len(Prefix) || len(token) > maxTokenSize || !strings.HasPrefix(token, Prefix) {
+		return ErrMalformedToken
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expectErr error
+	}{
+		{"valid", "foo@example.com", nil},
+		{"empty", "", ErrEmptyValue},
+		{"too long", strings.Repeat("a", maxValueSize+1), ErrValueTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateValue(tt.value); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		expectErr error
+	}{
+		{"valid", "tok_abc", nil},
+		{"prefix only", "tok_", ErrMalformedToken},
+		{"no prefix", "abc", ErrMalformedToken},
+		{"too long", "tok_" + strings.Repeat("a", maxTokenSize), ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateToken(tt.token); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
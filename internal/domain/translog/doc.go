@@ -0,0 +1,2 @@
+// Package translog provides a domain transparency log definitions.
+package translog
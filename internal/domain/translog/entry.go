@@ -0,0 +1,141 @@
+package translog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// NonceSize is a size of entry nonce in bytes.
+const NonceSize = 16
+
+// Entry represents a single issued hash in transparency log. Input is never
+// logged, only the hash and the way it was made.
+//
+// Leaf hashed into log tree carries commitment to entry content only, so
+// log can be audited without revealing hashes. Content, i.e. commitment
+// preimage, is kept private. Random nonce keeps commitments of guessable
+// hashes from being brute-forced.
+type Entry struct {
+	index      int64
+	leaf       string
+	commitment string
+	preimage   string
+	data       entryData
+}
+
+// entryData is a commitment preimage of entry.
+type entryData struct {
+	Index         int64     `json:"index"`
+	Tenant        string    `json:"tenant,omitempty"`
+	Algorithm     string    `json:"algorithm"`
+	Hash          string    `json:"hash"`
+	PepperVersion string    `json:"pepper_version,omitempty"`
+	IssuedAt      time.Time `json:"issued_at"`
+	Nonce         string    `json:"nonce"`
+}
+
+// leafData is a content of entry leaf.
+type leafData struct {
+	Index      int64  `json:"index"`
+	Commitment string `json:"commitment"`
+}
+
+// NewEntry creates new log entry at index for hash issued to tenant. Nonce
+// must be NonceSize random bytes.
+func NewEntry(index int64, tenant string, h *hash.Hash, issuedAt time.Time, nonce []byte) (*Entry, error) {
+	if len(nonce) != NonceSize {
+		return nil, fmt.Errorf("nonce must be %d bytes, got %d", NonceSize, len(nonce))
+	}
+
+	data := entryData{
+		Index:         index,
+		Tenant:        tenant,
+		Algorithm:     h.Algorithm().String(),
+		Hash:          h.Hashed(),
+		PepperVersion: h.PepperVersion(),
+		IssuedAt:      issuedAt.UTC(),
+		Nonce:         hex.EncodeToString(nonce),
+	}
+
+	preimage, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal entry: %w", err)
+	}
+
+	return newEntry(index, string(preimage), data)
+}
+
+// ParseEntry restores entry at index from its commitment preimage.
+func ParseEntry(index int64, preimage string) (*Entry, error) {
+	var data entryData
+	if err := json.Unmarshal([]byte(preimage), &data); err != nil {
+		return nil, fmt.Errorf("%w %d: %v", ErrMalformedEntry, index, err)
+	}
+
+	if data.Index != index {
+		return nil, fmt.Errorf("%w %d: stored at index %d", ErrMalformedEntry, data.Index, index)
+	}
+
+	return newEntry(index, preimage, data)
+}
+
+func newEntry(index int64, preimage string, data entryData) (*Entry, error) {
+	sum := sha256.Sum256([]byte(preimage))
+	commitment := hex.EncodeToString(sum[:])
+
+	leaf, err := json.Marshal(leafData{Index: index, Commitment: commitment})
+	if err != nil {
+		return nil, fmt.Errorf("marshal leaf: %w", err)
+	}
+
+	return &Entry{
+		index:      index,
+		leaf:       string(leaf),
+		commitment: commitment,
+		preimage:   preimage,
+		data:       data,
+	}, nil
+}
+
+// Redacted returns copy of entry with leaf only, its content is empty.
+func (e *Entry) Redacted() *Entry {
+	return &Entry{
+		index:      e.index,
+		leaf:       e.leaf,
+		commitment: e.commitment,
+		data:       entryData{Index: e.index},
+	}
+}
+
+// Index returns entry position in log.
+func (e *Entry) Index() int64 { return e.index }
+
+// Leaf returns entry leaf hashed into log tree.
+func (e *Entry) Leaf() string { return e.leaf }
+
+// Commitment returns hex-encoded SHA-256 of entry preimage.
+func (e *Entry) Commitment() string { return e.commitment }
+
+// Preimage returns JSON entry content committed to by leaf, empty if entry
+// is redacted.
+func (e *Entry) Preimage() string { return e.preimage }
+
+// Tenant returns name of tenant hash was issued to.
+func (e *Entry) Tenant() string { return e.data.Tenant }
+
+// Algorithm returns name of hash algorithm.
+func (e *Entry) Algorithm() string { return e.data.Algorithm }
+
+// Hash returns issued hash.
+func (e *Entry) Hash() string { return e.data.Hash }
+
+// PepperVersion returns server pepper version hash was made with.
+func (e *Entry) PepperVersion() string { return e.data.PepperVersion }
+
+// IssuedAt returns hash issuance time.
+func (e *Entry) IssuedAt() time.Time { return e.data.IssuedAt }
@@ -0,0 +1,38 @@
+package translog
+
+import (
+	"context"
+	"errors"
+)
+
+// Transparency log domain errors.
+var (
+	ErrMalformedEntry = errors.New("malformed log entry")
+	ErrNoTreeHead     = errors.New("no signed tree head yet")
+)
+
+// Signer is a contract that tree head signers should implement.
+type Signer interface {
+	// KeyID returns identifier of signing key.
+	KeyID() string
+
+	// Sign signs message.
+	Sign(message []byte) ([]byte, error)
+}
+
+// Repository is a contract that transparency log repositories should
+// implement. Entries are addressed by zero-based index.
+type Repository interface {
+	// Append appends entries following already stored ones.
+	Append(ctx context.Context, entries []*Entry) error
+
+	// Entries returns up to limit entries starting from offset.
+	Entries(ctx context.Context, offset, limit int64) ([]*Entry, error)
+
+	// SaveHead stores signed tree head.
+	SaveHead(ctx context.Context, head *TreeHead) error
+
+	// LatestHead returns the latest stored tree head. Returns ErrNoTreeHead
+	// if none is stored.
+	LatestHead(ctx context.Context) (*TreeHead, error)
+}
@@ -0,0 +1,109 @@
+package translog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+type mockSigner struct{}
+
+func (mockSigner) KeyID() string { return "key-1" }
+
+func (mockSigner) Sign(message []byte) ([]byte, error) { return message[:2], nil }
+
+func TestEntry(t *testing.T) {
+	h, err := hash.NewWithParams("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5, hash.Params{PepperVersion: "v2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	issuedAt := time.Date(2026, 10, 19, 15, 4, 5, 0, time.FixedZone("MSK", 3*60*60))
+	nonce := bytes.Repeat([]byte{0xab}, NonceSize)
+	e, err := NewEntry(7, "sales", h, issuedAt, nonce)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const preimage = `{"index":7,"tenant":"sales","algorithm":"md5","hash":"acbd18db4cc2f85cedef654fccc4a4d8","pepper_version":"v2","issued_at":"2026-10-19T12:04:05Z","nonce":"abababababababababababababababab"}`
+	if e.Preimage() != preimage {
+		t.Errorf("expected preimage %s, got %s", preimage, e.Preimage())
+	}
+
+	sum := sha256.Sum256([]byte(preimage))
+	leaf := `{"index":7,"commitment":"` + hex.EncodeToString(sum[:]) + `"}`
+	if e.Leaf() != leaf || e.Commitment() != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected leaf %s, got %s", leaf, e.Leaf())
+	}
+	if strings.Contains(e.Leaf(), h.Hashed()) || strings.Contains(e.Leaf(), "sales") {
+		t.Errorf("leaf %s reveals entry content", e.Leaf())
+	}
+
+	other, err := NewEntry(7, "sales", h, issuedAt, bytes.Repeat([]byte{0xcd}, NonceSize))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.Commitment() == e.Commitment() {
+		t.Error("expected commitments of different nonces to differ")
+	}
+
+	parsed, err := ParseEntry(7, preimage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed.Leaf() != leaf || parsed.Tenant() != "sales" || parsed.Algorithm() != "md5" || parsed.Hash() != h.Hashed() ||
+		parsed.PepperVersion() != "v2" || !parsed.IssuedAt().Equal(issuedAt) {
+		t.Errorf("unexpected parsed entry %+v", parsed)
+	}
+
+	redacted := parsed.Redacted()
+	if redacted.Leaf() != leaf || redacted.Preimage() != "" || redacted.Tenant() != "" || redacted.Hash() != "" {
+		t.Errorf("unexpected redacted entry %+v", redacted)
+	}
+
+	if _, err := ParseEntry(8, preimage); !errors.Is(err, ErrMalformedEntry) {
+		t.Errorf("expected error %v, got %v", ErrMalformedEntry, err)
+	}
+	if _, err := ParseEntry(7, "{"); !errors.Is(err, ErrMalformedEntry) {
+		t.Errorf("expected error %v, got %v", ErrMalformedEntry, err)
+	}
+	if _, err := NewEntry(7, "sales", h, issuedAt, nonce[1:]); err == nil {
+		t.Error("expected error for short nonce")
+	}
+}
+
+func TestTreeHead_Sign(t *testing.T) {
+	head := &TreeHead{
+		Size:      3,
+		Root:      "aabb",
+		Timestamp: time.UnixMilli(0x0102),
+	}
+
+	data, err := head.SignedData()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect, _ := hex.DecodeString("0001" + "0000000000000102" + "0000000000000003" + "aabb")
+	if !bytes.Equal(data, expect) {
+		t.Errorf("expected signed data %x, got %x", expect, data)
+	}
+
+	if err := head.Sign(mockSigner{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if head.KeyID != "key-1" || !bytes.Equal(head.Signature, []byte{0, 1}) {
+		t.Errorf("unexpected signature %q %x", head.KeyID, head.Signature)
+	}
+
+	head.Root = "xyz"
+	if err := head.Sign(mockSigner{}); err == nil {
+		t.Error("expected error for malformed root")
+	}
+}
@@ -0,0 +1,59 @@
+package translog
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// TreeHead represents signed head of log tree.
+type TreeHead struct {
+	Size      int64     `json:"size"`
+	Root      string    `json:"root"`
+	Timestamp time.Time `json:"timestamp"`
+	KeyID     string    `json:"key_id"`
+	Signature []byte    `json:"signature"`
+}
+
+// Signature fields of RFC 6962 TreeHeadSignature.
+const (
+	versionV1          = 0
+	signatureTypeTree  = 1
+	treeHeadHeaderSize = 1 + 1 + 8 + 8
+)
+
+// SignedData returns data covered by head signature. It's RFC 6962
+// TreeHeadSignature: version, signature type, timestamp in milliseconds,
+// tree size and root hash.
+func (h *TreeHead) SignedData() ([]byte, error) {
+	root, err := hex.DecodeString(h.Root)
+	if err != nil {
+		return nil, fmt.Errorf("decode root: %w", err)
+	}
+
+	data := make([]byte, 0, treeHeadHeaderSize+len(root))
+	data = append(data, versionV1, signatureTypeTree)
+	data = binary.BigEndian.AppendUint64(data, uint64(h.Timestamp.UnixMilli()))
+	data = binary.BigEndian.AppendUint64(data, uint64(h.Size))
+
+	return append(data, root...), nil
+}
+
+// Sign signs head by signer.
+func (h *TreeHead) Sign(signer Signer) error {
+	data, err := h.SignedData()
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return fmt.Errorf("sign tree head: %w", err)
+	}
+
+	h.KeyID = signer.KeyID()
+	h.Signature = sig
+
+	return nil
+}
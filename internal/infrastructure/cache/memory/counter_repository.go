@@ -0,0 +1,126 @@
+package memoryinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
+)
+
+// pruneInterval is a minimum interval between expired buckets removals.
+const pruneInterval = time.Minute
+
+// CounterRepository represents in-process unique counters repository.
+// Buckets expire retention after the last addition, zero retention keeps
+// them forever.
+type CounterRepository struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	buckets  map[counterKey]*counterBucket
+	prunedAt time.Time
+}
+
+type counterKey struct {
+	tenant string
+	key    string
+	bucket int64
+}
+
+type counterBucket struct {
+	hll     cardinality.HyperLogLog
+	addedAt time.Time
+}
+
+// NewCounterRepository creates new instance of in-process counters
+// repository.
+func NewCounterRepository(retention time.Duration) *CounterRepository {
+	return &CounterRepository{
+		retention: retention,
+		now:       time.Now,
+		buckets:   map[counterKey]*counterBucket{},
+	}
+}
+
+// Add adds digest to counter bucket.
+func (r *CounterRepository) Add(_ context.Context, tenant, key string, bucket time.Time, digest string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.prune(now)
+
+	k := counterKey{tenant: tenant, key: key, bucket: bucket.Unix()}
+	b, ok := r.buckets[k]
+	if !ok {
+		b = &counterBucket{}
+		r.buckets[k] = b
+	}
+
+	b.hll.Add(digest)
+	b.addedAt = now
+
+	return nil
+}
+
+// Count estimates number of unique digests added to any of buckets of any of
+// keys.
+func (r *CounterRepository) Count(_ context.Context, tenant string, keys []string, buckets []time.Time) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+
+	var union cardinality.HyperLogLog
+	for _, key := range keys {
+		for _, bucket := range buckets {
+			b, ok := r.buckets[counterKey{tenant: tenant, key: key, bucket: bucket.Unix()}]
+			if !ok || r.expired(b, now) {
+				continue
+			}
+			union.Merge(&b.hll)
+		}
+	}
+
+	return union.Estimate(), nil
+}
+
+// CountBuckets estimates number of unique digests added to every bucket of
+// key.
+func (r *CounterRepository) CountBuckets(_ context.Context, tenant, key string, buckets []time.Time) ([]uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+
+	counts := make([]uint64, len(buckets))
+	for i, bucket := range buckets {
+		b, ok := r.buckets[counterKey{tenant: tenant, key: key, bucket: bucket.Unix()}]
+		if !ok || r.expired(b, now) {
+			continue
+		}
+		counts[i] = b.hll.Estimate()
+	}
+
+	return counts, nil
+}
+
+// prune removes expired buckets at most once per pruneInterval.
+func (r *CounterRepository) prune(now time.Time) {
+	if r.retention <= 0 || now.Sub(r.prunedAt) < pruneInterval {
+		return
+	}
+
+	for k, b := range r.buckets {
+		if r.expired(b, now) {
+			delete(r.buckets, k)
+		}
+	}
+	r.prunedAt = now
+}
+
+func (r *CounterRepository) expired(b *counterBucket, now time.Time) bool {
+	return r.retention > 0 && now.Sub(b.addedAt) >= r.retention
+}
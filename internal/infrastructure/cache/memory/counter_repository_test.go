@@ -0,0 +1,76 @@
+package memoryinfra
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCounterRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	r := NewCounterRepository(time.Hour)
+	r.now = func() time.Time { return now }
+
+	day1, day2 := now, now.Add(24*time.Hour)
+	for i := range 100 {
+		if err := r.Add(ctx, "sales", "spring", day1, strconv.Itoa(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Add(ctx, "sales", "spring", day2, strconv.Itoa(i+50)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Add(ctx, "sales", "autumn", day1, "autumn-"+strconv.Itoa(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		tenant  string
+		keys    []string
+		buckets []time.Time
+		expect  uint64
+	}{
+		{"single bucket", "sales", []string{"spring"}, []time.Time{day1}, 100},
+		{"union of buckets", "sales", []string{"spring"}, []time.Time{day1, day2}, 150},
+		{"union of keys", "sales", []string{"spring", "autumn"}, []time.Time{day1}, 200},
+		{"missing bucket", "sales", []string{"spring"}, []time.Time{day2.Add(24 * time.Hour)}, 0},
+		{"other tenant", "marketing", []string{"spring"}, []time.Time{day1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := r.Count(ctx, tt.tenant, tt.keys, tt.buckets)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Small cardinalities are estimated within a few percent.
+			if n+tt.expect/50 < tt.expect || n > tt.expect+tt.expect/50 {
+				t.Errorf("expected about %d, got %d", tt.expect, n)
+			}
+		})
+	}
+
+	counts, err := r.CountBuckets(ctx, "sales", "spring", []time.Time{day1, day2, day2.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(counts) != 3 || counts[0] < 98 || counts[0] > 102 || counts[1] < 98 || counts[1] > 102 || counts[2] != 0 {
+		t.Errorf("expected about 100, 100 and 0 per bucket, got %v", counts)
+	}
+
+	now = now.Add(time.Hour)
+	if n, _ := r.Count(ctx, "sales", []string{"spring"}, []time.Time{day1}); n != 0 {
+		t.Errorf("expected expired bucket to be ignored, got %d", n)
+	}
+
+	if err := r.Add(ctx, "sales", "spring", day2, "x"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.buckets) != 1 {
+		t.Errorf("expected expired buckets to be pruned, got %d buckets", len(r.buckets))
+	}
+}
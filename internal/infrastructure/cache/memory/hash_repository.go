@@ -0,0 +1,89 @@
+// Package memoryinfra provides in-process infrastructure capabilities for
+// running without external storage.
+package memoryinfra
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// ErrNotFound is returned when hash is not cached.
+var ErrNotFound = errors.New("hash not found")
+
+// HashRepository represents in-process LRU hash repository. TTL is ignored,
+// least recently used hashes are evicted once capacity is reached.
+type HashRepository struct {
+	capacity int
+
+	mu    sync.Mutex
+	items map[hashKey]*list.Element
+	order *list.List
+}
+
+type hashKey struct {
+	namespace string
+	input     string
+	alg       hash.Algorithm
+	params    hash.Params
+}
+
+type hashEntry struct {
+	key hashKey
+	h   *hash.Hash
+}
+
+// NewHashRepository creates new instance of in-process hash repository with
+// given capacity. Zero capacity disables caching.
+func NewHashRepository(capacity int) *HashRepository {
+	return &HashRepository{
+		capacity: capacity,
+		items:    map[hashKey]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Save saves provided hash to cache.
+func (r *HashRepository) Save(_ context.Context, namespace string, h *hash.Hash, _ time.Duration) error {
+	if r.capacity <= 0 {
+		return nil
+	}
+
+	key := hashKey{namespace: namespace, input: h.Input(), alg: h.Algorithm(), params: h.Params()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.items[key]; ok {
+		el.Value.(*hashEntry).h = h
+		r.order.MoveToFront(el)
+		return nil
+	}
+
+	r.items[key] = r.order.PushFront(&hashEntry{key: key, h: h})
+	if r.order.Len() > r.capacity {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.items, oldest.Value.(*hashEntry).key)
+	}
+
+	return nil
+}
+
+// FindByInput finds hash by input string, algorithm, salt and pepper version.
+func (r *HashRepository) FindByInput(_ context.Context, namespace, input string, alg hash.Algorithm, params hash.Params) (*hash.Hash, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.items[hashKey{namespace: namespace, input: input, alg: alg, params: params}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	r.order.MoveToFront(el)
+	return el.Value.(*hashEntry).h, nil
+}
@@ -0,0 +1,92 @@
+package memoryinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestHashRepository(t *testing.T) {
+	ctx := context.Background()
+	r := NewHashRepository(2)
+
+	a := mustHash(t, "a")
+	b := mustHash(t, "b")
+	c := mustHash(t, "c")
+
+	for _, h := range []*hash.Hash{a, b} {
+		if err := r.Save(ctx, "sales", h, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := r.FindByInput(ctx, "marketing", "a", hash.AlgorithmMD5, hash.Params{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected namespaces to be isolated, got %v", err)
+	}
+
+	if got, err := r.FindByInput(ctx, "sales", "a", hash.AlgorithmMD5, hash.Params{}); err != nil || got != a {
+		t.Errorf("expected cached hash, got %v, %v", got, err)
+	}
+
+	if err := r.Save(ctx, "sales", c, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.FindByInput(ctx, "sales", "b", hash.AlgorithmMD5, hash.Params{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected least recently used hash to be evicted, got %v", err)
+	}
+
+	if _, err := r.FindByInput(ctx, "sales", "a", hash.AlgorithmMD5, hash.Params{}); err != nil {
+		t.Errorf("expected recently used hash to be kept, got %v", err)
+	}
+}
+
+func TestHashRepository_Params(t *testing.T) {
+	ctx := context.Background()
+	r := NewHashRepository(10)
+
+	params := hash.Params{Salt: "s", PepperVersion: "v1"}
+	h, err := hash.NewWithParams("a", "hashed-a", hash.AlgorithmMD5, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Save(ctx, "", h, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, p := range []hash.Params{{}, {Salt: "s"}, {PepperVersion: "v1"}, {Salt: "s", PepperVersion: "v2"}} {
+		if _, err := r.FindByInput(ctx, "", "a", hash.AlgorithmMD5, p); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected miss for params %+v, got %v", p, err)
+		}
+	}
+
+	if got, err := r.FindByInput(ctx, "", "a", hash.AlgorithmMD5, params); err != nil || got != h {
+		t.Errorf("expected cached hash, got %v, %v", got, err)
+	}
+}
+
+func TestHashRepository_Disabled(t *testing.T) {
+	ctx := context.Background()
+	r := NewHashRepository(0)
+
+	if err := r.Save(ctx, "", mustHash(t, "a"), 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.FindByInput(ctx, "", "a", hash.AlgorithmMD5, hash.Params{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected disabled cache to miss, got %v", err)
+	}
+}
+
+func mustHash(t *testing.T, input string) *hash.Hash {
+	t.Helper()
+
+	h, err := hash.New(input, "hashed-"+input, hash.AlgorithmMD5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
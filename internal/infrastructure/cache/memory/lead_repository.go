@@ -0,0 +1,127 @@
+package memoryinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+)
+
+// LeadRepository represents in-process leads repository. Leads expire
+// retention after they are first seen and pinned pepper versions retention
+// after the last pin, zero retention keeps them forever.
+type LeadRepository struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	leads    map[leadKey]*dedup.Lead
+	peppers  map[leadKey]pinnedPepper
+	prunedAt time.Time
+}
+
+type leadKey struct {
+	tenant    string
+	namespace string
+	key       string
+}
+
+type pinnedPepper struct {
+	version  string
+	pinnedAt time.Time
+}
+
+// NewLeadRepository creates new instance of in-process leads repository.
+func NewLeadRepository(retention time.Duration) *LeadRepository {
+	return &LeadRepository{
+		retention: retention,
+		now:       time.Now,
+		leads:     map[leadKey]*dedup.Lead{},
+		peppers:   map[leadKey]pinnedPepper{},
+	}
+}
+
+// Register saves lead unless lead with the same key was first seen within
+// window.
+func (r *LeadRepository) Register(_ context.Context, tenant, namespace string, lead *dedup.Lead, window time.Duration) (*dedup.Lead, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.prune(now)
+
+	k := leadKey{tenant: tenant, namespace: namespace, key: lead.Key()}
+	if seen, ok := r.leads[k]; ok && !r.expired(seen.FirstSeen(), now) && seen.Within(window, lead.FirstSeen()) {
+		return seen, false, nil
+	}
+	r.leads[k] = lead
+
+	return lead, true, nil
+}
+
+// Find finds lead by key.
+func (r *LeadRepository) Find(_ context.Context, tenant, namespace, key string) (*dedup.Lead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lead, ok := r.leads[leadKey{tenant: tenant, namespace: namespace, key: key}]
+	if !ok || r.expired(lead.FirstSeen(), r.now()) {
+		return nil, dedup.ErrNotFound
+	}
+
+	return lead, nil
+}
+
+// PinPepperVersion saves server pepper version of namespace unless one is
+// already saved.
+func (r *LeadRepository) PinPepperVersion(_ context.Context, tenant, namespace, version string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	k := leadKey{tenant: tenant, namespace: namespace}
+	if p, ok := r.peppers[k]; ok && !r.expired(p.pinnedAt, now) {
+		version = p.version
+	}
+	r.peppers[k] = pinnedPepper{version: version, pinnedAt: now}
+
+	return version, nil
+}
+
+// PepperVersion finds server pepper version pinned by namespace.
+func (r *LeadRepository) PepperVersion(_ context.Context, tenant, namespace string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.peppers[leadKey{tenant: tenant, namespace: namespace}]
+	if !ok || r.expired(p.pinnedAt, r.now()) {
+		return "", dedup.ErrNotFound
+	}
+
+	return p.version, nil
+}
+
+// prune removes expired leads and pepper versions at most once per
+// pruneInterval.
+func (r *LeadRepository) prune(now time.Time) {
+	if r.retention <= 0 || now.Sub(r.prunedAt) < pruneInterval {
+		return
+	}
+
+	for k, lead := range r.leads {
+		if r.expired(lead.FirstSeen(), now) {
+			delete(r.leads, k)
+		}
+	}
+	for k, p := range r.peppers {
+		if r.expired(p.pinnedAt, now) {
+			delete(r.peppers, k)
+		}
+	}
+	r.prunedAt = now
+}
+
+func (r *LeadRepository) expired(since, now time.Time) bool {
+	return r.retention > 0 && now.Sub(since) >= r.retention
+}
@@ -0,0 +1,101 @@
+package memoryinfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+)
+
+func TestLeadRepository_MixedWindows(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	r := NewLeadRepository(90 * 24 * time.Hour)
+	r.now = func() time.Time { return now }
+
+	const day = 24 * time.Hour
+
+	register := func(source string, window time.Duration) (*dedup.Lead, bool) {
+		t.Helper()
+		lead, isNew, err := r.Register(ctx, "sales", "leads", dedup.NewLead("key", source, now), window)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return lead, isNew
+	}
+
+	tests := []struct {
+		name         string
+		at           time.Duration
+		source       string
+		window       time.Duration
+		expectNew    bool
+		expectSource string
+	}{
+		{"first", 0, "web", 30 * day, true, "web"},
+		{"shorter window within", 12 * time.Hour, "crm", day, false, "web"},
+		{"shorter window after", 2 * day, "crm", day, true, "crm"},
+		{"longer window after shorter one", 10 * day, "ads", 30 * day, false, "crm"},
+		{"zero window", 60 * day, "ads", 0, false, "crm"},
+		{"after retention", 93 * day, "ads", 0, true, "ads"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = start.Add(tt.at)
+			lead, isNew := register(tt.source, tt.window)
+			if isNew != tt.expectNew || lead.Source() != tt.expectSource {
+				t.Errorf("expected new %v lead of %q, got %v of %q", tt.expectNew, tt.expectSource, isNew, lead.Source())
+			}
+		})
+	}
+
+	now = start.Add(200 * day)
+	if _, err := r.Find(ctx, "sales", "leads", "key"); !errors.Is(err, dedup.ErrNotFound) {
+		t.Errorf("expected error %v, got %v", dedup.ErrNotFound, err)
+	}
+}
+
+func TestLeadRepository_PinPepperVersion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	r := NewLeadRepository(time.Hour)
+	r.now = func() time.Time { return now }
+
+	if _, err := r.PepperVersion(ctx, "sales", "leads"); !errors.Is(err, dedup.ErrNotFound) {
+		t.Errorf("expected error %v, got %v", dedup.ErrNotFound, err)
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Duration
+		version string
+		expect  string
+	}{
+		{"first pin", 0, "v1", "v1"},
+		{"pinned", 30 * time.Minute, "v2", "v1"},
+		{"refreshed by pin", 80 * time.Minute, "v2", "v1"},
+		{"after retention", 3 * time.Hour, "v2", "v2"},
+	}
+
+	start := now
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = start.Add(tt.at)
+			version, err := r.PinPepperVersion(ctx, "sales", "leads", tt.version)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tt.expect {
+				t.Errorf("expected version %q, got %q", tt.expect, version)
+			}
+		})
+	}
+
+	if version, err := r.PepperVersion(ctx, "marketing", "leads"); !errors.Is(err, dedup.ErrNotFound) {
+		t.Errorf("expected other tenant namespace unpinned, got %q, %v", version, err)
+	}
+}
@@ -0,0 +1,44 @@
+package memoryinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UsageRepository represents in-process tenant usage counters repository.
+type UsageRepository struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// NewUsageRepository creates new instance of in-process usage repository.
+func NewUsageRepository() *UsageRepository {
+	return &UsageRepository{
+		counts: map[string]int64{},
+	}
+}
+
+// Increment increments tenant counter for UTC day of provided time and returns
+// new value.
+func (r *UsageRepository) Increment(_ context.Context, tenant string, at time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := usageKey(tenant, at)
+	r.counts[key]++
+
+	return r.counts[key], nil
+}
+
+// Usage returns tenant counter for UTC day of provided time.
+func (r *UsageRepository) Usage(_ context.Context, tenant string, at time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.counts[usageKey(tenant, at)], nil
+}
+
+func usageKey(tenant string, at time.Time) string {
+	return tenant + ":" + at.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,84 @@
+package redisinfra
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CounterRepository represents Redis unique counters repository. Buckets are
+// Redis HyperLogLogs expiring retention after the last addition, zero
+// retention keeps them forever.
+type CounterRepository struct {
+	redisCli  *redis.Client
+	retention time.Duration
+}
+
+// NewCounterRepository creates new instance of Redis counters repository.
+func NewCounterRepository(redisCli *redis.Client, retention time.Duration) *CounterRepository {
+	return &CounterRepository{
+		redisCli:  redisCli,
+		retention: retention,
+	}
+}
+
+// Add adds digest to counter bucket.
+func (r *CounterRepository) Add(ctx context.Context, tenant, key string, bucket time.Time, digest string) error {
+	k := counterKey(tenant, key, bucket)
+
+	pipe := r.redisCli.TxPipeline()
+	pipe.PFAdd(ctx, k, digest)
+	if r.retention > 0 {
+		pipe.Expire(ctx, k, r.retention)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("add to counter: %w", err)
+	}
+
+	return nil
+}
+
+// Count estimates number of unique digests added to any of buckets of any of
+// keys. Redis estimates union of HyperLogLogs counted at once.
+func (r *CounterRepository) Count(ctx context.Context, tenant string, keys []string, buckets []time.Time) (uint64, error) {
+	ks := make([]string, 0, len(keys)*len(buckets))
+	for _, key := range keys {
+		for _, b := range buckets {
+			ks = append(ks, counterKey(tenant, key, b))
+		}
+	}
+
+	n, err := r.redisCli.PFCount(ctx, ks...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("count unique: %w", err)
+	}
+
+	return uint64(n), nil
+}
+
+// CountBuckets estimates number of unique digests added to every bucket of
+// key. Buckets are counted in single pipeline.
+func (r *CounterRepository) CountBuckets(ctx context.Context, tenant, key string, buckets []time.Time) ([]uint64, error) {
+	cmds := make([]*redis.IntCmd, len(buckets))
+	pipe := r.redisCli.Pipeline()
+	for i, b := range buckets {
+		cmds[i] = pipe.PFCount(ctx, counterKey(tenant, key, b))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("count buckets: %w", err)
+	}
+
+	counts := make([]uint64, len(cmds))
+	for i, cmd := range cmds {
+		counts[i] = uint64(cmd.Val())
+	}
+
+	return counts, nil
+}
+
+func counterKey(tenant, key string, bucket time.Time) string {
+	return tenantKey(tenant, "hll:"+key+":"+strconv.FormatInt(bucket.Unix(), 10))
+}
@@ -0,0 +1,126 @@
+package redisinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+)
+
+// maxFilterUpdateAttempts is a maximum number of attempts to update filter
+// changed concurrently.
+const maxFilterUpdateAttempts = 16
+
+// FilterRepository represents Redis filters repository. Filters are stored
+// in their binary format without expiration. Updates are optimistic
+// transactions, so they are atomic across service replicas.
+type FilterRepository struct {
+	redisCli *redis.Client
+}
+
+// NewFilterRepository creates new instance of Redis filters repository.
+func NewFilterRepository(redisCli *redis.Client) *FilterRepository {
+	return &FilterRepository{
+		redisCli: redisCli,
+	}
+}
+
+// Save saves filter replacing saved one.
+func (r *FilterRepository) Save(ctx context.Context, tenant, name string, f filter.Filter) error {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal filter: %w", err)
+	}
+
+	if err := r.redisCli.Set(ctx, filterKey(tenant, name), data, 0).Err(); err != nil {
+		return fmt.Errorf("save filter: %w", err)
+	}
+
+	return nil
+}
+
+// Find finds filter by name.
+func (r *FilterRepository) Find(ctx context.Context, tenant, name string) (filter.Filter, error) {
+	data, err := r.redisCli.Get(ctx, filterKey(tenant, name)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, filter.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get filter: %w", err)
+	}
+
+	return filter.Unmarshal(data)
+}
+
+// Create saves filter unless filter with the same name exists.
+func (r *FilterRepository) Create(ctx context.Context, tenant, name string, f filter.Filter) error {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal filter: %w", err)
+	}
+
+	ok, err := r.redisCli.SetNX(ctx, filterKey(tenant, name), data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("save filter: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w %q", filter.ErrExists, name)
+	}
+
+	return nil
+}
+
+// Update finds filter, calls fn with it and saves it unless filter was
+// changed meanwhile, then it retries.
+func (r *FilterRepository) Update(ctx context.Context, tenant, name string, fn func(filter.Filter) error) (filter.Filter, error) {
+	key := filterKey(tenant, name)
+
+	var f filter.Filter
+	update := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return filter.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get filter: %w", err)
+		}
+
+		if f, err = filter.Unmarshal(data); err != nil {
+			return err
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+
+		if data, err = f.MarshalBinary(); err != nil {
+			return fmt.Errorf("marshal filter: %w", err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			return nil
+		})
+		return err
+	}
+
+	for range maxFilterUpdateAttempts {
+		err := r.redisCli.Watch(ctx, update, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("update filter: %w", redis.TxFailedErr)
+}
+
+func filterKey(tenant, name string) string {
+	return tenantKey(tenant, "filter:"+name)
+}
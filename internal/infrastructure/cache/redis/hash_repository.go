@@ -17,7 +17,7 @@ type HashRepository struct {
 }
 
 // NewHashRepository creates new instance of Redis hash repository by provided
-// Redis client and values TTL.
+// Redis client and default values TTL.
 func NewHashRepository(redisCli *redis.Client, ttl time.Duration) *HashRepository {
 	return &HashRepository{
 		redisCli: redisCli,
@@ -26,27 +26,41 @@ func NewHashRepository(redisCli *redis.Client, ttl time.Duration) *HashRepositor
 }
 
 // Save saves provided hash to cache.
-func (r *HashRepository) Save(ctx context.Context, h *hash.Hash) error {
-	key := fmt.Sprintf("%s:input:%s", h.Algorithm().String(), h.Input())
-	if err := r.redisCli.Set(ctx, key, h.Hashed(), r.ttl).Err(); err != nil {
+func (r *HashRepository) Save(ctx context.Context, namespace string, h *hash.Hash, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = r.ttl
+	}
+
+	if err := r.redisCli.Set(ctx, hashKey(namespace, h.Input(), h.Algorithm(), h.Params()), h.Hashed(), ttl).Err(); err != nil {
 		return fmt.Errorf("cache hash: %w", err)
 	}
 
 	return nil
 }
 
-// FindByInput finds hash by input string and algorithm.
-func (r *HashRepository) FindByInput(ctx context.Context, input string, alg hash.Algorithm) (*hash.Hash, error) {
-	key := fmt.Sprintf("%s:input:%s", alg.String(), input)
-	hashed, err := r.redisCli.Get(ctx, key).Result()
+// FindByInput finds hash by input string, algorithm, salt and pepper version.
+func (r *HashRepository) FindByInput(ctx context.Context, namespace, input string, alg hash.Algorithm, params hash.Params) (*hash.Hash, error) {
+	hashed, err := r.redisCli.Get(ctx, hashKey(namespace, input, alg, params)).Result()
 	if err != nil {
 		return nil, fmt.Errorf("get from cache: %w", err)
 	}
 
-	h, err := hash.New(input, hashed, alg)
+	h, err := hash.NewWithParams(input, hashed, alg, params)
 	if err != nil {
 		return nil, fmt.Errorf("new hash: %w", err)
 	}
 
 	return h, nil
 }
+
+// hashKey builds cache key. Namespaced keys are prefixed with tenant name,
+// shared keys keep the original layout. Pepper version and salt are quoted,
+// so they can't be confused with input.
+func hashKey(namespace, input string, alg hash.Algorithm, params hash.Params) string {
+	key := fmt.Sprintf("%s:input:%s", alg.String(), input)
+	if params != (hash.Params{}) {
+		key = fmt.Sprintf("%s:pepper:%q:salt:%q:input:%s", alg.String(), params.PepperVersion, params.Salt, input)
+	}
+
+	return tenantKey(namespace, key)
+}
@@ -0,0 +1,231 @@
+package redisinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+)
+
+// unfinishedJobsKey is a set of identifiers of jobs not in terminal status.
+const unfinishedJobsKey = "jobs:unfinished"
+
+// acquireLeaseScript sets job lease to owner unless it is held, like SET NX
+// PX does, and extends lease already held by owner.
+var acquireLeaseScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+  return 1
+end
+
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+
+return 0
+`)
+
+// releaseLeaseScript deletes job lease if it is held by owner.
+var releaseLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+
+return 0
+`)
+
+// JobRepository represents Redis jobs repository.
+//
+// Job state is stored as JSON string, rows and results are stored as lists.
+// Finished jobs expire after retention.
+type JobRepository struct {
+	redisCli  *redis.Client
+	retention time.Duration
+}
+
+// NewJobRepository creates new instance of Redis jobs repository with given
+// retention of finished jobs.
+func NewJobRepository(redisCli *redis.Client, retention time.Duration) *JobRepository {
+	return &JobRepository{
+		redisCli:  redisCli,
+		retention: retention,
+	}
+}
+
+// Save stores job state. Unfinished jobs are tracked to be resumed, finished
+// ones are set to expire.
+func (r *JobRepository) Save(ctx context.Context, j *job.Job) error {
+	data, err := json.Marshal(j.Snapshot())
+	if err != nil {
+		return fmt.Errorf("marshal job: %w", err)
+	}
+
+	pipe := r.redisCli.TxPipeline()
+	if j.Status().Terminal() {
+		pipe.Set(ctx, jobKey(j.ID()), data, r.retention)
+		pipe.Expire(ctx, jobRowsKey(j.ID()), r.retention)
+		pipe.Expire(ctx, jobResultsKey(j.ID()), r.retention)
+		pipe.SRem(ctx, unfinishedJobsKey, j.ID())
+	} else {
+		pipe.Set(ctx, jobKey(j.ID()), data, 0)
+		pipe.SAdd(ctx, unfinishedJobsKey, j.ID())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("save job: %w", err)
+	}
+
+	return nil
+}
+
+// Find finds job by identifier.
+func (r *JobRepository) Find(ctx context.Context, id string) (*job.Job, error) {
+	data, err := r.redisCli.Get(ctx, jobKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, job.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+
+	var s job.Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal job: %w", err)
+	}
+
+	return job.FromSnapshot(s)
+}
+
+// FindUnfinished finds jobs which are not in terminal status.
+func (r *JobRepository) FindUnfinished(ctx context.Context) ([]*job.Job, error) {
+	ids, err := r.redisCli.SMembers(ctx, unfinishedJobsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get unfinished jobs: %w", err)
+	}
+
+	jobs := make([]*job.Job, 0, len(ids))
+	for _, id := range ids {
+		j, err := r.Find(ctx, id)
+		if errors.Is(err, job.ErrNotFound) {
+			r.redisCli.SRem(ctx, unfinishedJobsKey, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, nil
+}
+
+// AppendRows appends uploaded rows.
+func (r *JobRepository) AppendRows(ctx context.Context, id string, rows []string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(rows))
+	for i, row := range rows {
+		values[i] = row
+	}
+
+	if err := r.redisCli.RPush(ctx, jobRowsKey(id), values...).Err(); err != nil {
+		return fmt.Errorf("append rows: %w", err)
+	}
+
+	return nil
+}
+
+// Rows returns up to limit rows starting from offset.
+func (r *JobRepository) Rows(ctx context.Context, id string, offset, limit int64) ([]string, error) {
+	rows, err := r.redisCli.LRange(ctx, jobRowsKey(id), offset, offset+limit-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+// AppendResults appends results of rows following already stored ones.
+func (r *JobRepository) AppendResults(ctx context.Context, id string, results []job.Result) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(results))
+	for i, res := range results {
+		data, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		values[i] = data
+	}
+
+	if err := r.redisCli.RPush(ctx, jobResultsKey(id), values...).Err(); err != nil {
+		return fmt.Errorf("append results: %w", err)
+	}
+
+	return nil
+}
+
+// Results returns up to limit results starting from offset.
+func (r *JobRepository) Results(ctx context.Context, id string, offset, limit int64) ([]job.Result, error) {
+	values, err := r.redisCli.LRange(ctx, jobResultsKey(id), offset, offset+limit-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get results: %w", err)
+	}
+
+	results := make([]job.Result, len(values))
+	for i, v := range values {
+		if err := json.Unmarshal([]byte(v), &results[i]); err != nil {
+			return nil, fmt.Errorf("unmarshal result: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// CountResults returns number of stored results.
+func (r *JobRepository) CountResults(ctx context.Context, id string) (int64, error) {
+	n, err := r.redisCli.LLen(ctx, jobResultsKey(id)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("count results: %w", err)
+	}
+
+	return n, nil
+}
+
+// AcquireLease acquires or extends lease of job processing by owner for ttl.
+// Returns false if unexpired lease is held by another owner.
+func (r *JobRepository) AcquireLease(ctx context.Context, id, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := acquireLeaseScript.Run(ctx, r.redisCli, []string{jobLeaseKey(id)},
+		owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("acquire lease: %w", err)
+	}
+
+	return acquired == 1, nil
+}
+
+// ReleaseLease releases lease held by owner.
+func (r *JobRepository) ReleaseLease(ctx context.Context, id, owner string) error {
+	if err := releaseLeaseScript.Run(ctx, r.redisCli, []string{jobLeaseKey(id)}, owner).Err(); err != nil {
+		return fmt.Errorf("release lease: %w", err)
+	}
+
+	return nil
+}
+
+func jobKey(id string) string { return "job:" + id }
+
+func jobRowsKey(id string) string { return "job:" + id + ":rows" }
+
+func jobResultsKey(id string) string { return "job:" + id + ":results" }
+
+func jobLeaseKey(id string) string { return "job:" + id + ":lease" }
@@ -0,0 +1,12 @@
+package redisinfra
+
+// tenantKey prefixes key with tenant name. Keys of default tenant, which has
+// empty name, are kept in shared namespace. Tenant names can't contain ':',
+// so keys of different tenants never collide.
+func tenantKey(tenant, key string) string {
+	if tenant == "" {
+		return key
+	}
+
+	return "tenant:" + tenant + ":" + key
+}
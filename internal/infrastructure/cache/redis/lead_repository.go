@@ -0,0 +1,150 @@
+package redisinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+)
+
+// registerLeadScript saves lead unless lead first seen within window is
+// already saved, like SETNX does, but replaces leads seen before window.
+// Saved leads expire retention after they are first seen, so windows of
+// later calls don't shorten them.
+var registerLeadScript = redis.NewScript(`
+local now = tonumber(ARGV[2])
+local window = tonumber(ARGV[3])
+local retention = tonumber(ARGV[4])
+
+local seen = redis.call('HMGET', KEYS[1], 'source', 'first_seen')
+if seen[2] and (window == 0 or now - tonumber(seen[2]) < window) then
+  return {0, seen[1], tonumber(seen[2])}
+end
+
+redis.call('HSET', KEYS[1], 'source', ARGV[1], 'first_seen', ARGV[2])
+if retention > 0 then
+  redis.call('PEXPIRE', KEYS[1], retention)
+else
+  redis.call('PERSIST', KEYS[1])
+end
+
+return {1, ARGV[1], now}
+`)
+
+// pinPepperScript saves pepper version unless one is saved and returns saved
+// one. Pinned version expires retention after the last pin, so it outlives
+// leads of namespace.
+var pinPepperScript = redis.NewScript(`
+local retention = tonumber(ARGV[2])
+
+local version = redis.call('GET', KEYS[1])
+if not version then
+  version = ARGV[1]
+  redis.call('SET', KEYS[1], version)
+end
+
+if retention > 0 then
+  redis.call('PEXPIRE', KEYS[1], retention)
+end
+
+return version
+`)
+
+// LeadRepository represents Redis leads repository. Leads expire retention
+// after they are first seen, zero retention keeps them forever.
+type LeadRepository struct {
+	redisCli  *redis.Client
+	retention time.Duration
+}
+
+// NewLeadRepository creates new instance of Redis leads repository.
+func NewLeadRepository(redisCli *redis.Client, retention time.Duration) *LeadRepository {
+	return &LeadRepository{
+		redisCli:  redisCli,
+		retention: retention,
+	}
+}
+
+// Register saves lead unless lead with the same key was first seen within
+// window.
+func (r *LeadRepository) Register(ctx context.Context, tenant, namespace string, lead *dedup.Lead, window time.Duration) (*dedup.Lead, bool, error) {
+	res, err := registerLeadScript.Run(ctx, r.redisCli, []string{leadKey(tenant, namespace, lead.Key())},
+		lead.Source(), lead.FirstSeen().UnixMilli(), window.Milliseconds(), r.retention.Milliseconds()).Slice()
+	if err != nil {
+		return nil, false, fmt.Errorf("run register lead script: %w", err)
+	}
+
+	if len(res) != 3 {
+		return nil, false, fmt.Errorf("unexpected register lead script result %v", res)
+	}
+
+	registered, _ := res[0].(int64)
+	source, _ := res[1].(string)
+	firstSeen, _ := res[2].(int64)
+
+	return dedup.NewLead(lead.Key(), source, time.UnixMilli(firstSeen)), registered == 1, nil
+}
+
+// Find finds lead by key.
+func (r *LeadRepository) Find(ctx context.Context, tenant, namespace, key string) (*dedup.Lead, error) {
+	fields, err := r.redisCli.HMGet(ctx, leadKey(tenant, namespace, key), "source", "first_seen").Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, dedup.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get lead: %w", err)
+	}
+
+	source, _ := fields[0].(string)
+	ts, ok := fields[1].(string)
+	if !ok {
+		return nil, dedup.ErrNotFound
+	}
+
+	firstSeen, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse first seen time: %w", err)
+	}
+
+	return dedup.NewLead(key, source, time.UnixMilli(firstSeen)), nil
+}
+
+// PinPepperVersion saves server pepper version of namespace unless one is
+// already saved.
+func (r *LeadRepository) PinPepperVersion(ctx context.Context, tenant, namespace, version string) (string, error) {
+	pinned, err := pinPepperScript.Run(ctx, r.redisCli, []string{leadPepperKey(tenant, namespace)},
+		version, r.retention.Milliseconds()).Text()
+	if err != nil {
+		return "", fmt.Errorf("run pin pepper script: %w", err)
+	}
+
+	return pinned, nil
+}
+
+// PepperVersion finds server pepper version pinned by namespace.
+func (r *LeadRepository) PepperVersion(ctx context.Context, tenant, namespace string) (string, error) {
+	version, err := r.redisCli.Get(ctx, leadPepperKey(tenant, namespace)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", dedup.ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get pepper version: %w", err)
+	}
+
+	return version, nil
+}
+
+// leadKey builds lead key. Namespaced keys are prefixed with tenant name like
+// hash keys are.
+func leadKey(tenant, namespace, key string) string {
+	return tenantKey(tenant, fmt.Sprintf("dedup:%s:%s", namespace, key))
+}
+
+// leadPepperKey builds key of server pepper version pinned by namespace.
+func leadPepperKey(tenant, namespace string) string {
+	return tenantKey(tenant, fmt.Sprintf("dedup-pepper:%s", namespace))
+}
@@ -0,0 +1,127 @@
+package redisinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/placement"
+)
+
+// saveNodeSetScript saves node set record unless saved version differs from
+// expected one. Returns new version or -1 on conflict.
+var saveNodeSetScript = redis.NewScript(`
+local version = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if version ~= tonumber(ARGV[2]) then
+  return -1
+end
+
+redis.call('HSET', KEYS[1], 'set', ARGV[1], 'version', version + 1)
+return version + 1
+`)
+
+// NodeSetRepository represents Redis node sets repository. Node sets are
+// stored as JSON with version in hashes without expiration.
+type NodeSetRepository struct {
+	redisCli *redis.Client
+}
+
+// NewNodeSetRepository creates new instance of Redis node sets repository.
+func NewNodeSetRepository(redisCli *redis.Client) *NodeSetRepository {
+	return &NodeSetRepository{
+		redisCli: redisCli,
+	}
+}
+
+type nodeSetRecord struct {
+	Kind         string   `json:"kind"`
+	Nodes        []string `json:"nodes"`
+	VirtualNodes int      `json:"virtual_nodes,omitempty"`
+}
+
+// Save saves node set replacing saved one of given version.
+func (r *NodeSetRepository) Save(ctx context.Context, tenant string, set *placement.NodeSet, version uint64) (uint64, error) {
+	data, err := json.Marshal(nodeSetRecord{
+		Kind:         set.Kind().String(),
+		Nodes:        set.Nodes(),
+		VirtualNodes: set.VirtualNodes(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("marshal node set: %w", err)
+	}
+
+	saved, err := saveNodeSetScript.Run(ctx, r.redisCli, []string{nodeSetKey(tenant, set.Name())}, data, version).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("run save node set script: %w", err)
+	}
+	if saved < 0 {
+		return 0, placement.ErrConflict
+	}
+
+	return uint64(saved), nil
+}
+
+// Find finds node set by name with its version.
+func (r *NodeSetRepository) Find(ctx context.Context, tenant, name string) (*placement.NodeSet, uint64, error) {
+	fields, err := r.redisCli.HMGet(ctx, nodeSetKey(tenant, name), "set", "version").Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("get node set: %w", err)
+	}
+
+	data, ok := fields[0].(string)
+	if !ok {
+		return nil, 0, placement.ErrNotFound
+	}
+
+	version, err := parseNodeSetVersion(fields[1])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rec nodeSetRecord
+	if err := json.Unmarshal([]byte(data), &rec); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal node set: %w", err)
+	}
+
+	kind, err := placement.ParseKind(rec.Kind)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	set, err := placement.NewNodeSet(name, kind, rec.Nodes, rec.VirtualNodes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return set, version, nil
+}
+
+// Version finds version of node set by name.
+func (r *NodeSetRepository) Version(ctx context.Context, tenant, name string) (uint64, error) {
+	v, err := r.redisCli.HGet(ctx, nodeSetKey(tenant, name), "version").Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, placement.ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get node set version: %w", err)
+	}
+
+	return parseNodeSetVersion(v)
+}
+
+func parseNodeSetVersion(v any) (uint64, error) {
+	s, _ := v.(string)
+	version, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse node set version: %w", err)
+	}
+
+	return version, nil
+}
+
+func nodeSetKey(tenant, name string) string {
+	return tenantKey(tenant, "placement:"+name)
+}
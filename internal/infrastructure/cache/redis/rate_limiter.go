@@ -0,0 +1,68 @@
+package redisinfra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
+)
+
+// tokenBucketScript refills and takes a token atomically. Redis server time is
+// used, so replicas with skewed clocks share the same budget.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+
+return {allowed, wait}
+`)
+
+// RateLimiter represents Redis token bucket rate limiter shared by all
+// service replicas.
+type RateLimiter struct {
+	redisCli *redis.Client
+}
+
+// NewRateLimiter creates new instance of Redis rate limiter.
+func NewRateLimiter(redisCli *redis.Client) *RateLimiter {
+	return &RateLimiter{
+		redisCli: redisCli,
+	}
+}
+
+// Allow takes a token from bucket identified by key.
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	if limit.Unlimited() {
+		return ratelimit.Decision{Allowed: true}, nil
+	}
+
+	res, err := tokenBucketScript.Run(ctx, l.redisCli, []string{"ratelimit:" + key}, limit.Rate, limit.Capacity()).Int64Slice()
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("run token bucket script: %w", err)
+	}
+
+	return ratelimit.Decision{
+		Allowed:    res[0] == 1,
+		RetryAfter: time.Duration(res[1]) * time.Millisecond,
+	}, nil
+}
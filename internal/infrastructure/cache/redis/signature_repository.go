@@ -0,0 +1,116 @@
+package redisinfra
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+)
+
+// SignatureRepository represents Redis LSH index of signatures. Signatures
+// are stored by item id, every band is a set of ids of items with equal band.
+//
+// Re-indexed item stays in buckets of its previous bands. It's only a
+// spurious candidate, since similarity is estimated by the current signature.
+type SignatureRepository struct {
+	redisCli *redis.Client
+}
+
+// NewSignatureRepository creates new instance of Redis signatures repository.
+func NewSignatureRepository(redisCli *redis.Client) *SignatureRepository {
+	return &SignatureRepository{
+		redisCli: redisCli,
+	}
+}
+
+// Save saves item signature and adds item to buckets of its bands.
+func (r *SignatureRepository) Save(ctx context.Context, tenant, index, id string, sig similarity.Signature, bands []uint64) error {
+	_, err := r.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, signatureKey(tenant, index, id), encodeSignature(sig), 0)
+		for i, b := range bands {
+			pipe.SAdd(ctx, bandKey(tenant, index, i, b), id)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save signature: %w", err)
+	}
+
+	return nil
+}
+
+// Candidates returns signatures of items sharing at least one bucket with
+// given bands.
+func (r *SignatureRepository) Candidates(ctx context.Context, tenant, index string, bands []uint64) (map[string]similarity.Signature, error) {
+	keys := make([]string, len(bands))
+	for i, b := range bands {
+		keys[i] = bandKey(tenant, index, i, b)
+	}
+
+	ids, err := r.redisCli.SUnion(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("union bands: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sigKeys := make([]string, len(ids))
+	for i, id := range ids {
+		sigKeys[i] = signatureKey(tenant, index, id)
+	}
+
+	values, err := r.redisCli.MGet(ctx, sigKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get signatures: %w", err)
+	}
+
+	sigs := make(map[string]similarity.Signature, len(ids))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		sig, err := decodeSignature(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode %q signature: %w", ids[i], err)
+		}
+		sigs[ids[i]] = sig
+	}
+
+	return sigs, nil
+}
+
+func encodeSignature(sig similarity.Signature) []byte {
+	b := make([]byte, 0, len(sig)*8)
+	for _, c := range sig {
+		b = binary.BigEndian.AppendUint64(b, c)
+	}
+	return b
+}
+
+func decodeSignature(s string) (similarity.Signature, error) {
+	if len(s)%8 != 0 {
+		return nil, fmt.Errorf("signature size %d is not a multiple of 8", len(s))
+	}
+
+	sig := make(similarity.Signature, len(s)/8)
+	for i := range sig {
+		sig[i] = binary.BigEndian.Uint64([]byte(s[i*8 : (i+1)*8]))
+	}
+	return sig, nil
+}
+
+// signatureKey builds item signature key. Namespaced keys are prefixed with
+// tenant name like hash keys are.
+func signatureKey(tenant, index, id string) string {
+	return tenantKey(tenant, fmt.Sprintf("lsh:%s:sig:%s", index, id))
+}
+
+func bandKey(tenant, index string, band int, value uint64) string {
+	return tenantKey(tenant, fmt.Sprintf("lsh:%s:band:%d:%s", index, band, strconv.FormatUint(value, 16)))
+}
@@ -0,0 +1,51 @@
+package redisinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+)
+
+// TokenRepository represents Redis tokens repository.
+//
+// Sealed values are stored without expiration, tokens must stay reversible as
+// long as downstream systems keep them.
+type TokenRepository struct {
+	redisCli *redis.Client
+}
+
+// NewTokenRepository creates new instance of Redis tokens repository.
+func NewTokenRepository(redisCli *redis.Client) *TokenRepository {
+	return &TokenRepository{
+		redisCli: redisCli,
+	}
+}
+
+// Save saves sealed value of token unless token is already saved.
+func (r *TokenRepository) Save(ctx context.Context, tok string, sealed []byte) error {
+	if err := r.redisCli.SetNX(ctx, tokenKey(tok), sealed, 0).Err(); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	return nil
+}
+
+// Find finds sealed value of token.
+func (r *TokenRepository) Find(ctx context.Context, tok string) ([]byte, error) {
+	sealed, err := r.redisCli.Get(ctx, tokenKey(tok)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, token.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	return sealed, nil
+}
+
+func tokenKey(tok string) string {
+	return "token:" + tok
+}
@@ -0,0 +1,57 @@
+package redisinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// usageRetention is how long daily usage counters are kept.
+const usageRetention = 35 * 24 * time.Hour
+
+// UsageRepository represents Redis tenant usage counters repository.
+type UsageRepository struct {
+	redisCli *redis.Client
+}
+
+// NewUsageRepository creates new instance of Redis usage repository.
+func NewUsageRepository(redisCli *redis.Client) *UsageRepository {
+	return &UsageRepository{
+		redisCli: redisCli,
+	}
+}
+
+// Increment increments tenant counter for UTC day of provided time and returns
+// new value.
+func (r *UsageRepository) Increment(ctx context.Context, tenant string, at time.Time) (int64, error) {
+	key := usageKey(tenant, at)
+
+	pipe := r.redisCli.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, usageRetention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("increment usage: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// Usage returns tenant counter for UTC day of provided time.
+func (r *UsageRepository) Usage(ctx context.Context, tenant string, at time.Time) (int64, error) {
+	n, err := r.redisCli.Get(ctx, usageKey(tenant, at)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get usage: %w", err)
+	}
+
+	return n, nil
+}
+
+func usageKey(tenant string, at time.Time) string {
+	return tenantKey(tenant, "usage:"+at.UTC().Format(time.DateOnly))
+}
@@ -0,0 +1,2 @@
+// Package canonical provides canonicalization of structured documents.
+package canonical
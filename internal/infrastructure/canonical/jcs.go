@@ -0,0 +1,315 @@
+package canonical
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// maxDepth is a maximum nesting depth of documents.
+const maxDepth = 128
+
+// JCS is a JSON canonicalizer of RFC 8785 JSON Canonicalization Scheme.
+//
+// Object keys are sorted by UTF-16 code units, numbers are serialized as
+// ECMAScript does and insignificant whitespace is removed. Documents must be
+// I-JSON, so duplicate keys and numbers out of IEEE 754 double range are
+// rejected.
+type JCS struct{}
+
+// Canonicalize returns canonical form of JSON document with selected fields
+// only. Fields can be selected in objects only.
+func (*JCS) Canonicalize(doc string, fields hash.Fields) (string, error) {
+	if !utf8.ValidString(doc) {
+		return "", fmt.Errorf("%w: invalid UTF-8", hash.ErrMalformedDocument)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+
+	v, err := parse(dec, 0)
+	if err != nil {
+		return "", malformed(err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("%w: unexpected data after top-level value", hash.ErrMalformedDocument)
+	}
+
+	if !fields.Empty() {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%w: fields can be selected in object only", hash.ErrMalformedDocument)
+		}
+
+		if v, err = selectFields(obj, fields); err != nil {
+			return "", err
+		}
+	}
+
+	var sb strings.Builder
+	if err := write(&sb, v); err != nil {
+		return "", malformed(err)
+	}
+
+	return sb.String(), nil
+}
+
+func malformed(err error) error {
+	if errors.Is(err, hash.ErrMalformedDocument) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", hash.ErrMalformedDocument, err)
+}
+
+// parse parses JSON value. Objects are parsed to maps, arrays to slices and
+// numbers to json.Number.
+func parse(dec *json.Decoder, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting exceeds %d levels", maxDepth)
+	}
+
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := make(map[string]any)
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key := keyTok.(string)
+			if _, ok := obj[key]; ok {
+				return nil, fmt.Errorf("duplicate key %q", key)
+			}
+
+			if obj[key], err = parse(dec, depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := make([]any, 0)
+		for dec.More() {
+			v, err := parse(dec, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+
+		_, err = dec.Token()
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+// selectFields returns object with included fields without excluded ones.
+func selectFields(obj map[string]any, fields hash.Fields) (map[string]any, error) {
+	if len(fields.Include) > 0 {
+		selected := make(map[string]any)
+		for _, path := range fields.Include {
+			keys, err := hash.SplitFieldPath(path)
+			if err != nil {
+				return nil, fmt.Errorf("%w %q", err, path)
+			}
+			include(selected, obj, keys)
+		}
+		obj = selected
+	}
+
+	for _, path := range fields.Exclude {
+		keys, err := hash.SplitFieldPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", err, path)
+		}
+		exclude(obj, keys)
+	}
+
+	return obj, nil
+}
+
+// include copies field by keys from src to dst. Missing fields are skipped.
+func include(dst, src map[string]any, keys []string) {
+	v, ok := src[keys[0]]
+	if !ok {
+		return
+	}
+
+	if len(keys) == 1 {
+		dst[keys[0]] = v
+		return
+	}
+
+	child, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+
+	dstChild, ok := dst[keys[0]].(map[string]any)
+	if !ok {
+		dstChild = make(map[string]any)
+		dst[keys[0]] = dstChild
+	}
+
+	include(dstChild, child, keys[1:])
+}
+
+// exclude removes field by keys from obj.
+func exclude(obj map[string]any, keys []string) {
+	if len(keys) == 1 {
+		delete(obj, keys[0])
+		return
+	}
+
+	if child, ok := obj[keys[0]].(map[string]any); ok {
+		exclude(child, keys[1:])
+	}
+}
+
+func write(sb *strings.Builder, v any) error {
+	switch v := v.(type) {
+	case nil:
+		sb.WriteString("null")
+	case bool:
+		sb.WriteString(strconv.FormatBool(v))
+	case string:
+		writeString(sb, v)
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil || math.IsInf(f, 0) {
+			return fmt.Errorf("number %s is out of range", v)
+		}
+		sb.WriteString(formatNumber(f))
+	case []any:
+		sb.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if err := write(sb, e); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.SortFunc(keys, compareUTF16)
+
+		sb.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeString(sb, k)
+			sb.WriteByte(':')
+			if err := write(sb, v[k]); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected value %T", v)
+	}
+
+	return nil
+}
+
+// writeString writes string as ECMAScript JSON.stringify does: only quote,
+// backslash and control characters are escaped.
+func writeString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(sb, `\u%04x`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+}
+
+// formatNumber formats number as ECMAScript Number.prototype.toString does.
+func formatNumber(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+
+	// Shortest round-trip digits and exponent of d.ddde±x form.
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(e, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	n, _ := strconv.Atoi(exp)
+	n++
+	k := len(digits)
+
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits
+	}
+
+	s := sign + digits[:1]
+	if k > 1 {
+		s += "." + digits[1:]
+	}
+
+	if n-1 < 0 {
+		return s + "e-" + strconv.Itoa(1-n)
+	}
+	return s + "e+" + strconv.Itoa(n-1)
+}
+
+// compareUTF16 compares strings by UTF-16 code units.
+func compareUTF16(a, b string) int {
+	return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+}
@@ -0,0 +1,160 @@
+package canonical
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestJCS_Canonicalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		expect string
+	}{
+		{
+			name: "RFC 8785 example",
+			doc: `{
+				"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+				"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+				"literals": [null, true, false]
+			}`,
+			expect: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name: "RFC 8785 sorting",
+			doc: `{
+				"\u20ac": "Euro Sign",
+				"\r": "Carriage Return",
+				"\ufb33": "Hebrew Letter Dalet With Dagesh",
+				"1": "One",
+				"\ud83d\ude00": "Emoji: Grinning Face",
+				"\u0080": "Control",
+				"\u00f6": "Latin Small Letter O With Diaeresis"
+			}`,
+			expect: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			name:   "nested and html",
+			doc:    ` { "b" : [ {"y":1,"x":2} ], "a": "<&>\u2028" } `,
+			expect: "{\"a\":\"<&>\u2028\",\"b\":[{\"x\":2,\"y\":1}]}",
+		},
+		{"scalar", `"foo"`, `"foo"`},
+		{"empty containers", `{"a":{},"b":[]}`, `{"a":{},"b":[]}`},
+	}
+
+	c := &JCS{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Canonicalize(tt.doc, hash.Fields{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expect, got)
+			}
+		})
+	}
+}
+
+// TestFormatNumber checks samples of RFC 8785 appendix B.
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		bits   uint64
+		expect string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expect, func(t *testing.T) {
+			if got := formatNumber(math.Float64frombits(tt.bits)); got != tt.expect {
+				t.Errorf("formatNumber(%#016x) expected %q, got %q", tt.bits, tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestJCS_Fields(t *testing.T) {
+	const doc = `{"email":"a@b.c","phone":"1","address":{"zip":"123","city":"X"},"meta":{"ts":1},"tags":["a"]}`
+
+	tests := []struct {
+		name   string
+		fields hash.Fields
+		expect string
+	}{
+		{"include", hash.Fields{Include: []string{"phone", "email", "missing"}}, `{"email":"a@b.c","phone":"1"}`},
+		{"include nested", hash.Fields{Include: []string{"address.zip", "tags.0"}}, `{"address":{"zip":"123"}}`},
+		{"include overlapping", hash.Fields{Include: []string{"address.zip", "address"}}, `{"address":{"city":"X","zip":"123"}}`},
+		{"exclude", hash.Fields{Exclude: []string{"meta", "address.city"}}, `{"address":{"zip":"123"},"email":"a@b.c","phone":"1","tags":["a"]}`},
+		{"include and exclude", hash.Fields{Include: []string{"email", "address"}, Exclude: []string{"address.city"}}, `{"address":{"zip":"123"},"email":"a@b.c"}`},
+	}
+
+	c := &JCS{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Canonicalize(doc, tt.fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %s, got %s", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestJCS_Malformed(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       string
+		fields    hash.Fields
+		expectErr error
+	}{
+		{"empty", "", hash.Fields{}, hash.ErrMalformedDocument},
+		{"syntax", `{"a":}`, hash.Fields{}, hash.ErrMalformedDocument},
+		{"unterminated", `{"a":1`, hash.Fields{}, hash.ErrMalformedDocument},
+		{"trailing data", `{"a":1} {}`, hash.Fields{}, hash.ErrMalformedDocument},
+		{"duplicate key", `{"a":1,"a":2}`, hash.Fields{}, hash.ErrMalformedDocument},
+		{"number out of range", `[1e999]`, hash.Fields{}, hash.ErrMalformedDocument},
+		{"invalid UTF-8", "\"\xff\"", hash.Fields{}, hash.ErrMalformedDocument},
+		{"too deep", strings.Repeat("[", maxDepth+2) + strings.Repeat("]", maxDepth+2), hash.Fields{}, hash.ErrMalformedDocument},
+		{"fields of array", `[1]`, hash.Fields{Include: []string{"a"}}, hash.ErrMalformedDocument},
+		{"invalid path", `{"a":1}`, hash.Fields{Exclude: []string{"a..b"}}, hash.ErrInvalidFieldPath},
+	}
+
+	c := &JCS{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Canonicalize(tt.doc, tt.fields); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Package certs provides TLS certificates loading and hot reloading.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNoClientCA is returned when client CA file has no PEM certificates.
+var ErrNoClientCA = errors.New("no certificates found in client CA file")
+
+// Reloader keeps server certificate and client CA pool loaded from disk.
+//
+// Files are checked for modification at most once per interval during TLS
+// handshakes, so rotated certificates are picked up without restart. If
+// reload fails previously loaded certificates stay in use.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+	log          *slog.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewReloader creates new instance of certificates reloader and loads
+// certificates for the first time. Client CA file is optional.
+func NewReloader(certFile, keyFile, clientCAFile string, interval time.Duration, log *slog.Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		interval:     interval,
+		log:          log,
+	}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns server TLS configuration backed by reloader.
+//
+// Client certificates are verified against client CA pool when client CA file
+// is set. If requireClientCert is true, clients without certificate are
+// rejected.
+func (r *Reloader) TLSConfig(minVersion uint16, requireClientCert bool) *tls.Config {
+	clientAuth := tls.NoClientCert
+	if r.clientCAFile != "" {
+		clientAuth = tls.VerifyClientCertIfGiven
+		if requireClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.reloadIfModified()
+
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return &tls.Config{
+				MinVersion:   minVersion,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.clientCAs,
+				ClientAuth:   clientAuth,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+func (r *Reloader) reloadIfModified() {
+	r.mu.Lock()
+	if time.Since(r.checkedAt) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.checkedAt = time.Now()
+	loaded := r.modTime
+	r.mu.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		r.log.Error("failed to stat certificates", slog.String("error", err.Error()))
+		return
+	}
+
+	if !modTime.After(loaded) {
+		return
+	}
+
+	if err := r.load(modTime); err != nil {
+		r.log.Error("failed to reload certificates", slog.String("error", err.Error()))
+		return
+	}
+
+	r.log.Info("certificates reloaded", slog.String("cert", r.certFile))
+}
+
+func (r *Reloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return ErrNoClientCA
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.modTime = modTime
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if f == "" {
+			continue
+		}
+
+		fi, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %q: %w", f, err)
+		}
+
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// ParseVersion parses TLS version string like "1.2" or "1.3". Empty string
+// means TLS 1.2.
+func ParseVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", v)
+	}
+}
@@ -0,0 +1,154 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloader_TLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	writeCert(t, certFile, keyFile, 1, time.Now().Add(-time.Hour))
+	writeCert(t, caFile, filepath.Join(dir, "ca.key"), 100, time.Now().Add(-time.Hour))
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r, err := NewReloader(certFile, keyFile, caFile, 0, log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := r.TLSConfig(tls.VersionTLS13, true)
+	if got := serial(t, cfg); got != 1 {
+		t.Errorf("expected serial 1, got %d", got)
+	}
+
+	srvCfg, _ := cfg.GetConfigForClient(nil)
+	if srvCfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("expected client cert to be required, got %v", srvCfg.ClientAuth)
+	}
+
+	if srvCfg.ClientCAs == nil {
+		t.Error("expected client CA pool")
+	}
+
+	writeCert(t, certFile, keyFile, 2, time.Now().Add(time.Hour))
+	if got := serial(t, cfg); got != 2 {
+		t.Errorf("expected reloaded serial 2, got %d", got)
+	}
+
+	if err := os.WriteFile(certFile, []byte("broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(certFile, time.Now().Add(2*time.Hour), time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got := serial(t, cfg); got != 2 {
+		t.Errorf("expected previous serial 2 kept after failed reload, got %d", got)
+	}
+}
+
+func TestNewReloader_MissingFile(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if _, err := NewReloader("missing.crt", "missing.key", "", time.Minute, log); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in        string
+		expect    uint16
+		expectErr bool
+	}{
+		{"", tls.VersionTLS12, false},
+		{"1.2", tls.VersionTLS12, false},
+		{"1.3", tls.VersionTLS13, false},
+		{"1.0", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if (err != nil) != tt.expectErr {
+			t.Errorf("ParseVersion(%q) expected error: %v, got: %v", tt.in, tt.expectErr, err)
+			continue
+		}
+
+		if got != tt.expect {
+			t.Errorf("ParseVersion(%q) expect %d, got %d", tt.in, tt.expect, got)
+		}
+	}
+}
+
+func serial(t *testing.T, cfg *tls.Config) int64 {
+	t.Helper()
+
+	srvCfg, err := cfg.GetConfigForClient(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(srvCfg.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	return leaf.SerialNumber.Int64()
+}
+
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "hasher"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package checksum provides checksum manifests of files compatible with
+// coreutils sha256sum and md5sum.
+package checksum
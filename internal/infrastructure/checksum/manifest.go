@@ -0,0 +1,179 @@
+package checksum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+)
+
+// ErrMalformedLine is returned when manifest line is neither GNU nor BSD tag
+// formatted.
+var ErrMalformedLine = errors.New("improperly formatted checksum line")
+
+// tags are BSD tag names of algorithms.
+var tags = map[hash.Algorithm]string{
+	hash.AlgorithmMD5:    "MD5",
+	hash.AlgorithmSHA256: "SHA256",
+}
+
+// Format represents manifest line format.
+type Format int8
+
+// Supported manifest formats.
+const (
+	// FormatGNU is "<checksum>  <path>" as sha256sum writes.
+	FormatGNU Format = iota + 1
+	// FormatTag is "SHA256 (<path>) = <checksum>" as sha256sum --tag and BSD
+	// tools write.
+	FormatTag
+)
+
+// Entry represents manifest line: hex-encoded checksum of file content.
+type Entry struct {
+	Path      string
+	Algorithm hash.Algorithm
+	Checksum  string
+}
+
+// FormatLine formats entry as manifest line without trailing newline. Paths
+// with backslashes or line breaks are escaped and the line is prefixed with
+// backslash like coreutils do.
+func FormatLine(e Entry, f Format) string {
+	path, escaped := escape(e.Path)
+
+	var prefix string
+	if escaped {
+		prefix = `\`
+	}
+
+	if f == FormatTag {
+		return fmt.Sprintf("%s%s (%s) = %s", prefix, tags[e.Algorithm], path, e.Checksum)
+	}
+
+	return fmt.Sprintf("%s%s  %s", prefix, e.Checksum, path)
+}
+
+// ParseLine parses manifest line of either format. GNU lines don't name
+// algorithm, so alg is assumed for them. Checksum is lower-cased.
+func ParseLine(line string, alg hash.Algorithm) (Entry, error) {
+	line = strings.TrimSuffix(line, "\r")
+
+	escaped := strings.HasPrefix(line, `\`)
+	if escaped {
+		line = line[1:]
+	}
+
+	e, ok := parseTag(line)
+	if !ok {
+		e, ok = parseGNU(line, alg)
+	}
+	if !ok || e.Path == "" {
+		return Entry{}, ErrMalformedLine
+	}
+
+	if escaped {
+		if e.Path, ok = unescape(e.Path); !ok {
+			return Entry{}, ErrMalformedLine
+		}
+	}
+
+	return e, nil
+}
+
+func parseTag(line string) (Entry, bool) {
+	for alg, tag := range tags {
+		rest, ok := strings.CutPrefix(line, tag+" (")
+		if !ok {
+			continue
+		}
+
+		i := strings.LastIndex(rest, ") = ")
+		if i < 0 {
+			return Entry{}, false
+		}
+
+		sum := strings.ToLower(rest[i+len(") = "):])
+		if !isChecksum(alg, sum) {
+			return Entry{}, false
+		}
+
+		return Entry{Path: rest[:i], Algorithm: alg, Checksum: sum}, true
+	}
+
+	return Entry{}, false
+}
+
+func parseGNU(line string, alg hash.Algorithm) (Entry, bool) {
+	sum, path, ok := strings.Cut(line, " ")
+	if !ok {
+		return Entry{}, false
+	}
+
+	// Second separator character is mode: space for text and asterisk for
+	// binary, which are the same on POSIX systems.
+	if path == "" || (path[0] != ' ' && path[0] != '*') {
+		return Entry{}, false
+	}
+
+	sum = strings.ToLower(sum)
+	if !isChecksum(alg, sum) {
+		return Entry{}, false
+	}
+
+	return Entry{Path: path[1:], Algorithm: alg, Checksum: sum}, true
+}
+
+func isChecksum(alg hash.Algorithm, sum string) bool {
+	newHash, ok := hasher.Functions()[alg]
+	if !ok || len(sum) != newHash().Size()*2 {
+		return false
+	}
+
+	for _, c := range sum {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func escape(path string) (string, bool) {
+	if !strings.ContainsAny(path, "\\\n\r") {
+		return path, false
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+
+	return r.Replace(path), true
+}
+
+func unescape(path string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] != '\\' {
+			b.WriteByte(path[i])
+			continue
+		}
+
+		if i++; i == len(path) {
+			return "", false
+		}
+
+		switch path[i] {
+		case '\\':
+			b.WriteByte('\\')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			return "", false
+		}
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,148 @@
+package checksum
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+const (
+	helloMD5    = "5d41402abc4b2a76b9719d911017c592"
+	helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+)
+
+func TestFormatLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  Entry
+		format Format
+		expect string
+	}{
+		{
+			name:   "gnu",
+			entry:  Entry{Path: "leads/a.csv", Algorithm: hash.AlgorithmSHA256, Checksum: helloSHA256},
+			format: FormatGNU,
+			expect: helloSHA256 + "  leads/a.csv",
+		},
+		{
+			name:   "tag",
+			entry:  Entry{Path: "leads/a.csv", Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+			format: FormatTag,
+			expect: "MD5 (leads/a.csv) = " + helloMD5,
+		},
+		{
+			name:   "escaped gnu",
+			entry:  Entry{Path: "a\\b\nc", Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+			format: FormatGNU,
+			expect: `\` + helloMD5 + `  a\\b\nc`,
+		},
+		{
+			name:   "escaped tag",
+			entry:  Entry{Path: "a\nb", Algorithm: hash.AlgorithmSHA256, Checksum: helloSHA256},
+			format: FormatTag,
+			expect: `\SHA256 (a\nb) = ` + helloSHA256,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatLine(tt.entry, tt.format)
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+
+			parsed, err := ParseLine(got, tt.entry.Algorithm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parsed != tt.entry {
+				t.Errorf("expected %+v parsed back, got %+v", tt.entry, parsed)
+			}
+		})
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		alg         hash.Algorithm
+		expect      Entry
+		expectedErr error
+	}{
+		{
+			name:   "binary mode",
+			line:   helloMD5 + " *a.csv",
+			alg:    hash.AlgorithmMD5,
+			expect: Entry{Path: "a.csv", Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+		},
+		{
+			name:   "upper case checksum and CRLF",
+			line:   strings.ToUpper(helloSHA256) + "  a.csv\r",
+			alg:    hash.AlgorithmSHA256,
+			expect: Entry{Path: "a.csv", Algorithm: hash.AlgorithmSHA256, Checksum: helloSHA256},
+		},
+		{
+			name:   "path with spaces",
+			line:   helloMD5 + "  my leads.csv",
+			alg:    hash.AlgorithmMD5,
+			expect: Entry{Path: "my leads.csv", Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+		},
+		{
+			name:   "tag names algorithm",
+			line:   "MD5 (x (1).csv) = " + helloMD5,
+			alg:    hash.AlgorithmSHA256,
+			expect: Entry{Path: "x (1).csv", Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+		},
+		{
+			name:        "checksum of other algorithm",
+			line:        helloMD5 + "  a.csv",
+			alg:         hash.AlgorithmSHA256,
+			expectedErr: ErrMalformedLine,
+		},
+		{
+			name:        "single space",
+			line:        helloMD5 + " a.csv",
+			alg:         hash.AlgorithmMD5,
+			expectedErr: ErrMalformedLine,
+		},
+		{
+			name:        "no path",
+			line:        helloMD5 + "  ",
+			alg:         hash.AlgorithmMD5,
+			expectedErr: ErrMalformedLine,
+		},
+		{
+			name:        "not hex",
+			line:        strings.Repeat("z", 32) + "  a.csv",
+			alg:         hash.AlgorithmMD5,
+			expectedErr: ErrMalformedLine,
+		},
+		{
+			name:        "invalid escape",
+			line:        `\` + helloMD5 + `  a\tb`,
+			alg:         hash.AlgorithmMD5,
+			expectedErr: ErrMalformedLine,
+		},
+		{
+			name:        "empty line",
+			alg:         hash.AlgorithmMD5,
+			expectedErr: ErrMalformedLine,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLine(tt.line, tt.alg)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if got != tt.expect {
+				t.Errorf("expected %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package checksum
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+)
+
+// Result represents checksum of file or error reading it.
+type Result struct {
+	Entry
+	Err error
+}
+
+// Files returns files of paths. Directories are walked recursively in lexical
+// order, symbolic links to files are included and other special files are
+// skipped.
+func Files(paths []string) ([]string, error) {
+	var files []string
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.Type().IsRegular() {
+				files = append(files, path)
+				return nil
+			}
+
+			if d.Type()&fs.ModeSymlink != 0 {
+				if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() {
+					files = append(files, path)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %q: %w", root, err)
+		}
+	}
+
+	return files, nil
+}
+
+// Sum computes checksums of files with workers in parallel and calls fn for
+// every file in order of files. Zero workers means number of CPUs. Unreadable
+// files are reported to fn, error of fn stops summing.
+func Sum(ctx context.Context, alg hash.Algorithm, files []string, workers int, fn func(Result) error) error {
+	if _, ok := hasher.Functions()[alg]; !ok {
+		return fmt.Errorf("%w %q", hash.ErrUnsupportedAlgorithm, alg)
+	}
+
+	return ordered(ctx, len(files), workers, func(i int) Result {
+		sum, err := sumFile(alg, files[i])
+		return Result{
+			Entry: Entry{Path: files[i], Algorithm: alg, Checksum: sum},
+			Err:   err,
+		}
+	}, fn)
+}
+
+// Status represents result of checksum verification.
+type Status int8
+
+// Verification statuses.
+const (
+	StatusOK Status = iota + 1
+	StatusFailed
+	StatusMissing
+)
+
+// String strings status numeric constant as sha256sum --check reports it.
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "OK"
+	case StatusFailed:
+		return "FAILED"
+	case StatusMissing:
+		return "FAILED open or read"
+	default:
+		return ""
+	}
+}
+
+// CheckResult represents verification result of manifest entry. Err is set
+// for missing or unreadable files.
+type CheckResult struct {
+	Entry
+	Status Status
+	Err    error
+}
+
+// String formats result as sha256sum --check reports it. Paths are escaped
+// like in manifest lines.
+func (r CheckResult) String() string {
+	path, escaped := escape(r.Path)
+	if escaped {
+		path = `\` + path
+	}
+
+	return path + ": " + r.Status.String()
+}
+
+// Check verifies checksums of manifest entries with workers in parallel and
+// calls fn for every entry in order of entries. Zero workers means number of
+// CPUs. Error of fn stops checking.
+func Check(ctx context.Context, entries []Entry, workers int, fn func(CheckResult) error) error {
+	return ordered(ctx, len(entries), workers, func(i int) CheckResult {
+		e := entries[i]
+
+		sum, err := sumFile(e.Algorithm, e.Path)
+		switch {
+		case err != nil:
+			return CheckResult{Entry: e, Status: StatusMissing, Err: err}
+		case sum != e.Checksum:
+			return CheckResult{Entry: e, Status: StatusFailed}
+		default:
+			return CheckResult{Entry: e, Status: StatusOK}
+		}
+	}, fn)
+}
+
+func sumFile(alg hash.Algorithm, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	sum, err := hasher.Sum(alg, f)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	return sum, nil
+}
+
+// ordered runs work for indexes below n with workers in parallel and calls
+// emit with results in order of indexes.
+func ordered[T any](ctx context.Context, n, workers int, work func(i int) T, emit func(T) error) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	results := make([]chan T, n)
+	for i := range results {
+		results[i] = make(chan T, 1)
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range n {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] <- work(i)
+			}
+		}()
+	}
+
+	err := func() error {
+		for _, ch := range results {
+			select {
+			case r := <-ch:
+				if err := emit(r); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}()
+
+	cancel()
+	wg.Wait()
+
+	return err
+}
@@ -0,0 +1,112 @@
+package checksum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestSum(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "b", "hello.csv"), "hello")
+	writeFile(t, filepath.Join(dir, "a.csv"), "")
+	for i := range 20 {
+		writeFile(t, filepath.Join(dir, "c", fmt.Sprintf("%02d.csv", i)), "hello")
+	}
+	if err := os.Symlink(filepath.Join(dir, "a.csv"), filepath.Join(dir, "link.csv")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Files([]string{dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 23 || files[0] != filepath.Join(dir, "a.csv") || files[1] != filepath.Join(dir, "b", "hello.csv") {
+		t.Fatalf("expected files in lexical order, got %v", files)
+	}
+
+	var got []Result
+	err = Sum(context.Background(), hash.AlgorithmMD5, files, 4, func(r Result) error {
+		got = append(got, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, r := range got {
+		if r.Path != files[i] || r.Err != nil {
+			t.Fatalf("expected result of %q, got %+v", files[i], r)
+		}
+	}
+	if got[1].Checksum != helloMD5 || got[0].Checksum != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("unexpected checksums %q and %q", got[0].Checksum, got[1].Checksum)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = Sum(context.Background(), hash.AlgorithmMD5, files, 4, func(Result) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected summing to stop after %d calls, got %v", calls, err)
+	}
+
+	if err := Sum(context.Background(), hash.Algorithm(42), files, 0, nil); !errors.Is(err, hash.ErrUnsupportedAlgorithm) {
+		t.Errorf("expected error %v, got %v", hash.ErrUnsupportedAlgorithm, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	ok := filepath.Join(dir, "ok.csv")
+	changed := filepath.Join(dir, "changed.csv")
+	writeFile(t, ok, "hello")
+	writeFile(t, changed, "hello!")
+
+	entries := []Entry{
+		{Path: ok, Algorithm: hash.AlgorithmSHA256, Checksum: helloSHA256},
+		{Path: changed, Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+		{Path: filepath.Join(dir, "missing.csv"), Algorithm: hash.AlgorithmMD5, Checksum: helloMD5},
+	}
+
+	var statuses []Status
+	err := Check(context.Background(), entries, 0, func(r CheckResult) error {
+		statuses = append(statuses, r.Status)
+		if (r.Err != nil) != (r.Status == StatusMissing) {
+			t.Errorf("unexpected error %v of %s file", r.Err, r.Status)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Status{StatusOK, StatusFailed, StatusMissing}
+	if !slices.Equal(statuses, expected) {
+		t.Errorf("expected statuses %v, got %v", expected, statuses)
+	}
+
+	r := CheckResult{Entry: Entry{Path: "a\\nb"}, Status: StatusFailed}
+	if got, expect := r.String(), `\a\\nb: FAILED`; got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 type Config struct {
 	GRPC struct {
 		Port int `koanf:"port"`
+		TLS  TLS `koanf:"tls"`
 	} `koanf:"grpc"`
 	Redis struct {
 		Host     string        `koanf:"host"`
@@ -25,8 +26,297 @@ type Config struct {
 		Password string        `koanf:"password"` // FIXME: replace with Vault-readed value.
 		TTL      time.Duration `koanf:"ttl"`
 	} `koanf:"redis"`
+	Auth struct {
+		APIKeys []APIKey `koanf:"apikeys"`
+	} `koanf:"auth"`
+	RateLimit  RateLimit `koanf:"ratelimit"`
+	Tenants    []Tenant  `koanf:"tenants"`
+	Recipes    []Recipe  `koanf:"recipes"`
+	Jobs       Jobs      `koanf:"jobs"`
+	Pepper     Pepper    `koanf:"pepper"`
+	Vault      Vault     `koanf:"vault"`
+	FPE        FPE       `koanf:"fpe"`
+	KDF        KDF       `koanf:"kdf"`
+	TransLog   TransLog  `koanf:"translog"`
+	Receipts   Receipts  `koanf:"receipts"`
+	Similarity struct {
+		Indexes []SimilarityIndex `koanf:"indexes"`
+	} `koanf:"similarity"`
+	Dedup       Dedup        `koanf:"dedup"`
+	Filters     Filters      `koanf:"filters"`
+	Counters    Counters     `koanf:"counters"`
+	Experiments []Experiment `koanf:"experiments"`
+	Breach      struct {
+		Dir string `koanf:"dir"`
+	} `koanf:"breach"`
 }
 
+// TLS represents gRPC listener TLS configuration.
+//
+// Client certificates are verified only when ClientCAFile is set.
+type TLS struct {
+	Enabled           bool          `koanf:"enabled"`
+	CertFile          string        `koanf:"certfile"`
+	KeyFile           string        `koanf:"keyfile"`
+	ClientCAFile      string        `koanf:"clientcafile"`
+	RequireClientCert bool          `koanf:"requireclientcert"`
+	MinVersion        string        `koanf:"minversion"`
+	ReloadInterval    time.Duration `koanf:"reloadinterval"`
+}
+
+// APIKey binds API key passed in "x-api-key" metadata to a client name.
+type APIKey struct {
+	Key    string `koanf:"key"`
+	Client string `koanf:"client"`
+}
+
+// RateLimit represents per-client rate limiting configuration.
+//
+// Clients are matched by caller subject: API key client name, client
+// certificate subject or peer IP. Distributed limits are stored in Redis and
+// shared between replicas.
+type RateLimit struct {
+	Enabled     bool          `koanf:"enabled"`
+	Distributed bool          `koanf:"distributed"`
+	Rate        float64       `koanf:"rate"`
+	Burst       int           `koanf:"burst"`
+	Methods     []MethodLimit `koanf:"methods"`
+	Clients     []ClientLimit `koanf:"clients"`
+}
+
+// MethodLimit represents token bucket limit for a full RPC method name.
+type MethodLimit struct {
+	Method string  `koanf:"method"`
+	Rate   float64 `koanf:"rate"`
+	Burst  int     `koanf:"burst"`
+}
+
+// ClientLimit represents token bucket limits of a single client.
+type ClientLimit struct {
+	Client  string        `koanf:"client"`
+	Rate    float64       `koanf:"rate"`
+	Burst   int           `koanf:"burst"`
+	Methods []MethodLimit `koanf:"methods"`
+}
+
+// Tenant represents business unit configuration. Clients are caller subjects
+// bound to tenant, see RateLimit for subject matching.
+type Tenant struct {
+	Name          string        `koanf:"name"`
+	Clients       []string      `koanf:"clients"`
+	Algorithm     string        `koanf:"algorithm"`
+	Normalization string        `koanf:"normalization"`
+	Pepper        string        `koanf:"pepper"`
+	TTL           time.Duration `koanf:"ttl"`
+	DailyQuota    int64         `koanf:"dailyquota"`
+}
+
+// Recipe represents composite key recipe of records hashing.
+//
+// Encoding is "separator" or "length-prefix", the latter is used if it's
+// empty. Empty algorithm means caller tenant default.
+type Recipe struct {
+	Name      string        `koanf:"name"`
+	Fields    []RecipeField `koanf:"fields"`
+	Encoding  string        `koanf:"encoding"`
+	Separator string        `koanf:"separator"`
+	Algorithm string        `koanf:"algorithm"`
+}
+
+// RecipeField represents a single field of composite key. Empty
+// normalization means none.
+type RecipeField struct {
+	Name          string `koanf:"name"`
+	Normalization string `koanf:"normalization"`
+	Optional      bool   `koanf:"optional"`
+}
+
+// Jobs represents asynchronous hash jobs configuration.
+//
+// Job state, rows and results are stored in Redis or, if Store is "disk", in
+// Dir on local disk. Finished jobs are removed after Retention. Job is
+// processed by the replica holding its lease, which expires LeaseTTL after
+// the last renewal.
+type Jobs struct {
+	Store       string        `koanf:"store"`
+	Dir         string        `koanf:"dir"`
+	Retention   time.Duration `koanf:"retention"`
+	Workers     int           `koanf:"workers"`
+	Concurrency int           `koanf:"concurrency"`
+	ChunkSize   int           `koanf:"chunksize"`
+	MaxRows     int64         `koanf:"maxrows"`
+	LeaseTTL    time.Duration `koanf:"leasettl"`
+}
+
+// Pepper represents server pepper configuration.
+//
+// File contains "version=secret" line per pepper. Version is a current pepper
+// version, empty one means the last in file. Server pepper is disabled if File
+// is empty.
+type Pepper struct {
+	File    string `koanf:"file"`
+	Version string `koanf:"version"`
+}
+
+// Vault represents tokenization configuration.
+//
+// KeyFile contains hex-encoded 32 bytes master key. Detokenizers are callers
+// permitted to detokenize as "apikey:<client>" or "certificate:<subject>"
+// entries. Tokenization is disabled if KeyFile is empty.
+type Vault struct {
+	KeyFile      string   `koanf:"keyfile"`
+	Detokenizers []string `koanf:"detokenizers"`
+}
+
+// KDF represents key derivation configuration.
+//
+// KeyFile contains hex-encoded master key of at least 32 bytes HKDF derives
+// keys from. Derivers are callers permitted to derive keys, see Vault for
+// entries format. Key derivation is disabled if KeyFile is empty.
+type KDF struct {
+	KeyFile  string   `koanf:"keyfile"`
+	Derivers []string `koanf:"derivers"`
+}
+
+// FPE represents format-preserving encryption configuration.
+//
+// Decrypters are callers permitted to decrypt, see Vault for entries format.
+// Encryption is disabled if there are no Schemes.
+type FPE struct {
+	Schemes    []FPEScheme `koanf:"schemes"`
+	Decrypters []string    `koanf:"decrypters"`
+}
+
+// FPEScheme represents named format-preserving encryption scheme.
+//
+// Mode is "ff1" or "ff3-1". Values are encrypted over Alphabet characters or,
+// if it's empty, over first Radix of digits and latin letters, 10 by default.
+// KeyFile contains hex-encoded AES key, Tweak is hex-encoded default tweak.
+type FPEScheme struct {
+	Name     string `koanf:"name"`
+	Mode     string `koanf:"mode"`
+	Alphabet string `koanf:"alphabet"`
+	Radix    int    `koanf:"radix"`
+	KeyFile  string `koanf:"keyfile"`
+	Tweak    string `koanf:"tweak"`
+}
+
+// TransLog represents transparency log of issued hashes configuration.
+//
+// Log is stored in Dir, tree heads are signed every SignInterval by Ed25519
+// key in PEM KeyFile. Tenants select logged tenants, empty list means every
+// tenant. Log is disabled if Dir is empty.
+type TransLog struct {
+	Dir          string        `koanf:"dir"`
+	KeyFile      string        `koanf:"keyfile"`
+	Tenants      []string      `koanf:"tenants"`
+	SignInterval time.Duration `koanf:"signinterval"`
+}
+
+// Receipts represents signed hash receipts configuration.
+//
+// KeyFile contains Ed25519 private key in PEM. PreviousKeys are PEM public
+// key files of rotated keys, they are published to verify receipts issued
+// before rotation. Receipts are disabled if KeyFile is empty.
+type Receipts struct {
+	KeyFile      string   `koanf:"keyfile"`
+	PreviousKeys []string `koanf:"previouskeys"`
+}
+
+// SimilarityIndex represents named LSH index of near-duplicate texts.
+//
+// Algorithm is "minhash" or "simhash". MinHash signature of Permutations
+// components, 128 by default, is split into Bands, 32 by default. SimHash
+// fingerprint is split into Bands dividing 64, 4 by default. Texts are split
+// into character shingles of Shingle size, 3 by default, or into words if
+// Words is set.
+type SimilarityIndex struct {
+	Name         string `koanf:"name"`
+	Algorithm    string `koanf:"algorithm"`
+	Permutations int    `koanf:"permutations"`
+	Bands        int    `koanf:"bands"`
+	Shingle      int    `koanf:"shingle"`
+	Words        bool   `koanf:"words"`
+}
+
+// Dedup represents leads deduplication configuration.
+//
+// Leads are stored in Redis or, if Store is "memory", in-process. Leads are
+// removed Retention after they were first seen whatever window they were
+// registered with, zero Retention keeps them forever.
+type Dedup struct {
+	Store     string        `koanf:"store"`
+	Retention time.Duration `koanf:"retention"`
+}
+
+// Filters represents membership filters configuration.
+//
+// Filters are stored in Redis or, if Store is "disk", in Dir on local disk.
+type Filters struct {
+	Store string `koanf:"store"`
+	Dir   string `koanf:"dir"`
+}
+
+// Counters represents unique counters configuration.
+//
+// Digests are counted in Bucket long time buckets in Redis HyperLogLogs or,
+// if Store is "memory", in-process. Buckets are removed Retention after the
+// last addition, zero Retention keeps them forever.
+type Counters struct {
+	Store     string        `koanf:"store"`
+	Bucket    time.Duration `koanf:"bucket"`
+	Retention time.Duration `koanf:"retention"`
+}
+
+// Experiment represents experiment units are assigned to variants of.
+//
+// Units are hashed with Salt by Algorithm, "sha256" if it's empty. Traffic
+// is a share of units in experiment, 1 by default. Experiments of the same
+// Layer are mutually exclusive, every one takes Traffic share of layer units
+// starting at Offset. Holdout is a share of experiment units held out of
+// every variant.
+type Experiment struct {
+	Name      string              `koanf:"name"`
+	Salt      string              `koanf:"salt"`
+	Algorithm string              `koanf:"algorithm"`
+	Layer     string              `koanf:"layer"`
+	Offset    float64             `koanf:"offset"`
+	Traffic   float64             `koanf:"traffic"`
+	Holdout   float64             `koanf:"holdout"`
+	Variants  []ExperimentVariant `koanf:"variants"`
+}
+
+// ExperimentVariant represents experiment variant, units are allocated to
+// variants proportionally to Weight.
+type ExperimentVariant struct {
+	Name   string `koanf:"name"`
+	Weight uint32 `koanf:"weight"`
+}
+
+// Job stores.
+const (
+	JobStoreRedis = "redis"
+	JobStoreDisk  = "disk"
+)
+
+// Lead stores.
+const (
+	LeadStoreRedis  = "redis"
+	LeadStoreMemory = "memory"
+)
+
+// Filter stores.
+const (
+	FilterStoreRedis = "redis"
+	FilterStoreDisk  = "disk"
+)
+
+// Counter stores.
+const (
+	CounterStoreRedis  = "redis"
+	CounterStoreMemory = "memory"
+)
+
 // New creates new instance of config with default values.
 //
 // Depends on application mode parses different config files.
@@ -72,9 +362,29 @@ const (
 
 func (c *Config) loadDefaults() {
 	c.GRPC.Port = 6969
+	c.GRPC.TLS.MinVersion = "1.2"
+	c.GRPC.TLS.ReloadInterval = time.Minute
 	c.Redis.Host = "127.0.0.1"
 	c.Redis.Port = 6379
 	c.Redis.TTL = 5 * time.Minute
 	c.Redis.Username = "default"
 	c.Redis.Password = "1234qwerASDF"
+	c.RateLimit.Rate = 100
+	c.RateLimit.Burst = 200
+	c.Jobs.Store = JobStoreRedis
+	c.Jobs.Dir = "data/jobs"
+	c.Jobs.Retention = 7 * 24 * time.Hour
+	c.Jobs.Workers = 2
+	c.Jobs.Concurrency = 8
+	c.Jobs.ChunkSize = 500
+	c.Jobs.MaxRows = 50_000_000
+	c.Jobs.LeaseTTL = 30 * time.Second
+	c.TransLog.SignInterval = time.Minute
+	c.Dedup.Store = LeadStoreRedis
+	c.Dedup.Retention = 90 * 24 * time.Hour
+	c.Filters.Store = FilterStoreRedis
+	c.Filters.Dir = "data/filters"
+	c.Counters.Store = CounterStoreRedis
+	c.Counters.Bucket = 24 * time.Hour
+	c.Counters.Retention = 90 * 24 * time.Hour
 }
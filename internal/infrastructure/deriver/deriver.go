@@ -0,0 +1,94 @@
+package deriver
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"encoding/hex"
+	"fmt"
+	stdhash "hash"
+	"os"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// MinKeySize is a minimum size of master key in bytes.
+const MinKeySize = 32
+
+// ErrInvalidKey is returned when master key is too short.
+var ErrInvalidKey = fmt.Errorf("key must be at least %d bytes", MinKeySize)
+
+// Deriver is a key deriver. HKDF derives keys from master key, PBKDF2 from
+// caller password. Hash functions are picked by algorithm.
+type Deriver struct {
+	key   []byte
+	funcs map[hash.Algorithm]func() stdhash.Hash
+}
+
+// New creates new instance of deriver by master key and hash functions of
+// supported algorithms.
+func New(key []byte, funcs map[hash.Algorithm]func() stdhash.Hash) (*Deriver, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return &Deriver{
+		key:   key,
+		funcs: funcs,
+	}, nil
+}
+
+// LoadKeyfile creates new instance of deriver by master key stored
+// hex-encoded in file. Surrounding whitespace is ignored.
+func LoadKeyfile(file string, funcs map[hash.Algorithm]func() stdhash.Hash) (*Deriver, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read keyfile: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decode keyfile: %w", err)
+	}
+
+	return New(key, funcs)
+}
+
+// HKDF derives key of length bytes from master key with HKDF.
+func (d *Deriver) HKDF(alg hash.Algorithm, salt []byte, info string, length int) ([]byte, error) {
+	h, err := d.function(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := hkdf.Key(h, d.key, salt, info, length)
+	if err != nil {
+		return nil, fmt.Errorf("hkdf: %w", err)
+	}
+
+	return key, nil
+}
+
+// PBKDF2 derives key of length bytes from password with PBKDF2.
+func (d *Deriver) PBKDF2(alg hash.Algorithm, password string, salt []byte, iterations, length int) ([]byte, error) {
+	h, err := d.function(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(h, password, salt, iterations, length)
+	if err != nil {
+		return nil, fmt.Errorf("pbkdf2: %w", err)
+	}
+
+	return key, nil
+}
+
+func (d *Deriver) function(alg hash.Algorithm) (func() stdhash.Hash, error) {
+	h, ok := d.funcs[alg]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", hash.ErrUnsupportedAlgorithm, alg)
+	}
+
+	return h, nil
+}
@@ -0,0 +1,114 @@
+package deriver
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+)
+
+func TestDeriver_HKDF(t *testing.T) {
+	// RFC 5869 test case 2.
+	key := sequence(0x00, 80)
+	d, err := New(key, hasher.Functions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := d.HKDF(hash.AlgorithmSHA256, sequence(0x60, 80), string(sequence(0xb0, 80)), 82)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c" +
+		"59045a99cac7827271cb41c65e590e09da3275600c2f09b8367793a9aca3db71" +
+		"cc30c58179ec3e87c14c01d5c1f3434f1d87"
+	if hex.EncodeToString(got) != expected {
+		t.Errorf("expected %s, got %x", expected, got)
+	}
+
+	other, err := d.HKDF(hash.AlgorithmSHA256, nil, "tenant other", 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sales, err := d.HKDF(hash.AlgorithmSHA256, nil, "tenant sales", 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hex.EncodeToString(other) == hex.EncodeToString(sales) {
+		t.Error("expected different info to derive different keys")
+	}
+
+	if _, err := d.HKDF(hash.Algorithm(42), nil, "", 32); !errors.Is(err, hash.ErrUnsupportedAlgorithm) {
+		t.Errorf("expected error %v, got %v", hash.ErrUnsupportedAlgorithm, err)
+	}
+}
+
+func TestDeriver_PBKDF2(t *testing.T) {
+	d, err := New(sequence(0x00, MinKeySize), hasher.Functions())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := d.PBKDF2(hash.AlgorithmSHA256, "password", []byte("salt"), 4096, 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"
+	if hex.EncodeToString(got) != expected {
+		t.Errorf("expected %s, got %x", expected, got)
+	}
+}
+
+func TestLoadKeyfile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		content   string
+		expectErr bool
+	}{
+		{
+			name:    "valid key",
+			content: hex.EncodeToString(sequence(0x00, MinKeySize)) + "\n",
+		},
+		{
+			name:      "short key",
+			content:   hex.EncodeToString(sequence(0x00, MinKeySize-1)),
+			expectErr: true,
+		},
+		{
+			name:      "not hex",
+			content:   "not hex",
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, string(rune('a'+i)))
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadKeyfile(file, hasher.Functions())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func sequence(start byte, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = start + byte(i)
+	}
+
+	return b
+}
@@ -0,0 +1,2 @@
+// Package deriver provides key derivers.
+package deriver
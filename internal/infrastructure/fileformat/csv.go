@@ -0,0 +1,125 @@
+package fileformat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+// CSV represents codec of comma separated files with header row. Records
+// with number of fields different from header are malformed.
+type CSV struct{}
+
+// NewReader reads header and creates CSV records reader.
+func (CSV) NewReader(r io.Reader) (record.Reader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return &csvReader{r: cr}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		if _, ok := index[col]; !ok {
+			index[col] = i
+		}
+	}
+
+	return &csvReader{
+		r:      cr,
+		header: header,
+		index:  index,
+	}, nil
+}
+
+// NewWriter creates CSV records writer and writes header.
+func (CSV) NewWriter(w io.Writer, columns []string) (record.Writer, error) {
+	cw := csv.NewWriter(w)
+	if columns != nil {
+		if err := cw.Write(columns); err != nil {
+			return nil, fmt.Errorf("write header: %w", err)
+		}
+	}
+
+	return &csvWriter{w: cw}, nil
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+	index  map[string]int
+}
+
+func (r *csvReader) Columns() []string { return r.header }
+
+func (r *csvReader) Read() (record.Record, error) {
+	if r.header == nil {
+		return nil, io.EOF
+	}
+
+	fields, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("%w: %w", record.ErrMalformed, err)
+		}
+		return nil, err
+	}
+
+	if len(fields) != len(r.header) {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", record.ErrMalformed, len(r.header), len(fields))
+	}
+
+	return &csvRecord{index: r.index, fields: fields}, nil
+}
+
+type csvRecord struct {
+	index  map[string]int
+	fields []string
+}
+
+func (r *csvRecord) Get(column string) (string, bool) {
+	i, ok := r.index[column]
+	if !ok {
+		return "", false
+	}
+
+	return r.fields[i], true
+}
+
+func (r *csvRecord) Set(column, value string) {
+	if i, ok := r.index[column]; ok {
+		r.fields[i] = value
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(r record.Record) error {
+	rec, ok := r.(*csvRecord)
+	if !ok {
+		return fmt.Errorf("unexpected record type %T", r)
+	}
+
+	return w.w.Write(rec.fields)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
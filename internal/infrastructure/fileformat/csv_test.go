@@ -0,0 +1,88 @@
+package fileformat
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+func TestCSV(t *testing.T) {
+	in := "\ufeffemail,phone,note\n" +
+		"a@example.com,+1 555,\"multi\nline\"\n" +
+		"broken,row\n" +
+		"b@example.com,,ok\n"
+
+	r, err := CSV{}.NewReader(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(r.Columns(), ","); got != "email,phone,note" {
+		t.Errorf("expected header without BOM, got %q", got)
+	}
+
+	var out bytes.Buffer
+	w, err := CSV{}.NewWriter(&out, r.Columns())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var malformed int
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, record.ErrMalformed) {
+			malformed++
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		email, ok := rec.Get("email")
+		if !ok {
+			t.Fatal("expected email column")
+		}
+		rec.Set("email", "h("+email+")")
+
+		if _, ok := rec.Get("missing"); ok {
+			t.Error("expected missing column not to be found")
+		}
+
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if malformed != 1 {
+		t.Errorf("expected 1 malformed record, got %d", malformed)
+	}
+
+	expect := "email,phone,note\n" +
+		"h(a@example.com),+1 555,\"multi\nline\"\n" +
+		"h(b@example.com),,ok\n"
+	if out.String() != expect {
+		t.Errorf("expected %q, got %q", expect, out.String())
+	}
+}
+
+func TestCSV_Empty(t *testing.T) {
+	r, err := CSV{}.NewReader(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package fileformat provides bulk file formats readers and writers.
+package fileformat
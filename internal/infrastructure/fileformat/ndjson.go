@@ -0,0 +1,180 @@
+package fileformat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+// maxLineSize is a maximum size of a single NDJSON line.
+const maxLineSize = 1 << 20
+
+// NDJSON represents codec of newline delimited JSON objects. Object keys
+// order and values of not changed keys are preserved. Lines which aren't
+// JSON objects are malformed, blank lines are skipped.
+type NDJSON struct{}
+
+// NewReader creates NDJSON records reader.
+func (NDJSON) NewReader(r io.Reader) (record.Reader, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &ndjsonReader{s: s}, nil
+}
+
+// NewWriter creates NDJSON records writer. Columns are ignored.
+func (NDJSON) NewWriter(w io.Writer, _ []string) (record.Writer, error) {
+	return &ndjsonWriter{w: bufio.NewWriter(w)}, nil
+}
+
+type ndjsonReader struct {
+	s *bufio.Scanner
+}
+
+func (r *ndjsonReader) Columns() []string { return nil }
+
+func (r *ndjsonReader) Read() (record.Record, error) {
+	for r.s.Scan() {
+		line := bytes.TrimSpace(r.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		rec, err := parseObject(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", record.ErrMalformed, err)
+		}
+
+		return rec, nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return nil, fmt.Errorf("scan line: %w", err)
+	}
+
+	return nil, io.EOF
+}
+
+type ndjsonField struct {
+	key string
+	raw json.RawMessage
+}
+
+type ndjsonRecord struct {
+	fields []ndjsonField
+}
+
+// Get returns string values unquoted and other non-null values in their JSON
+// form.
+func (r *ndjsonRecord) Get(column string) (string, bool) {
+	for _, f := range r.fields {
+		if f.key != column {
+			continue
+		}
+
+		switch f.raw[0] {
+		case 'n':
+			return "", false
+		case '"':
+			var s string
+			if err := json.Unmarshal(f.raw, &s); err != nil {
+				return "", false
+			}
+			return s, true
+		default:
+			return string(f.raw), true
+		}
+	}
+
+	return "", false
+}
+
+func (r *ndjsonRecord) Set(column, value string) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	for i := range r.fields {
+		if r.fields[i].key == column {
+			r.fields[i].raw = raw
+		}
+	}
+}
+
+func parseObject(line []byte) (*ndjsonRecord, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("line is not a JSON object")
+	}
+
+	rec := &ndjsonRecord{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := tok.(string)
+		if !ok {
+			return nil, errors.New("object key is not a string")
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		rec.fields = append(rec.fields, ndjsonField{key: key, raw: raw})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+
+	return rec, nil
+}
+
+type ndjsonWriter struct {
+	w *bufio.Writer
+}
+
+func (w *ndjsonWriter) Write(r record.Record) error {
+	rec, ok := r.(*ndjsonRecord)
+	if !ok {
+		return fmt.Errorf("unexpected record type %T", r)
+	}
+
+	w.w.WriteByte('{')
+	for i, f := range rec.fields {
+		if i > 0 {
+			w.w.WriteByte(',')
+		}
+
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return fmt.Errorf("marshal key: %w", err)
+		}
+
+		w.w.Write(key)
+		w.w.WriteByte(':')
+		w.w.Write(f.raw)
+	}
+	w.w.WriteByte('}')
+
+	return w.w.WriteByte('\n')
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.w.Flush()
+}
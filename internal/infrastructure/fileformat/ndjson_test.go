@@ -0,0 +1,75 @@
+package fileformat
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+)
+
+func TestNDJSON(t *testing.T) {
+	in := `{"phone":5551234,"email":"A@example.com","tags":["x"],"vip":true}` + "\n" +
+		"\n" +
+		`not json` + "\n" +
+		`{"email":null,"first_name":"Ann"}` + "\n" +
+		`{"email":"b"} trailing` + "\n"
+
+	r, err := NDJSON{}.NewReader(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	w, _ := NDJSON{}.NewWriter(&out, r.Columns())
+
+	var (
+		malformed int
+		values    []string
+	)
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, record.ErrMalformed) {
+			malformed++
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, col := range []string{"email", "phone", "vip", "tags"} {
+			if v, ok := rec.Get(col); ok {
+				values = append(values, col+"="+v)
+			}
+		}
+
+		if _, ok := rec.Get("email"); ok {
+			rec.Set("email", "hashed")
+		}
+
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	_ = w.Flush()
+
+	if malformed != 2 {
+		t.Errorf("expected 2 malformed records, got %d", malformed)
+	}
+
+	expectValues := `email=A@example.com,phone=5551234,vip=true,tags=["x"]`
+	if got := strings.Join(values, ","); got != expectValues {
+		t.Errorf("expected values %s, got %s", expectValues, got)
+	}
+
+	expect := `{"phone":5551234,"email":"hashed","tags":["x"],"vip":true}` + "\n" +
+		`{"email":null,"first_name":"Ann"}` + "\n"
+	if out.String() != expect {
+		t.Errorf("expected %q, got %q", expect, out.String())
+	}
+}
@@ -0,0 +1,11 @@
+package fileformat
+
+import "github.com/tmybsv/leadgen-test-task/internal/domain/record"
+
+// All returns codecs of every supported file format.
+func All() map[record.Format]record.Codec {
+	return map[record.Format]record.Codec{
+		record.FormatCSV:    CSV{},
+		record.FormatNDJSON: NDJSON{},
+	}
+}
@@ -0,0 +1,3 @@
+// Package fpeinfra provides FF1 and FF3-1 format-preserving ciphers of NIST
+// SP 800-38G.
+package fpeinfra
@@ -0,0 +1,146 @@
+package fpeinfra
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+)
+
+const (
+	// ff1Rounds is a number of FF1 Feistel rounds.
+	ff1Rounds = 10
+	// ff1MaxLen is a maximum FF1 numeral string length. The standard allows
+	// much longer strings, but they are neither needed nor cheap.
+	ff1MaxLen = 4096
+	// ff1MaxTweakLen is a maximum FF1 tweak length in bytes.
+	ff1MaxTweakLen = 256
+)
+
+// FF1 is a FF1 format-preserving cipher with AES.
+type FF1 struct {
+	block  cipher.Block
+	radix  int
+	minLen int
+}
+
+// NewFF1 creates new FF1 cipher by AES-128, AES-192 or AES-256 key and radix.
+func NewFF1(key []byte, radix int) (*FF1, error) {
+	if !validRadix(radix) {
+		return nil, fpe.ErrInvalidCipherRadix
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+
+	return &FF1{
+		block:  block,
+		radix:  radix,
+		minLen: minLength(radix),
+	}, nil
+}
+
+// Encrypt encrypts numeral string with tweak of up to 256 bytes.
+func (c *FF1) Encrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	return c.cipher(tweak, x, true)
+}
+
+// Decrypt decrypts numeral string encrypted with the same tweak.
+func (c *FF1) Decrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	return c.cipher(tweak, x, false)
+}
+
+// cipher implements algorithms 7 and 8 of NIST SP 800-38G.
+func (c *FF1) cipher(tweak []byte, x []uint16, encrypt bool) ([]uint16, error) {
+	if err := validate(x, c.radix, c.minLen, ff1MaxLen); err != nil {
+		return nil, err
+	}
+
+	if len(tweak) > ff1MaxTweakLen {
+		return nil, fmt.Errorf("%w: FF1 tweak must be at most %d bytes", fpe.ErrInvalidTweak, ff1MaxTweakLen)
+	}
+
+	var (
+		n     = len(x)
+		t     = len(tweak)
+		u     = n / 2
+		v     = n - u
+		a     = append([]uint16(nil), x[:u]...)
+		b     = append([]uint16(nil), x[u:]...)
+		radix = big.NewInt(int64(c.radix))
+	)
+
+	modU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+
+	bLen := (new(big.Int).Sub(modV, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((bLen+3)/4) + 4
+	pad := ((-t-bLen-1)%16 + 16) % 16
+
+	pq := make([]byte, 16+t+pad+1+bLen)
+	pq[0], pq[1], pq[2] = 1, 2, 1
+	pq[3], pq[4], pq[5] = byte(c.radix>>16), byte(c.radix>>8), byte(c.radix)
+	pq[6], pq[7] = ff1Rounds, byte(u)
+	binary.BigEndian.PutUint32(pq[8:], uint32(n))
+	binary.BigEndian.PutUint32(pq[12:], uint32(t))
+	copy(pq[16:], tweak)
+	q := pq[16+t+pad:]
+
+	s := make([]byte, (d+15)/16*16)
+	y := new(big.Int)
+	for i := range ff1Rounds {
+		round := i
+		src := b
+		if !encrypt {
+			round = ff1Rounds - 1 - i
+			src = a
+		}
+
+		q[0] = byte(round)
+		num(src, radix).FillBytes(q[1:])
+		c.expand(s, pq)
+		y.SetBytes(s[:d])
+
+		m, mod := u, modU
+		if round%2 == 1 {
+			m, mod = v, modV
+		}
+
+		if encrypt {
+			z := num(a, radix)
+			z.Add(z, y).Mod(z, mod)
+			a, b = b, str(z, radix, m)
+		} else {
+			z := num(b, radix)
+			z.Sub(z, y).Mod(z, mod)
+			a, b = str(z, radix, m), a
+		}
+	}
+
+	return append(a, b...), nil
+}
+
+// expand fills s with PRF of data followed by encrypted PRF blocks xored
+// with block counter.
+func (c *FF1) expand(s, data []byte) {
+	r := s[:16]
+	clear(r)
+	for i := 0; i < len(data); i += 16 {
+		for j := range 16 {
+			r[j] ^= data[i+j]
+		}
+		c.block.Encrypt(r, r)
+	}
+
+	for j := 1; j < len(s)/16; j++ {
+		blk := s[j*16 : (j+1)*16]
+		copy(blk, r)
+		binary.BigEndian.PutUint64(blk[8:], binary.BigEndian.Uint64(r[8:])^uint64(j))
+		c.block.Encrypt(blk, blk)
+	}
+}
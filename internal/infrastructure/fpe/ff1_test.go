@@ -0,0 +1,134 @@
+package fpeinfra
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// TestFF1_NIST checks samples of NIST SP 800-38G FF1 examples.
+func TestFF1_NIST(t *testing.T) {
+	const (
+		key128 = "2B7E151628AED2A6ABF7158809CF4F3C"
+		key192 = "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F"
+		key256 = "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94"
+	)
+
+	tests := []struct {
+		name       string
+		key        string
+		radix      int
+		tweak      string
+		plaintext  string
+		ciphertext string
+	}{
+		{"sample 1", key128, 10, "", "0123456789", "2433477484"},
+		{"sample 2", key128, 10, "39383736353433323130", "0123456789", "6124200773"},
+		{"sample 3", key128, 36, "3737373770717273373737", "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+		{"sample 4", key192, 10, "", "0123456789", "2830668132"},
+		{"sample 5", key192, 10, "39383736353433323130", "0123456789", "2496655549"},
+		{"sample 6", key192, 36, "3737373770717273373737", "0123456789abcdefghi", "xbj3kv35jrawxv32ysr"},
+		{"sample 7", key256, 10, "", "0123456789", "6657667009"},
+		{"sample 8", key256, 10, "39383736353433323130", "0123456789", "1001623463"},
+		{"sample 9", key256, 36, "3737373770717273373737", "0123456789abcdefghi", "xs8a0azh2avyalyzuwd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFF1(mustHex(t, tt.key), tt.radix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tweak := mustHex(t, tt.tweak)
+
+			got, err := c.Encrypt(tweak, numerals(tt.plaintext))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s := text(got); s != tt.ciphertext {
+				t.Errorf("expected ciphertext %q, got %q", tt.ciphertext, s)
+			}
+
+			got, err = c.Decrypt(tweak, numerals(tt.ciphertext))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s := text(got); s != tt.plaintext {
+				t.Errorf("expected plaintext %q, got %q", tt.plaintext, s)
+			}
+		})
+	}
+}
+
+func TestFF1_Validation(t *testing.T) {
+	c, err := NewFF1(make([]byte, 16), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tweak     []byte
+		x         []uint16
+		expectErr error
+	}{
+		{"too short", nil, numerals("12345"), fpe.ErrInvalidLength},
+		{"too long", nil, make([]uint16, ff1MaxLen+1), fpe.ErrInvalidLength},
+		{"numeral out of radix", nil, numerals("12345a"), fpe.ErrNumeralOutOfBounds},
+		{"too long tweak", make([]byte, ff1MaxTweakLen+1), numerals("123456"), fpe.ErrInvalidTweak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Encrypt(tt.tweak, tt.x); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+
+	if _, err := NewFF1(make([]byte, 16), 1); !errors.Is(err, fpe.ErrInvalidCipherRadix) {
+		t.Errorf("expected error %v, got %v", fpe.ErrInvalidCipherRadix, err)
+	}
+	if _, err := NewFF1(make([]byte, 16), 1<<16+1); !errors.Is(err, fpe.ErrInvalidCipherRadix) {
+		t.Errorf("expected error %v, got %v", fpe.ErrInvalidCipherRadix, err)
+	}
+	if _, err := NewFF1(make([]byte, 16), 1<<16); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := NewFF1(make([]byte, 15), 10); err == nil {
+		t.Error("expected invalid key error, got nil")
+	}
+}
+
+func numerals(s string) []uint16 {
+	x := make([]uint16, len(s))
+	for i := range s {
+		x[i] = uint16(strings.IndexByte(digits, s[i]))
+	}
+	return x
+}
+
+func text(x []uint16) string {
+	var sb strings.Builder
+	for _, d := range x {
+		sb.WriteByte(digits[d])
+	}
+	return sb.String()
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,136 @@
+package fpeinfra
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+)
+
+const (
+	// ff3Rounds is a number of FF3 Feistel rounds.
+	ff3Rounds = 8
+	// ff31TweakLen is a FF3-1 tweak length in bytes.
+	ff31TweakLen = 7
+)
+
+// FF31 is a FF3-1 format-preserving cipher with AES.
+type FF31 struct {
+	block  cipher.Block
+	radix  int
+	minLen int
+	maxLen int
+}
+
+// NewFF31 creates new FF3-1 cipher by AES-128, AES-192 or AES-256 key and
+// radix.
+func NewFF31(key []byte, radix int) (*FF31, error) {
+	if !validRadix(radix) {
+		return nil, fpe.ErrInvalidCipherRadix
+	}
+
+	block, err := aes.NewCipher(revb(slices.Clone(key)))
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+
+	return &FF31{
+		block:  block,
+		radix:  radix,
+		minLen: minLength(radix),
+		maxLen: 2 * int(math.Floor(96/math.Log2(float64(radix)))),
+	}, nil
+}
+
+// Encrypt encrypts numeral string with 7 bytes tweak.
+func (c *FF31) Encrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	t, err := expandTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cipher(t, x, true)
+}
+
+// Decrypt decrypts numeral string encrypted with the same tweak.
+func (c *FF31) Decrypt(tweak []byte, x []uint16) ([]uint16, error) {
+	t, err := expandTweak(tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cipher(t, x, false)
+}
+
+// expandTweak expands 56 bits FF3-1 tweak to 64 bits FF3 one, which halves
+// are 28 bits of FF3-1 tweak followed by 4 bits each.
+func expandTweak(tweak []byte) ([8]byte, error) {
+	if len(tweak) != ff31TweakLen {
+		return [8]byte{}, fmt.Errorf("%w: FF3-1 tweak must be %d bytes", fpe.ErrInvalidTweak, ff31TweakLen)
+	}
+
+	return [8]byte{
+		tweak[0], tweak[1], tweak[2], tweak[3] & 0xf0,
+		tweak[4], tweak[5], tweak[6], tweak[3] << 4,
+	}, nil
+}
+
+// cipher implements FF3 algorithms 9 and 10 of NIST SP 800-38G with 64 bits
+// tweak. FF3-1 differs in tweak only.
+func (c *FF31) cipher(tweak [8]byte, x []uint16, encrypt bool) ([]uint16, error) {
+	if err := validate(x, c.radix, c.minLen, c.maxLen); err != nil {
+		return nil, err
+	}
+
+	var (
+		n     = len(x)
+		u     = (n + 1) / 2
+		v     = n - u
+		a     = append([]uint16(nil), x[:u]...)
+		b     = append([]uint16(nil), x[u:]...)
+		radix = big.NewInt(int64(c.radix))
+	)
+
+	modU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+
+	var p [16]byte
+	y := new(big.Int)
+	for i := range ff3Rounds {
+		round := i
+		src := b
+		if !encrypt {
+			round = ff3Rounds - 1 - i
+			src = a
+		}
+
+		m, mod, w := u, modU, tweak[4:]
+		if round%2 == 1 {
+			m, mod, w = v, modV, tweak[:4]
+		}
+
+		copy(p[:4], w)
+		p[3] ^= byte(round)
+		num(rev(src), radix).FillBytes(p[4:])
+
+		revb(p[:])
+		c.block.Encrypt(p[:], p[:])
+		y.SetBytes(revb(p[:]))
+
+		if encrypt {
+			z := num(rev(a), radix)
+			z.Add(z, y).Mod(z, mod)
+			a, b = b, rev(str(z, radix, m))
+		} else {
+			z := num(rev(b), radix)
+			z.Sub(z, y).Mod(z, mod)
+			a, b = rev(str(z, radix, m)), a
+		}
+	}
+
+	return append(a, b...), nil
+}
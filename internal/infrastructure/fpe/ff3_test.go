@@ -0,0 +1,122 @@
+package fpeinfra
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+)
+
+// TestFF3_NIST checks samples of NIST SP 800-38G FF3 examples. FF3-1 uses the
+// same algorithm with expanded 56 bits tweak.
+func TestFF3_NIST(t *testing.T) {
+	const (
+		key128 = "EF4359D8D580AA4F7F036D6F04FC6A94"
+		key192 = "EF4359D8D580AA4F7F036D6F04FC6A942B7E151628AED2A6"
+		key256 = "EF4359D8D580AA4F7F036D6F04FC6A942B7E151628AED2A6ABF7158809CF4F3C"
+	)
+
+	tests := []struct {
+		name       string
+		key        string
+		radix      int
+		tweak      string
+		plaintext  string
+		ciphertext string
+	}{
+		{"sample 1", key128, 10, "D8E7920AFA330A73", "890121234567890000", "750918814058654607"},
+		{"sample 2", key128, 10, "9A768A92F60E12D8", "890121234567890000", "018989839189395384"},
+		{"sample 3", key128, 10, "D8E7920AFA330A73", "89012123456789000000789000000", "48598367162252569629397416226"},
+		{"sample 4", key128, 10, "0000000000000000", "89012123456789000000789000000", "34695224821734535122613701434"},
+		{"sample 5", key128, 26, "9A768A92F60E12D8", "0123456789abcdefghi", "g2pk40i992fn20cjakb"},
+		{"sample 6", key192, 10, "D8E7920AFA330A73", "890121234567890000", "646965393875028755"},
+		{"sample 7", key192, 10, "9A768A92F60E12D8", "890121234567890000", "961610514491424446"},
+		{"sample 8", key192, 10, "D8E7920AFA330A73", "89012123456789000000789000000", "53048884065350204541786380807"},
+		{"sample 9", key192, 10, "0000000000000000", "89012123456789000000789000000", "98083802678820389295041483512"},
+		{"sample 10", key192, 26, "9A768A92F60E12D8", "0123456789abcdefghi", "i0ihe2jfj7a9opf9p88"},
+		{"sample 11", key256, 10, "D8E7920AFA330A73", "890121234567890000", "922011205562777495"},
+		{"sample 12", key256, 10, "9A768A92F60E12D8", "890121234567890000", "504149865578056140"},
+		{"sample 13", key256, 10, "D8E7920AFA330A73", "89012123456789000000789000000", "04344343235792599165734622699"},
+		{"sample 14", key256, 10, "0000000000000000", "89012123456789000000789000000", "30859239999374053872365555822"},
+		{"sample 15", key256, 26, "9A768A92F60E12D8", "0123456789abcdefghi", "p0b2godfja9bhb7bk38"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFF31(mustHex(t, tt.key), tt.radix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tweak := [8]byte(mustHex(t, tt.tweak))
+
+			got, err := c.cipher(tweak, numerals(tt.plaintext), true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s := text(got); s != tt.ciphertext {
+				t.Errorf("expected ciphertext %q, got %q", tt.ciphertext, s)
+			}
+
+			got, err = c.cipher(tweak, numerals(tt.ciphertext), false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s := text(got); s != tt.plaintext {
+				t.Errorf("expected plaintext %q, got %q", tt.plaintext, s)
+			}
+		})
+	}
+}
+
+func TestFF31(t *testing.T) {
+	c, err := NewFF31(mustHex(t, "2DE79D232DF5585D68CE47882AE256D6"), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tweak := mustHex(t, "CBD09280979564")
+
+	got, err := c.Encrypt(tweak, numerals("3992520240"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := text(got); s != "8901801106" {
+		t.Errorf("expected ciphertext %q, got %q", "8901801106", s)
+	}
+
+	got, err = c.Decrypt(tweak, got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := text(got); s != "3992520240" {
+		t.Errorf("expected plaintext %q, got %q", "3992520240", s)
+	}
+}
+
+func TestFF31_Validation(t *testing.T) {
+	c, err := NewFF31(make([]byte, 16), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tweak     []byte
+		x         []uint16
+		expectErr error
+	}{
+		{"too short", make([]byte, 7), numerals("12345"), fpe.ErrInvalidLength},
+		{"too long", make([]byte, 7), make([]uint16, 57), fpe.ErrInvalidLength},
+		{"FF3 tweak", make([]byte, 8), numerals("123456"), fpe.ErrInvalidTweak},
+		{"no tweak", nil, numerals("123456"), fpe.ErrInvalidTweak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Encrypt(tt.tweak, tt.x); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package fpeinfra
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+)
+
+// minDomainSize is a minimum number of possible values, radix^minlen, NIST
+// SP 800-38G requires.
+const minDomainSize = 1_000_000
+
+// LoadKey loads AES key stored hex-encoded in file. Surrounding whitespace is
+// ignored.
+func LoadKey(file string) ([]byte, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+
+	return key, nil
+}
+
+// validRadix reports whether radix is supported by NIST SP 800-38G.
+func validRadix(radix int) bool {
+	return radix >= 2 && radix <= 1<<16
+}
+
+// minLength returns minimum numeral string length of radix.
+func minLength(radix int) int {
+	n, size := 0, 1
+	for size < minDomainSize {
+		size *= radix
+		n++
+	}
+
+	return max(n, 2)
+}
+
+// validate validates numeral string length and numerals.
+func validate(x []uint16, radix, minLen, maxLen int) error {
+	if len(x) < minLen || len(x) > maxLen {
+		return fmt.Errorf("%w: must be from %d to %d, got %d", fpe.ErrInvalidLength, minLen, maxLen, len(x))
+	}
+
+	for _, d := range x {
+		if int(d) >= radix {
+			return fpe.ErrNumeralOutOfBounds
+		}
+	}
+
+	return nil
+}
+
+// num returns number represented by numeral string, the first numeral is the
+// most significant.
+func num(x []uint16, radix *big.Int) *big.Int {
+	n := new(big.Int)
+	d := new(big.Int)
+	for _, v := range x {
+		n.Mul(n, radix)
+		n.Add(n, d.SetUint64(uint64(v)))
+	}
+
+	return n
+}
+
+// str returns numeral string of length m representing n.
+func str(n *big.Int, radix *big.Int, m int) []uint16 {
+	x := make([]uint16, m)
+	n = new(big.Int).Set(n)
+	d := new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		n.DivMod(n, radix, d)
+		x[i] = uint16(d.Uint64())
+	}
+
+	return x
+}
+
+// rev returns numerals in reverse order.
+func rev(x []uint16) []uint16 {
+	r := make([]uint16, len(x))
+	for i, v := range x {
+		r[len(x)-1-i] = v
+	}
+
+	return r
+}
+
+// revb reverses bytes in place.
+func revb(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return b
+}
@@ -1,7 +1,9 @@
 package hasher
 
 import (
+	"crypto/hmac"
 	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 )
 
@@ -12,3 +14,11 @@ type MD5 struct{}
 func (*MD5) Hash(input string) string {
 	return fmt.Sprintf("%x", md5.Sum([]byte(input)))
 }
+
+// MAC returns HMAC-MD5 of input keyed by key.
+func (*MD5) MAC(key []byte, input string) string {
+	mac := hmac.New(md5.New, key)
+	mac.Write([]byte(input))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
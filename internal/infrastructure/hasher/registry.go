@@ -0,0 +1,27 @@
+package hasher
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	stdhash "hash"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// All returns hashers of every supported algorithm. Server and command line
+// tools share it, so they always hash with the same implementations.
+func All() map[hash.Algorithm]hash.Hasher {
+	return map[hash.Algorithm]hash.Hasher{
+		hash.AlgorithmMD5:    &MD5{},
+		hash.AlgorithmSHA256: &SHA256{},
+	}
+}
+
+// Functions returns hash functions of every supported algorithm, e.g. for
+// HMAC based key derivation.
+func Functions() map[hash.Algorithm]func() stdhash.Hash {
+	return map[hash.Algorithm]func() stdhash.Hash{
+		hash.AlgorithmMD5:    md5.New,
+		hash.AlgorithmSHA256: sha256.New,
+	}
+}
@@ -1,7 +1,9 @@
 package hasher
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 )
 
@@ -12,3 +14,11 @@ type SHA256 struct{}
 func (*SHA256) Hash(input string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
 }
+
+// MAC returns HMAC-SHA256 of input keyed by key.
+func (*SHA256) MAC(key []byte, input string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
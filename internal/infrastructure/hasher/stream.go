@@ -0,0 +1,26 @@
+package hasher
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// Sum streams r through hash function of algorithm and returns hex-encoded
+// checksum. Checksum equals the one hasher of algorithm returns for the whole
+// content, but content isn't read into memory.
+func Sum(alg hash.Algorithm, r io.Reader) (string, error) {
+	newHash, ok := Functions()[alg]
+	if !ok {
+		return "", fmt.Errorf("%w %q", hash.ErrUnsupportedAlgorithm, alg)
+	}
+
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("read: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
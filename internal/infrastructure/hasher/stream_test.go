@@ -0,0 +1,30 @@
+package hasher
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestSum(t *testing.T) {
+	content := strings.Repeat("foo@example.com\n", 10000)
+
+	for alg, h := range All() {
+		t.Run(alg.String(), func(t *testing.T) {
+			got, err := Sum(alg, strings.NewReader(content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if expected := h.Hash(content); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+
+	if _, err := Sum(hash.Algorithm(42), strings.NewReader(content)); !errors.Is(err, hash.ErrUnsupportedAlgorithm) {
+		t.Errorf("expected error %v, got %v", hash.ErrUnsupportedAlgorithm, err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package limiter provides in-process rate limiters.
+package limiter
@@ -0,0 +1,87 @@
+package limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
+)
+
+const sweepInterval = time.Minute
+
+// TokenBucket is an in-process token bucket rate limiter. Buckets are kept per
+// key and dropped once they are full again, so idle callers don't consume
+// memory.
+type TokenBucket struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     ratelimit.Limit
+}
+
+// NewTokenBucket creates new instance of in-process token bucket limiter.
+func NewTokenBucket() *TokenBucket {
+	return &TokenBucket{
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from bucket identified by key.
+func (l *TokenBucket) Allow(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	if limit.Unlimited() {
+		return ratelimit.Decision{Allowed: true}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: limit.Capacity(), updatedAt: now, limit: limit}
+		l.buckets[key] = b
+	}
+
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return ratelimit.Decision{Allowed: true}, nil
+	}
+
+	wait := (1 - b.tokens) / limit.Rate
+	return ratelimit.Decision{
+		RetryAfter: time.Duration(math.Ceil(wait * float64(time.Second))),
+	}, nil
+}
+
+func (l *TokenBucket) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < sweepInterval {
+		return
+	}
+	l.sweptAt = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= b.limit.Capacity() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.updatedAt = now
+	b.tokens = math.Min(b.limit.Capacity(), b.tokens+elapsed*b.limit.Rate)
+}
@@ -0,0 +1,75 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
+)
+
+func TestTokenBucket_Allow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewTokenBucket()
+	l.now = func() time.Time { return now }
+
+	limit := ratelimit.Limit{Rate: 2, Burst: 3}
+	ctx := context.Background()
+
+	for i := range 3 {
+		d, err := l.Allow(ctx, "importer", limit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !d.Allowed {
+			t.Fatalf("request %d expected to be allowed", i)
+		}
+	}
+
+	d, _ := l.Allow(ctx, "importer", limit)
+	if d.Allowed {
+		t.Fatal("expected request over burst to be rejected")
+	}
+
+	if d.RetryAfter != 500*time.Millisecond {
+		t.Errorf("expected retry after 500ms, got %v", d.RetryAfter)
+	}
+
+	if d, _ := l.Allow(ctx, "other", limit); !d.Allowed {
+		t.Error("expected other key to have own bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if d, _ := l.Allow(ctx, "importer", limit); !d.Allowed {
+		t.Error("expected request to be allowed after refill")
+	}
+}
+
+func TestTokenBucket_Unlimited(t *testing.T) {
+	l := NewTokenBucket()
+
+	for range 100 {
+		if d, _ := l.Allow(context.Background(), "key", ratelimit.Limit{}); !d.Allowed {
+			t.Fatal("expected unlimited requests to be allowed")
+		}
+	}
+
+	if len(l.buckets) != 0 {
+		t.Errorf("expected no buckets for unlimited key, got %d", len(l.buckets))
+	}
+}
+
+func TestTokenBucket_Sweep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewTokenBucket()
+	l.now = func() time.Time { return now }
+
+	_, _ = l.Allow(context.Background(), "idle", ratelimit.Limit{Rate: 1, Burst: 1})
+
+	now = now.Add(2 * sweepInterval)
+	_, _ = l.Allow(context.Background(), "active", ratelimit.Limit{Rate: 1, Burst: 1})
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("expected idle bucket to be swept")
+	}
+}
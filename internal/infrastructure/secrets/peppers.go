@@ -0,0 +1,75 @@
+// Package secrets provides loading of server-held secrets from files.
+package secrets
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+// ErrNoPeppers is returned when peppers file has no peppers.
+var ErrNoPeppers = errors.New("no peppers found in file")
+
+// LoadPeppers loads versioned peppers from file with a "version=secret" line
+// per pepper. Blank lines and lines starting with "#" are ignored. Empty
+// current version means the last one in file, so rotation is appending a new
+// line.
+func LoadPeppers(file, current string) (*hash.Peppers, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open peppers: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		secrets = make(map[string]string)
+		last    string
+		n       int
+	)
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		n++
+
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		version, secret, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected version=secret", n)
+		}
+
+		version = strings.TrimSpace(version)
+		if _, ok := secrets[version]; ok {
+			return nil, fmt.Errorf("line %d: duplicate pepper version %q", n, version)
+		}
+
+		secrets[version] = secret
+		last = version
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read peppers: %w", err)
+	}
+
+	if len(secrets) == 0 {
+		return nil, ErrNoPeppers
+	}
+
+	if current == "" {
+		current = last
+	}
+
+	peppers, err := hash.NewPeppers(current, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("new peppers: %w", err)
+	}
+
+	return peppers, nil
+}
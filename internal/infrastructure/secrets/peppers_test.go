@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+)
+
+func TestLoadPeppers(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		current       string
+		expectCurrent string
+		expectErr     error
+	}{
+		{"last is current", "# rotated monthly\nv1=first\n\nv2=c2Vjb25k==\n", "", "v2", nil},
+		{"explicit current", "v1=first\nv2=second\n", "v1", "v1", nil},
+		{"unknown current", "v1=first\n", "v3", "", hash.ErrUnknownPepperVersion},
+		{"empty secret", "v1=\n", "", "", hash.ErrEmptyPepper},
+		{"empty file", "# nothing\n", "", "", ErrNoPeppers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "peppers")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := LoadPeppers(file, tt.current)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if p.Current() != tt.expectCurrent {
+				t.Errorf("expected current %q, got %q", tt.expectCurrent, p.Current())
+			}
+		})
+	}
+}
+
+func TestLoadPeppers_Malformed(t *testing.T) {
+	for _, content := range []string{"v1\n", "v1=a\nv1=b\n"} {
+		file := filepath.Join(t.TempDir(), "peppers")
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadPeppers(file, ""); err == nil {
+			t.Errorf("LoadPeppers(%q) expected error, got nil", content)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package signer provides digital signature signers.
+package signer
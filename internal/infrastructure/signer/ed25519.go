@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNotEd25519 is returned when key file contains key of another type.
+var ErrNotEd25519 = errors.New("key is not an Ed25519 key")
+
+// Ed25519 is an Ed25519 signer. Key identifier is RFC 7638 thumbprint of
+// public key JWK, so verifiers can match signatures to published keys.
+type Ed25519 struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewEd25519 creates new instance of Ed25519 signer with given private key.
+func NewEd25519(key ed25519.PrivateKey) *Ed25519 {
+	pub := key.Public().(ed25519.PublicKey)
+
+	return &Ed25519{
+		key:   key,
+		keyID: Thumbprint(pub),
+	}
+}
+
+// LoadEd25519 creates new instance of Ed25519 signer with PKCS #8 private key
+// read from PEM file, e.g. made by "openssl genpkey -algorithm ed25519".
+func LoadEd25519(file string) (*Ed25519, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("decode key file: no PEM block found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrNotEd25519
+	}
+
+	return NewEd25519(edKey), nil
+}
+
+// LoadEd25519PublicKey reads PKIX public key from PEM file, e.g. made by
+// "openssl pkey -pubout".
+func LoadEd25519PublicKey(file string) (ed25519.PublicKey, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("decode key file: no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrNotEd25519
+	}
+
+	return pub, nil
+}
+
+// KeyID returns key identifier.
+func (s *Ed25519) KeyID() string { return s.keyID }
+
+// PublicKey returns public key.
+func (s *Ed25519) PublicKey() ed25519.PublicKey { return s.key.Public().(ed25519.PublicKey) }
+
+// Sign signs message.
+func (s *Ed25519) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, message), nil
+}
+
+// Thumbprint returns RFC 7638 thumbprint of Ed25519 public key JWK.
+func Thumbprint(pub ed25519.PublicKey) string {
+	jwk := `{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(pub) + `"}`
+	sum := sha256.Sum256([]byte(jwk))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThumbprint(t *testing.T) {
+	// RFC 8037 appendix A.3.
+	pub, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expect = "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"
+	if got := Thumbprint(pub); got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+}
+
+func TestLoadEd25519(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := writeKey(t, key)
+	s, err := LoadEd25519(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pub := key.Public().(ed25519.PublicKey)
+	if s.KeyID() != Thumbprint(pub) || !s.PublicKey().Equal(pub) {
+		t.Errorf("expected key %q, got %q", Thumbprint(pub), s.KeyID())
+	}
+
+	msg := []byte("tree head")
+	sig, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		t.Error("signature is not valid")
+	}
+}
+
+func TestLoadEd25519PublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "key.pub")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := LoadEd25519PublicKey(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(pub) {
+		t.Errorf("expected key %x, got %x", pub, got)
+	}
+}
+
+func TestLoadEd25519_Invalid(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := LoadEd25519(writeKey(t, ecKey)); !errors.Is(err, ErrNotEd25519) {
+		t.Errorf("expected error %v, got %v", ErrNotEd25519, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(file, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := LoadEd25519(file); err == nil {
+		t.Error("expected error for malformed key file")
+	}
+}
+
+func writeKey(t *testing.T, key any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return file
+}
@@ -0,0 +1,2 @@
+// Package sketch provides MinHash and SimHash locality-sensitive signatures.
+package sketch
@@ -0,0 +1,76 @@
+package sketch
+
+import (
+	"math"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+)
+
+// MinHash is a MinHash signature of tokens set. Every signature component is
+// a minimum of tokens hashes under its own permutation, so the probability of
+// equal components is Jaccard similarity of sets.
+type MinHash struct {
+	seeds []uint64
+	bands int
+}
+
+// NewMinHash creates new MinHash with given number of permutations split
+// into LSH bands of equal size. More rows per band mean fewer but more
+// similar candidates.
+func NewMinHash(permutations, bands int) (*MinHash, error) {
+	if permutations <= 0 || bands <= 0 || permutations%bands != 0 {
+		return nil, similarity.ErrInvalidBands
+	}
+
+	return &MinHash{
+		seeds: seeds(permutations),
+		bands: bands,
+	}, nil
+}
+
+// Sketch returns MinHash signature of tokens set.
+func (m *MinHash) Sketch(tokens []string) similarity.Signature {
+	sig := make(similarity.Signature, len(m.seeds))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	for _, token := range tokens {
+		h := mixhash.String(token)
+		for i, s := range m.seeds {
+			if v := mixhash.Mix(h ^ s); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+
+	return sig
+}
+
+// Similarity estimates Jaccard similarity as a share of equal components.
+func (m *MinHash) Similarity(a, b similarity.Signature) float64 {
+	if len(a) != len(m.seeds) || len(b) != len(m.seeds) {
+		return 0
+	}
+
+	var equal int
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(a))
+}
+
+// Bands returns hashes of signature bands.
+func (m *MinHash) Bands(sig similarity.Signature) []uint64 {
+	rows := len(sig) / m.bands
+	bands := make([]uint64, m.bands)
+	for i := range bands {
+		bands[i] = bandHash(sig[i*rows : (i+1)*rows])
+	}
+
+	return bands
+}
@@ -0,0 +1,34 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+)
+
+// seed is a fixed seed of MinHash permutations. Signatures are stored, so
+// permutations must never change.
+const seed = 0x5eed_1ead_2026_0001
+
+// bandHash returns 64-bit hash of signature components.
+func bandHash(components []uint64) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	for _, c := range components {
+		binary.BigEndian.PutUint64(b[:], c)
+		h.Write(b[:])
+	}
+	return mixhash.Mix(h.Sum64())
+}
+
+// seeds returns n SplitMix64 sequence values.
+func seeds(n int) []uint64 {
+	s := make([]uint64, n)
+	state := uint64(seed)
+	for i := range s {
+		state += 0x9e3779b97f4a7c15
+		s[i] = mixhash.Mix(state)
+	}
+	return s
+}
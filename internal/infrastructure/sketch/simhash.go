@@ -0,0 +1,72 @@
+package sketch
+
+import (
+	"math/bits"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/mixhash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+)
+
+// SimHash is a 64-bit SimHash fingerprint of tokens. Every bit is a sign of
+// sum of the same bit of tokens hashes, so similar tokens differ in few bits.
+// Repeated tokens weigh more.
+type SimHash struct {
+	bands int
+}
+
+// NewSimHash creates new SimHash with fingerprint split into given number of
+// LSH bands, which must divide 64.
+func NewSimHash(bands int) (*SimHash, error) {
+	if bands <= 0 || 64%bands != 0 {
+		return nil, similarity.ErrInvalidBands
+	}
+
+	return &SimHash{
+		bands: bands,
+	}, nil
+}
+
+// Sketch returns SimHash fingerprint of tokens.
+func (s *SimHash) Sketch(tokens []string) similarity.Signature {
+	var weights [64]int
+	for _, token := range tokens {
+		h := mixhash.String(token)
+		for i := range weights {
+			if h&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+
+	return similarity.Signature{fingerprint}
+}
+
+// Similarity estimates similarity as a share of equal fingerprint bits. It
+// approximates cosine rather than Jaccard similarity of tokens.
+func (s *SimHash) Similarity(a, b similarity.Signature) float64 {
+	if len(a) != 1 || len(b) != 1 {
+		return 0
+	}
+
+	return 1 - float64(bits.OnesCount64(a[0]^b[0]))/64
+}
+
+// Bands returns fingerprint bit ranges.
+func (s *SimHash) Bands(sig similarity.Signature) []uint64 {
+	width := 64 / s.bands
+	bands := make([]uint64, s.bands)
+	for i := range bands {
+		bands[i] = sig[0] >> (i * width) & (1<<width - 1)
+	}
+
+	return bands
+}
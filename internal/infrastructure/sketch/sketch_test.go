@@ -0,0 +1,158 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+)
+
+func TestNewMinHash(t *testing.T) {
+	tests := []struct {
+		name         string
+		permutations int
+		bands        int
+		expectErr    error
+	}{
+		{"valid", 128, 32, nil},
+		{"uneven bands", 128, 30, similarity.ErrInvalidBands},
+		{"no permutations", 0, 1, similarity.ErrInvalidBands},
+		{"no bands", 128, 0, similarity.ErrInvalidBands},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMinHash(tt.permutations, tt.bands); !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestMinHash_Similarity(t *testing.T) {
+	m, err := NewMinHash(256, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		a, b    []string
+		jaccard float64
+	}{
+		{"equal", tokens(0, 100), tokens(0, 100), 1},
+		{"half", tokens(0, 100), tokens(33, 133), 67.0 / 133},
+		{"quarter", tokens(0, 100), tokens(60, 160), 40.0 / 160},
+		{"disjoint", tokens(0, 100), tokens(100, 200), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.Similarity(m.Sketch(tt.a), m.Sketch(tt.b))
+			if math.Abs(got-tt.jaccard) > 0.1 {
+				t.Errorf("expected similarity about %.2f, got %.2f", tt.jaccard, got)
+			}
+		})
+	}
+
+	if got := m.Similarity(m.Sketch(tokens(0, 10)), similarity.Signature{1}); got != 0 {
+		t.Errorf("expected zero similarity of foreign signature, got %.2f", got)
+	}
+}
+
+func TestMinHash_Sketch_Stable(t *testing.T) {
+	m, err := NewMinHash(4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Signatures are stored, so they must never change between releases.
+	sig := m.Sketch([]string{"jon", "smith"})
+	again := m.Sketch([]string{"smith", "jon", "jon"})
+	for i := range sig {
+		if sig[i] != again[i] {
+			t.Fatalf("expected order and duplicates independent signature, got %x and %x", sig, again)
+		}
+	}
+
+	if bands := m.Bands(sig); len(bands) != 2 || bands[0] == bands[1] {
+		t.Errorf("unexpected bands %x", bands)
+	}
+}
+
+func TestNewSimHash(t *testing.T) {
+	for _, bands := range []int{1, 4, 8, 64} {
+		if _, err := NewSimHash(bands); err != nil {
+			t.Errorf("unexpected error for %d bands: %v", bands, err)
+		}
+	}
+
+	for _, bands := range []int{0, 3, 128} {
+		if _, err := NewSimHash(bands); !errors.Is(err, similarity.ErrInvalidBands) {
+			t.Errorf("expected error %v for %d bands, got %v", similarity.ErrInvalidBands, bands, err)
+		}
+	}
+}
+
+func TestSimHash_Similarity(t *testing.T) {
+	s, err := NewSimHash(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := s.Sketch(tokens(0, 100))
+	if got := s.Similarity(base, s.Sketch(tokens(0, 100))); got != 1 {
+		t.Errorf("expected similarity of equal tokens 1, got %.2f", got)
+	}
+
+	near := s.Similarity(base, s.Sketch(tokens(5, 105)))
+	far := s.Similarity(base, s.Sketch(tokens(100, 200)))
+	if near <= far || near < 0.8 {
+		t.Errorf("expected near tokens more similar than disjoint ones, got %.2f and %.2f", near, far)
+	}
+
+	bands := s.Bands(similarity.Signature{0x0123_4567_89ab_cdef})
+	expect := []uint64{0xcdef, 0x89ab, 0x4567, 0x0123}
+	for i := range expect {
+		if bands[i] != expect[i] {
+			t.Fatalf("expected bands %x, got %x", expect, bands)
+		}
+	}
+}
+
+func TestNearDuplicates(t *testing.T) {
+	m, err := NewMinHash(128, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := m.Sketch(similarity.Tokenize("Jon Smith, 12 Main St", 3))
+	b := m.Sketch(similarity.Tokenize("John Smith, 12 Main Street", 3))
+	c := m.Sketch(similarity.Tokenize("Mary Jones, 7 Oak Avenue", 3))
+
+	if !shareBand(m.Bands(a), m.Bands(b)) {
+		t.Errorf("expected near duplicates to share band, similarity %.2f", m.Similarity(a, b))
+	}
+	if shareBand(m.Bands(a), m.Bands(c)) {
+		t.Errorf("expected different leads not to share band, similarity %.2f", m.Similarity(a, c))
+	}
+}
+
+func tokens(from, to int) []string {
+	t := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		t = append(t, fmt.Sprintf("token-%d", i))
+	}
+	return t
+}
+
+func shareBand(a, b []uint64) bool {
+	for i := range a {
+		if a[i] == b[i] {
+			return true
+		}
+	}
+	return false
+}
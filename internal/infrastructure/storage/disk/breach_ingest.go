@@ -0,0 +1,336 @@
+package diskinfra
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+)
+
+// defaultIngestChunk is a number of records sorted in memory at once if
+// chunk size is not set, about 160 MiB.
+const defaultIngestChunk = 1 << 22
+
+// breachRecord represents corpus hash with count. Hashes shorter than 32
+// bytes are zero-padded, so records of the same algorithm compare by hash.
+type breachRecord struct {
+	digest [32]byte
+	count  uint64
+}
+
+func compareBreachRecords(a, b breachRecord) int {
+	return bytes.Compare(a.digest[:], b.digest[:])
+}
+
+// IngestBreachCorpus reads corpus of "HASH[:COUNT]" lines of given algorithm
+// and writes it to store directory replacing ingested one. Counts of
+// repeated hashes are summed. Returns number of distinct hashes.
+//
+// Corpus is sorted externally: chunks of chunkSize records are sorted in
+// memory and written to temporary runs, which are merged into corpus file.
+func IngestBreachCorpus(dir string, alg breach.Algorithm, corpus io.Reader, chunkSize int) (uint64, error) {
+	if alg.Size() == 0 {
+		return 0, breach.ErrUnsupportedAlgorithm
+	}
+
+	if chunkSize <= 0 {
+		chunkSize = defaultIngestChunk
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return 0, fmt.Errorf("create corpus directory: %w", err)
+	}
+
+	runsDir, err := os.MkdirTemp(dir, "ingest-")
+	if err != nil {
+		return 0, fmt.Errorf("create runs directory: %w", err)
+	}
+	defer os.RemoveAll(runsDir)
+
+	runs, err := writeBreachRuns(runsDir, alg, corpus, chunkSize)
+	if err != nil {
+		return 0, err
+	}
+
+	path := breachFilePath(dir, alg)
+	tmp := path + ".tmp"
+	records, err := mergeBreachRuns(tmp, alg, runs)
+	if err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, fmt.Errorf("replace corpus: %w", err)
+	}
+
+	return records, nil
+}
+
+// writeBreachRuns writes sorted runs of corpus chunks and returns their
+// paths.
+func writeBreachRuns(dir string, alg breach.Algorithm, corpus io.Reader, chunkSize int) ([]string, error) {
+	var (
+		runs  []string
+		chunk = make([]breachRecord, 0, min(chunkSize, 1<<16))
+	)
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("run-%06d", len(runs)))
+		if err := writeBreachRun(path, alg, chunk); err != nil {
+			return err
+		}
+		runs = append(runs, path)
+		chunk = chunk[:0]
+
+		return nil
+	}
+
+	sc := bufio.NewScanner(corpus)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+
+		digest, count, err := breach.ParseLine(alg, sc.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		var rec breachRecord
+		copy(rec.digest[:], digest)
+		rec.count = count
+		chunk = append(chunk, rec)
+
+		if len(chunk) == chunkSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read corpus: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// writeBreachRun sorts chunk, sums counts of repeated hashes and writes it
+// in corpus records format.
+func writeBreachRun(path string, alg breach.Algorithm, chunk []breachRecord) error {
+	slices.SortFunc(chunk, compareBreachRecords)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create run: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for i := 0; i < len(chunk); {
+		rec := chunk[i]
+		for i++; i < len(chunk) && chunk[i].digest == rec.digest; i++ {
+			rec.count = addCount(rec.count, chunk[i].count)
+		}
+
+		if err := writeBreachRecord(w, alg, rec); err != nil {
+			return fmt.Errorf("write run: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("write run: %w", err)
+	}
+
+	return f.Close()
+}
+
+// mergeBreachRuns merges sorted runs into corpus file at path, writes prefix
+// index and returns number of distinct hashes.
+func mergeBreachRuns(path string, alg breach.Algorithm, runs []string) (uint64, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("create corpus: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(breachDataOffset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seek corpus: %w", err)
+	}
+
+	h := &runHeap{}
+	for _, path := range runs {
+		rf, err := os.Open(path)
+		if err != nil {
+			return 0, fmt.Errorf("open run: %w", err)
+		}
+		defer rf.Close()
+
+		r := &runReader{r: bufio.NewReader(rf), alg: alg}
+		if ok, err := r.next(); err != nil {
+			return 0, err
+		} else if ok {
+			heap.Push(h, r)
+		}
+	}
+
+	var (
+		w        = bufio.NewWriter(f)
+		prefixes = make([]uint64, breach.Prefixes)
+		records  uint64
+		cur      breachRecord
+		hasCur   bool
+	)
+
+	write := func(rec breachRecord) error {
+		if err := writeBreachRecord(w, alg, rec); err != nil {
+			return fmt.Errorf("write corpus: %w", err)
+		}
+		prefixes[breach.PrefixIndex(rec.digest[:])]++
+		records++
+		return nil
+	}
+
+	for h.Len() > 0 {
+		r := (*h)[0]
+		rec := r.rec
+
+		ok, err := r.next()
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+
+		if hasCur && rec.digest == cur.digest {
+			cur.count = addCount(cur.count, rec.count)
+			continue
+		}
+
+		if hasCur {
+			if err := write(cur); err != nil {
+				return 0, err
+			}
+		}
+		cur, hasCur = rec, true
+	}
+
+	if hasCur {
+		if err := write(cur); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, fmt.Errorf("write corpus: %w", err)
+	}
+
+	head := make([]byte, breachDataOffset)
+	copy(head, breachMagic)
+	head[4] = breachVersion
+	head[5] = byte(alg)
+	head[6] = byte(alg.Size())
+	binary.BigEndian.PutUint64(head[8:], records)
+
+	var offset uint64
+	for p, n := range prefixes {
+		binary.BigEndian.PutUint64(head[breachHeaderSize+p*offsetSize:], offset)
+		offset += n
+	}
+	binary.BigEndian.PutUint64(head[breachHeaderSize+breach.Prefixes*offsetSize:], offset)
+
+	if _, err := f.WriteAt(head, 0); err != nil {
+		return 0, fmt.Errorf("write index: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("sync corpus: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("close corpus: %w", err)
+	}
+
+	return records, nil
+}
+
+func writeBreachRecord(w io.Writer, alg breach.Algorithm, rec breachRecord) error {
+	var buf [32 + breachCountSize]byte
+	n := copy(buf[:], rec.digest[:alg.Size()])
+	binary.BigEndian.PutUint64(buf[n:], rec.count)
+
+	_, err := w.Write(buf[:n+breachCountSize])
+	return err
+}
+
+// addCount adds counts saturating at maximum uint64.
+func addCount(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// runReader reads records of sorted run.
+type runReader struct {
+	r   *bufio.Reader
+	alg breach.Algorithm
+	rec breachRecord
+}
+
+// next reads next record, reports false if run is over.
+func (r *runReader) next() (bool, error) {
+	var buf [32 + breachCountSize]byte
+	size := r.alg.Size()
+
+	_, err := io.ReadFull(r.r, buf[:size+breachCountSize])
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read run: %w", err)
+	}
+
+	r.rec = breachRecord{count: binary.BigEndian.Uint64(buf[size:])}
+	copy(r.rec.digest[:], buf[:size])
+
+	return true, nil
+}
+
+// runHeap orders run readers by their current records.
+type runHeap []*runReader
+
+func (h runHeap) Len() int { return len(h) }
+
+func (h runHeap) Less(i, j int) bool { return compareBreachRecords(h[i].rec, h[j].rec) < 0 }
+
+func (h runHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *runHeap) Push(x any) { *h = append(*h, x.(*runReader)) }
+
+func (h *runHeap) Pop() any {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
@@ -0,0 +1,167 @@
+package diskinfra
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+)
+
+// Breach corpus file of every algorithm is stored in "<algorithm>.range"
+// file, all integers are big-endian:
+//
+//	header  magic "HRNG", version u8, algorithm u8, hash size u8, reserved
+//	        u8, records u64
+//	index   breach.Prefixes+1 entries u64, number of records before prefix
+//	records hash, count u64, sorted by hash
+const (
+	breachMagic      = "HRNG"
+	breachVersion    = 1
+	breachHeaderSize = 16
+	breachIndexSize  = (breach.Prefixes + 1) * offsetSize
+	breachDataOffset = breachHeaderSize + breachIndexSize
+	breachCountSize  = 8
+)
+
+// BreachStore represents on-disk breach corpus store of hashes indexed by
+// prefix. Corpora are written by IngestBreachCorpus and read without loading
+// them into memory.
+type BreachStore struct {
+	files map[breach.Algorithm]*breachFile
+}
+
+type breachFile struct {
+	f       *os.File
+	alg     breach.Algorithm
+	records uint64
+}
+
+// OpenBreachStore opens corpora of every algorithm ingested into directory.
+// Corpora ingested after opening are not seen until store is reopened.
+func OpenBreachStore(dir string) (*BreachStore, error) {
+	s := &BreachStore{
+		files: map[breach.Algorithm]*breachFile{},
+	}
+
+	for _, alg := range []breach.Algorithm{breach.AlgorithmSHA1, breach.AlgorithmNTLM, breach.AlgorithmSHA256} {
+		bf, err := openBreachFile(breachFilePath(dir, alg), alg)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("open %s corpus: %w", alg, err)
+		}
+		s.files[alg] = bf
+	}
+
+	return s, nil
+}
+
+// Range returns entries of hashes of given algorithm starting with
+// uppercase prefix, sorted by suffix.
+func (s *BreachStore) Range(_ context.Context, alg breach.Algorithm, prefix string) ([]breach.Entry, error) {
+	bf, ok := s.files[alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", breach.ErrNotIngested, alg)
+	}
+
+	p, err := strconv.ParseUint(prefix, 16, 32)
+	if err != nil || len(prefix) != breach.PrefixSize {
+		return nil, breach.ErrInvalidPrefix
+	}
+
+	var bounds [2 * offsetSize]byte
+	if _, err := bf.f.ReadAt(bounds[:], int64(breachHeaderSize+p*offsetSize)); err != nil {
+		return nil, fmt.Errorf("read index: %w", err)
+	}
+
+	start := binary.BigEndian.Uint64(bounds[:offsetSize])
+	end := binary.BigEndian.Uint64(bounds[offsetSize:])
+	if start > end || end > bf.records {
+		return nil, breach.ErrMalformedStore
+	}
+
+	recSize := uint64(bf.alg.Size() + breachCountSize)
+	data := make([]byte, (end-start)*recSize)
+	if _, err := bf.f.ReadAt(data, int64(breachDataOffset+start*recSize)); err != nil {
+		return nil, fmt.Errorf("read records: %w", err)
+	}
+
+	entries := make([]breach.Entry, 0, end-start)
+	for rec := range slices.Chunk(data, int(recSize)) {
+		digest := rec[:bf.alg.Size()]
+		entries = append(entries, breach.Entry{
+			Suffix: breach.Suffix(digest),
+			Count:  binary.BigEndian.Uint64(rec[bf.alg.Size():]),
+		})
+	}
+
+	return entries, nil
+}
+
+// Close closes corpus files.
+func (s *BreachStore) Close() error {
+	var errs []error
+	for _, bf := range s.files {
+		errs = append(errs, bf.f.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func openBreachFile(path string, alg breach.Algorithm) (*breachFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bf, err := readBreachHeader(f, alg)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return bf, nil
+}
+
+func readBreachHeader(f *os.File, alg breach.Algorithm) (*breachFile, error) {
+	var header [breachHeaderSize]byte
+	if _, err := f.ReadAt(header[:], 0); err != nil {
+		return nil, fmt.Errorf("%w: %w", breach.ErrMalformedStore, err)
+	}
+
+	if string(header[:4]) != breachMagic || header[4] != breachVersion ||
+		breach.Algorithm(header[5]) != alg || int(header[6]) != alg.Size() {
+		return nil, fmt.Errorf("%w: unexpected header", breach.ErrMalformedStore)
+	}
+
+	records := binary.BigEndian.Uint64(header[8:])
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat corpus: %w", err)
+	}
+
+	recSize := uint64(alg.Size() + breachCountSize)
+	if uint64(info.Size()) != breachDataOffset+records*recSize {
+		return nil, fmt.Errorf("%w: unexpected size", breach.ErrMalformedStore)
+	}
+
+	return &breachFile{
+		f:       f,
+		alg:     alg,
+		records: records,
+	}, nil
+}
+
+func breachFilePath(dir string, alg breach.Algorithm) string {
+	return filepath.Join(dir, alg.String()+".range")
+}
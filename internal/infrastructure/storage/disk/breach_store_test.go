@@ -0,0 +1,120 @@
+package diskinfra
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+)
+
+// testCorpus contains SHA-1 hashes, two of them share "5BAA6" prefix and one
+// is repeated across chunks.
+const testCorpus = `5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:10
+7C4A8D09CA3762AF61E59520943DC26494F8941B:5
+
+5baa6000000000000000000000000000000000ff:2
+FFFFF00000000000000000000000000000000000
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3
+00000000000000000000000000000000000000AA:1
+`
+
+func TestBreachStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Chunk of 2 records makes several runs to merge.
+	records, err := IngestBreachCorpus(dir, breach.AlgorithmSHA1, strings.NewReader(testCorpus), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if records != 5 {
+		t.Errorf("expected 5 distinct hashes, got %d", records)
+	}
+
+	s, err := OpenBreachStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	tests := []struct {
+		name   string
+		prefix string
+		expect []breach.Entry
+	}{
+		{"shared prefix", "5BAA6", []breach.Entry{
+			{Suffix: "000000000000000000000000000000000FF", Count: 2},
+			{Suffix: "1E4C9B93F3F0682250B6CF8331B7EE68FD8", Count: 13},
+		}},
+		{"first prefix", "00000", []breach.Entry{
+			{Suffix: "000000000000000000000000000000000AA", Count: 1},
+		}},
+		{"last prefix", "FFFFF", []breach.Entry{
+			{Suffix: "00000000000000000000000000000000000", Count: 1},
+		}},
+		{"empty prefix", "12345", []breach.Entry{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := s.Range(ctx, breach.AlgorithmSHA1, tt.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(entries, tt.expect) {
+				t.Errorf("expected %v, got %v", tt.expect, entries)
+			}
+		})
+	}
+
+	if _, err := s.Range(ctx, breach.AlgorithmNTLM, "5BAA6"); !errors.Is(err, breach.ErrNotIngested) {
+		t.Errorf("expected error %v, got %v", breach.ErrNotIngested, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "sha1.range" {
+		t.Errorf("expected only corpus file to be left, got %v", entries)
+	}
+}
+
+func TestIngestBreachCorpus_MalformedLine(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := IngestBreachCorpus(dir, breach.AlgorithmNTLM, strings.NewReader("8846F7EAEE8FB117AD06BDD830B7586C\nnot-a-hash\n"), 0)
+	if !errors.Is(err, breach.ErrMalformedLine) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected malformed line 2 error, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "ntlm.range")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no corpus file, got %v", err)
+	}
+}
+
+func TestOpenBreachStore_Malformed(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := IngestBreachCorpus(dir, breach.AlgorithmSHA1, strings.NewReader(testCorpus), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "sha1.range")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data[:len(data)-1], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenBreachStore(dir); !errors.Is(err, breach.ErrMalformedStore) {
+		t.Errorf("expected error %v, got %v", breach.ErrMalformedStore, err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package diskinfra provides an embedded on-disk storage for single node
+// deployments.
+package diskinfra
@@ -0,0 +1,161 @@
+package diskinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+)
+
+// FilterRepository represents on-disk filters repository.
+//
+// Every filter is stored in its binary format in "<name>.filter" file, filters
+// of tenants are stored in tenant subdirectories.
+type FilterRepository struct {
+	dir string
+
+	// mu serializes file accesses, so updates don't lose each other items.
+	mu sync.Mutex
+}
+
+// NewFilterRepository creates new instance of on-disk filters repository in
+// given directory. Directory is created if it doesn't exist.
+func NewFilterRepository(dir string) (*FilterRepository, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create filters directory: %w", err)
+	}
+
+	return &FilterRepository{
+		dir: dir,
+	}, nil
+}
+
+// Save saves filter. Filter file is replaced atomically.
+func (r *FilterRepository) Save(_ context.Context, tenant, name string, f filter.Filter) error {
+	file, err := r.filterFile(tenant, name)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.write(file, f)
+}
+
+// Find finds filter by name.
+func (r *FilterRepository) Find(_ context.Context, tenant, name string) (filter.Filter, error) {
+	file, err := r.filterFile(tenant, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.read(file)
+}
+
+// Create saves filter unless filter with the same name exists.
+func (r *FilterRepository) Create(_ context.Context, tenant, name string, f filter.Filter) error {
+	file, err := r.filterFile(tenant, name)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := os.Stat(file); err == nil {
+		return fmt.Errorf("%w %q", filter.ErrExists, name)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("stat filter: %w", err)
+	}
+
+	return r.write(file, f)
+}
+
+// Update finds filter, calls fn with it and saves it. Filters are updated
+// one at a time, since directory is owned by single process.
+func (r *FilterRepository) Update(_ context.Context, tenant, name string, fn func(filter.Filter) error) (filter.Filter, error) {
+	file, err := r.filterFile(tenant, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(f); err != nil {
+		return nil, err
+	}
+
+	if err := r.write(file, f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// write replaces filter file atomically. Caller must hold mu.
+func (r *FilterRepository) write(file string, f filter.Filter) error {
+	data, err := f.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal filter: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return fmt.Errorf("create tenant directory: %w", err)
+	}
+
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write filter: %w", err)
+	}
+
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("replace filter: %w", err)
+	}
+
+	return nil
+}
+
+// read reads filter file. Caller must hold mu.
+func (r *FilterRepository) read(file string) (filter.Filter, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, filter.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read filter: %w", err)
+	}
+
+	return filter.Unmarshal(data)
+}
+
+// filterFile returns filter file rejecting names escaping filters directory.
+func (r *FilterRepository) filterFile(tenant, name string) (string, error) {
+	if err := filter.ValidateName(name); err != nil {
+		return "", err
+	}
+
+	dir := r.dir
+	if tenant != "" {
+		if tenant != filepath.Base(tenant) || tenant == "." || tenant == ".." {
+			return "", fmt.Errorf("invalid tenant name %q", tenant)
+		}
+		dir = filepath.Join(dir, tenant)
+	}
+
+	return filepath.Join(dir, name+".filter"), nil
+}
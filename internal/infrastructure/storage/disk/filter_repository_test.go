@@ -0,0 +1,113 @@
+package diskinfra
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+)
+
+func TestFilterRepository(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	r, err := NewFilterRepository(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.Find(ctx, "sales", "dnc"); !errors.Is(err, filter.ErrNotFound) {
+		t.Errorf("expected error %v, got %v", filter.ErrNotFound, err)
+	}
+
+	f, err := filter.NewCuckoo(100, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Add("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Save(ctx, "sales", "dnc", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sales", "dnc.filter")); err != nil {
+		t.Errorf("expected filter file, got %v", err)
+	}
+
+	got, err := r.Find(ctx, "sales", "dnc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Kind() != filter.KindCuckoo || !got.MightContain("foo") || got.Count() != 1 {
+		t.Errorf("unexpected filter %v of %d items", got.Kind(), got.Count())
+	}
+
+	if _, err := r.Find(ctx, "", "dnc"); !errors.Is(err, filter.ErrNotFound) {
+		t.Errorf("expected filters of other tenant separated, got %v", err)
+	}
+
+	if err := r.Save(ctx, "..", "dnc", f); err == nil {
+		t.Error("expected error for tenant escaping directory")
+	}
+	if _, err := r.Find(ctx, "sales", "../dnc"); !errors.Is(err, filter.ErrInvalidName) {
+		t.Errorf("expected error %v, got %v", filter.ErrInvalidName, err)
+	}
+}
+
+func TestFilterRepository_Update(t *testing.T) {
+	ctx := context.Background()
+
+	r, err := NewFilterRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	add := func(f filter.Filter) error { return f.Add("foo") }
+	if _, err := r.Update(ctx, "sales", "dnc", add); !errors.Is(err, filter.ErrNotFound) {
+		t.Errorf("expected error %v, got %v", filter.ErrNotFound, err)
+	}
+
+	f, err := filter.NewBloom(1000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Create(ctx, "sales", "dnc", f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Create(ctx, "sales", "dnc", f); !errors.Is(err, filter.ErrExists) {
+		t.Errorf("expected error %v, got %v", filter.ErrExists, err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Update(ctx, "sales", "dnc", add); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := r.Find(ctx, "sales", "dnc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Count() != writers {
+		t.Errorf("expected %d items added, got %d", writers, got.Count())
+	}
+
+	if _, err := r.Update(ctx, "sales", "dnc", func(filter.Filter) error { return filter.ErrFull }); !errors.Is(err, filter.ErrFull) {
+		t.Errorf("expected error %v, got %v", filter.ErrFull, err)
+	}
+	if got, _ := r.Find(ctx, "sales", "dnc"); got.Count() != writers {
+		t.Errorf("expected failed update discarded, got %d items", got.Count())
+	}
+}
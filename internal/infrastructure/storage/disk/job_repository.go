@@ -0,0 +1,308 @@
+package diskinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+)
+
+// JobRepository represents on-disk jobs repository.
+//
+// Every job is stored in its own directory with job state file and indexed
+// logs of rows and results. Finished jobs are removed after retention when
+// unfinished jobs are looked up. Leases are kept in memory, since jobs
+// directory is used by a single process.
+type JobRepository struct {
+	dir       string
+	retention time.Duration
+
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+// lease represents job lease held by owner until expiry.
+type lease struct {
+	owner  string
+	expiry time.Time
+}
+
+// NewJobRepository creates new instance of on-disk jobs repository in given
+// directory with given retention of finished jobs. Directory is created if
+// it doesn't exist.
+func NewJobRepository(dir string, retention time.Duration) (*JobRepository, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create jobs directory: %w", err)
+	}
+
+	return &JobRepository{
+		dir:       dir,
+		retention: retention,
+		leases:    make(map[string]lease),
+	}, nil
+}
+
+// Save stores job state. State file is replaced atomically.
+func (r *JobRepository) Save(_ context.Context, j *job.Job) error {
+	data, err := json.Marshal(j.Snapshot())
+	if err != nil {
+		return fmt.Errorf("marshal job: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dir, err := r.jobDir(j.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create job directory: %w", err)
+	}
+
+	tmp := filepath.Join(dir, "job.json.tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write job: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(dir, "job.json")); err != nil {
+		return fmt.Errorf("replace job: %w", err)
+	}
+
+	return nil
+}
+
+// Find finds job by identifier.
+func (r *JobRepository) Find(_ context.Context, id string) (*job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.find(id)
+}
+
+// FindUnfinished finds jobs which are not in terminal status and removes
+// finished jobs older than retention.
+func (r *JobRepository) FindUnfinished(_ context.Context) ([]*job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read jobs directory: %w", err)
+	}
+
+	var jobs []*job.Job
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		j, err := r.find(e.Name())
+		if errors.Is(err, job.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !j.Status().Terminal() {
+			jobs = append(jobs, j)
+			continue
+		}
+
+		if r.retention > 0 && time.Since(j.UpdatedAt()) > r.retention {
+			if err := os.RemoveAll(filepath.Join(r.dir, e.Name())); err != nil {
+				return nil, fmt.Errorf("remove expired job: %w", err)
+			}
+		}
+	}
+
+	return jobs, nil
+}
+
+// AppendRows appends uploaded rows.
+func (r *JobRepository) AppendRows(_ context.Context, id string, rows []string) error {
+	records := make([][]byte, len(rows))
+	for i, row := range rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("marshal row: %w", err)
+		}
+		records[i] = data
+	}
+
+	return r.append(id, "rows", records)
+}
+
+// Rows returns up to limit rows starting from offset.
+func (r *JobRepository) Rows(_ context.Context, id string, offset, limit int64) ([]string, error) {
+	records, err := r.read(id, "rows", offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]string, len(records))
+	for i, rec := range records {
+		if err := json.Unmarshal(rec, &rows[i]); err != nil {
+			return nil, fmt.Errorf("unmarshal row: %w", err)
+		}
+	}
+
+	return rows, nil
+}
+
+// AppendResults appends results of rows following already stored ones.
+func (r *JobRepository) AppendResults(_ context.Context, id string, results []job.Result) error {
+	records := make([][]byte, len(results))
+	for i, res := range results {
+		data, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		records[i] = data
+	}
+
+	return r.append(id, "results", records)
+}
+
+// Results returns up to limit results starting from offset.
+func (r *JobRepository) Results(_ context.Context, id string, offset, limit int64) ([]job.Result, error) {
+	records, err := r.read(id, "results", offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]job.Result, len(records))
+	for i, rec := range records {
+		if err := json.Unmarshal(rec, &results[i]); err != nil {
+			return nil, fmt.Errorf("unmarshal result: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// CountResults returns number of stored results.
+func (r *JobRepository) CountResults(_ context.Context, id string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, err := r.log(id, "results")
+	if err != nil {
+		return 0, err
+	}
+
+	return l.count()
+}
+
+// AcquireLease acquires or extends lease of job processing by owner for ttl.
+// Returns false if unexpired lease is held by another owner.
+func (r *JobRepository) AcquireLease(_ context.Context, id, owner string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := r.leases[id]; ok && l.owner != owner && now.Before(l.expiry) {
+		return false, nil
+	}
+
+	r.leases[id] = lease{owner: owner, expiry: now.Add(ttl)}
+
+	return true, nil
+}
+
+// ReleaseLease releases lease held by owner.
+func (r *JobRepository) ReleaseLease(_ context.Context, id, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, ok := r.leases[id]; ok && l.owner == owner {
+		delete(r.leases, id)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) find(id string) (*job.Job, error) {
+	dir, err := r.jobDir(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "job.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, job.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read job: %w", err)
+	}
+
+	var s job.Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal job: %w", err)
+	}
+
+	return job.FromSnapshot(s)
+}
+
+func (r *JobRepository) append(id, name string, records [][]byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, err := r.log(id, name)
+	if err != nil {
+		return err
+	}
+
+	if err := l.append(records); err != nil {
+		return fmt.Errorf("append %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) read(id, name string, offset, limit int64) ([][]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, err := r.log(id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := l.read(offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	return records, nil
+}
+
+func (r *JobRepository) log(id, name string) (recordLog, error) {
+	dir, err := r.jobDir(id)
+	if err != nil {
+		return recordLog{}, err
+	}
+
+	return recordLog{
+		dataFile:  filepath.Join(dir, name+".ndjson"),
+		indexFile: filepath.Join(dir, name+".idx"),
+	}, nil
+}
+
+// jobDir returns job directory rejecting identifiers escaping jobs
+// directory.
+func (r *JobRepository) jobDir(id string) (string, error) {
+	if id == "" || id != filepath.Base(id) || id == "." || id == ".." {
+		return "", job.ErrNotFound
+	}
+
+	return filepath.Join(r.dir, id), nil
+}
@@ -0,0 +1,173 @@
+package diskinfra
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+)
+
+func TestJobRepository(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	r, err := NewJobRepository(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	j, _ := job.New("job-1", nil, job.Settings{}, time.Now())
+	if err := r.Save(ctx, j); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := []string{"a", "multi\nline", "", "c"}
+	if err := r.AppendRows(ctx, j.ID(), rows[:2]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.AppendRows(ctx, j.ID(), rows[2:]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := r.Rows(ctx, j.ID(), 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows[1:]) {
+		t.Errorf("expected rows %q, got %q", rows[1:], got)
+	}
+
+	results := []job.Result{{Hash: "h1"}, {Error: "failed"}}
+	if err := r.AppendResults(ctx, j.ID(), results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n, err := r.CountResults(ctx, j.ID()); err != nil || n != 2 {
+		t.Errorf("expected 2 results, got %d, %v", n, err)
+	}
+
+	gotResults, err := r.Results(ctx, j.ID(), 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(gotResults, results[1:]) {
+		t.Errorf("expected results %v, got %v", results[1:], gotResults)
+	}
+
+	reopened, err := NewJobRepository(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unfinished, err := reopened.FindUnfinished(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unfinished) != 1 || unfinished[0].ID() != j.ID() {
+		t.Errorf("expected unfinished job to be found after reopen, got %v", unfinished)
+	}
+}
+
+func TestJobRepository_Expired(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	r, _ := NewJobRepository(dir, time.Minute)
+
+	old := time.Now().Add(-time.Hour)
+	j, _ := job.New("job-1", nil, job.Settings{}, old)
+	_ = j.Cancel(old)
+	if err := r.Save(ctx, j); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.FindUnfinished(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.Find(ctx, j.ID()); !errors.Is(err, job.ErrNotFound) {
+		t.Errorf("expected expired job to be removed, got %v", err)
+	}
+}
+
+func TestJobRepository_InterruptedAppend(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	r, _ := NewJobRepository(dir, 0)
+
+	j, _ := job.New("job-1", nil, job.Settings{}, time.Now())
+	_ = r.Save(ctx, j)
+	_ = r.AppendResults(ctx, j.ID(), []job.Result{{Hash: "h1"}})
+
+	// Simulate crash after data was written but before it was indexed.
+	f, err := os.OpenFile(filepath.Join(dir, j.ID(), "results.ndjson"), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"hash":"lost"}` + "\n")
+	_ = f.Close()
+
+	if err := r.AppendResults(ctx, j.ID(), []job.Result{{Hash: "h2"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := r.Results(ctx, j.ID(), 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := []job.Result{{Hash: "h1"}, {Hash: "h2"}}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %v, got %v", expect, got)
+	}
+}
+
+func TestJobRepository_Lease(t *testing.T) {
+	ctx := context.Background()
+	r, _ := NewJobRepository(t.TempDir(), 0)
+
+	steps := []struct {
+		owner  string
+		ttl    time.Duration
+		expect bool
+	}{
+		{owner: "a", ttl: time.Hour, expect: true},
+		{owner: "b", ttl: time.Hour, expect: false},
+		{owner: "a", ttl: -time.Second, expect: true},
+		{owner: "b", ttl: time.Hour, expect: true},
+	}
+
+	for i, step := range steps {
+		acquired, err := r.AcquireLease(ctx, "job-1", step.owner, step.ttl)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if acquired != step.expect {
+			t.Errorf("step %d: expected acquired %v by %q, got %v", i, step.expect, step.owner, acquired)
+		}
+	}
+
+	_ = r.ReleaseLease(ctx, "job-1", "a")
+	if acquired, _ := r.AcquireLease(ctx, "job-1", "a", time.Hour); acquired {
+		t.Error("expected lease of another owner to survive release")
+	}
+
+	_ = r.ReleaseLease(ctx, "job-1", "b")
+	if acquired, _ := r.AcquireLease(ctx, "job-1", "a", time.Hour); !acquired {
+		t.Error("expected released lease to be acquired")
+	}
+}
+
+func TestJobRepository_InvalidID(t *testing.T) {
+	r, _ := NewJobRepository(t.TempDir(), 0)
+
+	for _, id := range []string{"", "..", "../job", "a/b"} {
+		if _, err := r.Find(context.Background(), id); !errors.Is(err, job.ErrNotFound) {
+			t.Errorf("Find(%q) expected %v, got %v", id, job.ErrNotFound, err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package diskinfra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// offsetSize is a size of a single index entry.
+const offsetSize = 8
+
+// recordLog represents append-only log of newline separated records with an
+// index of record offsets allowing to read records by their position.
+//
+// Data is written before index, so records interrupted by crash are never
+// indexed and stay unreachable.
+type recordLog struct {
+	dataFile  string
+	indexFile string
+}
+
+func (l recordLog) append(records [][]byte) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	data, err := os.OpenFile(l.dataFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open data: %w", err)
+	}
+	defer data.Close()
+
+	index, err := os.OpenFile(l.indexFile, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("open index: %w", err)
+	}
+	defer index.Close()
+
+	dataInfo, err := data.Stat()
+	if err != nil {
+		return fmt.Errorf("stat data: %w", err)
+	}
+
+	indexInfo, err := index.Stat()
+	if err != nil {
+		return fmt.Errorf("stat index: %w", err)
+	}
+
+	var (
+		buf     bytes.Buffer
+		offsets = make([]byte, 0, len(records)*offsetSize)
+		pos     = dataInfo.Size()
+	)
+	for _, r := range records {
+		offsets = binary.BigEndian.AppendUint64(offsets, uint64(pos))
+		buf.Write(r)
+		buf.WriteByte('\n')
+		pos += int64(len(r)) + 1
+	}
+
+	if _, err := data.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write data: %w", err)
+	}
+	if err := data.Sync(); err != nil {
+		return fmt.Errorf("sync data: %w", err)
+	}
+
+	// Index entry partially written before crash is dropped.
+	end := indexInfo.Size() - indexInfo.Size()%offsetSize
+	if _, err := index.WriteAt(offsets, end); err != nil {
+		return fmt.Errorf("write index: %w", err)
+	}
+	if err := index.Truncate(end + int64(len(offsets))); err != nil {
+		return fmt.Errorf("truncate index: %w", err)
+	}
+
+	return nil
+}
+
+func (l recordLog) count() (int64, error) {
+	fi, err := os.Stat(l.indexFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("stat index: %w", err)
+	}
+
+	return fi.Size() / offsetSize, nil
+}
+
+func (l recordLog) read(offset, limit int64) ([][]byte, error) {
+	total, err := l.count()
+	if err != nil {
+		return nil, err
+	}
+
+	if offset >= total || limit <= 0 {
+		return nil, nil
+	}
+	limit = min(limit, total-offset)
+
+	index, err := os.Open(l.indexFile)
+	if err != nil {
+		return nil, fmt.Errorf("open index: %w", err)
+	}
+	defer index.Close()
+
+	offsets := make([]byte, (limit+1)*offsetSize)
+	n, err := index.ReadAt(offsets, offset*offsetSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read index: %w", err)
+	}
+
+	data, err := os.Open(l.dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("open data: %w", err)
+	}
+	defer data.Close()
+
+	start := int64(binary.BigEndian.Uint64(offsets))
+
+	var end int64
+	if int64(n) > limit*offsetSize {
+		end = int64(binary.BigEndian.Uint64(offsets[limit*offsetSize:]))
+	} else {
+		fi, err := data.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("stat data: %w", err)
+		}
+		end = fi.Size()
+	}
+
+	block := make([]byte, end-start)
+	if _, err := data.ReadAt(block, start); err != nil {
+		return nil, fmt.Errorf("read data: %w", err)
+	}
+
+	records := make([][]byte, 0, limit)
+	for i := int64(0); i < limit; i++ {
+		rec := block[int64(binary.BigEndian.Uint64(offsets[i*offsetSize:]))-start:]
+		j := bytes.IndexByte(rec, '\n')
+		if j < 0 {
+			return nil, fmt.Errorf("read data: record %d is truncated", offset+i)
+		}
+		records = append(records, rec[:j])
+	}
+
+	return records, nil
+}
@@ -0,0 +1,121 @@
+package diskinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+)
+
+// LogRepository represents on-disk transparency log repository.
+//
+// Entry preimages and signed tree heads are stored in indexed logs, every
+// signed head is kept.
+type LogRepository struct {
+	entries recordLog
+	heads   recordLog
+
+	mu sync.Mutex
+}
+
+// NewLogRepository creates new instance of on-disk transparency log
+// repository in given directory. Directory is created if it doesn't exist.
+func NewLogRepository(dir string) (*LogRepository, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+
+	return &LogRepository{
+		entries: recordLog{
+			dataFile:  filepath.Join(dir, "entries.ndjson"),
+			indexFile: filepath.Join(dir, "entries.idx"),
+		},
+		heads: recordLog{
+			dataFile:  filepath.Join(dir, "heads.ndjson"),
+			indexFile: filepath.Join(dir, "heads.idx"),
+		},
+	}, nil
+}
+
+// Append appends entries following already stored ones.
+func (r *LogRepository) Append(_ context.Context, entries []*translog.Entry) error {
+	records := make([][]byte, len(entries))
+	for i, e := range entries {
+		records[i] = []byte(e.Preimage())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.entries.append(records); err != nil {
+		return fmt.Errorf("append entries: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns up to limit entries starting from offset.
+func (r *LogRepository) Entries(_ context.Context, offset, limit int64) ([]*translog.Entry, error) {
+	r.mu.Lock()
+	records, err := r.entries.read(offset, limit)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("read entries: %w", err)
+	}
+
+	entries := make([]*translog.Entry, len(records))
+	for i, rec := range records {
+		if entries[i], err = translog.ParseEntry(offset+int64(i), string(rec)); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// SaveHead stores signed tree head.
+func (r *LogRepository) SaveHead(_ context.Context, head *translog.TreeHead) error {
+	data, err := json.Marshal(head)
+	if err != nil {
+		return fmt.Errorf("marshal tree head: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.heads.append([][]byte{data}); err != nil {
+		return fmt.Errorf("append tree head: %w", err)
+	}
+
+	return nil
+}
+
+// LatestHead returns the latest stored tree head.
+func (r *LogRepository) LatestHead(_ context.Context) (*translog.TreeHead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, err := r.heads.count()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, translog.ErrNoTreeHead
+	}
+
+	records, err := r.heads.read(n-1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("read tree head: %w", err)
+	}
+
+	var head translog.TreeHead
+	if err := json.Unmarshal(records[0], &head); err != nil {
+		return nil, fmt.Errorf("unmarshal tree head: %w", err)
+	}
+
+	return &head, nil
+}
@@ -0,0 +1,76 @@
+package diskinfra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+)
+
+func TestLogRepository(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	r, err := NewLogRepository(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.LatestHead(ctx); !errors.Is(err, translog.ErrNoTreeHead) {
+		t.Errorf("expected error %v, got %v", translog.ErrNoTreeHead, err)
+	}
+
+	h, _ := hash.New("foo", "acbd18db4cc2f85cedef654fccc4a4d8", hash.AlgorithmMD5)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	var entries []*translog.Entry
+	for i := range 3 {
+		e, err := translog.NewEntry(int64(i), "sales", h, now.Add(time.Duration(i)*time.Second), make([]byte, translog.NonceSize))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := r.Append(ctx, entries[:1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Append(ctx, entries[1:]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reopened repository reads entries stored before.
+	r, err = NewLogRepository(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := r.Entries(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Index() != 1 || got[1].Leaf() != entries[2].Leaf() {
+		t.Errorf("expected entries %v, got %v", entries[1:], got)
+	}
+
+	heads := []*translog.TreeHead{
+		{Size: 1, Root: "aa", Timestamp: now, KeyID: "k", Signature: []byte{1}},
+		{Size: 3, Root: "bb", Timestamp: now.Add(time.Minute), KeyID: "k", Signature: []byte{2}},
+	}
+	for _, head := range heads {
+		if err := r.SaveHead(ctx, head); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	head, err := r.LatestHead(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(head, heads[1]) {
+		t.Errorf("expected head %+v, got %+v", heads[1], head)
+	}
+}
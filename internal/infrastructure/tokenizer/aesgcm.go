@@ -0,0 +1,124 @@
+package tokenizer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+)
+
+// KeySize is a size of master key in bytes.
+const KeySize = 32
+
+// tokenSize is a number of MAC bytes token is made of.
+const tokenSize = 20
+
+// ErrInvalidKey is returned when master key has invalid size.
+var ErrInvalidKey = fmt.Errorf("key must be %d bytes", KeySize)
+
+// ErrOpen is returned when sealed value can't be decrypted, e.g. it was made
+// by another key or bound to another token.
+var ErrOpen = errors.New("open sealed value")
+
+var tokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// AESGCM is a token cipher. Tokens are HMAC-SHA256 of values, values are
+// sealed with AES-256-GCM using token as additional data, so sealed value
+// can't be moved to another token. Both keys are derived from a single master
+// key with HKDF.
+type AESGCM struct {
+	macKey []byte
+	aead   cipher.AEAD
+}
+
+// NewAESGCM creates new instance of cipher by master key.
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	macKey, err := hkdf.Key(sha256.New, key, nil, "hasher token mac", KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive mac key: %w", err)
+	}
+
+	encKey, err := hkdf.Key(sha256.New, key, nil, "hasher token encryption", KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %w", err)
+	}
+
+	return &AESGCM{
+		macKey: macKey,
+		aead:   aead,
+	}, nil
+}
+
+// LoadKeyfile creates new instance of cipher by master key stored hex-encoded
+// in file. Surrounding whitespace is ignored.
+func LoadKeyfile(file string) (*AESGCM, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read keyfile: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decode keyfile: %w", err)
+	}
+
+	return NewAESGCM(key)
+}
+
+// Token derives token of value.
+func (c *AESGCM) Token(value string) string {
+	mac := hmac.New(sha256.New, c.macKey)
+	mac.Write([]byte(value))
+
+	return token.Prefix + strings.ToLower(tokenEncoding.EncodeToString(mac.Sum(nil)[:tokenSize]))
+}
+
+// Seal encrypts value bound to token. Sealed value is a random nonce followed
+// by ciphertext.
+func (c *AESGCM) Seal(tok, value string) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(value)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("read nonce: %w", err)
+	}
+
+	return c.aead.Seal(nonce, nonce, []byte(value), []byte(tok)), nil
+}
+
+// Open decrypts value sealed for token.
+func (c *AESGCM) Open(tok string, sealed []byte) (string, error) {
+	if len(sealed) < c.aead.NonceSize() {
+		return "", ErrOpen
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	value, err := c.aead.Open(nil, nonce, ciphertext, []byte(tok))
+	if err != nil {
+		return "", ErrOpen
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,115 @@
+package tokenizer
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+)
+
+func TestAESGCM_Token(t *testing.T) {
+	c := mustCipher(t, 1)
+
+	tok := c.Token("foo@example.com")
+	if err := token.ValidateToken(tok); err != nil {
+		t.Fatalf("expected valid token, got %q: %v", tok, err)
+	}
+
+	if len(tok) != len(token.Prefix)+32 {
+		t.Errorf("expected %d chars token, got %q", len(token.Prefix)+32, tok)
+	}
+
+	if c.Token("foo@example.com") != tok {
+		t.Error("expected equal values to get equal tokens")
+	}
+
+	if c.Token("bar@example.com") == tok {
+		t.Error("expected different values to get different tokens")
+	}
+
+	if mustCipher(t, 2).Token("foo@example.com") == tok {
+		t.Error("expected different keys to derive different tokens")
+	}
+}
+
+func TestAESGCM_SealOpen(t *testing.T) {
+	c := mustCipher(t, 1)
+	tok := c.Token("foo@example.com")
+
+	sealed, err := c.Seal(tok, "foo@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bytes.Contains(sealed, []byte("foo@example.com")) {
+		t.Error("expected value to be encrypted")
+	}
+
+	value, err := c.Open(tok, sealed)
+	if err != nil || value != "foo@example.com" {
+		t.Fatalf("expected opened value, got %q, %v", value, err)
+	}
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name   string
+		cipher *AESGCM
+		token  string
+		sealed []byte
+	}{
+		{"another token", c, c.Token("bar@example.com"), sealed},
+		{"another key", mustCipher(t, 2), tok, sealed},
+		{"tampered", c, tok, tampered},
+		{"truncated", c, tok, sealed[:5]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cipher.Open(tt.token, tt.sealed); !errors.Is(err, ErrOpen) {
+				t.Errorf("expected error %v, got %v", ErrOpen, err)
+			}
+		})
+	}
+}
+
+func TestLoadKeyfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expectErr bool
+	}{
+		{"valid", strings.Repeat("ab", KeySize) + "\n", false},
+		{"short", strings.Repeat("ab", KeySize-1), true},
+		{"not hex", strings.Repeat("zz", KeySize), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "key")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadKeyfile(file)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func mustCipher(t *testing.T, b byte) *AESGCM {
+	t.Helper()
+
+	c, err := NewAESGCM(bytes.Repeat([]byte{b}, KeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,2 @@
+// Package tokenizer provides token ciphers.
+package tokenizer
@@ -0,0 +1,54 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type breachServer struct {
+	pbhasher.UnimplementedBreachServiceServer
+	breachSvc *application.BreachService
+}
+
+func (s *breachServer) Range(ctx context.Context, req *pbhasher.BreachRangeRequest) (*pbhasher.BreachRangeResponse, error) {
+	alg, err := convertBreachAlgorithm(req.Algorithm)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	entries, err := s.breachSvc.Range(ctx, alg, req.Prefix, req.Padding)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.BreachRangeResponse{
+		Entries: make([]*pbhasher.BreachEntry, len(entries)),
+	}
+	for i, e := range entries {
+		resp.Entries[i] = &pbhasher.BreachEntry{
+			Suffix: e.Suffix,
+			Count:  e.Count,
+		}
+	}
+
+	return resp, nil
+}
+
+// convertBreachAlgorithm converts protobuf breach algorithm to domain one.
+func convertBreachAlgorithm(alg pbhasher.BreachAlgorithm) (breach.Algorithm, error) {
+	switch alg {
+	case pbhasher.BreachAlgorithm_BREACH_ALGORITHM_SHA1:
+		return breach.AlgorithmSHA1, nil
+	case pbhasher.BreachAlgorithm_BREACH_ALGORITHM_NTLM:
+		return breach.AlgorithmNTLM, nil
+	case pbhasher.BreachAlgorithm_BREACH_ALGORITHM_SHA256:
+		return breach.AlgorithmSHA256, nil
+	default:
+		return 0, breach.ErrUnsupportedAlgorithm
+	}
+}
@@ -0,0 +1,90 @@
+package grpcsrv
+
+import (
+	"context"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type counterServer struct {
+	pbhasher.UnimplementedCounterServiceServer
+	counterSvc *application.CounterService
+}
+
+func (s *counterServer) CountUnique(ctx context.Context, req *pbhasher.CountUniqueRequest) (*pbhasher.CountUniqueResponse, error) {
+	start, end, err := convertRange(req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	total, buckets, err := s.counterSvc.CountUnique(ctx, req.Key, start, end)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.CountUniqueResponse{
+		Count:   total,
+		Buckets: make([]*pbhasher.BucketCount, len(buckets)),
+	}
+	for i, b := range buckets {
+		resp.Buckets[i] = &pbhasher.BucketCount{
+			Start: timestamppb.New(b.Start),
+			Count: b.Count,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *counterServer) MergeCounts(ctx context.Context, req *pbhasher.MergeCountsRequest) (*pbhasher.MergeCountsResponse, error) {
+	if len(req.Keys) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of keys exceeds %d", maxBatchSize)
+	}
+
+	start, end, err := convertRange(req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.counterSvc.MergeCounts(ctx, req.Keys, start, end)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.MergeCountsResponse{
+		Count: n,
+	}, nil
+}
+
+// convertRange converts protobuf range to domain one. Unset timestamps are
+// converted to zero time, so service defaults are used.
+func convertRange(start, end *timestamppb.Timestamp) (time.Time, time.Time, error) {
+	s, err := convertTimestamp(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	e, err := convertTimestamp(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return s, e, nil
+}
+
+func convertTimestamp(ts *timestamppb.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return ts.AsTime(), nil
+}
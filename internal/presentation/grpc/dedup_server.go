@@ -0,0 +1,97 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type dedupServer struct {
+	pbhasher.UnimplementedDedupServiceServer
+	dedupSvc *application.DedupService
+}
+
+func (s *dedupServer) SeenBefore(ctx context.Context, req *pbhasher.SeenBeforeRequest) (*pbhasher.SeenBeforeResponse, error) {
+	dedupReq, err := toDedupRequest(req.Input, req.Algorithm, req.Normalization, req.Window)
+	if err != nil {
+		return nil, err
+	}
+	dedupReq.Namespace = req.Namespace
+
+	lead, seen, err := s.dedupSvc.SeenBefore(ctx, dedupReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.SeenBeforeResponse{
+		Seen: seen,
+	}
+	if seen {
+		resp.Lead = toPBLead(lead)
+	}
+
+	return resp, nil
+}
+
+func (s *dedupServer) Register(ctx context.Context, req *pbhasher.RegisterRequest) (*pbhasher.RegisterResponse, error) {
+	dedupReq, err := toDedupRequest(req.Input, req.Algorithm, req.Normalization, req.Window)
+	if err != nil {
+		return nil, err
+	}
+	dedupReq.Namespace = req.Namespace
+	dedupReq.Source = req.Source
+
+	lead, isNew, err := s.dedupSvc.Register(ctx, dedupReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.RegisterResponse{
+		New:  isNew,
+		Lead: toPBLead(lead),
+	}, nil
+}
+
+func toDedupRequest(input string, alg pbhasher.HashAlgorithm, norm pbhasher.HashNormalization, window *durationpb.Duration) (application.DedupRequest, error) {
+	if input == "" {
+		return application.DedupRequest{}, status.Error(codes.InvalidArgument, "input is required")
+	}
+
+	domainAlg, err := convertAlgorithm(alg)
+	if err != nil {
+		return application.DedupRequest{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	domainNorm, err := convertNormalization(norm)
+	if err != nil {
+		return application.DedupRequest{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	req := application.DedupRequest{
+		Input:         input,
+		Algorithm:     domainAlg,
+		Normalization: domainNorm,
+	}
+	if window != nil {
+		if err := window.CheckValid(); err != nil {
+			return application.DedupRequest{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		req.Window = window.AsDuration()
+	}
+
+	return req, nil
+}
+
+func toPBLead(lead *dedup.Lead) *pbhasher.Lead {
+	return &pbhasher.Lead{
+		Hash:      lead.Key(),
+		Source:    lead.Source(),
+		FirstSeen: timestamppb.New(lead.FirstSeen()),
+	}
+}
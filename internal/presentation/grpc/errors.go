@@ -0,0 +1,124 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/breach"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/dedup"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/experiment"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/fpe"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/kdf"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/merkle"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/placement"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/similarity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/token"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus converts application error to gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, hash.ErrEmptyInput),
+		errors.Is(err, hash.ErrSaltTooLong),
+		errors.Is(err, hash.ErrUnknownPepperVersion),
+		errors.Is(err, hash.ErrMalformedDocument),
+		errors.Is(err, hash.ErrInvalidFieldPath),
+		errors.Is(err, application.ErrAlgorithmRequired),
+		errors.Is(err, record.ErrMissingColumn),
+		errors.Is(err, record.ErrUnsupportedFormat),
+		errors.Is(err, record.ErrMissingField),
+		errors.Is(err, record.ErrSeparatorInValue),
+		errors.Is(err, token.ErrEmptyValue),
+		errors.Is(err, token.ErrValueTooLong),
+		errors.Is(err, token.ErrMalformedToken),
+		errors.Is(err, fpe.ErrEmptyValue),
+		errors.Is(err, fpe.ErrInvalidTweak),
+		errors.Is(err, fpe.ErrInvalidLength),
+		errors.Is(err, merkle.ErrEmptyTree),
+		errors.Is(err, merkle.ErrIndexOutOfRange),
+		errors.Is(err, merkle.ErrSizeOutOfRange),
+		errors.Is(err, merkle.ErrMalformedHash),
+		errors.Is(err, receipt.ErrRequestIDTooLong),
+		errors.Is(err, dedup.ErrInvalidNamespace),
+		errors.Is(err, dedup.ErrSourceTooLong),
+		errors.Is(err, dedup.ErrNegativeWindow),
+		errors.Is(err, similarity.ErrEmptyText),
+		errors.Is(err, similarity.ErrInvalidID),
+		errors.Is(err, similarity.ErrInvalidThreshold),
+		errors.Is(err, filter.ErrInvalidName),
+		errors.Is(err, filter.ErrInvalidCapacity),
+		errors.Is(err, filter.ErrInvalidFPRate),
+		errors.Is(err, filter.ErrTooLarge),
+		errors.Is(err, filter.ErrUnsupportedKind),
+		errors.Is(err, filter.ErrMalformed),
+		errors.Is(err, cardinality.ErrInvalidKey),
+		errors.Is(err, cardinality.ErrNoKeys),
+		errors.Is(err, cardinality.ErrTooManyKeys),
+		errors.Is(err, cardinality.ErrInvalidRange),
+		errors.Is(err, cardinality.ErrTooManyBuckets),
+		errors.Is(err, experiment.ErrEmptyUnit),
+		errors.Is(err, placement.ErrInvalidName),
+		errors.Is(err, placement.ErrNoNodes),
+		errors.Is(err, placement.ErrTooManyNodes),
+		errors.Is(err, placement.ErrEmptyNode),
+		errors.Is(err, placement.ErrDuplicateNode),
+		errors.Is(err, placement.ErrUnknownNode),
+		errors.Is(err, placement.ErrInvalidVirtualNodes),
+		errors.Is(err, placement.ErrInvalidReplicas),
+		errors.Is(err, placement.ErrEmptyKey),
+		errors.Is(err, placement.ErrUnsupportedKind),
+		errors.Is(err, breach.ErrInvalidPrefix),
+		errors.Is(err, breach.ErrUnsupportedAlgorithm),
+		errors.Is(err, kdf.ErrInvalidLength),
+		errors.Is(err, kdf.ErrInvalidIterations),
+		errors.Is(err, kdf.ErrTooMuchWork),
+		errors.Is(err, kdf.ErrEmptyPassword),
+		errors.Is(err, kdf.ErrPasswordTooLong),
+		errors.Is(err, kdf.ErrSaltTooLong),
+		errors.Is(err, kdf.ErrInfoTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tenant.ErrQuotaExceeded),
+		errors.Is(err, job.ErrTooManyRows),
+		errors.Is(err, filter.ErrFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, job.ErrNotFound),
+		errors.Is(err, token.ErrNotFound),
+		errors.Is(err, fpe.ErrUnknownScheme),
+		errors.Is(err, record.ErrUnknownRecipe),
+		errors.Is(err, translog.ErrNoTreeHead),
+		errors.Is(err, similarity.ErrUnknownIndex),
+		errors.Is(err, filter.ErrNotFound),
+		errors.Is(err, experiment.ErrUnknownExperiment),
+		errors.Is(err, placement.ErrNotFound),
+		errors.Is(err, breach.ErrNotIngested):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, filter.ErrExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, token.ErrForbidden),
+		errors.Is(err, kdf.ErrForbidden),
+		errors.Is(err, fpe.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, placement.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, job.ErrInvalidTransition),
+		errors.Is(err, filter.ErrDeleteUnsupported):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,54 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/experiment"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type experimentServer struct {
+	pbhasher.UnimplementedExperimentServiceServer
+	experimentSvc *application.ExperimentService
+}
+
+func (s *experimentServer) Assign(ctx context.Context, req *pbhasher.AssignRequest) (*pbhasher.AssignResponse, error) {
+	if len(req.Experiments) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of experiments exceeds %d", maxBatchSize)
+	}
+
+	assignments, err := s.experimentSvc.Assign(ctx, req.UnitId, req.Experiments)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.AssignResponse{
+		Assignments: make([]*pbhasher.Assignment, len(assignments)),
+	}
+	for i, a := range assignments {
+		resp.Assignments[i] = &pbhasher.Assignment{
+			Experiment: a.Experiment,
+			Status:     toPBAssignmentStatus(a.Status),
+			Variant:    a.Variant,
+			Bucket:     a.Bucket,
+		}
+	}
+
+	return resp, nil
+}
+
+func toPBAssignmentStatus(st experiment.Status) pbhasher.AssignmentStatus {
+	switch st {
+	case experiment.StatusAssigned:
+		return pbhasher.AssignmentStatus_ASSIGNMENT_STATUS_ASSIGNED
+	case experiment.StatusHoldout:
+		return pbhasher.AssignmentStatus_ASSIGNMENT_STATUS_HOLDOUT
+	case experiment.StatusExcluded:
+		return pbhasher.AssignmentStatus_ASSIGNMENT_STATUS_EXCLUDED
+	default:
+		return pbhasher.AssignmentStatus_ASSIGNMENT_STATUS_UNSPECIFIED
+	}
+}
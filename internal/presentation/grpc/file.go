@@ -0,0 +1,151 @@
+package grpcsrv
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fileChunkSize is a size of hashed file chunks sent to client.
+const fileChunkSize = 64 << 10
+
+// HashFile hashes file streamed by client while it is being uploaded, so
+// client should read hashed chunks concurrently with sending file.
+func (s *hashServer) HashFile(stream grpc.BidiStreamingServer[pbhasher.HashFileRequest, pbhasher.HashFileResponse]) error {
+	if s.fileSvc == nil {
+		return status.Error(codes.Unimplemented, "files hashing is disabled")
+	}
+
+	req, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "file format and rules are required")
+	}
+	if err != nil {
+		return err
+	}
+
+	format, err := convertFormat(req.Format)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	spec, err := convertSpec(req.Rules)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	in, pw := io.Pipe()
+	go func() {
+		for {
+			if len(req.Data) > 0 {
+				if _, err := pw.Write(req.Data); err != nil {
+					return
+				}
+			}
+
+			var err error
+			req, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	out := bufio.NewWriterSize(chunkWriter{stream: stream}, fileChunkSize)
+
+	params := hash.Params{Salt: req.Salt, PepperVersion: req.PepperVersion}
+	report, err := s.fileSvc.HashFile(stream.Context(), format, spec, params, in, out)
+	in.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return st.Err()
+		}
+		return toStatus(err)
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	return stream.Send(&pbhasher.HashFileResponse{
+		Payload: &pbhasher.HashFileResponse_Report{Report: toPBReport(report)},
+	})
+}
+
+// chunkWriter sends every write as a data message.
+type chunkWriter struct {
+	stream grpc.BidiStreamingServer[pbhasher.HashFileRequest, pbhasher.HashFileResponse]
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pbhasher.HashFileResponse{
+		Payload: &pbhasher.HashFileResponse_Data{Data: p},
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func convertFormat(pbFormat pbhasher.FileFormat) (record.Format, error) {
+	switch pbFormat {
+	case pbhasher.FileFormat_FILE_FORMAT_CSV:
+		return record.FormatCSV, nil
+	case pbhasher.FileFormat_FILE_FORMAT_NDJSON:
+		return record.FormatNDJSON, nil
+	default:
+		return 0, errors.New("unsupported file format")
+	}
+}
+
+func convertSpec(pbRules []*pbhasher.ColumnRule) (*record.Spec, error) {
+	rules := make([]*record.Rule, len(pbRules))
+	for i, r := range pbRules {
+		alg, err := convertAlgorithm(r.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		norm, err := convertNormalization(r.Normalization)
+		if err != nil {
+			return nil, err
+		}
+
+		if rules[i], err = record.NewRule(r.Column, alg, norm); err != nil {
+			return nil, err
+		}
+	}
+
+	return record.NewSpec(rules...)
+}
+
+func toPBReport(r *application.FileReport) *pbhasher.HashFileReport {
+	errs := make([]*pbhasher.RowError, len(r.Errors))
+	for i, e := range r.Errors {
+		errs[i] = &pbhasher.RowError{
+			Row:    e.Row,
+			Column: e.Column,
+			Error:  e.Err,
+		}
+	}
+
+	return &pbhasher.HashFileReport{
+		Rows:          r.Rows,
+		FailedRows:    r.FailedRows,
+		Errors:        errs,
+		PepperVersion: r.PepperVersion,
+	}
+}
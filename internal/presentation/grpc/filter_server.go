@@ -0,0 +1,184 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/filter"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// filterChunkSize is a size of exported filter chunks.
+const filterChunkSize = 1 << 20
+
+type filterServer struct {
+	pbhasher.UnimplementedFilterServiceServer
+	filterSvc *application.FilterService
+}
+
+func (s *filterServer) CreateFilter(ctx context.Context, req *pbhasher.CreateFilterRequest) (*pbhasher.FilterInfo, error) {
+	kind, err := convertFilterKind(req.Kind)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	f, err := s.filterSvc.Create(ctx, req.Name, kind, req.Capacity, req.FpRate)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBFilterInfo(req.Name, f), nil
+}
+
+func (s *filterServer) GetFilter(ctx context.Context, req *pbhasher.GetFilterRequest) (*pbhasher.FilterInfo, error) {
+	f, err := s.filterSvc.Filter(ctx, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBFilterInfo(req.Name, f), nil
+}
+
+func (s *filterServer) AddItems(ctx context.Context, req *pbhasher.FilterItemsRequest) (*pbhasher.FilterInfo, error) {
+	return s.modify(ctx, req, s.filterSvc.Add)
+}
+
+func (s *filterServer) RemoveItems(ctx context.Context, req *pbhasher.FilterItemsRequest) (*pbhasher.FilterInfo, error) {
+	return s.modify(ctx, req, s.filterSvc.Remove)
+}
+
+func (s *filterServer) MightContain(ctx context.Context, req *pbhasher.FilterItemsRequest) (*pbhasher.MightContainResponse, error) {
+	alg, norm, err := convertFilterItems(req)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.filterSvc.MightContain(ctx, req.Name, req.Inputs, alg, norm)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.MightContainResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *filterServer) ExportFilter(req *pbhasher.ExportFilterRequest, stream grpc.ServerStreamingServer[pbhasher.FilterChunk]) error {
+	data, err := s.filterSvc.Export(stream.Context(), req.Name)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for len(data) > 0 {
+		n := min(len(data), filterChunkSize)
+		if err := stream.Send(&pbhasher.FilterChunk{Data: data[:n]}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+
+	return nil
+}
+
+// ImportFilter collects filter data of every message and imports filter
+// once client closes stream.
+func (s *filterServer) ImportFilter(stream grpc.ClientStreamingServer[pbhasher.ImportFilterRequest, pbhasher.FilterInfo]) error {
+	var (
+		name string
+		data []byte
+	)
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			name = req.Name
+		}
+
+		if len(data)+len(req.Data) > filter.MaxSize {
+			return status.Errorf(codes.ResourceExhausted, "filter size exceeds %d bytes", filter.MaxSize)
+		}
+		data = append(data, req.Data...)
+	}
+
+	f, err := s.filterSvc.Import(stream.Context(), name, data)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return stream.SendAndClose(toPBFilterInfo(name, f))
+}
+
+func (s *filterServer) modify(
+	ctx context.Context,
+	req *pbhasher.FilterItemsRequest,
+	fn func(context.Context, string, []string, hash.Algorithm, hash.Normalization) (filter.Filter, error),
+) (*pbhasher.FilterInfo, error) {
+	alg, norm, err := convertFilterItems(req)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fn(ctx, req.Name, req.Inputs, alg, norm)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBFilterInfo(req.Name, f), nil
+}
+
+func convertFilterItems(req *pbhasher.FilterItemsRequest) (hash.Algorithm, hash.Normalization, error) {
+	if len(req.Inputs) > maxBatchSize {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "number of inputs exceeds %d", maxBatchSize)
+	}
+
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	norm, err := convertNormalization(req.Normalization)
+	if err != nil {
+		return 0, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return alg, norm, nil
+}
+
+// convertFilterKind converts protobuf filter kind to domain one.
+func convertFilterKind(kind pbhasher.FilterKind) (filter.Kind, error) {
+	switch kind {
+	case pbhasher.FilterKind_FILTER_KIND_BLOOM:
+		return filter.KindBloom, nil
+	case pbhasher.FilterKind_FILTER_KIND_CUCKOO:
+		return filter.KindCuckoo, nil
+	default:
+		return 0, filter.ErrUnsupportedKind
+	}
+}
+
+func toPBFilterInfo(name string, f filter.Filter) *pbhasher.FilterInfo {
+	kind := pbhasher.FilterKind_FILTER_KIND_BLOOM
+	if f.Kind() == filter.KindCuckoo {
+		kind = pbhasher.FilterKind_FILTER_KIND_CUCKOO
+	}
+
+	return &pbhasher.FilterInfo{
+		Name:     name,
+		Kind:     kind,
+		Capacity: f.Capacity(),
+		FpRate:   f.FPRate(),
+		Count:    f.Count(),
+	}
+}
@@ -0,0 +1,35 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+type fpeServer struct {
+	pbhasher.UnimplementedFPEServiceServer
+	fpeSvc *application.FPEService
+}
+
+func (s *fpeServer) Encrypt(ctx context.Context, req *pbhasher.FPERequest) (*pbhasher.FPEResponse, error) {
+	value, err := s.fpeSvc.Encrypt(ctx, req.Scheme, req.Value, req.Tweak)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.FPEResponse{
+		Value: value,
+	}, nil
+}
+
+func (s *fpeServer) Decrypt(ctx context.Context, req *pbhasher.FPERequest) (*pbhasher.FPEResponse, error) {
+	value, err := s.fpeSvc.Decrypt(ctx, req.Scheme, req.Value, req.Tweak)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.FPEResponse{
+		Value: value,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package grpcsrv
+
+import (
+	"context"
+	"net"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is a metadata key carrying caller API key.
+const APIKeyHeader = "x-api-key"
+
+// Authenticator resolves caller identity.
+type Authenticator struct {
+	apiKeys map[string]string
+}
+
+// NewAuthenticator creates new instance of authenticator with provided API
+// keys mapped to client names.
+func NewAuthenticator(apiKeys map[string]string) *Authenticator {
+	return &Authenticator{
+		apiKeys: apiKeys,
+	}
+}
+
+// Authenticate resolves caller identity and stores it in context.
+//
+// Caller is identified by API key if it is passed, then by verified client
+// certificate subject if mutual TLS is used, otherwise by peer IP address.
+// Unknown API keys are rejected, callers that can't be identified are passed
+// through without identity.
+func (a *Authenticator) Authenticate(ctx context.Context) (context.Context, error) {
+	if keys := metadata.ValueFromIncomingContext(ctx, APIKeyHeader); len(keys) > 0 {
+		client, ok := a.apiKeys[keys[0]]
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+
+		return withIdentity(ctx, client, identity.SourceAPIKey), nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if chains := tlsInfo.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+			return withIdentity(ctx, chains[0][0].Subject.String(), identity.SourceCertificate), nil
+		}
+	}
+
+	if p.Addr == nil {
+		return ctx, nil
+	}
+
+	host := p.Addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return withIdentity(ctx, host, identity.SourcePeer), nil
+}
+
+func withIdentity(ctx context.Context, subject string, src identity.Source) context.Context {
+	id, err := identity.New(subject, src)
+	if err != nil {
+		return ctx
+	}
+
+	return identity.NewContext(ctx, id)
+}
@@ -0,0 +1,180 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/job"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type jobServer struct {
+	pbhasher.UnimplementedJobServiceServer
+	jobSvc *application.JobService
+}
+
+// SubmitJob creates job with settings of the first message, uploads rows of
+// every message and commits job once client closes stream. Job is canceled
+// if upload fails.
+func (s *jobServer) SubmitJob(stream grpc.ClientStreamingServer[pbhasher.SubmitJobRequest, pbhasher.Job]) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "job rows are required")
+	}
+	if err != nil {
+		return err
+	}
+
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	norm, err := convertNormalization(req.Normalization)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	j, err := s.jobSvc.CreateJob(ctx, job.Settings{
+		Algorithm:     alg,
+		Normalization: norm,
+		Params:        hash.Params{Salt: req.Salt, PepperVersion: req.PepperVersion},
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	if err := s.upload(stream, j.ID(), req); err != nil {
+		if _, cancelErr := s.jobSvc.CancelJob(context.WithoutCancel(ctx), j.ID()); cancelErr != nil {
+			return toStatus(errors.Join(err, cancelErr))
+		}
+		return err
+	}
+
+	j, err = s.jobSvc.CommitJob(ctx, j.ID())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return stream.SendAndClose(toPBJob(j))
+}
+
+func (s *jobServer) upload(stream grpc.ClientStreamingServer[pbhasher.SubmitJobRequest, pbhasher.Job], id string, req *pbhasher.SubmitJobRequest) error {
+	var total int
+	for {
+		if len(req.Rows) > 0 {
+			if err := s.jobSvc.UploadRows(stream.Context(), id, req.Rows); err != nil {
+				return toStatus(err)
+			}
+			total += len(req.Rows)
+		}
+
+		var err error
+		req, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if total == 0 {
+		return status.Error(codes.InvalidArgument, "job rows are required")
+	}
+
+	return nil
+}
+
+func (s *jobServer) GetJob(ctx context.Context, req *pbhasher.GetJobRequest) (*pbhasher.Job, error) {
+	j, err := s.jobSvc.GetJob(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBJob(j), nil
+}
+
+func (s *jobServer) WatchJob(req *pbhasher.WatchJobRequest, stream grpc.ServerStreamingServer[pbhasher.Job]) error {
+	err := s.jobSvc.WatchJob(stream.Context(), req.Id, func(j *job.Job) error {
+		return stream.Send(toPBJob(j))
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+func (s *jobServer) DownloadResults(req *pbhasher.DownloadResultsRequest, stream grpc.ServerStreamingServer[pbhasher.JobResult]) error {
+	if req.Offset < 0 {
+		return status.Error(codes.InvalidArgument, "offset cannot be negative")
+	}
+
+	err := s.jobSvc.Results(stream.Context(), req.Id, req.Offset, func(i int64, r job.Result) error {
+		return stream.Send(&pbhasher.JobResult{
+			Index: i,
+			Hash:  r.Hash,
+			Error: r.Error,
+		})
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+func (s *jobServer) CancelJob(ctx context.Context, req *pbhasher.CancelJobRequest) (*pbhasher.Job, error) {
+	j, err := s.jobSvc.CancelJob(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBJob(j), nil
+}
+
+func toPBJob(j *job.Job) *pbhasher.Job {
+	return &pbhasher.Job{
+		Id:            j.ID(),
+		Status:        toPBJobStatus(j.Status()),
+		Algorithm:     toPBAlgorithm(j.Settings().Algorithm),
+		Normalization: toPBNormalization(j.Settings().Normalization),
+		TotalRows:     j.Total(),
+		ProcessedRows: j.Processed(),
+		FailedRows:    j.Failed(),
+		Error:         j.Error(),
+		CreatedAt:     timestamppb.New(j.CreatedAt()),
+		UpdatedAt:     timestamppb.New(j.UpdatedAt()),
+		Salt:          j.Settings().Params.Salt,
+		PepperVersion: j.Settings().Params.PepperVersion,
+	}
+}
+
+func toPBJobStatus(st job.Status) pbhasher.JobStatus {
+	switch st {
+	case job.StatusUploading:
+		return pbhasher.JobStatus_JOB_STATUS_UPLOADING
+	case job.StatusPending:
+		return pbhasher.JobStatus_JOB_STATUS_PENDING
+	case job.StatusRunning:
+		return pbhasher.JobStatus_JOB_STATUS_RUNNING
+	case job.StatusSucceeded:
+		return pbhasher.JobStatus_JOB_STATUS_SUCCEEDED
+	case job.StatusFailed:
+		return pbhasher.JobStatus_JOB_STATUS_FAILED
+	case job.StatusCanceled:
+		return pbhasher.JobStatus_JOB_STATUS_CANCELED
+	default:
+		return pbhasher.JobStatus_JOB_STATUS_UNSPECIFIED
+	}
+}
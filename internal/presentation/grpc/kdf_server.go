@@ -0,0 +1,47 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type kdfServer struct {
+	pbhasher.UnimplementedKDFServiceServer
+	kdfSvc *application.KDFService
+}
+
+func (s *kdfServer) DeriveHKDF(ctx context.Context, req *pbhasher.DeriveHKDFRequest) (*pbhasher.DerivedKey, error) {
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := s.kdfSvc.HKDF(ctx, alg, req.Salt, req.Info, int(req.Length))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.DerivedKey{
+		Key: key,
+	}, nil
+}
+
+func (s *kdfServer) DerivePBKDF2(ctx context.Context, req *pbhasher.DerivePBKDF2Request) (*pbhasher.DerivedKey, error) {
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := s.kdfSvc.PBKDF2(ctx, alg, req.Password, req.Salt, int(req.Iterations), int(req.Length))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.DerivedKey{
+		Key: key,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type merkleServer struct {
+	pbhasher.UnimplementedMerkleServiceServer
+	merkleSvc *application.MerkleService
+}
+
+func (s *merkleServer) BuildTree(ctx context.Context, req *pbhasher.BuildTreeRequest) (*pbhasher.BuildTreeResponse, error) {
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tree, err := s.merkleSvc.BuildTree(ctx, req.Leaves, alg)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.BuildTreeResponse{
+		Root: tree.Root(),
+		Size: tree.Size(),
+	}
+
+	if req.IncludeProofs {
+		resp.Proofs = make([]*pbhasher.InclusionProof, tree.Size())
+		for i := range tree.Size() {
+			leafHash, err := tree.LeafHash(i)
+			if err != nil {
+				return nil, toStatus(err)
+			}
+
+			path, err := tree.Proof(i)
+			if err != nil {
+				return nil, toStatus(err)
+			}
+
+			resp.Proofs[i] = &pbhasher.InclusionProof{
+				Index:     i,
+				LeafHash:  leafHash,
+				AuditPath: path,
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *merkleServer) VerifyInclusion(ctx context.Context, req *pbhasher.VerifyInclusionRequest) (*pbhasher.VerifyInclusionResponse, error) {
+	alg, err := convertAlgorithm(req.Algorithm)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	valid, err := s.merkleSvc.VerifyInclusion(ctx, alg, req.Leaf, req.Index, req.TreeSize, req.AuditPath, req.Root)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.VerifyInclusionResponse{
+		Valid: valid,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/placement"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type placementServer struct {
+	pbhasher.UnimplementedPlacementServiceServer
+	placementSvc *application.PlacementService
+}
+
+func (s *placementServer) RegisterNodeSet(ctx context.Context, req *pbhasher.RegisterNodeSetRequest) (*pbhasher.NodeSetChange, error) {
+	kind, err := convertPlacementKind(req.Kind)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	set, moved, err := s.placementSvc.Register(ctx, req.Name, kind, req.Nodes, int(req.VirtualNodes), int(req.Replicas))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.NodeSetChange{
+		NodeSet:       toPBNodeSet(set),
+		MovedFraction: moved,
+	}, nil
+}
+
+func (s *placementServer) GetNodeSet(ctx context.Context, req *pbhasher.GetNodeSetRequest) (*pbhasher.NodeSet, error) {
+	set, err := s.placementSvc.NodeSet(ctx, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPBNodeSet(set), nil
+}
+
+func (s *placementServer) AddNodes(ctx context.Context, req *pbhasher.UpdateNodesRequest) (*pbhasher.NodeSetChange, error) {
+	set, moved, err := s.placementSvc.AddNodes(ctx, req.Name, req.Nodes, int(req.Replicas))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.NodeSetChange{
+		NodeSet:       toPBNodeSet(set),
+		MovedFraction: moved,
+	}, nil
+}
+
+func (s *placementServer) RemoveNodes(ctx context.Context, req *pbhasher.UpdateNodesRequest) (*pbhasher.NodeSetChange, error) {
+	set, moved, err := s.placementSvc.RemoveNodes(ctx, req.Name, req.Nodes, int(req.Replicas))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.NodeSetChange{
+		NodeSet:       toPBNodeSet(set),
+		MovedFraction: moved,
+	}, nil
+}
+
+func (s *placementServer) Locate(ctx context.Context, req *pbhasher.LocateRequest) (*pbhasher.LocateResponse, error) {
+	if len(req.Keys) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of keys exceeds %d", maxBatchSize)
+	}
+
+	placed, err := s.placementSvc.Locate(ctx, req.Name, req.Keys, int(req.Replicas))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.LocateResponse{
+		Placements: make([]*pbhasher.KeyPlacement, len(placed)),
+	}
+	for i, nodes := range placed {
+		resp.Placements[i] = &pbhasher.KeyPlacement{
+			Key:   req.Keys[i],
+			Nodes: nodes,
+		}
+	}
+
+	return resp, nil
+}
+
+// convertPlacementKind converts protobuf placement kind to domain one.
+func convertPlacementKind(kind pbhasher.PlacementKind) (placement.Kind, error) {
+	switch kind {
+	case pbhasher.PlacementKind_PLACEMENT_KIND_RING:
+		return placement.KindRing, nil
+	case pbhasher.PlacementKind_PLACEMENT_KIND_RENDEZVOUS:
+		return placement.KindRendezvous, nil
+	case pbhasher.PlacementKind_PLACEMENT_KIND_JUMP:
+		return placement.KindJump, nil
+	default:
+		return 0, placement.ErrUnsupportedKind
+	}
+}
+
+func toPBPlacementKind(kind placement.Kind) pbhasher.PlacementKind {
+	switch kind {
+	case placement.KindRing:
+		return pbhasher.PlacementKind_PLACEMENT_KIND_RING
+	case placement.KindRendezvous:
+		return pbhasher.PlacementKind_PLACEMENT_KIND_RENDEZVOUS
+	case placement.KindJump:
+		return pbhasher.PlacementKind_PLACEMENT_KIND_JUMP
+	default:
+		return pbhasher.PlacementKind_PLACEMENT_KIND_UNSPECIFIED
+	}
+}
+
+func toPBNodeSet(set *placement.NodeSet) *pbhasher.NodeSet {
+	return &pbhasher.NodeSet{
+		Name:         set.Name(),
+		Kind:         toPBPlacementKind(set.Kind()),
+		Nodes:        set.Nodes(),
+		VirtualNodes: uint32(set.VirtualNodes()),
+	}
+}
@@ -0,0 +1,81 @@
+package grpcsrv
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/identity"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterHeader is a trailer key carrying number of seconds client should
+// wait before retrying rate limited request.
+const RetryAfterHeader = "retry-after"
+
+// RateLimitUnaryInterceptor returns unary server interceptor that limits
+// requests per caller identity and RPC method.
+//
+// Rejected requests get codes.ResourceExhausted with retry-after trailer and
+// RetryInfo details. Limiter errors are logged and requests are let through.
+func RateLimitUnaryInterceptor(limiter ratelimit.Limiter, policy *ratelimit.Policy, log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if d, ok := allow(ctx, limiter, policy, info.FullMethod, log); !ok {
+			_ = grpc.SetTrailer(ctx, retryAfterTrailer(d))
+			return nil, rateLimited(d)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor returns stream server interceptor that limits
+// streams per caller identity and RPC method. Every stream is counted as a
+// single request regardless of number of messages.
+func RateLimitStreamInterceptor(limiter ratelimit.Limiter, policy *ratelimit.Policy, log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if d, ok := allow(ss.Context(), limiter, policy, info.FullMethod, log); !ok {
+			ss.SetTrailer(retryAfterTrailer(d))
+			return rateLimited(d)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func allow(ctx context.Context, limiter ratelimit.Limiter, policy *ratelimit.Policy, method string, log *slog.Logger) (ratelimit.Decision, bool) {
+	subject, key := "", "anonymous"
+	if id, ok := identity.FromContext(ctx); ok {
+		subject = id.Subject()
+		key = id.Source().String() + ":" + subject
+	}
+
+	d, err := limiter.Allow(ctx, key+":"+method, policy.Limit(subject, method))
+	if err != nil {
+		log.Error("failed to check rate limit", slog.String("error", err.Error()))
+		return d, true
+	}
+
+	return d, d.Allowed
+}
+
+func retryAfterTrailer(d ratelimit.Decision) metadata.MD {
+	seconds := int(math.Ceil(d.RetryAfter.Seconds()))
+	return metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds))
+}
+
+func rateLimited(d ratelimit.Decision) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(d.RetryAfter)}); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,35 @@
+package grpcsrv
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+type receiptServer struct {
+	pbhasher.UnimplementedReceiptServiceServer
+	receiptSvc *application.ReceiptService
+}
+
+func (s *receiptServer) GetJWKS(ctx context.Context, _ *pbhasher.GetJWKSRequest) (*pbhasher.JWKS, error) {
+	keys := s.receiptSvc.Keys(ctx)
+
+	jwks := &pbhasher.JWKS{
+		Keys: make([]*pbhasher.JWK, len(keys)),
+	}
+	for i, k := range keys {
+		jwks.Keys[i] = &pbhasher.JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k.PublicKey),
+			Kid: k.ID,
+			Use: "sig",
+			Alg: receipt.SignatureAlgorithm,
+		}
+	}
+
+	return jwks, nil
+}
@@ -6,48 +6,303 @@ import (
 	"errors"
 
 	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/cardinality"
 	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
 	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxBatchSize is a maximum number of requests in a single batch.
+const maxBatchSize = 1000
+
 type hashServer struct {
 	pbhasher.UnimplementedHasherServiceServer
-	hashSvc *application.HashService
+	hashSvc    *application.HashService
+	fileSvc    *application.FileService
+	recordSvc  *application.RecordService
+	receiptSvc *application.ReceiptService
+	counterSvc *application.CounterService
+}
+
+// Services represents application services exposed over gRPC. Files and
+// records hashing, receipts, deduplication, similarity search, membership
+// filters, unique counting, experiments, placement, breach ranges, jobs,
+// tokenization, FPE, key derivation and transparency log API are served only
+// if their services are set.
+type Services struct {
+	Hash       *application.HashService
+	File       *application.FileService
+	Record     *application.RecordService
+	Job        *application.JobService
+	Token      *application.TokenService
+	FPE        *application.FPEService
+	KDF        *application.KDFService
+	Merkle     *application.MerkleService
+	TransLog   *application.TransparencyLogService
+	Receipt    *application.ReceiptService
+	Dedup      *application.DedupService
+	Similarity *application.SimilarityService
+	Filter     *application.FilterService
+	Counter    *application.CounterService
+	Experiment *application.ExperimentService
+	Placement  *application.PlacementService
+	Breach     *application.BreachService
 }
 
 // Register wraps a native gRPC register and registers gRPC server
-// implementation.
-func Register(s *grpc.Server, hashSvc *application.HashService) {
+// implementations.
+func Register(s *grpc.Server, svcs Services) {
 	pbhasher.RegisterHasherServiceServer(s, &hashServer{
-		hashSvc: hashSvc,
+		hashSvc:    svcs.Hash,
+		fileSvc:    svcs.File,
+		recordSvc:  svcs.Record,
+		receiptSvc: svcs.Receipt,
+		counterSvc: svcs.Counter,
 	})
+
+	pbhasher.RegisterMerkleServiceServer(s, &merkleServer{
+		merkleSvc: svcs.Merkle,
+	})
+
+	if svcs.Job != nil {
+		pbhasher.RegisterJobServiceServer(s, &jobServer{
+			jobSvc: svcs.Job,
+		})
+	}
+
+	if svcs.Token != nil {
+		pbhasher.RegisterTokenServiceServer(s, &tokenServer{
+			tokenSvc: svcs.Token,
+		})
+	}
+
+	if svcs.FPE != nil {
+		pbhasher.RegisterFPEServiceServer(s, &fpeServer{
+			fpeSvc: svcs.FPE,
+		})
+	}
+
+	if svcs.KDF != nil {
+		pbhasher.RegisterKDFServiceServer(s, &kdfServer{
+			kdfSvc: svcs.KDF,
+		})
+	}
+
+	if svcs.TransLog != nil {
+		pbhasher.RegisterTransparencyLogServiceServer(s, &translogServer{
+			translogSvc: svcs.TransLog,
+		})
+	}
+
+	if svcs.Receipt != nil {
+		pbhasher.RegisterReceiptServiceServer(s, &receiptServer{
+			receiptSvc: svcs.Receipt,
+		})
+	}
+
+	if svcs.Dedup != nil {
+		pbhasher.RegisterDedupServiceServer(s, &dedupServer{
+			dedupSvc: svcs.Dedup,
+		})
+	}
+
+	if svcs.Similarity != nil {
+		pbhasher.RegisterSimilarityServiceServer(s, &similarityServer{
+			similaritySvc: svcs.Similarity,
+		})
+	}
+
+	if svcs.Filter != nil {
+		pbhasher.RegisterFilterServiceServer(s, &filterServer{
+			filterSvc: svcs.Filter,
+		})
+	}
+
+	if svcs.Counter != nil {
+		pbhasher.RegisterCounterServiceServer(s, &counterServer{
+			counterSvc: svcs.Counter,
+		})
+	}
+
+	if svcs.Experiment != nil {
+		pbhasher.RegisterExperimentServiceServer(s, &experimentServer{
+			experimentSvc: svcs.Experiment,
+		})
+	}
+
+	if svcs.Placement != nil {
+		pbhasher.RegisterPlacementServiceServer(s, &placementServer{
+			placementSvc: svcs.Placement,
+		})
+	}
+
+	if svcs.Breach != nil {
+		pbhasher.RegisterBreachServiceServer(s, &breachServer{
+			breachSvc: svcs.Breach,
+		})
+	}
 }
 
+// Hash hashes single input. Receipt is issued on request if receipts are
+// enabled.
 func (s *hashServer) Hash(ctx context.Context, req *pbhasher.HashRequest) (*pbhasher.HashResponse, error) {
+	if req.WithReceipt {
+		if s.receiptSvc == nil {
+			return nil, status.Error(codes.FailedPrecondition, "receipts are disabled")
+		}
+
+		if err := receipt.ValidateRequestID(req.RequestId); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	h, err := s.hash(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbhasher.HashResponse{
+		Hash:          h.Hashed(),
+		Salt:          h.Salt(),
+		PepperVersion: h.PepperVersion(),
+	}
+
+	if req.WithReceipt {
+		if resp.Receipt, err = s.receiptSvc.Issue(ctx, h, req.RequestId); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	return resp, nil
+}
+
+// HashRecord hashes composite key of record fields built by named recipe.
+func (s *hashServer) HashRecord(ctx context.Context, req *pbhasher.HashRecordRequest) (*pbhasher.HashResponse, error) {
+	if s.recordSvc == nil {
+		return nil, status.Error(codes.Unimplemented, "records hashing is disabled")
+	}
+
+	if req.Recipe == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe is required")
+	}
+
+	h, err := s.recordSvc.HashRecord(ctx, req.Recipe, req.Fields, hash.Params{
+		Salt:          req.Salt,
+		PepperVersion: req.PepperVersion,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.HashResponse{
+		Hash:          h.Hashed(),
+		Salt:          h.Salt(),
+		PepperVersion: h.PepperVersion(),
+	}, nil
+}
+
+// HashBatch hashes every request independently. Failed requests don't fail
+// the whole batch, their errors are returned in results.
+func (s *hashServer) HashBatch(ctx context.Context, req *pbhasher.HashBatchRequest) (*pbhasher.HashBatchResponse, error) {
+	if len(req.Requests) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size exceeds %d", maxBatchSize)
+	}
+
+	results := make([]*pbhasher.HashBatchResult, len(req.Requests))
+	for i, r := range req.Requests {
+		h, err := s.hash(ctx, r)
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.ResourceExhausted {
+				return nil, err
+			}
+
+			results[i] = &pbhasher.HashBatchResult{Error: status.Convert(err).Message()}
+			continue
+		}
+
+		results[i] = &pbhasher.HashBatchResult{
+			Hash:          h.Hashed(),
+			Salt:          h.Salt(),
+			PepperVersion: h.PepperVersion(),
+		}
+	}
+
+	return &pbhasher.HashBatchResponse{
+		Results: results,
+	}, nil
+}
+
+// hash hashes single input and adds its digest to counter if requested.
+func (s *hashServer) hash(ctx context.Context, req *pbhasher.HashRequest) (*hash.Hash, error) {
 	if req.Input == "" {
 		return nil, status.Error(codes.InvalidArgument, "input is required")
 	}
 
+	if req.Counter != "" {
+		if s.counterSvc == nil {
+			return nil, status.Error(codes.FailedPrecondition, "unique counting is disabled")
+		}
+
+		if err := cardinality.ValidateKey(req.Counter); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
 	domainAlg, err := convertAlgorithm(req.Algorithm)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	h, err := s.hashSvc.CreateHash(ctx, req.Input, domainAlg)
+	domainNorm, err := convertNormalization(req.Normalization)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return &pbhasher.HashResponse{
-		Hash: h.Hashed(),
-	}, nil
+	params := hash.Params{
+		Salt:          req.Salt,
+		PepperVersion: req.PepperVersion,
+	}
+
+	var h *hash.Hash
+	switch req.InputFormat {
+	case pbhasher.HashInputFormat_HASH_INPUT_FORMAT_UNSPECIFIED, pbhasher.HashInputFormat_HASH_INPUT_FORMAT_TEXT:
+		if len(req.IncludeFields) > 0 || len(req.ExcludeFields) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "fields can be selected for JSON input only")
+		}
+		h, err = s.hashSvc.CreateHash(ctx, req.Input, domainAlg, domainNorm, params)
+	case pbhasher.HashInputFormat_HASH_INPUT_FORMAT_JSON:
+		if domainNorm != 0 {
+			return nil, status.Error(codes.InvalidArgument, "normalization is not supported for JSON input")
+		}
+		h, err = s.hashSvc.CreateDocumentHash(ctx, req.Input, hash.Fields{
+			Include: req.IncludeFields,
+			Exclude: req.ExcludeFields,
+		}, domainAlg, params)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "unsupported input format")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if req.Counter != "" {
+		if err := s.counterSvc.Add(ctx, req.Counter, h); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	return h, nil
 }
 
+// convertAlgorithm converts protobuf algorithm to domain one. Unspecified
+// algorithm is converted to zero value, so service defaults are used.
 func convertAlgorithm(pbAlg pbhasher.HashAlgorithm) (hash.Algorithm, error) {
 	switch pbAlg {
+	case pbhasher.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED:
+		return 0, nil
 	case pbhasher.HashAlgorithm_HASH_ALGORITHM_MD5:
 		return hash.AlgorithmMD5, nil
 	case pbhasher.HashAlgorithm_HASH_ALGORITHM_SHA256:
@@ -56,3 +311,53 @@ func convertAlgorithm(pbAlg pbhasher.HashAlgorithm) (hash.Algorithm, error) {
 		return 0, errors.New("unsupported algorithm")
 	}
 }
+
+// convertNormalization converts protobuf normalization to domain one.
+// Unspecified normalization is converted to zero value, so service defaults
+// are used.
+func convertNormalization(pbNorm pbhasher.HashNormalization) (hash.Normalization, error) {
+	switch pbNorm {
+	case pbhasher.HashNormalization_HASH_NORMALIZATION_UNSPECIFIED:
+		return 0, nil
+	case pbhasher.HashNormalization_HASH_NORMALIZATION_NONE:
+		return hash.NormalizationNone, nil
+	case pbhasher.HashNormalization_HASH_NORMALIZATION_TRIM:
+		return hash.NormalizationTrim, nil
+	case pbhasher.HashNormalization_HASH_NORMALIZATION_LOWER:
+		return hash.NormalizationLower, nil
+	case pbhasher.HashNormalization_HASH_NORMALIZATION_DIGITS:
+		return hash.NormalizationDigits, nil
+	default:
+		return 0, errors.New("unsupported normalization")
+	}
+}
+
+// toPBAlgorithm converts domain algorithm to protobuf one. Zero algorithm is
+// converted to unspecified.
+func toPBAlgorithm(alg hash.Algorithm) pbhasher.HashAlgorithm {
+	switch alg {
+	case hash.AlgorithmMD5:
+		return pbhasher.HashAlgorithm_HASH_ALGORITHM_MD5
+	case hash.AlgorithmSHA256:
+		return pbhasher.HashAlgorithm_HASH_ALGORITHM_SHA256
+	default:
+		return pbhasher.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+	}
+}
+
+// toPBNormalization converts domain normalization to protobuf one. Zero
+// normalization is converted to unspecified.
+func toPBNormalization(norm hash.Normalization) pbhasher.HashNormalization {
+	switch norm {
+	case hash.NormalizationNone:
+		return pbhasher.HashNormalization_HASH_NORMALIZATION_NONE
+	case hash.NormalizationTrim:
+		return pbhasher.HashNormalization_HASH_NORMALIZATION_TRIM
+	case hash.NormalizationLower:
+		return pbhasher.HashNormalization_HASH_NORMALIZATION_LOWER
+	case hash.NormalizationDigits:
+		return pbhasher.HashNormalization_HASH_NORMALIZATION_DIGITS
+	default:
+		return pbhasher.HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+	}
+}
@@ -0,0 +1,40 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+type similarityServer struct {
+	pbhasher.UnimplementedSimilarityServiceServer
+	similaritySvc *application.SimilarityService
+}
+
+func (s *similarityServer) AddItem(ctx context.Context, req *pbhasher.AddItemRequest) (*pbhasher.AddItemResponse, error) {
+	if err := s.similaritySvc.Add(ctx, req.Index, req.Id, req.Text); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.AddItemResponse{}, nil
+}
+
+func (s *similarityServer) FindSimilar(ctx context.Context, req *pbhasher.FindSimilarRequest) (*pbhasher.FindSimilarResponse, error) {
+	matches, err := s.similaritySvc.FindSimilar(ctx, req.Index, req.Text, req.MinSimilarity, int(req.Limit))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.FindSimilarResponse{
+		Items: make([]*pbhasher.SimilarItem, len(matches)),
+	}
+	for i, m := range matches {
+		resp.Items[i] = &pbhasher.SimilarItem{
+			Id:         m.ID,
+			Similarity: m.Similarity,
+		}
+	}
+
+	return resp, nil
+}
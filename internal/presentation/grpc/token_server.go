@@ -0,0 +1,35 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+type tokenServer struct {
+	pbhasher.UnimplementedTokenServiceServer
+	tokenSvc *application.TokenService
+}
+
+func (s *tokenServer) Tokenize(ctx context.Context, req *pbhasher.TokenizeRequest) (*pbhasher.TokenizeResponse, error) {
+	tok, err := s.tokenSvc.Tokenize(ctx, req.Value)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.TokenizeResponse{
+		Token: tok,
+	}, nil
+}
+
+func (s *tokenServer) Detokenize(ctx context.Context, req *pbhasher.DetokenizeRequest) (*pbhasher.DetokenizeResponse, error) {
+	value, err := s.tokenSvc.Detokenize(ctx, req.Token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.DetokenizeResponse{
+		Value: value,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/translog"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type translogServer struct {
+	pbhasher.UnimplementedTransparencyLogServiceServer
+	translogSvc *application.TransparencyLogService
+}
+
+func (s *translogServer) GetSignedTreeHead(ctx context.Context, _ *pbhasher.GetSignedTreeHeadRequest) (*pbhasher.SignedTreeHead, error) {
+	head, err := s.translogSvc.TreeHead(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.SignedTreeHead{
+		TreeSize:  head.Size,
+		RootHash:  head.Root,
+		Timestamp: timestamppb.New(head.Timestamp),
+		KeyId:     head.KeyID,
+		Signature: head.Signature,
+	}, nil
+}
+
+func (s *translogServer) GetEntries(ctx context.Context, req *pbhasher.GetEntriesRequest) (*pbhasher.GetEntriesResponse, error) {
+	entries, err := s.translogSvc.Entries(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pbhasher.GetEntriesResponse{
+		Entries: make([]*pbhasher.LogEntry, len(entries)),
+	}
+	for i, e := range entries {
+		resp.Entries[i] = toPBLogEntry(e)
+	}
+
+	return resp, nil
+}
+
+func (s *translogServer) GetInclusionProof(ctx context.Context, req *pbhasher.GetInclusionProofRequest) (*pbhasher.GetInclusionProofResponse, error) {
+	proof, err := s.translogSvc.InclusionProof(ctx, req.Index, req.TreeSize)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.GetInclusionProofResponse{
+		Entry:     toPBLogEntry(proof.Entry),
+		TreeSize:  proof.TreeSize,
+		AuditPath: proof.AuditPath,
+	}, nil
+}
+
+func (s *translogServer) GetConsistencyProof(ctx context.Context, req *pbhasher.GetConsistencyProofRequest) (*pbhasher.GetConsistencyProofResponse, error) {
+	proof, err := s.translogSvc.ConsistencyProof(ctx, req.First, req.Second)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pbhasher.GetConsistencyProofResponse{
+		First:  proof.First,
+		Second: proof.Second,
+		Proof:  proof.Proof,
+	}, nil
+}
+
+func toPBLogEntry(e *translog.Entry) *pbhasher.LogEntry {
+	pb := &pbhasher.LogEntry{
+		Index:      e.Index(),
+		Leaf:       e.Leaf(),
+		Commitment: e.Commitment(),
+	}
+	if e.Preimage() == "" {
+		return pb
+	}
+
+	pb.Tenant = e.Tenant()
+	pb.Algorithm = e.Algorithm()
+	pb.Hash = e.Hash()
+	pb.PepperVersion = e.PepperVersion()
+	pb.IssuedAt = timestamppb.New(e.IssuedAt())
+	pb.Preimage = e.Preimage()
+
+	return pb
+}
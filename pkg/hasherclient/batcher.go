@@ -0,0 +1,105 @@
+package hasherclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batcher collects concurrent Hash calls into HashBatch requests.
+type batcher struct {
+	c        *Client
+	maxSize  int
+	maxDelay time.Duration
+
+	mu      sync.Mutex
+	pending []*pendingCall
+	timer   *time.Timer
+}
+
+type pendingCall struct {
+	req    Request
+	done   chan struct{}
+	digest Digest
+	err    error
+}
+
+func newBatcher(c *Client, maxSize int, maxDelay time.Duration) *batcher {
+	return &batcher{
+		c:        c,
+		maxSize:  maxSize,
+		maxDelay: maxDelay,
+	}
+}
+
+// hash enqueues request and waits for its batch. Batch is sent once it is full
+// or maxDelay after its first request was enqueued.
+func (b *batcher) hash(ctx context.Context, req Request) (Digest, error) {
+	call := &pendingCall{req: req, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, call)
+	switch {
+	case len(b.pending) >= b.maxSize:
+		batch := b.take()
+		b.mu.Unlock()
+		go b.send(batch)
+	case len(b.pending) == 1:
+		b.timer = time.AfterFunc(b.maxDelay, b.flush)
+		b.mu.Unlock()
+	default:
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+		return call.digest, call.err
+	case <-ctx.Done():
+		return Digest{}, ctx.Err()
+	}
+}
+
+func (b *batcher) flush() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+
+	b.send(batch)
+}
+
+// take detaches pending calls. Must be called with mu held.
+func (b *batcher) take() []*pendingCall {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	batch := b.pending
+	b.pending = nil
+
+	return batch
+}
+
+// send sends batch detached from callers contexts, so a single canceled
+// caller doesn't fail the whole batch. Client default timeout still applies.
+func (b *batcher) send(batch []*pendingCall) {
+	if len(batch) == 0 {
+		return
+	}
+
+	reqs := make([]Request, len(batch))
+	for i, call := range batch {
+		reqs[i] = call.req
+	}
+
+	results, err := b.c.hashBatch(context.Background(), reqs)
+	for i, call := range batch {
+		if err != nil {
+			call.err = err
+		} else {
+			call.digest = Digest{Hash: results[i].Hash, PepperVersion: results[i].PepperVersion}
+			call.err = results[i].Err
+		}
+		close(call.done)
+	}
+}
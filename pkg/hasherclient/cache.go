@@ -0,0 +1,82 @@
+package hasherclient
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cache represents LRU cache of recent results. Nil cache is disabled.
+//
+// Peppered results are keyed by requests with server pepper version they
+// were hashed with, so requests without pinned version miss them: current
+// version may be rotated meanwhile. Results without pepper are keyed by
+// requests as is. Requests with Counter are never cached, every one of them
+// must reach unique counter on server.
+type cache struct {
+	size int
+
+	mu    sync.Mutex
+	items map[Request]*list.Element
+	order *list.List
+}
+
+type cacheEntry struct {
+	req    Request
+	digest Digest
+}
+
+func newCache(size int) *cache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &cache{
+		size:  size,
+		items: make(map[Request]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+func (c *cache) get(req Request) (Digest, bool) {
+	if c == nil || req.Counter != "" {
+		return Digest{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[req]
+	if !ok {
+		return Digest{}, false
+	}
+
+	c.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).digest, true
+}
+
+// put caches digest of request, pinned to digest pepper version if it's
+// peppered.
+func (c *cache) put(req Request, digest Digest) {
+	if c == nil || req.Counter != "" {
+		return
+	}
+	if digest.PepperVersion != "" {
+		req.PepperVersion = digest.PepperVersion
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[req]; ok {
+		el.Value.(*cacheEntry).digest = digest
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[req] = c.order.PushFront(&cacheEntry{req: req, digest: digest})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).req)
+	}
+}
@@ -0,0 +1,224 @@
+package hasherclient
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is a metadata key carrying caller API key.
+const APIKeyHeader = "x-api-key"
+
+// Client represents hasher service client. Client is safe for concurrent use.
+type Client struct {
+	conn     *grpc.ClientConn
+	ownConn  bool
+	rpc      pbhasher.HasherServiceClient
+	receipts pbhasher.ReceiptServiceClient
+	opts     *options
+	batcher  *batcher
+	cache    *cache
+
+	// noBatch is set once service reports HashBatch as unimplemented.
+	noBatch atomic.Bool
+}
+
+// New creates new client connected to service at given address.
+func New(addr string, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(o.creds)}, o.dialOpts...)
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("new gRPC client: %w", err)
+	}
+
+	c := newClient(conn, o)
+	c.ownConn = true
+
+	return c, nil
+}
+
+// NewFromConn creates new client over existing connection. Connection isn't
+// closed by Close. Transport and dial options are ignored.
+func NewFromConn(conn *grpc.ClientConn, opts ...Option) *Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return newClient(conn, o)
+}
+
+func newClient(conn *grpc.ClientConn, o *options) *Client {
+	c := &Client{
+		conn:     conn,
+		rpc:      pbhasher.NewHasherServiceClient(conn),
+		receipts: pbhasher.NewReceiptServiceClient(conn),
+		opts:     o,
+		cache:    newCache(o.cacheSize),
+	}
+
+	if o.batchSize > 1 {
+		c.batcher = newBatcher(c, o.batchSize, o.batchDelay)
+	}
+
+	return c
+}
+
+// Close closes connection if it was opened by client.
+func (c *Client) Close() error {
+	if !c.ownConn {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// Hash hashes single input and returns hash with server pepper version it
+// was mixed with. Concurrent calls are batched if batching is enabled.
+func (c *Client) Hash(ctx context.Context, req Request) (Digest, error) {
+	if d, ok := c.cache.get(req); ok {
+		return d, nil
+	}
+
+	var (
+		d   Digest
+		err error
+	)
+	if c.batcher != nil && !c.noBatch.Load() {
+		d, err = c.batcher.hash(ctx, req)
+	} else {
+		d, err = c.hashOne(ctx, req)
+	}
+	if err != nil {
+		return Digest{}, err
+	}
+
+	c.cache.put(req, d)
+	return d, nil
+}
+
+// HashRecord hashes composite key of record fields built by named recipe
+// defined in service config. Results are not cached.
+func (c *Client) HashRecord(ctx context.Context, recipe string, fields map[string]string) (string, error) {
+	resp, err := invoke(ctx, c, func(ctx context.Context) (*pbhasher.HashResponse, error) {
+		return c.rpc.HashRecord(ctx, &pbhasher.HashRecordRequest{
+			Recipe: recipe,
+			Fields: fields,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Hash, nil
+}
+
+// HashBatch hashes every request independently and returns results in the
+// same order. Requests are split into batches of at most MaxBatchSize.
+// Requests with cached results are not sent to the service. If service
+// doesn't support batches, requests are sent one by one.
+func (c *Client) HashBatch(ctx context.Context, reqs []Request) ([]Result, error) {
+	results := make([]Result, len(reqs))
+
+	var misses []int
+	for i, r := range reqs {
+		if d, ok := c.cache.get(r); ok {
+			results[i] = Result{Hash: d.Hash, PepperVersion: d.PepperVersion}
+			continue
+		}
+		misses = append(misses, i)
+	}
+
+	for start := 0; start < len(misses); start += MaxBatchSize {
+		idx := misses[start:min(start+MaxBatchSize, len(misses))]
+
+		chunk := make([]Request, len(idx))
+		for i, j := range idx {
+			chunk[i] = reqs[j]
+		}
+
+		res, err := c.hashBatch(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, j := range idx {
+			results[j] = res[i]
+			if res[i].Err == nil {
+				c.cache.put(reqs[j], Digest{Hash: res[i].Hash, PepperVersion: res[i].PepperVersion})
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) hashOne(ctx context.Context, req Request) (Digest, error) {
+	resp, err := invoke(ctx, c, func(ctx context.Context) (*pbhasher.HashResponse, error) {
+		return c.rpc.Hash(ctx, req.toProto())
+	})
+	if err != nil {
+		return Digest{}, err
+	}
+
+	return Digest{Hash: resp.Hash, PepperVersion: resp.PepperVersion}, nil
+}
+
+// hashBatch sends a single batch request, falling back to one by one requests
+// if service doesn't implement batches.
+func (c *Client) hashBatch(ctx context.Context, reqs []Request) ([]Result, error) {
+	if !c.noBatch.Load() {
+		pbReq := &pbhasher.HashBatchRequest{Requests: make([]*pbhasher.HashRequest, len(reqs))}
+		for i, r := range reqs {
+			pbReq.Requests[i] = r.toProto()
+		}
+
+		resp, err := invoke(ctx, c, func(ctx context.Context) (*pbhasher.HashBatchResponse, error) {
+			return c.rpc.HashBatch(ctx, pbReq)
+		})
+
+		switch {
+		case err == nil:
+			return batchResults(resp, len(reqs))
+		case status.Code(err) == codes.Unimplemented:
+			c.noBatch.Store(true)
+		default:
+			return nil, err
+		}
+	}
+
+	results := make([]Result, len(reqs))
+	for i, r := range reqs {
+		d, err := c.hashOne(ctx, r)
+		results[i] = Result{Hash: d.Hash, PepperVersion: d.PepperVersion, Err: err}
+	}
+
+	return results, nil
+}
+
+func batchResults(resp *pbhasher.HashBatchResponse, n int) ([]Result, error) {
+	if len(resp.Results) != n {
+		return nil, fmt.Errorf("expected %d results, got %d", n, len(resp.Results))
+	}
+
+	results := make([]Result, n)
+	for i, r := range resp.Results {
+		if r.Error != "" {
+			results[i].Err = &RequestError{Message: r.Error}
+			continue
+		}
+		results[i] = Result{Hash: r.Hash, PepperVersion: r.PepperVersion}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,616 @@
+package hasherclient
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/application"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/hash"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/record"
+	"github.com/tmybsv/leadgen-test-task/internal/domain/tenant"
+	memoryinfra "github.com/tmybsv/leadgen-test-task/internal/infrastructure/cache/memory"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/canonical"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/hasher"
+	"github.com/tmybsv/leadgen-test-task/internal/infrastructure/signer"
+	grpcsrv "github.com/tmybsv/leadgen-test-task/internal/presentation/grpc"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeServer struct {
+	pbhasher.UnimplementedHasherServiceServer
+
+	noBatch     bool
+	unavailable int
+	delay       func(call int) time.Duration
+	// pepper is a current pepper version, hashes are mixed with requested
+	// or current version.
+	pepper string
+
+	mu         sync.Mutex
+	calls      int
+	hashCalls  int
+	batchCalls int
+	apiKeys    []string
+}
+
+func (s *fakeServer) begin(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	s.calls++
+	call := s.calls
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		s.apiKeys = append(s.apiKeys, md.Get(APIKeyHeader)...)
+	}
+	s.mu.Unlock()
+
+	if call <= s.unavailable {
+		return call, status.Error(codes.Unavailable, "try again")
+	}
+
+	if s.delay != nil {
+		select {
+		case <-time.After(s.delay(call)):
+		case <-ctx.Done():
+			return call, ctx.Err()
+		}
+	}
+
+	return call, nil
+}
+
+func (s *fakeServer) Hash(ctx context.Context, req *pbhasher.HashRequest) (*pbhasher.HashResponse, error) {
+	s.mu.Lock()
+	s.hashCalls++
+	s.mu.Unlock()
+
+	if _, err := s.begin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Input == "" {
+		return nil, status.Error(codes.InvalidArgument, "input is required")
+	}
+
+	version := s.pepperVersion(req)
+	return &pbhasher.HashResponse{Hash: md5Hex(version + req.Input), PepperVersion: version}, nil
+}
+
+func (s *fakeServer) HashBatch(ctx context.Context, req *pbhasher.HashBatchRequest) (*pbhasher.HashBatchResponse, error) {
+	if s.noBatch {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+
+	s.mu.Lock()
+	s.batchCalls++
+	s.mu.Unlock()
+
+	if _, err := s.begin(ctx); err != nil {
+		return nil, err
+	}
+
+	resp := &pbhasher.HashBatchResponse{}
+	for _, r := range req.Requests {
+		if r.Input == "" {
+			resp.Results = append(resp.Results, &pbhasher.HashBatchResult{Error: "input is required"})
+			continue
+		}
+		version := s.pepperVersion(r)
+		resp.Results = append(resp.Results, &pbhasher.HashBatchResult{Hash: md5Hex(version + r.Input), PepperVersion: version})
+	}
+
+	return resp, nil
+}
+
+func (s *fakeServer) pepperVersion(req *pbhasher.HashRequest) string {
+	if req.PepperVersion != "" {
+		return req.PepperVersion
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pepper
+}
+
+func (s *fakeServer) stats() (hashCalls, batchCalls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.hashCalls, s.batchCalls
+}
+
+func TestClient_Hash_Server(t *testing.T) {
+	tenants, err := tenant.NewRegistry(tenant.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashSvc := application.NewHashService(memoryinfra.NewHashRepository(10), memoryinfra.NewUsageRepository(), tenants, nil, hasher.All(), &canonical.JCS{}, nil)
+	cli := newTestClient(t, func(s *grpc.Server) { grpcsrv.Register(s, grpcsrv.Services{Hash: hashSvc}) })
+
+	got, err := cli.Hash(context.Background(), Request{Input: " Hello ", Algorithm: AlgorithmSHA256, Normalization: NormalizationLower})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expect := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; got.Hash != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+
+	results, err := cli.HashBatch(context.Background(), []Request{
+		{Input: "hello", Algorithm: AlgorithmMD5},
+		{Input: "hello"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Hash != "5d41402abc4b2a76b9719d911017c592" || results[0].Err != nil {
+		t.Errorf("unexpected first result %+v", results[0])
+	}
+
+	var reqErr *RequestError
+	if !errors.As(results[1].Err, &reqErr) {
+		t.Errorf("expected request error for missing algorithm, got %+v", results[1])
+	}
+}
+
+func TestClient_HashRecord_Server(t *testing.T) {
+	tenants, err := tenant.NewRegistry(tenant.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lead, err := record.NewRecipe("lead", []record.RecipeField{
+		{Name: "email", Normalization: hash.NormalizationLower},
+		{Name: "phone", Normalization: hash.NormalizationDigits},
+	}, record.EncodingSeparator, "|", hash.AlgorithmMD5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipes, err := record.NewRecipes(lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hashSvc := application.NewHashService(memoryinfra.NewHashRepository(10), memoryinfra.NewUsageRepository(), tenants, nil, hasher.All(), &canonical.JCS{}, nil)
+	cli := newTestClient(t, func(s *grpc.Server) {
+		grpcsrv.Register(s, grpcsrv.Services{Hash: hashSvc, Record: application.NewRecordService(hashSvc, recipes)})
+	})
+
+	got, err := cli.HashRecord(context.Background(), "lead", map[string]string{"email": "Foo@Example.com", "phone": "+7 999"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expect := md5Hex("foo@example.com|7999"); got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+
+	if _, err := cli.HashRecord(context.Background(), "event", nil); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown recipe, got %v", err)
+	}
+}
+
+func TestClient_HashWithReceipt_Server(t *testing.T) {
+	tenants, err := tenant.NewRegistry(tenant.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiptSigner := signer.NewEd25519(key)
+	receiptSvc := application.NewReceiptService(receiptSigner, []receipt.Key{{ID: receiptSigner.KeyID(), PublicKey: receiptSigner.PublicKey()}})
+
+	hashSvc := application.NewHashService(memoryinfra.NewHashRepository(10), memoryinfra.NewUsageRepository(), tenants, nil, hasher.All(), &canonical.JCS{}, nil)
+	cli := newTestClient(t, func(s *grpc.Server) {
+		grpcsrv.Register(s, grpcsrv.Services{Hash: hashSvc, Receipt: receiptSvc})
+	})
+
+	ctx := context.Background()
+	got, token, err := cli.HashWithReceipt(ctx, Request{Input: "hello", Algorithm: AlgorithmMD5}, "req-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, err := cli.Keys(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := VerifyReceipt(token, keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Digest != got || r.Algorithm != AlgorithmMD5 || r.RequestID != "req-1" || r.KeyID != receiptSigner.KeyID() {
+		t.Errorf("unexpected receipt %+v of hash %q", r, got)
+	}
+
+	tampered := token[:len(token)-4] + "AAAA"
+	if tampered == token {
+		tampered = token[:len(token)-4] + "BBBB"
+	}
+	if _, err := VerifyReceipt(tampered, keys); !errors.Is(err, ErrInvalidReceipt) {
+		t.Errorf("expected error %v, got %v", ErrInvalidReceipt, err)
+	}
+
+	if _, err := VerifyReceipt(token, &JWKS{}); !errors.Is(err, ErrUnknownReceiptKey) {
+		t.Errorf("expected error %v, got %v", ErrUnknownReceiptKey, err)
+	}
+}
+
+func TestVerifyReceipt(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := &JWKS{Keys: []JWK{{KeyType: "OKP", Curve: "Ed25519", KeyID: "k1", X: base64.RawURLEncoding.EncodeToString(pub)}}}
+
+	sign := func(header, claims string) string {
+		input := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+		return input + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(input)))
+	}
+
+	const (
+		validHeader = `{"alg":"EdDSA","typ":"hash-receipt+jws","kid":"k1"}`
+		validClaims = `{"hash_alg":"md5","digest":"abc","iat":1700000000}`
+	)
+
+	tests := []struct {
+		name      string
+		token     string
+		expectErr error
+	}{
+		{"valid", sign(validHeader, validClaims), nil},
+		{"wrong type", sign(`{"alg":"EdDSA","typ":"JWT","kid":"k1"}`, validClaims), ErrInvalidReceipt},
+		{"unknown claim", sign(validHeader, `{"hash_alg":"md5","digest":"abc","iat":1700000000,"admin":true}`), ErrInvalidReceipt},
+		{"unknown key", sign(`{"alg":"EdDSA","typ":"hash-receipt+jws","kid":"k2"}`, validClaims), ErrUnknownReceiptKey},
+		{"malformed", "abc", ErrInvalidReceipt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := VerifyReceipt(tt.token, keys)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if err == nil && (r.Digest != "abc" || r.Algorithm != AlgorithmMD5 || r.KeyID != "k1") {
+				t.Errorf("unexpected receipt %+v", r)
+			}
+		})
+	}
+}
+
+func TestClient_Hash_Batching(t *testing.T) {
+	srv := &fakeServer{}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(10, 50*time.Millisecond), WithAPIKey("secret"))
+
+	const n = 25
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			input := string(rune('a' + i))
+			got, err := cli.Hash(context.Background(), Request{Input: input})
+			if err == nil && got.Hash != md5Hex(input) {
+				err = errors.New("unexpected hash of " + input)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hashCalls, batchCalls := srv.stats()
+	if hashCalls != 0 || batchCalls < 3 || batchCalls >= n {
+		t.Errorf("expected calls to be batched, got %d hash and %d batch calls", hashCalls, batchCalls)
+	}
+
+	for _, k := range srv.apiKeys {
+		if k != "secret" {
+			t.Errorf("expected API key to be sent, got %q", k)
+		}
+	}
+}
+
+func TestClient_Hash_BatchUnimplemented(t *testing.T) {
+	srv := &fakeServer{noBatch: true}
+	cli := newTestClient(t, fakeRegister(srv))
+
+	for range 2 {
+		got, err := cli.Hash(context.Background(), Request{Input: "hello"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Hash != md5Hex("hello") {
+			t.Errorf("unexpected hash %q", got.Hash)
+		}
+	}
+
+	if hashCalls, _ := srv.stats(); hashCalls != 2 {
+		t.Errorf("expected fallback to single calls, got %d", hashCalls)
+	}
+
+	if !cli.noBatch.Load() {
+		t.Error("expected batching to be disabled")
+	}
+}
+
+func TestClient_Hash_Retry(t *testing.T) {
+	srv := &fakeServer{unavailable: 2}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithRetry(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}))
+
+	if _, err := cli.Hash(context.Background(), Request{Input: "hello"}); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+
+	srv = &fakeServer{unavailable: 5}
+	cli = newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithRetry(RetryPolicy{MaxAttempts: 2}))
+
+	if _, err := cli.Hash(context.Background(), Request{Input: "hello"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable after attempts exhausted, got %v", err)
+	}
+
+	if hashCalls, _ := srv.stats(); hashCalls != 2 {
+		t.Errorf("expected 2 attempts, got %d", hashCalls)
+	}
+}
+
+func TestClient_Hash_NoRetryOnInvalidArgument(t *testing.T) {
+	srv := &fakeServer{}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0))
+
+	if _, err := cli.Hash(context.Background(), Request{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if hashCalls, _ := srv.stats(); hashCalls != 1 {
+		t.Errorf("expected single attempt, got %d", hashCalls)
+	}
+}
+
+func TestClient_Hash_Hedging(t *testing.T) {
+	srv := &fakeServer{delay: func(call int) time.Duration {
+		if call == 1 {
+			return 5 * time.Second
+		}
+		return 0
+	}}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithHedging(20*time.Millisecond))
+
+	start := time.Now()
+	if _, err := cli.Hash(context.Background(), Request{Input: "hello"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected hedged request to win, took %v", elapsed)
+	}
+}
+
+func TestClient_Hash_Timeout(t *testing.T) {
+	srv := &fakeServer{delay: func(int) time.Duration { return 5 * time.Second }}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithTimeout(20*time.Millisecond))
+
+	if _, err := cli.Hash(context.Background(), Request{Input: "hello"}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_Hash_Cache(t *testing.T) {
+	srv := &fakeServer{pepper: "v1"}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithCache(1))
+
+	for _, req := range []Request{
+		{Input: "a", PepperVersion: "v1"},
+		{Input: "a", PepperVersion: "v1"},
+		{Input: "b", PepperVersion: "v1"},
+		{Input: "a", PepperVersion: "v1"},
+	} {
+		got, err := cli.Hash(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != (Digest{Hash: md5Hex("v1" + req.Input), PepperVersion: "v1"}) {
+			t.Errorf("unexpected digest %+v", got)
+		}
+	}
+
+	if hashCalls, _ := srv.stats(); hashCalls != 3 {
+		t.Errorf("expected 3 server calls with single entry cache, got %d", hashCalls)
+	}
+
+	results, err := cli.HashBatch(context.Background(), []Request{{Input: "a", PepperVersion: "v1"}, {Input: "c"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0].Hash != md5Hex("v1a") || results[1].Hash != md5Hex("v1c") || results[1].PepperVersion != "v1" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+// countingHasher counts hashed inputs.
+type countingHasher struct {
+	hash.Hasher
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (h *countingHasher) Hash(input string) string {
+	h.mu.Lock()
+	h.calls++
+	h.mu.Unlock()
+
+	return h.Hasher.Hash(input)
+}
+
+func TestClient_Hash_CacheWithoutPepper_Server(t *testing.T) {
+	tenants, err := tenant.NewRegistry(tenant.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md5Hasher := &countingHasher{Hasher: &hasher.MD5{}}
+	hashers := map[hash.Algorithm]hash.Hasher{hash.AlgorithmMD5: md5Hasher}
+	// Server cache is disabled, so every call reaching server is hashed.
+	hashSvc := application.NewHashService(memoryinfra.NewHashRepository(0), memoryinfra.NewUsageRepository(), tenants, nil, hashers, &canonical.JCS{}, nil)
+	cli := newTestClient(t, func(s *grpc.Server) {
+		grpcsrv.Register(s, grpcsrv.Services{Hash: hashSvc})
+	}, WithBatching(0, 0), WithCache(10))
+
+	ctx := context.Background()
+	req := Request{Input: "hello", Algorithm: AlgorithmMD5}
+	for range 3 {
+		got, err := cli.Hash(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != (Digest{Hash: md5Hex("hello")}) {
+			t.Errorf("unexpected digest %+v", got)
+		}
+	}
+
+	results, err := cli.HashBatch(ctx, []Request{req, {Input: "world", Algorithm: AlgorithmMD5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Hash != md5Hex("hello") || results[1].Hash != md5Hex("world") {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	if md5Hasher.calls != 2 {
+		t.Errorf("expected unpeppered results served from cache, got %d hashed inputs", md5Hasher.calls)
+	}
+}
+
+func TestClient_Hash_CacheSkipsCounter(t *testing.T) {
+	srv := &fakeServer{pepper: "v1"}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithCache(10))
+	ctx := context.Background()
+
+	reqs := []Request{
+		{Input: "a", PepperVersion: "v1"},
+		{Input: "a", PepperVersion: "v1", Counter: "signups"},
+		{Input: "a", PepperVersion: "v1", Counter: "signups"},
+	}
+	for _, req := range reqs {
+		if _, err := cli.Hash(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := cli.HashBatch(ctx, reqs[1:]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if hashCalls, batchCalls := srv.stats(); hashCalls != 3 || batchCalls != 1 {
+		t.Errorf("expected every counted request to reach server, got %d hash and %d batch calls", hashCalls, batchCalls)
+	}
+}
+
+func TestClient_Hash_CachePepperRotation(t *testing.T) {
+	srv := &fakeServer{pepper: "v1"}
+	cli := newTestClient(t, fakeRegister(srv), WithBatching(0, 0), WithCache(10))
+	ctx := context.Background()
+
+	if _, err := cli.Hash(ctx, Request{Input: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := cli.Hash(ctx, Request{Input: "a", PepperVersion: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hashCalls, _ := srv.stats(); hashCalls != 1 || got.Hash != md5Hex("v1a") {
+		t.Errorf("expected pinned request served from cache, got %d calls and %+v", hashCalls, got)
+	}
+
+	srv.mu.Lock()
+	srv.pepper = "v2"
+	srv.mu.Unlock()
+
+	got, err = cli.Hash(ctx, Request{Input: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != (Digest{Hash: md5Hex("v2a"), PepperVersion: "v2"}) {
+		t.Errorf("expected hash of rotated pepper, got %+v", got)
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	if alg, err := ParseAlgorithm("sha256"); err != nil || alg != AlgorithmSHA256 {
+		t.Errorf("expected sha256, got %v, %v", alg, err)
+	}
+
+	if alg, err := ParseAlgorithm(""); err != nil || alg != AlgorithmDefault {
+		t.Errorf("expected default, got %v, %v", alg, err)
+	}
+
+	if _, err := ParseAlgorithm("unspecified"); err == nil {
+		t.Error("expected error for unspecified algorithm name")
+	}
+}
+
+func fakeRegister(srv *fakeServer) func(*grpc.Server) {
+	return func(s *grpc.Server) { pbhasher.RegisterHasherServiceServer(s, srv) }
+}
+
+func newTestClient(t *testing.T, register func(*grpc.Server), opts ...Option) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewFromConn(conn, opts...)
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,18 @@
+// Package hasherclient provides Go client of hasher service.
+//
+// Client wraps generated gRPC stubs with sensible defaults: per-call
+// deadlines, retries on codes.Unavailable, optional hedged requests, automatic
+// micro-batching of concurrent Hash calls into HashBatch requests and optional
+// in-process LRU cache of recent results.
+//
+//	cli, err := hasherclient.New("hasher:6969", hasherclient.WithAPIKey(key))
+//	if err != nil {
+//		return err
+//	}
+//	defer cli.Close()
+//
+//	digest, err := cli.Hash(ctx, hasherclient.Request{
+//		Input:     "foo@example.com",
+//		Algorithm: hasherclient.AlgorithmSHA256,
+//	})
+package hasherclient
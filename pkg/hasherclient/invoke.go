@@ -0,0 +1,95 @@
+package hasherclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// invoke calls RPC with API key, deadline, retries and hedging applied.
+func invoke[T any](ctx context.Context, c *Client, call func(ctx context.Context) (T, error)) (T, error) {
+	if c.opts.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, APIKeyHeader, c.opts.apiKey)
+	}
+
+	if c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+
+	p := c.opts.retry
+	backoff := p.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		v, err := hedge(ctx, c.opts.hedgeDelay, call)
+		if err == nil || status.Code(err) != codes.Unavailable || attempt >= p.MaxAttempts {
+			return v, err
+		}
+
+		var sleep time.Duration
+		if backoff > 0 {
+			sleep = rand.N(backoff)
+		}
+
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return v, err
+		}
+
+		backoff = min(2*backoff, p.MaxBackoff)
+	}
+}
+
+type hedgeResult[T any] struct {
+	v   T
+	err error
+}
+
+// hedge calls RPC and, if it hasn't completed within delay, calls it once
+// more. The first successful response wins, the other call is canceled.
+func hedge[T any](ctx context.Context, delay time.Duration, call func(ctx context.Context) (T, error)) (T, error) {
+	if delay <= 0 {
+		return call(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult[T], 2)
+	run := func() {
+		v, err := call(ctx)
+		results <- hedgeResult[T]{v: v, err: err}
+	}
+
+	go run()
+	inflight := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var last hedgeResult[T]
+	for {
+		select {
+		case r := <-results:
+			inflight--
+			if r.err == nil {
+				return r.v, nil
+			}
+
+			last = r
+			if inflight == 0 {
+				return last.v, last.err
+			}
+		case <-timer.C:
+			if inflight > 0 {
+				go run()
+				inflight++
+			}
+		}
+	}
+}
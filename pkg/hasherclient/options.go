@@ -0,0 +1,118 @@
+package hasherclient
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Default client settings.
+const (
+	DefaultTimeout        = 10 * time.Second
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 2 * time.Second
+	DefaultBatchSize      = 100
+	DefaultBatchDelay     = 2 * time.Millisecond
+
+	// MaxBatchSize is a maximum number of requests server accepts in a batch.
+	MaxBatchSize = 1000
+)
+
+// Option configures client.
+type Option func(*options)
+
+// RetryPolicy represents retries of requests failed with codes.Unavailable.
+// Backoff grows exponentially from initial to max with full jitter.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type options struct {
+	creds      credentials.TransportCredentials
+	apiKey     string
+	dialOpts   []grpc.DialOption
+	timeout    time.Duration
+	retry      RetryPolicy
+	hedgeDelay time.Duration
+	batchSize  int
+	batchDelay time.Duration
+	cacheSize  int
+}
+
+func defaultOptions() *options {
+	return &options{
+		creds:   insecure.NewCredentials(),
+		timeout: DefaultTimeout,
+		retry: RetryPolicy{
+			MaxAttempts:    DefaultMaxAttempts,
+			InitialBackoff: DefaultInitialBackoff,
+			MaxBackoff:     DefaultMaxBackoff,
+		},
+		batchSize:  DefaultBatchSize,
+		batchDelay: DefaultBatchDelay,
+	}
+}
+
+// WithTLSConfig enables TLS with provided configuration. Plaintext is used by
+// default, matching default server configuration.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) { o.creds = credentials.NewTLS(cfg) }
+}
+
+// WithTransportCredentials sets custom transport credentials.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(o *options) { o.creds = creds }
+}
+
+// WithAPIKey sends API key with every request.
+func WithAPIKey(key string) Option {
+	return func(o *options) { o.apiKey = key }
+}
+
+// WithDialOptions appends raw gRPC dial options.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOpts = append(o.dialOpts, opts...) }
+}
+
+// WithTimeout sets deadline of every call unless context already has an
+// earlier one. Zero disables default deadline.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithRetry sets retry policy. MaxAttempts lower than two disables retries.
+func WithRetry(p RetryPolicy) Option {
+	return func(o *options) { o.retry = p }
+}
+
+// WithHedging sends a second identical request if the first one hasn't
+// completed within delay and uses whichever succeeds first. Hashing is
+// idempotent, so hedging is safe. Disabled by default.
+func WithHedging(delay time.Duration) Option {
+	return func(o *options) { o.hedgeDelay = delay }
+}
+
+// WithBatching configures micro-batching of concurrent Hash calls. Calls are
+// collected for up to maxDelay or until maxSize calls are pending and sent as
+// a single HashBatch request. maxSize lower than two disables batching.
+func WithBatching(maxSize int, maxDelay time.Duration) Option {
+	return func(o *options) {
+		o.batchSize = min(maxSize, MaxBatchSize)
+		o.batchDelay = maxDelay
+	}
+}
+
+// WithCache enables in-process LRU cache of given number of recent results.
+// Peppered results are served to requests with pinned PepperVersion only,
+// since current server pepper may be rotated. Results without pepper are
+// served to equal requests until evicted, even if server pepper is enabled
+// meanwhile. Requests with Counter always reach the service.
+func WithCache(size int) Option {
+	return func(o *options) { o.cacheSize = size }
+}
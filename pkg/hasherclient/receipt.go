@@ -0,0 +1,140 @@
+package hasherclient
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tmybsv/leadgen-test-task/internal/domain/receipt"
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+// Receipt verification errors.
+var (
+	ErrInvalidReceipt    = errors.New("invalid receipt")
+	ErrUnknownReceiptKey = errors.New("unknown receipt key")
+)
+
+// Receipt represents verified attestation that service produced Digest by
+// Algorithm at IssuedAt. RequestID is the one passed to HashWithReceipt.
+type Receipt struct {
+	Algorithm Algorithm
+	Digest    string
+	IssuedAt  time.Time
+	KeyID     string
+	RequestID string
+}
+
+// JWKS represents JSON Web Key Set of receipt verification keys. It can be
+// stored as JSON and used for offline verification.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents Ed25519 public key in JSON Web Key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+}
+
+// HashWithReceipt hashes single input and returns hash with signed receipt
+// bound to optional requestID. Results are neither batched nor cached.
+func (c *Client) HashWithReceipt(ctx context.Context, req Request, requestID string) (hash, receipt string, err error) {
+	pbReq := req.toProto()
+	pbReq.WithReceipt = true
+	pbReq.RequestId = requestID
+
+	resp, err := invoke(ctx, c, func(ctx context.Context) (*pbhasher.HashResponse, error) {
+		return c.rpc.Hash(ctx, pbReq)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return resp.Hash, resp.Receipt, nil
+}
+
+// Keys returns receipt verification keys published by service.
+func (c *Client) Keys(ctx context.Context) (*JWKS, error) {
+	resp, err := invoke(ctx, c, func(ctx context.Context) (*pbhasher.JWKS, error) {
+		return c.receipts.GetJWKS(ctx, &pbhasher.GetJWKSRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := &JWKS{Keys: make([]JWK, len(resp.Keys))}
+	for i, k := range resp.Keys {
+		jwks.Keys[i] = JWK{
+			KeyType:   k.Kty,
+			Curve:     k.Crv,
+			X:         k.X,
+			KeyID:     k.Kid,
+			Use:       k.Use,
+			Algorithm: k.Alg,
+		}
+	}
+
+	return jwks, nil
+}
+
+// VerifyReceipt verifies receipt signature by key of keys set it refers to
+// and returns receipt content. Caller should compare receipt digest with the
+// hash it's expected to attest.
+func VerifyReceipt(token string, keys *JWKS) (*Receipt, error) {
+	pubs, err := keys.receiptKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := receipt.Verify(token, pubs)
+	if errors.Is(err, receipt.ErrUnknownKey) {
+		return nil, fmt.Errorf("%w: %w", ErrUnknownReceiptKey, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReceipt, err)
+	}
+
+	alg, err := ParseAlgorithm(r.Algorithm.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReceipt, err)
+	}
+
+	return &Receipt{
+		Algorithm: alg,
+		Digest:    r.Digest,
+		IssuedAt:  r.IssuedAt,
+		KeyID:     r.KeyID,
+		RequestID: r.RequestID,
+	}, nil
+}
+
+// receiptKeys decodes Ed25519 keys of set, keys of other types are skipped.
+func (s *JWKS) receiptKeys() ([]receipt.Key, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	keys := make([]receipt.Key, 0, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.KeyType != "OKP" || k.Curve != "Ed25519" {
+			continue
+		}
+
+		pub, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: malformed key %q", ErrInvalidReceipt, k.KeyID)
+		}
+
+		keys = append(keys, receipt.Key{ID: k.KeyID, PublicKey: pub})
+	}
+
+	return keys, nil
+}
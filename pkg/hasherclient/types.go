@@ -0,0 +1,106 @@
+package hasherclient
+
+import (
+	"fmt"
+	"strings"
+
+	pbhasher "github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1"
+)
+
+// Algorithm represents hash algorithm. Zero value means caller tenant default.
+type Algorithm int32
+
+// Supported hash algorithms.
+const (
+	AlgorithmDefault = Algorithm(pbhasher.HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED)
+	AlgorithmMD5     = Algorithm(pbhasher.HashAlgorithm_HASH_ALGORITHM_MD5)
+	AlgorithmSHA256  = Algorithm(pbhasher.HashAlgorithm_HASH_ALGORITHM_SHA256)
+)
+
+// ParseAlgorithm parses algorithm name like "md5". Empty name means caller
+// tenant default.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	if name == "" {
+		return AlgorithmDefault, nil
+	}
+
+	v, ok := pbhasher.HashAlgorithm_value["HASH_ALGORITHM_"+strings.ToUpper(name)]
+	if !ok || v == 0 {
+		return 0, fmt.Errorf("unsupported algorithm %q", name)
+	}
+
+	return Algorithm(v), nil
+}
+
+// Normalization represents input normalization applied by service before
+// hashing. Zero value means caller tenant default.
+type Normalization int32
+
+// Supported normalizations.
+const (
+	NormalizationDefault = Normalization(pbhasher.HashNormalization_HASH_NORMALIZATION_UNSPECIFIED)
+	NormalizationNone    = Normalization(pbhasher.HashNormalization_HASH_NORMALIZATION_NONE)
+	NormalizationTrim    = Normalization(pbhasher.HashNormalization_HASH_NORMALIZATION_TRIM)
+	NormalizationLower   = Normalization(pbhasher.HashNormalization_HASH_NORMALIZATION_LOWER)
+	NormalizationDigits  = Normalization(pbhasher.HashNormalization_HASH_NORMALIZATION_DIGITS)
+)
+
+// ParseNormalization parses normalization name like "lower". Empty name means
+// caller tenant default.
+func ParseNormalization(name string) (Normalization, error) {
+	if name == "" {
+		return NormalizationDefault, nil
+	}
+
+	v, ok := pbhasher.HashNormalization_value["HASH_NORMALIZATION_"+strings.ToUpper(name)]
+	if !ok || v == 0 {
+		return 0, fmt.Errorf("unsupported normalization %q", name)
+	}
+
+	return Normalization(v), nil
+}
+
+// Request represents single hash request. Salt is hashed with input, empty
+// PepperVersion means current server pepper. Hash is added to unique counter
+// of Counter key if it's set.
+type Request struct {
+	Input         string
+	Algorithm     Algorithm
+	Normalization Normalization
+	Salt          string
+	PepperVersion string
+	Counter       string
+}
+
+func (r Request) toProto() *pbhasher.HashRequest {
+	return &pbhasher.HashRequest{
+		Input:         r.Input,
+		Algorithm:     pbhasher.HashAlgorithm(r.Algorithm),
+		Normalization: pbhasher.HashNormalization(r.Normalization),
+		Salt:          r.Salt,
+		PepperVersion: r.PepperVersion,
+		Counter:       r.Counter,
+	}
+}
+
+// Digest represents hash of input with version of server pepper input was
+// mixed with, empty if server pepper is disabled.
+type Digest struct {
+	Hash          string
+	PepperVersion string
+}
+
+// Result represents result of a single request in batch. Err is set if
+// request failed, other requests of the batch are not affected.
+type Result struct {
+	Hash          string
+	PepperVersion string
+	Err           error
+}
+
+// RequestError represents failure of a single request in batch.
+type RequestError struct {
+	Message string
+}
+
+func (e *RequestError) Error() string { return e.Message }
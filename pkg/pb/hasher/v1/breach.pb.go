@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: breach.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BreachAlgorithm int32
+
+const (
+	BreachAlgorithm_BREACH_ALGORITHM_UNSPECIFIED BreachAlgorithm = 0
+	BreachAlgorithm_BREACH_ALGORITHM_SHA1        BreachAlgorithm = 1
+	BreachAlgorithm_BREACH_ALGORITHM_NTLM        BreachAlgorithm = 2
+	BreachAlgorithm_BREACH_ALGORITHM_SHA256      BreachAlgorithm = 3
+)
+
+// Enum value maps for BreachAlgorithm.
+var (
+	BreachAlgorithm_name = map[int32]string{
+		0: "BREACH_ALGORITHM_UNSPECIFIED",
+		1: "BREACH_ALGORITHM_SHA1",
+		2: "BREACH_ALGORITHM_NTLM",
+		3: "BREACH_ALGORITHM_SHA256",
+	}
+	BreachAlgorithm_value = map[string]int32{
+		"BREACH_ALGORITHM_UNSPECIFIED": 0,
+		"BREACH_ALGORITHM_SHA1":        1,
+		"BREACH_ALGORITHM_NTLM":        2,
+		"BREACH_ALGORITHM_SHA256":      3,
+	}
+)
+
+func (x BreachAlgorithm) Enum() *BreachAlgorithm {
+	p := new(BreachAlgorithm)
+	*p = x
+	return p
+}
+
+func (x BreachAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreachAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_breach_proto_enumTypes[0].Descriptor()
+}
+
+func (BreachAlgorithm) Type() protoreflect.EnumType {
+	return &file_breach_proto_enumTypes[0]
+}
+
+func (x BreachAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreachAlgorithm.Descriptor instead.
+func (BreachAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_breach_proto_rawDescGZIP(), []int{0}
+}
+
+type BreachRangeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm BreachAlgorithm        `protobuf:"varint,1,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.BreachAlgorithm" json:"algorithm,omitempty"`
+	// Prefix is 5 hex characters, case-insensitive.
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Padding adds random suffixes with zero count, so there are 800 to 1000
+	// entries and response size doesn't reveal prefix.
+	Padding       bool `protobuf:"varint,3,opt,name=padding,proto3" json:"padding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreachRangeRequest) Reset() {
+	*x = BreachRangeRequest{}
+	mi := &file_breach_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreachRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreachRangeRequest) ProtoMessage() {}
+
+func (x *BreachRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breach_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreachRangeRequest.ProtoReflect.Descriptor instead.
+func (*BreachRangeRequest) Descriptor() ([]byte, []int) {
+	return file_breach_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BreachRangeRequest) GetAlgorithm() BreachAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return BreachAlgorithm_BREACH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *BreachRangeRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *BreachRangeRequest) GetPadding() bool {
+	if x != nil {
+		return x.Padding
+	}
+	return false
+}
+
+type BreachRangeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries are sorted by suffix.
+	Entries       []*BreachEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreachRangeResponse) Reset() {
+	*x = BreachRangeResponse{}
+	mi := &file_breach_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreachRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreachRangeResponse) ProtoMessage() {}
+
+func (x *BreachRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breach_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreachRangeResponse.ProtoReflect.Descriptor instead.
+func (*BreachRangeResponse) Descriptor() ([]byte, []int) {
+	return file_breach_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BreachRangeResponse) GetEntries() []*BreachEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type BreachEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Suffix is uppercase hex hash without prefix.
+	Suffix string `protobuf:"bytes,1,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	// Count is a number of times hash appears in breaches, zero for padding.
+	Count         uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreachEntry) Reset() {
+	*x = BreachEntry{}
+	mi := &file_breach_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreachEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreachEntry) ProtoMessage() {}
+
+func (x *BreachEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_breach_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreachEntry.ProtoReflect.Descriptor instead.
+func (*BreachEntry) Descriptor() ([]byte, []int) {
+	return file_breach_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BreachEntry) GetSuffix() string {
+	if x != nil {
+		return x.Suffix
+	}
+	return ""
+}
+
+func (x *BreachEntry) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_breach_proto protoreflect.FileDescriptor
+
+const file_breach_proto_rawDesc = "" +
+	"\n" +
+	"\fbreach.proto\x12\x11leadgen.hasher.v1\"\x88\x01\n" +
+	"\x12BreachRangeRequest\x12@\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2\".leadgen.hasher.v1.BreachAlgorithmR\talgorithm\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\apadding\x18\x03 \x01(\bR\apadding\"O\n" +
+	"\x13BreachRangeResponse\x128\n" +
+	"\aentries\x18\x01 \x03(\v2\x1e.leadgen.hasher.v1.BreachEntryR\aentries\";\n" +
+	"\vBreachEntry\x12\x16\n" +
+	"\x06suffix\x18\x01 \x01(\tR\x06suffix\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count*\x86\x01\n" +
+	"\x0fBreachAlgorithm\x12 \n" +
+	"\x1cBREACH_ALGORITHM_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BREACH_ALGORITHM_SHA1\x10\x01\x12\x19\n" +
+	"\x15BREACH_ALGORITHM_NTLM\x10\x02\x12\x1b\n" +
+	"\x17BREACH_ALGORITHM_SHA256\x10\x032g\n" +
+	"\rBreachService\x12V\n" +
+	"\x05Range\x12%.leadgen.hasher.v1.BreachRangeRequest\x1a&.leadgen.hasher.v1.BreachRangeResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_breach_proto_rawDescOnce sync.Once
+	file_breach_proto_rawDescData []byte
+)
+
+func file_breach_proto_rawDescGZIP() []byte {
+	file_breach_proto_rawDescOnce.Do(func() {
+		file_breach_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_breach_proto_rawDesc), len(file_breach_proto_rawDesc)))
+	})
+	return file_breach_proto_rawDescData
+}
+
+var file_breach_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_breach_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_breach_proto_goTypes = []any{
+	(BreachAlgorithm)(0),        // 0: leadgen.hasher.v1.BreachAlgorithm
+	(*BreachRangeRequest)(nil),  // 1: leadgen.hasher.v1.BreachRangeRequest
+	(*BreachRangeResponse)(nil), // 2: leadgen.hasher.v1.BreachRangeResponse
+	(*BreachEntry)(nil),         // 3: leadgen.hasher.v1.BreachEntry
+}
+var file_breach_proto_depIdxs = []int32{
+	0, // 0: leadgen.hasher.v1.BreachRangeRequest.algorithm:type_name -> leadgen.hasher.v1.BreachAlgorithm
+	3, // 1: leadgen.hasher.v1.BreachRangeResponse.entries:type_name -> leadgen.hasher.v1.BreachEntry
+	1, // 2: leadgen.hasher.v1.BreachService.Range:input_type -> leadgen.hasher.v1.BreachRangeRequest
+	2, // 3: leadgen.hasher.v1.BreachService.Range:output_type -> leadgen.hasher.v1.BreachRangeResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_breach_proto_init() }
+func file_breach_proto_init() {
+	if File_breach_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_breach_proto_rawDesc), len(file_breach_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_breach_proto_goTypes,
+		DependencyIndexes: file_breach_proto_depIdxs,
+		EnumInfos:         file_breach_proto_enumTypes,
+		MessageInfos:      file_breach_proto_msgTypes,
+	}.Build()
+	File_breach_proto = out.File
+	file_breach_proto_goTypes = nil
+	file_breach_proto_depIdxs = nil
+}
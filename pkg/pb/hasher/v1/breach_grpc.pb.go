@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: breach.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BreachService_Range_FullMethodName = "/leadgen.hasher.v1.BreachService/Range"
+)
+
+// BreachServiceClient is the client API for BreachService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BreachService serves k-anonymity range lookups of breach corpus hashes.
+// Callers hash checked value locally, send the first 5 hex characters of
+// hash and match returned suffixes, so the full hash is never sent.
+type BreachServiceClient interface {
+	Range(ctx context.Context, in *BreachRangeRequest, opts ...grpc.CallOption) (*BreachRangeResponse, error)
+}
+
+type breachServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBreachServiceClient(cc grpc.ClientConnInterface) BreachServiceClient {
+	return &breachServiceClient{cc}
+}
+
+func (c *breachServiceClient) Range(ctx context.Context, in *BreachRangeRequest, opts ...grpc.CallOption) (*BreachRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BreachRangeResponse)
+	err := c.cc.Invoke(ctx, BreachService_Range_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BreachServiceServer is the server API for BreachService service.
+// All implementations must embed UnimplementedBreachServiceServer
+// for forward compatibility.
+//
+// BreachService serves k-anonymity range lookups of breach corpus hashes.
+// Callers hash checked value locally, send the first 5 hex characters of
+// hash and match returned suffixes, so the full hash is never sent.
+type BreachServiceServer interface {
+	Range(context.Context, *BreachRangeRequest) (*BreachRangeResponse, error)
+	mustEmbedUnimplementedBreachServiceServer()
+}
+
+// UnimplementedBreachServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBreachServiceServer struct{}
+
+func (UnimplementedBreachServiceServer) Range(context.Context, *BreachRangeRequest) (*BreachRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Range not implemented")
+}
+func (UnimplementedBreachServiceServer) mustEmbedUnimplementedBreachServiceServer() {}
+func (UnimplementedBreachServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBreachServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BreachServiceServer will
+// result in compilation errors.
+type UnsafeBreachServiceServer interface {
+	mustEmbedUnimplementedBreachServiceServer()
+}
+
+func RegisterBreachServiceServer(s grpc.ServiceRegistrar, srv BreachServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBreachServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BreachService_ServiceDesc, srv)
+}
+
+func _BreachService_Range_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreachRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreachServiceServer).Range(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreachService_Range_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreachServiceServer).Range(ctx, req.(*BreachRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BreachService_ServiceDesc is the grpc.ServiceDesc for BreachService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BreachService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.BreachService",
+	HandlerType: (*BreachServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Range",
+			Handler:    _BreachService_Range_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "breach.proto",
+}
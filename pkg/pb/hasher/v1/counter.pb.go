@@ -0,0 +1,381 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: counter.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CountUniqueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountUniqueRequest) Reset() {
+	*x = CountUniqueRequest{}
+	mi := &file_counter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountUniqueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountUniqueRequest) ProtoMessage() {}
+
+func (x *CountUniqueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_counter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountUniqueRequest.ProtoReflect.Descriptor instead.
+func (*CountUniqueRequest) Descriptor() ([]byte, []int) {
+	return file_counter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CountUniqueRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CountUniqueRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *CountUniqueRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type CountUniqueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Count uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Buckets are in time order.
+	Buckets       []*BucketCount `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountUniqueResponse) Reset() {
+	*x = CountUniqueResponse{}
+	mi := &file_counter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountUniqueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountUniqueResponse) ProtoMessage() {}
+
+func (x *CountUniqueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_counter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountUniqueResponse.ProtoReflect.Descriptor instead.
+func (*CountUniqueResponse) Descriptor() ([]byte, []int) {
+	return file_counter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CountUniqueResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CountUniqueResponse) GetBuckets() []*BucketCount {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type BucketCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketCount) Reset() {
+	*x = BucketCount{}
+	mi := &file_counter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketCount) ProtoMessage() {}
+
+func (x *BucketCount) ProtoReflect() protoreflect.Message {
+	mi := &file_counter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketCount.ProtoReflect.Descriptor instead.
+func (*BucketCount) Descriptor() ([]byte, []int) {
+	return file_counter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BucketCount) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *BucketCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type MergeCountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keys are 1 to 16 counter keys.
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCountsRequest) Reset() {
+	*x = MergeCountsRequest{}
+	mi := &file_counter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCountsRequest) ProtoMessage() {}
+
+func (x *MergeCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_counter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCountsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCountsRequest) Descriptor() ([]byte, []int) {
+	return file_counter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MergeCountsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *MergeCountsRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *MergeCountsRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type MergeCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCountsResponse) Reset() {
+	*x = MergeCountsResponse{}
+	mi := &file_counter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCountsResponse) ProtoMessage() {}
+
+func (x *MergeCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_counter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCountsResponse.ProtoReflect.Descriptor instead.
+func (*MergeCountsResponse) Descriptor() ([]byte, []int) {
+	return file_counter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MergeCountsResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_counter_proto protoreflect.FileDescriptor
+
+const file_counter_proto_rawDesc = "" +
+	"\n" +
+	"\rcounter.proto\x12\x11leadgen.hasher.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x01\n" +
+	"\x12CountUniqueRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"e\n" +
+	"\x13CountUniqueResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x128\n" +
+	"\abuckets\x18\x02 \x03(\v2\x1e.leadgen.hasher.v1.BucketCountR\abuckets\"U\n" +
+	"\vBucketCount\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\x88\x01\n" +
+	"\x12MergeCountsRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"+\n" +
+	"\x13MergeCountsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count2\xcc\x01\n" +
+	"\x0eCounterService\x12\\\n" +
+	"\vCountUnique\x12%.leadgen.hasher.v1.CountUniqueRequest\x1a&.leadgen.hasher.v1.CountUniqueResponse\x12\\\n" +
+	"\vMergeCounts\x12%.leadgen.hasher.v1.MergeCountsRequest\x1a&.leadgen.hasher.v1.MergeCountsResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_counter_proto_rawDescOnce sync.Once
+	file_counter_proto_rawDescData []byte
+)
+
+func file_counter_proto_rawDescGZIP() []byte {
+	file_counter_proto_rawDescOnce.Do(func() {
+		file_counter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_counter_proto_rawDesc), len(file_counter_proto_rawDesc)))
+	})
+	return file_counter_proto_rawDescData
+}
+
+var file_counter_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_counter_proto_goTypes = []any{
+	(*CountUniqueRequest)(nil),    // 0: leadgen.hasher.v1.CountUniqueRequest
+	(*CountUniqueResponse)(nil),   // 1: leadgen.hasher.v1.CountUniqueResponse
+	(*BucketCount)(nil),           // 2: leadgen.hasher.v1.BucketCount
+	(*MergeCountsRequest)(nil),    // 3: leadgen.hasher.v1.MergeCountsRequest
+	(*MergeCountsResponse)(nil),   // 4: leadgen.hasher.v1.MergeCountsResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_counter_proto_depIdxs = []int32{
+	5, // 0: leadgen.hasher.v1.CountUniqueRequest.start:type_name -> google.protobuf.Timestamp
+	5, // 1: leadgen.hasher.v1.CountUniqueRequest.end:type_name -> google.protobuf.Timestamp
+	2, // 2: leadgen.hasher.v1.CountUniqueResponse.buckets:type_name -> leadgen.hasher.v1.BucketCount
+	5, // 3: leadgen.hasher.v1.BucketCount.start:type_name -> google.protobuf.Timestamp
+	5, // 4: leadgen.hasher.v1.MergeCountsRequest.start:type_name -> google.protobuf.Timestamp
+	5, // 5: leadgen.hasher.v1.MergeCountsRequest.end:type_name -> google.protobuf.Timestamp
+	0, // 6: leadgen.hasher.v1.CounterService.CountUnique:input_type -> leadgen.hasher.v1.CountUniqueRequest
+	3, // 7: leadgen.hasher.v1.CounterService.MergeCounts:input_type -> leadgen.hasher.v1.MergeCountsRequest
+	1, // 8: leadgen.hasher.v1.CounterService.CountUnique:output_type -> leadgen.hasher.v1.CountUniqueResponse
+	4, // 9: leadgen.hasher.v1.CounterService.MergeCounts:output_type -> leadgen.hasher.v1.MergeCountsResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_counter_proto_init() }
+func file_counter_proto_init() {
+	if File_counter_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_counter_proto_rawDesc), len(file_counter_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_counter_proto_goTypes,
+		DependencyIndexes: file_counter_proto_depIdxs,
+		MessageInfos:      file_counter_proto_msgTypes,
+	}.Build()
+	File_counter_proto = out.File
+	file_counter_proto_goTypes = nil
+	file_counter_proto_depIdxs = nil
+}
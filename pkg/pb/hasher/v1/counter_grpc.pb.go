@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: counter.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CounterService_CountUnique_FullMethodName = "/leadgen.hasher.v1.CounterService/CountUnique"
+	CounterService_MergeCounts_FullMethodName = "/leadgen.hasher.v1.CounterService/MergeCounts"
+)
+
+// CounterServiceClient is the client API for CounterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CounterService estimates numbers of unique hashed inputs. Digests are
+// added to counters by Hash and HashBatch requests with counter key and are
+// counted per caller tenant, counter key and time bucket. Range selects
+// buckets overlapping it, unset end means now and unset start means start
+// of end bucket.
+type CounterServiceClient interface {
+	// CountUnique estimates number of unique digests of counter within range
+	// and of every bucket of range.
+	CountUnique(ctx context.Context, in *CountUniqueRequest, opts ...grpc.CallOption) (*CountUniqueResponse, error)
+	// MergeCounts estimates number of unique digests of any of counters
+	// within range. Digests of several counters are counted once.
+	MergeCounts(ctx context.Context, in *MergeCountsRequest, opts ...grpc.CallOption) (*MergeCountsResponse, error)
+}
+
+type counterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCounterServiceClient(cc grpc.ClientConnInterface) CounterServiceClient {
+	return &counterServiceClient{cc}
+}
+
+func (c *counterServiceClient) CountUnique(ctx context.Context, in *CountUniqueRequest, opts ...grpc.CallOption) (*CountUniqueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountUniqueResponse)
+	err := c.cc.Invoke(ctx, CounterService_CountUnique_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) MergeCounts(ctx context.Context, in *MergeCountsRequest, opts ...grpc.CallOption) (*MergeCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCountsResponse)
+	err := c.cc.Invoke(ctx, CounterService_MergeCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CounterServiceServer is the server API for CounterService service.
+// All implementations must embed UnimplementedCounterServiceServer
+// for forward compatibility.
+//
+// CounterService estimates numbers of unique hashed inputs. Digests are
+// added to counters by Hash and HashBatch requests with counter key and are
+// counted per caller tenant, counter key and time bucket. Range selects
+// buckets overlapping it, unset end means now and unset start means start
+// of end bucket.
+type CounterServiceServer interface {
+	// CountUnique estimates number of unique digests of counter within range
+	// and of every bucket of range.
+	CountUnique(context.Context, *CountUniqueRequest) (*CountUniqueResponse, error)
+	// MergeCounts estimates number of unique digests of any of counters
+	// within range. Digests of several counters are counted once.
+	MergeCounts(context.Context, *MergeCountsRequest) (*MergeCountsResponse, error)
+	mustEmbedUnimplementedCounterServiceServer()
+}
+
+// UnimplementedCounterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCounterServiceServer struct{}
+
+func (UnimplementedCounterServiceServer) CountUnique(context.Context, *CountUniqueRequest) (*CountUniqueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountUnique not implemented")
+}
+func (UnimplementedCounterServiceServer) MergeCounts(context.Context, *MergeCountsRequest) (*MergeCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCounts not implemented")
+}
+func (UnimplementedCounterServiceServer) mustEmbedUnimplementedCounterServiceServer() {}
+func (UnimplementedCounterServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCounterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CounterServiceServer will
+// result in compilation errors.
+type UnsafeCounterServiceServer interface {
+	mustEmbedUnimplementedCounterServiceServer()
+}
+
+func RegisterCounterServiceServer(s grpc.ServiceRegistrar, srv CounterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCounterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CounterService_ServiceDesc, srv)
+}
+
+func _CounterService_CountUnique_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountUniqueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).CountUnique(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_CountUnique_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).CountUnique(ctx, req.(*CountUniqueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_MergeCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).MergeCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_MergeCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).MergeCounts(ctx, req.(*MergeCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CounterService_ServiceDesc is the grpc.ServiceDesc for CounterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CounterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.CounterService",
+	HandlerType: (*CounterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CountUnique",
+			Handler:    _CounterService_CountUnique_Handler,
+		},
+		{
+			MethodName: "MergeCounts",
+			Handler:    _CounterService_MergeCounts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "counter.proto",
+}
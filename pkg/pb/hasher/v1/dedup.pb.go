@@ -0,0 +1,458 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: dedup.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SeenBeforeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Algorithm     HashAlgorithm          `protobuf:"varint,3,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization      `protobuf:"varint,4,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	// Unset window never ends.
+	Window        *durationpb.Duration `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeenBeforeRequest) Reset() {
+	*x = SeenBeforeRequest{}
+	mi := &file_dedup_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeenBeforeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeenBeforeRequest) ProtoMessage() {}
+
+func (x *SeenBeforeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dedup_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeenBeforeRequest.ProtoReflect.Descriptor instead.
+func (*SeenBeforeRequest) Descriptor() ([]byte, []int) {
+	return file_dedup_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SeenBeforeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SeenBeforeRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *SeenBeforeRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *SeenBeforeRequest) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+func (x *SeenBeforeRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type SeenBeforeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Seen  bool                   `protobuf:"varint,1,opt,name=seen,proto3" json:"seen,omitempty"`
+	// Lead is set if seen.
+	Lead          *Lead `protobuf:"bytes,2,opt,name=lead,proto3" json:"lead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeenBeforeResponse) Reset() {
+	*x = SeenBeforeResponse{}
+	mi := &file_dedup_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeenBeforeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeenBeforeResponse) ProtoMessage() {}
+
+func (x *SeenBeforeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dedup_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeenBeforeResponse.ProtoReflect.Descriptor instead.
+func (*SeenBeforeResponse) Descriptor() ([]byte, []int) {
+	return file_dedup_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SeenBeforeResponse) GetSeen() bool {
+	if x != nil {
+		return x.Seen
+	}
+	return false
+}
+
+func (x *SeenBeforeResponse) GetLead() *Lead {
+	if x != nil {
+		return x.Lead
+	}
+	return nil
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Algorithm     HashAlgorithm          `protobuf:"varint,3,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization      `protobuf:"varint,4,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	// Unset window never ends.
+	Window *durationpb.Duration `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	// Source is a tag of source lead comes from.
+	Source        string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_dedup_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dedup_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_dedup_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *RegisterRequest) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+func (x *RegisterRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type RegisterResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	New   bool                   `protobuf:"varint,1,opt,name=new,proto3" json:"new,omitempty"`
+	// Lead is the first one seen within window, which is the registered one
+	// if new.
+	Lead          *Lead `protobuf:"bytes,2,opt,name=lead,proto3" json:"lead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_dedup_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dedup_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_dedup_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterResponse) GetNew() bool {
+	if x != nil {
+		return x.New
+	}
+	return false
+}
+
+func (x *RegisterResponse) GetLead() *Lead {
+	if x != nil {
+		return x.Lead
+	}
+	return nil
+}
+
+type Lead struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lead) Reset() {
+	*x = Lead{}
+	mi := &file_dedup_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lead) ProtoMessage() {}
+
+func (x *Lead) ProtoReflect() protoreflect.Message {
+	mi := &file_dedup_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lead.ProtoReflect.Descriptor instead.
+func (*Lead) Descriptor() ([]byte, []int) {
+	return file_dedup_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Lead) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Lead) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Lead) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+var File_dedup_proto protoreflect.FileDescriptor
+
+const file_dedup_proto_rawDesc = "" +
+	"\n" +
+	"\vdedup.proto\x12\x11leadgen.hasher.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fhasher.proto\"\x86\x02\n" +
+	"\x11SeenBeforeRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12>\n" +
+	"\talgorithm\x18\x03 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x04 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\x121\n" +
+	"\x06window\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06window\"U\n" +
+	"\x12SeenBeforeResponse\x12\x12\n" +
+	"\x04seen\x18\x01 \x01(\bR\x04seen\x12+\n" +
+	"\x04lead\x18\x02 \x01(\v2\x17.leadgen.hasher.v1.LeadR\x04lead\"\x9c\x02\n" +
+	"\x0fRegisterRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x12>\n" +
+	"\talgorithm\x18\x03 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x04 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\x121\n" +
+	"\x06window\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\"Q\n" +
+	"\x10RegisterResponse\x12\x10\n" +
+	"\x03new\x18\x01 \x01(\bR\x03new\x12+\n" +
+	"\x04lead\x18\x02 \x01(\v2\x17.leadgen.hasher.v1.LeadR\x04lead\"m\n" +
+	"\x04Lead\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"first_seen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen2\xbe\x01\n" +
+	"\fDedupService\x12Y\n" +
+	"\n" +
+	"SeenBefore\x12$.leadgen.hasher.v1.SeenBeforeRequest\x1a%.leadgen.hasher.v1.SeenBeforeResponse\x12S\n" +
+	"\bRegister\x12\".leadgen.hasher.v1.RegisterRequest\x1a#.leadgen.hasher.v1.RegisterResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_dedup_proto_rawDescOnce sync.Once
+	file_dedup_proto_rawDescData []byte
+)
+
+func file_dedup_proto_rawDescGZIP() []byte {
+	file_dedup_proto_rawDescOnce.Do(func() {
+		file_dedup_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dedup_proto_rawDesc), len(file_dedup_proto_rawDesc)))
+	})
+	return file_dedup_proto_rawDescData
+}
+
+var file_dedup_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_dedup_proto_goTypes = []any{
+	(*SeenBeforeRequest)(nil),     // 0: leadgen.hasher.v1.SeenBeforeRequest
+	(*SeenBeforeResponse)(nil),    // 1: leadgen.hasher.v1.SeenBeforeResponse
+	(*RegisterRequest)(nil),       // 2: leadgen.hasher.v1.RegisterRequest
+	(*RegisterResponse)(nil),      // 3: leadgen.hasher.v1.RegisterResponse
+	(*Lead)(nil),                  // 4: leadgen.hasher.v1.Lead
+	(HashAlgorithm)(0),            // 5: leadgen.hasher.v1.HashAlgorithm
+	(HashNormalization)(0),        // 6: leadgen.hasher.v1.HashNormalization
+	(*durationpb.Duration)(nil),   // 7: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_dedup_proto_depIdxs = []int32{
+	5,  // 0: leadgen.hasher.v1.SeenBeforeRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	6,  // 1: leadgen.hasher.v1.SeenBeforeRequest.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	7,  // 2: leadgen.hasher.v1.SeenBeforeRequest.window:type_name -> google.protobuf.Duration
+	4,  // 3: leadgen.hasher.v1.SeenBeforeResponse.lead:type_name -> leadgen.hasher.v1.Lead
+	5,  // 4: leadgen.hasher.v1.RegisterRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	6,  // 5: leadgen.hasher.v1.RegisterRequest.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	7,  // 6: leadgen.hasher.v1.RegisterRequest.window:type_name -> google.protobuf.Duration
+	4,  // 7: leadgen.hasher.v1.RegisterResponse.lead:type_name -> leadgen.hasher.v1.Lead
+	8,  // 8: leadgen.hasher.v1.Lead.first_seen:type_name -> google.protobuf.Timestamp
+	0,  // 9: leadgen.hasher.v1.DedupService.SeenBefore:input_type -> leadgen.hasher.v1.SeenBeforeRequest
+	2,  // 10: leadgen.hasher.v1.DedupService.Register:input_type -> leadgen.hasher.v1.RegisterRequest
+	1,  // 11: leadgen.hasher.v1.DedupService.SeenBefore:output_type -> leadgen.hasher.v1.SeenBeforeResponse
+	3,  // 12: leadgen.hasher.v1.DedupService.Register:output_type -> leadgen.hasher.v1.RegisterResponse
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_dedup_proto_init() }
+func file_dedup_proto_init() {
+	if File_dedup_proto != nil {
+		return
+	}
+	file_hasher_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dedup_proto_rawDesc), len(file_dedup_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dedup_proto_goTypes,
+		DependencyIndexes: file_dedup_proto_depIdxs,
+		MessageInfos:      file_dedup_proto_msgTypes,
+	}.Build()
+	File_dedup_proto = out.File
+	file_dedup_proto_goTypes = nil
+	file_dedup_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: dedup.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DedupService_SeenBefore_FullMethodName = "/leadgen.hasher.v1.DedupService/SeenBefore"
+	DedupService_Register_FullMethodName   = "/leadgen.hasher.v1.DedupService/Register"
+)
+
+// DedupServiceClient is the client API for DedupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DedupService deduplicates leads by hashed identifiers. Leads are kept per
+// caller tenant and namespace, identifiers are hashed like Hash does.
+type DedupServiceClient interface {
+	// SeenBefore reports whether lead was registered within window. Lead is
+	// not registered.
+	SeenBefore(ctx context.Context, in *SeenBeforeRequest, opts ...grpc.CallOption) (*SeenBeforeResponse, error)
+	// Register registers lead unless it was registered within window. Only
+	// one of concurrent callers gets the lead as new.
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+}
+
+type dedupServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDedupServiceClient(cc grpc.ClientConnInterface) DedupServiceClient {
+	return &dedupServiceClient{cc}
+}
+
+func (c *dedupServiceClient) SeenBefore(ctx context.Context, in *SeenBeforeRequest, opts ...grpc.CallOption) (*SeenBeforeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeenBeforeResponse)
+	err := c.cc.Invoke(ctx, DedupService_SeenBefore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dedupServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, DedupService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DedupServiceServer is the server API for DedupService service.
+// All implementations must embed UnimplementedDedupServiceServer
+// for forward compatibility.
+//
+// DedupService deduplicates leads by hashed identifiers. Leads are kept per
+// caller tenant and namespace, identifiers are hashed like Hash does.
+type DedupServiceServer interface {
+	// SeenBefore reports whether lead was registered within window. Lead is
+	// not registered.
+	SeenBefore(context.Context, *SeenBeforeRequest) (*SeenBeforeResponse, error)
+	// Register registers lead unless it was registered within window. Only
+	// one of concurrent callers gets the lead as new.
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	mustEmbedUnimplementedDedupServiceServer()
+}
+
+// UnimplementedDedupServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDedupServiceServer struct{}
+
+func (UnimplementedDedupServiceServer) SeenBefore(context.Context, *SeenBeforeRequest) (*SeenBeforeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeenBefore not implemented")
+}
+func (UnimplementedDedupServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedDedupServiceServer) mustEmbedUnimplementedDedupServiceServer() {}
+func (UnimplementedDedupServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeDedupServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DedupServiceServer will
+// result in compilation errors.
+type UnsafeDedupServiceServer interface {
+	mustEmbedUnimplementedDedupServiceServer()
+}
+
+func RegisterDedupServiceServer(s grpc.ServiceRegistrar, srv DedupServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDedupServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DedupService_ServiceDesc, srv)
+}
+
+func _DedupService_SeenBefore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeenBeforeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DedupServiceServer).SeenBefore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DedupService_SeenBefore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DedupServiceServer).SeenBefore(ctx, req.(*SeenBeforeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DedupService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DedupServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DedupService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DedupServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DedupService_ServiceDesc is the grpc.ServiceDesc for DedupService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DedupService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.DedupService",
+	HandlerType: (*DedupServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SeenBefore",
+			Handler:    _DedupService_SeenBefore_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _DedupService_Register_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dedup.proto",
+}
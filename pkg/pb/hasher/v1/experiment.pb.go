@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: experiment.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AssignmentStatus int32
+
+const (
+	AssignmentStatus_ASSIGNMENT_STATUS_UNSPECIFIED AssignmentStatus = 0
+	AssignmentStatus_ASSIGNMENT_STATUS_ASSIGNED    AssignmentStatus = 1
+	// Holdout means unit is in experiment traffic, but held out of variants.
+	AssignmentStatus_ASSIGNMENT_STATUS_HOLDOUT AssignmentStatus = 2
+	// Excluded means unit is out of experiment or layer traffic.
+	AssignmentStatus_ASSIGNMENT_STATUS_EXCLUDED AssignmentStatus = 3
+)
+
+// Enum value maps for AssignmentStatus.
+var (
+	AssignmentStatus_name = map[int32]string{
+		0: "ASSIGNMENT_STATUS_UNSPECIFIED",
+		1: "ASSIGNMENT_STATUS_ASSIGNED",
+		2: "ASSIGNMENT_STATUS_HOLDOUT",
+		3: "ASSIGNMENT_STATUS_EXCLUDED",
+	}
+	AssignmentStatus_value = map[string]int32{
+		"ASSIGNMENT_STATUS_UNSPECIFIED": 0,
+		"ASSIGNMENT_STATUS_ASSIGNED":    1,
+		"ASSIGNMENT_STATUS_HOLDOUT":     2,
+		"ASSIGNMENT_STATUS_EXCLUDED":    3,
+	}
+)
+
+func (x AssignmentStatus) Enum() *AssignmentStatus {
+	p := new(AssignmentStatus)
+	*p = x
+	return p
+}
+
+func (x AssignmentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_experiment_proto_enumTypes[0].Descriptor()
+}
+
+func (AssignmentStatus) Type() protoreflect.EnumType {
+	return &file_experiment_proto_enumTypes[0]
+}
+
+func (x AssignmentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentStatus.Descriptor instead.
+func (AssignmentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_experiment_proto_rawDescGZIP(), []int{0}
+}
+
+type AssignRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UnitId string                 `protobuf:"bytes,1,opt,name=unit_id,json=unitId,proto3" json:"unit_id,omitempty"`
+	// Experiments selects experiments by name, every experiment if empty.
+	Experiments   []string `protobuf:"bytes,2,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRequest) Reset() {
+	*x = AssignRequest{}
+	mi := &file_experiment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRequest) ProtoMessage() {}
+
+func (x *AssignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_experiment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRequest.ProtoReflect.Descriptor instead.
+func (*AssignRequest) Descriptor() ([]byte, []int) {
+	return file_experiment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AssignRequest) GetUnitId() string {
+	if x != nil {
+		return x.UnitId
+	}
+	return ""
+}
+
+func (x *AssignRequest) GetExperiments() []string {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type AssignResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Assignments are in the same order as selected experiments.
+	Assignments   []*Assignment `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignResponse) Reset() {
+	*x = AssignResponse{}
+	mi := &file_experiment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignResponse) ProtoMessage() {}
+
+func (x *AssignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_experiment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignResponse.ProtoReflect.Descriptor instead.
+func (*AssignResponse) Descriptor() ([]byte, []int) {
+	return file_experiment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssignResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type Assignment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Experiment string                 `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	Status     AssignmentStatus       `protobuf:"varint,2,opt,name=status,proto3,enum=leadgen.hasher.v1.AssignmentStatus" json:"status,omitempty"`
+	// Variant is set only if status is assigned.
+	Variant string `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	// Bucket is unit bucket of experiment within [0, 10000).
+	Bucket        uint32 `protobuf:"varint,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_experiment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_experiment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_experiment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Assignment) GetExperiment() string {
+	if x != nil {
+		return x.Experiment
+	}
+	return ""
+}
+
+func (x *Assignment) GetStatus() AssignmentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AssignmentStatus_ASSIGNMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Assignment) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Assignment) GetBucket() uint32 {
+	if x != nil {
+		return x.Bucket
+	}
+	return 0
+}
+
+var File_experiment_proto protoreflect.FileDescriptor
+
+const file_experiment_proto_rawDesc = "" +
+	"\n" +
+	"\x10experiment.proto\x12\x11leadgen.hasher.v1\"J\n" +
+	"\rAssignRequest\x12\x17\n" +
+	"\aunit_id\x18\x01 \x01(\tR\x06unitId\x12 \n" +
+	"\vexperiments\x18\x02 \x03(\tR\vexperiments\"Q\n" +
+	"\x0eAssignResponse\x12?\n" +
+	"\vassignments\x18\x01 \x03(\v2\x1d.leadgen.hasher.v1.AssignmentR\vassignments\"\x9b\x01\n" +
+	"\n" +
+	"Assignment\x12\x1e\n" +
+	"\n" +
+	"experiment\x18\x01 \x01(\tR\n" +
+	"experiment\x12;\n" +
+	"\x06status\x18\x02 \x01(\x0e2#.leadgen.hasher.v1.AssignmentStatusR\x06status\x12\x18\n" +
+	"\avariant\x18\x03 \x01(\tR\avariant\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\rR\x06bucket*\x94\x01\n" +
+	"\x10AssignmentStatus\x12!\n" +
+	"\x1dASSIGNMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aASSIGNMENT_STATUS_ASSIGNED\x10\x01\x12\x1d\n" +
+	"\x19ASSIGNMENT_STATUS_HOLDOUT\x10\x02\x12\x1e\n" +
+	"\x1aASSIGNMENT_STATUS_EXCLUDED\x10\x032b\n" +
+	"\x11ExperimentService\x12M\n" +
+	"\x06Assign\x12 .leadgen.hasher.v1.AssignRequest\x1a!.leadgen.hasher.v1.AssignResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_experiment_proto_rawDescOnce sync.Once
+	file_experiment_proto_rawDescData []byte
+)
+
+func file_experiment_proto_rawDescGZIP() []byte {
+	file_experiment_proto_rawDescOnce.Do(func() {
+		file_experiment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_experiment_proto_rawDesc), len(file_experiment_proto_rawDesc)))
+	})
+	return file_experiment_proto_rawDescData
+}
+
+var file_experiment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_experiment_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_experiment_proto_goTypes = []any{
+	(AssignmentStatus)(0),  // 0: leadgen.hasher.v1.AssignmentStatus
+	(*AssignRequest)(nil),  // 1: leadgen.hasher.v1.AssignRequest
+	(*AssignResponse)(nil), // 2: leadgen.hasher.v1.AssignResponse
+	(*Assignment)(nil),     // 3: leadgen.hasher.v1.Assignment
+}
+var file_experiment_proto_depIdxs = []int32{
+	3, // 0: leadgen.hasher.v1.AssignResponse.assignments:type_name -> leadgen.hasher.v1.Assignment
+	0, // 1: leadgen.hasher.v1.Assignment.status:type_name -> leadgen.hasher.v1.AssignmentStatus
+	1, // 2: leadgen.hasher.v1.ExperimentService.Assign:input_type -> leadgen.hasher.v1.AssignRequest
+	2, // 3: leadgen.hasher.v1.ExperimentService.Assign:output_type -> leadgen.hasher.v1.AssignResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_experiment_proto_init() }
+func file_experiment_proto_init() {
+	if File_experiment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_experiment_proto_rawDesc), len(file_experiment_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_experiment_proto_goTypes,
+		DependencyIndexes: file_experiment_proto_depIdxs,
+		EnumInfos:         file_experiment_proto_enumTypes,
+		MessageInfos:      file_experiment_proto_msgTypes,
+	}.Build()
+	File_experiment_proto = out.File
+	file_experiment_proto_goTypes = nil
+	file_experiment_proto_depIdxs = nil
+}
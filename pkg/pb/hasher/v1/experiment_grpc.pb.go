@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: experiment.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExperimentService_Assign_FullMethodName = "/leadgen.hasher.v1.ExperimentService/Assign"
+)
+
+// ExperimentServiceClient is the client API for ExperimentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExperimentService deterministically assigns units, e.g. lead ids, to
+// variants of experiments defined in server config. Assignments are stable
+// across releases and replicas.
+type ExperimentServiceClient interface {
+	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
+}
+
+type experimentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExperimentServiceClient(cc grpc.ClientConnInterface) ExperimentServiceClient {
+	return &experimentServiceClient{cc}
+}
+
+func (c *experimentServiceClient) Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignResponse)
+	err := c.cc.Invoke(ctx, ExperimentService_Assign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExperimentServiceServer is the server API for ExperimentService service.
+// All implementations must embed UnimplementedExperimentServiceServer
+// for forward compatibility.
+//
+// ExperimentService deterministically assigns units, e.g. lead ids, to
+// variants of experiments defined in server config. Assignments are stable
+// across releases and replicas.
+type ExperimentServiceServer interface {
+	Assign(context.Context, *AssignRequest) (*AssignResponse, error)
+	mustEmbedUnimplementedExperimentServiceServer()
+}
+
+// UnimplementedExperimentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExperimentServiceServer struct{}
+
+func (UnimplementedExperimentServiceServer) Assign(context.Context, *AssignRequest) (*AssignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Assign not implemented")
+}
+func (UnimplementedExperimentServiceServer) mustEmbedUnimplementedExperimentServiceServer() {}
+func (UnimplementedExperimentServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeExperimentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExperimentServiceServer will
+// result in compilation errors.
+type UnsafeExperimentServiceServer interface {
+	mustEmbedUnimplementedExperimentServiceServer()
+}
+
+func RegisterExperimentServiceServer(s grpc.ServiceRegistrar, srv ExperimentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExperimentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExperimentService_ServiceDesc, srv)
+}
+
+func _ExperimentService_Assign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExperimentServiceServer).Assign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExperimentService_Assign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExperimentServiceServer).Assign(ctx, req.(*AssignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExperimentService_ServiceDesc is the grpc.ServiceDesc for ExperimentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExperimentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.ExperimentService",
+	HandlerType: (*ExperimentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Assign",
+			Handler:    _ExperimentService_Assign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "experiment.proto",
+}
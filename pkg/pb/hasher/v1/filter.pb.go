@@ -0,0 +1,643 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: filter.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FilterKind int32
+
+const (
+	FilterKind_FILTER_KIND_UNSPECIFIED FilterKind = 0
+	FilterKind_FILTER_KIND_BLOOM       FilterKind = 1
+	FilterKind_FILTER_KIND_CUCKOO      FilterKind = 2
+)
+
+// Enum value maps for FilterKind.
+var (
+	FilterKind_name = map[int32]string{
+		0: "FILTER_KIND_UNSPECIFIED",
+		1: "FILTER_KIND_BLOOM",
+		2: "FILTER_KIND_CUCKOO",
+	}
+	FilterKind_value = map[string]int32{
+		"FILTER_KIND_UNSPECIFIED": 0,
+		"FILTER_KIND_BLOOM":       1,
+		"FILTER_KIND_CUCKOO":      2,
+	}
+)
+
+func (x FilterKind) Enum() *FilterKind {
+	p := new(FilterKind)
+	*p = x
+	return p
+}
+
+func (x FilterKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_filter_proto_enumTypes[0].Descriptor()
+}
+
+func (FilterKind) Type() protoreflect.EnumType {
+	return &file_filter_proto_enumTypes[0]
+}
+
+func (x FilterKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterKind.Descriptor instead.
+func (FilterKind) EnumDescriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind  FilterKind             `protobuf:"varint,2,opt,name=kind,proto3,enum=leadgen.hasher.v1.FilterKind" json:"kind,omitempty"`
+	// Capacity is a number of items filter is sized for.
+	Capacity uint64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// False positive rate at capacity, greater than 0 and less than 1.
+	FpRate        float64 `protobuf:"fixed64,4,opt,name=fp_rate,json=fpRate,proto3" json:"fp_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFilterRequest) Reset() {
+	*x = CreateFilterRequest{}
+	mi := &file_filter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFilterRequest) ProtoMessage() {}
+
+func (x *CreateFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFilterRequest.ProtoReflect.Descriptor instead.
+func (*CreateFilterRequest) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateFilterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFilterRequest) GetKind() FilterKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FilterKind_FILTER_KIND_UNSPECIFIED
+}
+
+func (x *CreateFilterRequest) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateFilterRequest) GetFpRate() float64 {
+	if x != nil {
+		return x.FpRate
+	}
+	return 0
+}
+
+type GetFilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFilterRequest) Reset() {
+	*x = GetFilterRequest{}
+	mi := &file_filter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFilterRequest) ProtoMessage() {}
+
+func (x *GetFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFilterRequest.ProtoReflect.Descriptor instead.
+func (*GetFilterRequest) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFilterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FilterItemsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Inputs []string               `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Algorithm and normalization must be the same filter was built with.
+	Algorithm     HashAlgorithm     `protobuf:"varint,3,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization `protobuf:"varint,4,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterItemsRequest) Reset() {
+	*x = FilterItemsRequest{}
+	mi := &file_filter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterItemsRequest) ProtoMessage() {}
+
+func (x *FilterItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterItemsRequest.ProtoReflect.Descriptor instead.
+func (*FilterItemsRequest) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FilterItemsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FilterItemsRequest) GetInputs() []string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *FilterItemsRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *FilterItemsRequest) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+type MightContainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results are in inputs order.
+	Results       []bool `protobuf:"varint,1,rep,packed,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MightContainResponse) Reset() {
+	*x = MightContainResponse{}
+	mi := &file_filter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MightContainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MightContainResponse) ProtoMessage() {}
+
+func (x *MightContainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MightContainResponse.ProtoReflect.Descriptor instead.
+func (*MightContainResponse) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MightContainResponse) GetResults() []bool {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ExportFilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFilterRequest) Reset() {
+	*x = ExportFilterRequest{}
+	mi := &file_filter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFilterRequest) ProtoMessage() {}
+
+func (x *ExportFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFilterRequest.ProtoReflect.Descriptor instead.
+func (*ExportFilterRequest) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportFilterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FilterChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterChunk) Reset() {
+	*x = FilterChunk{}
+	mi := &file_filter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterChunk) ProtoMessage() {}
+
+func (x *FilterChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterChunk.ProtoReflect.Descriptor instead.
+func (*FilterChunk) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FilterChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is read from the first message only.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFilterRequest) Reset() {
+	*x = ImportFilterRequest{}
+	mi := &file_filter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFilterRequest) ProtoMessage() {}
+
+func (x *ImportFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFilterRequest.ProtoReflect.Descriptor instead.
+func (*ImportFilterRequest) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportFilterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportFilterRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type FilterInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind     FilterKind             `protobuf:"varint,2,opt,name=kind,proto3,enum=leadgen.hasher.v1.FilterKind" json:"kind,omitempty"`
+	Capacity uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	FpRate   float64                `protobuf:"fixed64,4,opt,name=fp_rate,json=fpRate,proto3" json:"fp_rate,omitempty"`
+	// Count is a number of added items, duplicates included.
+	Count         uint64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterInfo) Reset() {
+	*x = FilterInfo{}
+	mi := &file_filter_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterInfo) ProtoMessage() {}
+
+func (x *FilterInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_filter_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterInfo.ProtoReflect.Descriptor instead.
+func (*FilterInfo) Descriptor() ([]byte, []int) {
+	return file_filter_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FilterInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FilterInfo) GetKind() FilterKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FilterKind_FILTER_KIND_UNSPECIFIED
+}
+
+func (x *FilterInfo) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *FilterInfo) GetFpRate() float64 {
+	if x != nil {
+		return x.FpRate
+	}
+	return 0
+}
+
+func (x *FilterInfo) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_filter_proto protoreflect.FileDescriptor
+
+const file_filter_proto_rawDesc = "" +
+	"\n" +
+	"\ffilter.proto\x12\x11leadgen.hasher.v1\x1a\fhasher.proto\"\x91\x01\n" +
+	"\x13CreateFilterRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1d.leadgen.hasher.v1.FilterKindR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12\x17\n" +
+	"\afp_rate\x18\x04 \x01(\x01R\x06fpRate\"&\n" +
+	"\x10GetFilterRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xcc\x01\n" +
+	"\x12FilterItemsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06inputs\x18\x02 \x03(\tR\x06inputs\x12>\n" +
+	"\talgorithm\x18\x03 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x04 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\"0\n" +
+	"\x14MightContainResponse\x12\x18\n" +
+	"\aresults\x18\x01 \x03(\bR\aresults\")\n" +
+	"\x13ExportFilterRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"!\n" +
+	"\vFilterChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"=\n" +
+	"\x13ImportFilterRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x9e\x01\n" +
+	"\n" +
+	"FilterInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1d.leadgen.hasher.v1.FilterKindR\x04kind\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12\x17\n" +
+	"\afp_rate\x18\x04 \x01(\x01R\x06fpRate\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x04R\x05count*X\n" +
+	"\n" +
+	"FilterKind\x12\x1b\n" +
+	"\x17FILTER_KIND_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FILTER_KIND_BLOOM\x10\x01\x12\x16\n" +
+	"\x12FILTER_KIND_CUCKOO\x10\x022\xf1\x04\n" +
+	"\rFilterService\x12U\n" +
+	"\fCreateFilter\x12&.leadgen.hasher.v1.CreateFilterRequest\x1a\x1d.leadgen.hasher.v1.FilterInfo\x12O\n" +
+	"\tGetFilter\x12#.leadgen.hasher.v1.GetFilterRequest\x1a\x1d.leadgen.hasher.v1.FilterInfo\x12P\n" +
+	"\bAddItems\x12%.leadgen.hasher.v1.FilterItemsRequest\x1a\x1d.leadgen.hasher.v1.FilterInfo\x12S\n" +
+	"\vRemoveItems\x12%.leadgen.hasher.v1.FilterItemsRequest\x1a\x1d.leadgen.hasher.v1.FilterInfo\x12^\n" +
+	"\fMightContain\x12%.leadgen.hasher.v1.FilterItemsRequest\x1a'.leadgen.hasher.v1.MightContainResponse\x12X\n" +
+	"\fExportFilter\x12&.leadgen.hasher.v1.ExportFilterRequest\x1a\x1e.leadgen.hasher.v1.FilterChunk0\x01\x12W\n" +
+	"\fImportFilter\x12&.leadgen.hasher.v1.ImportFilterRequest\x1a\x1d.leadgen.hasher.v1.FilterInfo(\x01B6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_filter_proto_rawDescOnce sync.Once
+	file_filter_proto_rawDescData []byte
+)
+
+func file_filter_proto_rawDescGZIP() []byte {
+	file_filter_proto_rawDescOnce.Do(func() {
+		file_filter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_filter_proto_rawDesc), len(file_filter_proto_rawDesc)))
+	})
+	return file_filter_proto_rawDescData
+}
+
+var file_filter_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filter_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_filter_proto_goTypes = []any{
+	(FilterKind)(0),              // 0: leadgen.hasher.v1.FilterKind
+	(*CreateFilterRequest)(nil),  // 1: leadgen.hasher.v1.CreateFilterRequest
+	(*GetFilterRequest)(nil),     // 2: leadgen.hasher.v1.GetFilterRequest
+	(*FilterItemsRequest)(nil),   // 3: leadgen.hasher.v1.FilterItemsRequest
+	(*MightContainResponse)(nil), // 4: leadgen.hasher.v1.MightContainResponse
+	(*ExportFilterRequest)(nil),  // 5: leadgen.hasher.v1.ExportFilterRequest
+	(*FilterChunk)(nil),          // 6: leadgen.hasher.v1.FilterChunk
+	(*ImportFilterRequest)(nil),  // 7: leadgen.hasher.v1.ImportFilterRequest
+	(*FilterInfo)(nil),           // 8: leadgen.hasher.v1.FilterInfo
+	(HashAlgorithm)(0),           // 9: leadgen.hasher.v1.HashAlgorithm
+	(HashNormalization)(0),       // 10: leadgen.hasher.v1.HashNormalization
+}
+var file_filter_proto_depIdxs = []int32{
+	0,  // 0: leadgen.hasher.v1.CreateFilterRequest.kind:type_name -> leadgen.hasher.v1.FilterKind
+	9,  // 1: leadgen.hasher.v1.FilterItemsRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	10, // 2: leadgen.hasher.v1.FilterItemsRequest.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	0,  // 3: leadgen.hasher.v1.FilterInfo.kind:type_name -> leadgen.hasher.v1.FilterKind
+	1,  // 4: leadgen.hasher.v1.FilterService.CreateFilter:input_type -> leadgen.hasher.v1.CreateFilterRequest
+	2,  // 5: leadgen.hasher.v1.FilterService.GetFilter:input_type -> leadgen.hasher.v1.GetFilterRequest
+	3,  // 6: leadgen.hasher.v1.FilterService.AddItems:input_type -> leadgen.hasher.v1.FilterItemsRequest
+	3,  // 7: leadgen.hasher.v1.FilterService.RemoveItems:input_type -> leadgen.hasher.v1.FilterItemsRequest
+	3,  // 8: leadgen.hasher.v1.FilterService.MightContain:input_type -> leadgen.hasher.v1.FilterItemsRequest
+	5,  // 9: leadgen.hasher.v1.FilterService.ExportFilter:input_type -> leadgen.hasher.v1.ExportFilterRequest
+	7,  // 10: leadgen.hasher.v1.FilterService.ImportFilter:input_type -> leadgen.hasher.v1.ImportFilterRequest
+	8,  // 11: leadgen.hasher.v1.FilterService.CreateFilter:output_type -> leadgen.hasher.v1.FilterInfo
+	8,  // 12: leadgen.hasher.v1.FilterService.GetFilter:output_type -> leadgen.hasher.v1.FilterInfo
+	8,  // 13: leadgen.hasher.v1.FilterService.AddItems:output_type -> leadgen.hasher.v1.FilterInfo
+	8,  // 14: leadgen.hasher.v1.FilterService.RemoveItems:output_type -> leadgen.hasher.v1.FilterInfo
+	4,  // 15: leadgen.hasher.v1.FilterService.MightContain:output_type -> leadgen.hasher.v1.MightContainResponse
+	6,  // 16: leadgen.hasher.v1.FilterService.ExportFilter:output_type -> leadgen.hasher.v1.FilterChunk
+	8,  // 17: leadgen.hasher.v1.FilterService.ImportFilter:output_type -> leadgen.hasher.v1.FilterInfo
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_filter_proto_init() }
+func file_filter_proto_init() {
+	if File_filter_proto != nil {
+		return
+	}
+	file_hasher_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_filter_proto_rawDesc), len(file_filter_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filter_proto_goTypes,
+		DependencyIndexes: file_filter_proto_depIdxs,
+		EnumInfos:         file_filter_proto_enumTypes,
+		MessageInfos:      file_filter_proto_msgTypes,
+	}.Build()
+	File_filter_proto = out.File
+	file_filter_proto_goTypes = nil
+	file_filter_proto_depIdxs = nil
+}
@@ -0,0 +1,370 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: filter.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FilterService_CreateFilter_FullMethodName = "/leadgen.hasher.v1.FilterService/CreateFilter"
+	FilterService_GetFilter_FullMethodName    = "/leadgen.hasher.v1.FilterService/GetFilter"
+	FilterService_AddItems_FullMethodName     = "/leadgen.hasher.v1.FilterService/AddItems"
+	FilterService_RemoveItems_FullMethodName  = "/leadgen.hasher.v1.FilterService/RemoveItems"
+	FilterService_MightContain_FullMethodName = "/leadgen.hasher.v1.FilterService/MightContain"
+	FilterService_ExportFilter_FullMethodName = "/leadgen.hasher.v1.FilterService/ExportFilter"
+	FilterService_ImportFilter_FullMethodName = "/leadgen.hasher.v1.FilterService/ImportFilter"
+)
+
+// FilterServiceClient is the client API for FilterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FilterService keeps Bloom and cuckoo filters of hashed inputs, e.g.
+// do-not-contact suppression lists. Filters are kept per caller tenant, inputs
+// are hashed like Hash does and never stored.
+type FilterServiceClient interface {
+	CreateFilter(ctx context.Context, in *CreateFilterRequest, opts ...grpc.CallOption) (*FilterInfo, error)
+	GetFilter(ctx context.Context, in *GetFilterRequest, opts ...grpc.CallOption) (*FilterInfo, error)
+	// AddItems adds up to 1000 inputs. Inputs added before filter is full are
+	// kept.
+	AddItems(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*FilterInfo, error)
+	// RemoveItems removes up to 1000 inputs, cuckoo filters only.
+	RemoveItems(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*FilterInfo, error)
+	// MightContain checks up to 1000 inputs. False positives happen at about
+	// filter rate, false negatives never do.
+	MightContain(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*MightContainResponse, error)
+	// ExportFilter streams filter in binary format, see README.
+	ExportFilter(ctx context.Context, in *ExportFilterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FilterChunk], error)
+	// ImportFilter uploads filter in binary format replacing filter of the
+	// same name.
+	ImportFilter(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportFilterRequest, FilterInfo], error)
+}
+
+type filterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilterServiceClient(cc grpc.ClientConnInterface) FilterServiceClient {
+	return &filterServiceClient{cc}
+}
+
+func (c *filterServiceClient) CreateFilter(ctx context.Context, in *CreateFilterRequest, opts ...grpc.CallOption) (*FilterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterInfo)
+	err := c.cc.Invoke(ctx, FilterService_CreateFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filterServiceClient) GetFilter(ctx context.Context, in *GetFilterRequest, opts ...grpc.CallOption) (*FilterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterInfo)
+	err := c.cc.Invoke(ctx, FilterService_GetFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filterServiceClient) AddItems(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*FilterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterInfo)
+	err := c.cc.Invoke(ctx, FilterService_AddItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filterServiceClient) RemoveItems(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*FilterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterInfo)
+	err := c.cc.Invoke(ctx, FilterService_RemoveItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filterServiceClient) MightContain(ctx context.Context, in *FilterItemsRequest, opts ...grpc.CallOption) (*MightContainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MightContainResponse)
+	err := c.cc.Invoke(ctx, FilterService_MightContain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filterServiceClient) ExportFilter(ctx context.Context, in *ExportFilterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FilterChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FilterService_ServiceDesc.Streams[0], FilterService_ExportFilter_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportFilterRequest, FilterChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FilterService_ExportFilterClient = grpc.ServerStreamingClient[FilterChunk]
+
+func (c *filterServiceClient) ImportFilter(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportFilterRequest, FilterInfo], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FilterService_ServiceDesc.Streams[1], FilterService_ImportFilter_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportFilterRequest, FilterInfo]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FilterService_ImportFilterClient = grpc.ClientStreamingClient[ImportFilterRequest, FilterInfo]
+
+// FilterServiceServer is the server API for FilterService service.
+// All implementations must embed UnimplementedFilterServiceServer
+// for forward compatibility.
+//
+// FilterService keeps Bloom and cuckoo filters of hashed inputs, e.g.
+// do-not-contact suppression lists. Filters are kept per caller tenant, inputs
+// are hashed like Hash does and never stored.
+type FilterServiceServer interface {
+	CreateFilter(context.Context, *CreateFilterRequest) (*FilterInfo, error)
+	GetFilter(context.Context, *GetFilterRequest) (*FilterInfo, error)
+	// AddItems adds up to 1000 inputs. Inputs added before filter is full are
+	// kept.
+	AddItems(context.Context, *FilterItemsRequest) (*FilterInfo, error)
+	// RemoveItems removes up to 1000 inputs, cuckoo filters only.
+	RemoveItems(context.Context, *FilterItemsRequest) (*FilterInfo, error)
+	// MightContain checks up to 1000 inputs. False positives happen at about
+	// filter rate, false negatives never do.
+	MightContain(context.Context, *FilterItemsRequest) (*MightContainResponse, error)
+	// ExportFilter streams filter in binary format, see README.
+	ExportFilter(*ExportFilterRequest, grpc.ServerStreamingServer[FilterChunk]) error
+	// ImportFilter uploads filter in binary format replacing filter of the
+	// same name.
+	ImportFilter(grpc.ClientStreamingServer[ImportFilterRequest, FilterInfo]) error
+	mustEmbedUnimplementedFilterServiceServer()
+}
+
+// UnimplementedFilterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFilterServiceServer struct{}
+
+func (UnimplementedFilterServiceServer) CreateFilter(context.Context, *CreateFilterRequest) (*FilterInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFilter not implemented")
+}
+func (UnimplementedFilterServiceServer) GetFilter(context.Context, *GetFilterRequest) (*FilterInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFilter not implemented")
+}
+func (UnimplementedFilterServiceServer) AddItems(context.Context, *FilterItemsRequest) (*FilterInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItems not implemented")
+}
+func (UnimplementedFilterServiceServer) RemoveItems(context.Context, *FilterItemsRequest) (*FilterInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItems not implemented")
+}
+func (UnimplementedFilterServiceServer) MightContain(context.Context, *FilterItemsRequest) (*MightContainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MightContain not implemented")
+}
+func (UnimplementedFilterServiceServer) ExportFilter(*ExportFilterRequest, grpc.ServerStreamingServer[FilterChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportFilter not implemented")
+}
+func (UnimplementedFilterServiceServer) ImportFilter(grpc.ClientStreamingServer[ImportFilterRequest, FilterInfo]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportFilter not implemented")
+}
+func (UnimplementedFilterServiceServer) mustEmbedUnimplementedFilterServiceServer() {}
+func (UnimplementedFilterServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeFilterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilterServiceServer will
+// result in compilation errors.
+type UnsafeFilterServiceServer interface {
+	mustEmbedUnimplementedFilterServiceServer()
+}
+
+func RegisterFilterServiceServer(s grpc.ServiceRegistrar, srv FilterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFilterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FilterService_ServiceDesc, srv)
+}
+
+func _FilterService_CreateFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilterServiceServer).CreateFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilterService_CreateFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilterServiceServer).CreateFilter(ctx, req.(*CreateFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilterService_GetFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilterServiceServer).GetFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilterService_GetFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilterServiceServer).GetFilter(ctx, req.(*GetFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilterService_AddItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilterServiceServer).AddItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilterService_AddItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilterServiceServer).AddItems(ctx, req.(*FilterItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilterService_RemoveItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilterServiceServer).RemoveItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilterService_RemoveItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilterServiceServer).RemoveItems(ctx, req.(*FilterItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilterService_MightContain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilterServiceServer).MightContain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilterService_MightContain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilterServiceServer).MightContain(ctx, req.(*FilterItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilterService_ExportFilter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportFilterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilterServiceServer).ExportFilter(m, &grpc.GenericServerStream[ExportFilterRequest, FilterChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FilterService_ExportFilterServer = grpc.ServerStreamingServer[FilterChunk]
+
+func _FilterService_ImportFilter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FilterServiceServer).ImportFilter(&grpc.GenericServerStream[ImportFilterRequest, FilterInfo]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FilterService_ImportFilterServer = grpc.ClientStreamingServer[ImportFilterRequest, FilterInfo]
+
+// FilterService_ServiceDesc is the grpc.ServiceDesc for FilterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FilterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.FilterService",
+	HandlerType: (*FilterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFilter",
+			Handler:    _FilterService_CreateFilter_Handler,
+		},
+		{
+			MethodName: "GetFilter",
+			Handler:    _FilterService_GetFilter_Handler,
+		},
+		{
+			MethodName: "AddItems",
+			Handler:    _FilterService_AddItems_Handler,
+		},
+		{
+			MethodName: "RemoveItems",
+			Handler:    _FilterService_RemoveItems_Handler,
+		},
+		{
+			MethodName: "MightContain",
+			Handler:    _FilterService_MightContain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportFilter",
+			Handler:       _FilterService_ExportFilter_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportFilter",
+			Handler:       _FilterService_ImportFilter_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "filter.proto",
+}
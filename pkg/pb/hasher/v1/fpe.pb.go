@@ -0,0 +1,198 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: fpe.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FPERequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Scheme is a name of configured scheme.
+	Scheme string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Value  string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Tweak overrides scheme default tweak. FF3-1 tweaks are 7 bytes.
+	Tweak         []byte `protobuf:"bytes,3,opt,name=tweak,proto3" json:"tweak,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FPERequest) Reset() {
+	*x = FPERequest{}
+	mi := &file_fpe_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FPERequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FPERequest) ProtoMessage() {}
+
+func (x *FPERequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fpe_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FPERequest.ProtoReflect.Descriptor instead.
+func (*FPERequest) Descriptor() ([]byte, []int) {
+	return file_fpe_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FPERequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *FPERequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FPERequest) GetTweak() []byte {
+	if x != nil {
+		return x.Tweak
+	}
+	return nil
+}
+
+type FPEResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FPEResponse) Reset() {
+	*x = FPEResponse{}
+	mi := &file_fpe_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FPEResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FPEResponse) ProtoMessage() {}
+
+func (x *FPEResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fpe_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FPEResponse.ProtoReflect.Descriptor instead.
+func (*FPEResponse) Descriptor() ([]byte, []int) {
+	return file_fpe_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FPEResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_fpe_proto protoreflect.FileDescriptor
+
+const file_fpe_proto_rawDesc = "" +
+	"\n" +
+	"\tfpe.proto\x12\x11leadgen.hasher.v1\"P\n" +
+	"\n" +
+	"FPERequest\x12\x16\n" +
+	"\x06scheme\x18\x01 \x01(\tR\x06scheme\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
+	"\x05tweak\x18\x03 \x01(\fR\x05tweak\"#\n" +
+	"\vFPEResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value2\xa0\x01\n" +
+	"\n" +
+	"FPEService\x12H\n" +
+	"\aEncrypt\x12\x1d.leadgen.hasher.v1.FPERequest\x1a\x1e.leadgen.hasher.v1.FPEResponse\x12H\n" +
+	"\aDecrypt\x12\x1d.leadgen.hasher.v1.FPERequest\x1a\x1e.leadgen.hasher.v1.FPEResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_fpe_proto_rawDescOnce sync.Once
+	file_fpe_proto_rawDescData []byte
+)
+
+func file_fpe_proto_rawDescGZIP() []byte {
+	file_fpe_proto_rawDescOnce.Do(func() {
+		file_fpe_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fpe_proto_rawDesc), len(file_fpe_proto_rawDesc)))
+	})
+	return file_fpe_proto_rawDescData
+}
+
+var file_fpe_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_fpe_proto_goTypes = []any{
+	(*FPERequest)(nil),  // 0: leadgen.hasher.v1.FPERequest
+	(*FPEResponse)(nil), // 1: leadgen.hasher.v1.FPEResponse
+}
+var file_fpe_proto_depIdxs = []int32{
+	0, // 0: leadgen.hasher.v1.FPEService.Encrypt:input_type -> leadgen.hasher.v1.FPERequest
+	0, // 1: leadgen.hasher.v1.FPEService.Decrypt:input_type -> leadgen.hasher.v1.FPERequest
+	1, // 2: leadgen.hasher.v1.FPEService.Encrypt:output_type -> leadgen.hasher.v1.FPEResponse
+	1, // 3: leadgen.hasher.v1.FPEService.Decrypt:output_type -> leadgen.hasher.v1.FPEResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_fpe_proto_init() }
+func file_fpe_proto_init() {
+	if File_fpe_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fpe_proto_rawDesc), len(file_fpe_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fpe_proto_goTypes,
+		DependencyIndexes: file_fpe_proto_depIdxs,
+		MessageInfos:      file_fpe_proto_msgTypes,
+	}.Build()
+	File_fpe_proto = out.File
+	file_fpe_proto_goTypes = nil
+	file_fpe_proto_depIdxs = nil
+}
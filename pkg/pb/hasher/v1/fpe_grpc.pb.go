@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: fpe.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FPEService_Encrypt_FullMethodName = "/leadgen.hasher.v1.FPEService/Encrypt"
+	FPEService_Decrypt_FullMethodName = "/leadgen.hasher.v1.FPEService/Decrypt"
+)
+
+// FPEServiceClient is the client API for FPEService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FPEService encrypts values keeping their format, e.g. phone numbers stay
+// phone numbers. Only characters of scheme alphabet are encrypted.
+type FPEServiceClient interface {
+	Encrypt(ctx context.Context, in *FPERequest, opts ...grpc.CallOption) (*FPEResponse, error)
+	// Decrypt reverses Encrypt made with the same scheme and tweak.
+	Decrypt(ctx context.Context, in *FPERequest, opts ...grpc.CallOption) (*FPEResponse, error)
+}
+
+type fPEServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFPEServiceClient(cc grpc.ClientConnInterface) FPEServiceClient {
+	return &fPEServiceClient{cc}
+}
+
+func (c *fPEServiceClient) Encrypt(ctx context.Context, in *FPERequest, opts ...grpc.CallOption) (*FPEResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FPEResponse)
+	err := c.cc.Invoke(ctx, FPEService_Encrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPEServiceClient) Decrypt(ctx context.Context, in *FPERequest, opts ...grpc.CallOption) (*FPEResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FPEResponse)
+	err := c.cc.Invoke(ctx, FPEService_Decrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FPEServiceServer is the server API for FPEService service.
+// All implementations must embed UnimplementedFPEServiceServer
+// for forward compatibility.
+//
+// FPEService encrypts values keeping their format, e.g. phone numbers stay
+// phone numbers. Only characters of scheme alphabet are encrypted.
+type FPEServiceServer interface {
+	Encrypt(context.Context, *FPERequest) (*FPEResponse, error)
+	// Decrypt reverses Encrypt made with the same scheme and tweak.
+	Decrypt(context.Context, *FPERequest) (*FPEResponse, error)
+	mustEmbedUnimplementedFPEServiceServer()
+}
+
+// UnimplementedFPEServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFPEServiceServer struct{}
+
+func (UnimplementedFPEServiceServer) Encrypt(context.Context, *FPERequest) (*FPEResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Encrypt not implemented")
+}
+func (UnimplementedFPEServiceServer) Decrypt(context.Context, *FPERequest) (*FPEResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decrypt not implemented")
+}
+func (UnimplementedFPEServiceServer) mustEmbedUnimplementedFPEServiceServer() {}
+func (UnimplementedFPEServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeFPEServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FPEServiceServer will
+// result in compilation errors.
+type UnsafeFPEServiceServer interface {
+	mustEmbedUnimplementedFPEServiceServer()
+}
+
+func RegisterFPEServiceServer(s grpc.ServiceRegistrar, srv FPEServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFPEServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FPEService_ServiceDesc, srv)
+}
+
+func _FPEService_Encrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FPERequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPEServiceServer).Encrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FPEService_Encrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPEServiceServer).Encrypt(ctx, req.(*FPERequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPEService_Decrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FPERequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPEServiceServer).Decrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FPEService_Decrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPEServiceServer).Decrypt(ctx, req.(*FPERequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FPEService_ServiceDesc is the grpc.ServiceDesc for FPEService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FPEService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.FPEService",
+	HandlerType: (*FPEServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Encrypt",
+			Handler:    _FPEService_Encrypt_Handler,
+		},
+		{
+			MethodName: "Decrypt",
+			Handler:    _FPEService_Decrypt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fpe.proto",
+}
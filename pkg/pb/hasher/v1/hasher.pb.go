@@ -21,6 +21,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FileFormat int32
+
+const (
+	FileFormat_FILE_FORMAT_UNSPECIFIED FileFormat = 0
+	FileFormat_FILE_FORMAT_CSV         FileFormat = 1
+	FileFormat_FILE_FORMAT_NDJSON      FileFormat = 2
+)
+
+// Enum value maps for FileFormat.
+var (
+	FileFormat_name = map[int32]string{
+		0: "FILE_FORMAT_UNSPECIFIED",
+		1: "FILE_FORMAT_CSV",
+		2: "FILE_FORMAT_NDJSON",
+	}
+	FileFormat_value = map[string]int32{
+		"FILE_FORMAT_UNSPECIFIED": 0,
+		"FILE_FORMAT_CSV":         1,
+		"FILE_FORMAT_NDJSON":      2,
+	}
+)
+
+func (x FileFormat) Enum() *FileFormat {
+	p := new(FileFormat)
+	*p = x
+	return p
+}
+
+func (x FileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_hasher_proto_enumTypes[0].Descriptor()
+}
+
+func (FileFormat) Type() protoreflect.EnumType {
+	return &file_hasher_proto_enumTypes[0]
+}
+
+func (x FileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileFormat.Descriptor instead.
+func (FileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{0}
+}
+
 type HashAlgorithm int32
 
 const (
@@ -54,11 +103,11 @@ func (x HashAlgorithm) String() string {
 }
 
 func (HashAlgorithm) Descriptor() protoreflect.EnumDescriptor {
-	return file_hasher_proto_enumTypes[0].Descriptor()
+	return file_hasher_proto_enumTypes[1].Descriptor()
 }
 
 func (HashAlgorithm) Type() protoreflect.EnumType {
-	return &file_hasher_proto_enumTypes[0]
+	return &file_hasher_proto_enumTypes[1]
 }
 
 func (x HashAlgorithm) Number() protoreflect.EnumNumber {
@@ -67,13 +116,141 @@ func (x HashAlgorithm) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HashAlgorithm.Descriptor instead.
 func (HashAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return file_hasher_proto_rawDescGZIP(), []int{0}
+	return file_hasher_proto_rawDescGZIP(), []int{1}
+}
+
+type HashNormalization int32
+
+const (
+	HashNormalization_HASH_NORMALIZATION_UNSPECIFIED HashNormalization = 0
+	HashNormalization_HASH_NORMALIZATION_NONE        HashNormalization = 1
+	HashNormalization_HASH_NORMALIZATION_TRIM        HashNormalization = 2
+	HashNormalization_HASH_NORMALIZATION_LOWER       HashNormalization = 3
+	HashNormalization_HASH_NORMALIZATION_DIGITS      HashNormalization = 4
+)
+
+// Enum value maps for HashNormalization.
+var (
+	HashNormalization_name = map[int32]string{
+		0: "HASH_NORMALIZATION_UNSPECIFIED",
+		1: "HASH_NORMALIZATION_NONE",
+		2: "HASH_NORMALIZATION_TRIM",
+		3: "HASH_NORMALIZATION_LOWER",
+		4: "HASH_NORMALIZATION_DIGITS",
+	}
+	HashNormalization_value = map[string]int32{
+		"HASH_NORMALIZATION_UNSPECIFIED": 0,
+		"HASH_NORMALIZATION_NONE":        1,
+		"HASH_NORMALIZATION_TRIM":        2,
+		"HASH_NORMALIZATION_LOWER":       3,
+		"HASH_NORMALIZATION_DIGITS":      4,
+	}
+)
+
+func (x HashNormalization) Enum() *HashNormalization {
+	p := new(HashNormalization)
+	*p = x
+	return p
+}
+
+func (x HashNormalization) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashNormalization) Descriptor() protoreflect.EnumDescriptor {
+	return file_hasher_proto_enumTypes[2].Descriptor()
+}
+
+func (HashNormalization) Type() protoreflect.EnumType {
+	return &file_hasher_proto_enumTypes[2]
+}
+
+func (x HashNormalization) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashNormalization.Descriptor instead.
+func (HashNormalization) EnumDescriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{2}
+}
+
+type HashInputFormat int32
+
+const (
+	// Unspecified format means text.
+	HashInputFormat_HASH_INPUT_FORMAT_UNSPECIFIED HashInputFormat = 0
+	HashInputFormat_HASH_INPUT_FORMAT_TEXT        HashInputFormat = 1
+	HashInputFormat_HASH_INPUT_FORMAT_JSON        HashInputFormat = 2
+)
+
+// Enum value maps for HashInputFormat.
+var (
+	HashInputFormat_name = map[int32]string{
+		0: "HASH_INPUT_FORMAT_UNSPECIFIED",
+		1: "HASH_INPUT_FORMAT_TEXT",
+		2: "HASH_INPUT_FORMAT_JSON",
+	}
+	HashInputFormat_value = map[string]int32{
+		"HASH_INPUT_FORMAT_UNSPECIFIED": 0,
+		"HASH_INPUT_FORMAT_TEXT":        1,
+		"HASH_INPUT_FORMAT_JSON":        2,
+	}
+)
+
+func (x HashInputFormat) Enum() *HashInputFormat {
+	p := new(HashInputFormat)
+	*p = x
+	return p
+}
+
+func (x HashInputFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashInputFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_hasher_proto_enumTypes[3].Descriptor()
+}
+
+func (HashInputFormat) Type() protoreflect.EnumType {
+	return &file_hasher_proto_enumTypes[3]
+}
+
+func (x HashInputFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashInputFormat.Descriptor instead.
+func (HashInputFormat) EnumDescriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{3}
 }
 
 type HashRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Input         string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
 	Algorithm     HashAlgorithm          `protobuf:"varint,2,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization      `protobuf:"varint,3,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	// Salt is mixed into input after peppers.
+	Salt string `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+	// PepperVersion selects server pepper, current one if empty. Previous
+	// versions reproduce hashes made before pepper rotation, "none" means no
+	// server pepper.
+	PepperVersion string `protobuf:"bytes,5,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	// InputFormat JSON means input is a JSON document hashed in RFC 8785
+	// canonical form. Normalization must be unspecified for JSON input.
+	InputFormat HashInputFormat `protobuf:"varint,6,opt,name=input_format,json=inputFormat,proto3,enum=leadgen.hasher.v1.HashInputFormat" json:"input_format,omitempty"`
+	// IncludeFields and ExcludeFields select JSON object fields to hash by
+	// dot-separated paths, e.g. "address.zip". Every field is hashed if include
+	// list is empty.
+	IncludeFields []string `protobuf:"bytes,7,rep,name=include_fields,json=includeFields,proto3" json:"include_fields,omitempty"`
+	ExcludeFields []string `protobuf:"bytes,8,rep,name=exclude_fields,json=excludeFields,proto3" json:"exclude_fields,omitempty"`
+	// WithReceipt requests signed receipt of the hash, it's honored by Hash
+	// only. RequestId is an optional caller identifier of request included in
+	// receipt.
+	WithReceipt bool   `protobuf:"varint,9,opt,name=with_receipt,json=withReceipt,proto3" json:"with_receipt,omitempty"`
+	RequestId   string `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Counter is an optional counter key hash digest is added to, see
+	// CounterService.
+	Counter       string `protobuf:"bytes,11,opt,name=counter,proto3" json:"counter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,9 +299,79 @@ func (x *HashRequest) GetAlgorithm() HashAlgorithm {
 	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
 }
 
+func (x *HashRequest) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+func (x *HashRequest) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *HashRequest) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+func (x *HashRequest) GetInputFormat() HashInputFormat {
+	if x != nil {
+		return x.InputFormat
+	}
+	return HashInputFormat_HASH_INPUT_FORMAT_UNSPECIFIED
+}
+
+func (x *HashRequest) GetIncludeFields() []string {
+	if x != nil {
+		return x.IncludeFields
+	}
+	return nil
+}
+
+func (x *HashRequest) GetExcludeFields() []string {
+	if x != nil {
+		return x.ExcludeFields
+	}
+	return nil
+}
+
+func (x *HashRequest) GetWithReceipt() bool {
+	if x != nil {
+		return x.WithReceipt
+	}
+	return false
+}
+
+func (x *HashRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *HashRequest) GetCounter() string {
+	if x != nil {
+		return x.Counter
+	}
+	return ""
+}
+
 type HashResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hash  string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Salt  string                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	// PepperVersion is a version of server pepper input was mixed with, empty
+	// if server pepper is disabled.
+	PepperVersion string `protobuf:"bytes,3,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	// Receipt is JWS compact serialization of Ed25519 signed hash receipt, set
+	// only if requested. Verification keys are served by ReceiptService.
+	Receipt       string `protobuf:"bytes,4,opt,name=receipt,proto3" json:"receipt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -166,22 +413,701 @@ func (x *HashResponse) GetHash() string {
 	return ""
 }
 
+func (x *HashResponse) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *HashResponse) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+func (x *HashResponse) GetReceipt() string {
+	if x != nil {
+		return x.Receipt
+	}
+	return ""
+}
+
+type HashRecordRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Recipe string                 `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	// Fields not used by recipe are ignored.
+	Fields map[string]string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Salt and pepper version are applied to composite key, see HashRequest.
+	Salt          string `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	PepperVersion string `protobuf:"bytes,4,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashRecordRequest) Reset() {
+	*x = HashRecordRequest{}
+	mi := &file_hasher_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRecordRequest) ProtoMessage() {}
+
+func (x *HashRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRecordRequest.ProtoReflect.Descriptor instead.
+func (*HashRecordRequest) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HashRecordRequest) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *HashRecordRequest) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *HashRecordRequest) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *HashRecordRequest) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type HashBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*HashRequest         `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashBatchRequest) Reset() {
+	*x = HashBatchRequest{}
+	mi := &file_hasher_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashBatchRequest) ProtoMessage() {}
+
+func (x *HashBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashBatchRequest.ProtoReflect.Descriptor instead.
+func (*HashBatchRequest) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HashBatchRequest) GetRequests() []*HashRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type HashBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results are in the same order as requests.
+	Results       []*HashBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashBatchResponse) Reset() {
+	*x = HashBatchResponse{}
+	mi := &file_hasher_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashBatchResponse) ProtoMessage() {}
+
+func (x *HashBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashBatchResponse.ProtoReflect.Descriptor instead.
+func (*HashBatchResponse) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HashBatchResponse) GetResults() []*HashBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type HashBatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hash  string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Error is set instead of hash if request failed.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Salt          string `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	PepperVersion string `protobuf:"bytes,4,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashBatchResult) Reset() {
+	*x = HashBatchResult{}
+	mi := &file_hasher_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashBatchResult) ProtoMessage() {}
+
+func (x *HashBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashBatchResult.ProtoReflect.Descriptor instead.
+func (*HashBatchResult) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HashBatchResult) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *HashBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HashBatchResult) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *HashBatchResult) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type HashFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format and rules are read from the first message only.
+	Format FileFormat    `protobuf:"varint,1,opt,name=format,proto3,enum=leadgen.hasher.v1.FileFormat" json:"format,omitempty"`
+	Rules  []*ColumnRule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Data is a next chunk of file.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Salt and pepper version are applied to every hashed value, see
+	// HashRequest.
+	Salt          string `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+	PepperVersion string `protobuf:"bytes,5,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashFileRequest) Reset() {
+	*x = HashFileRequest{}
+	mi := &file_hasher_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashFileRequest) ProtoMessage() {}
+
+func (x *HashFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashFileRequest.ProtoReflect.Descriptor instead.
+func (*HashFileRequest) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HashFileRequest) GetFormat() FileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FileFormat_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *HashFileRequest) GetRules() []*ColumnRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *HashFileRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *HashFileRequest) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *HashFileRequest) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type ColumnRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Algorithm     HashAlgorithm          `protobuf:"varint,2,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization      `protobuf:"varint,3,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnRule) Reset() {
+	*x = ColumnRule{}
+	mi := &file_hasher_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnRule) ProtoMessage() {}
+
+func (x *ColumnRule) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnRule.ProtoReflect.Descriptor instead.
+func (*ColumnRule) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ColumnRule) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ColumnRule) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *ColumnRule) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+type HashFileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*HashFileResponse_Data
+	//	*HashFileResponse_Report
+	Payload       isHashFileResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashFileResponse) Reset() {
+	*x = HashFileResponse{}
+	mi := &file_hasher_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashFileResponse) ProtoMessage() {}
+
+func (x *HashFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashFileResponse.ProtoReflect.Descriptor instead.
+func (*HashFileResponse) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HashFileResponse) GetPayload() isHashFileResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *HashFileResponse) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*HashFileResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *HashFileResponse) GetReport() *HashFileReport {
+	if x != nil {
+		if x, ok := x.Payload.(*HashFileResponse_Report); ok {
+			return x.Report
+		}
+	}
+	return nil
+}
+
+type isHashFileResponse_Payload interface {
+	isHashFileResponse_Payload()
+}
+
+type HashFileResponse_Data struct {
+	// Data is a next chunk of hashed file.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type HashFileResponse_Report struct {
+	Report *HashFileReport `protobuf:"bytes,2,opt,name=report,proto3,oneof"`
+}
+
+func (*HashFileResponse_Data) isHashFileResponse_Payload() {}
+
+func (*HashFileResponse_Report) isHashFileResponse_Payload() {}
+
+type HashFileReport struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Rows       int64                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	FailedRows int64                  `protobuf:"varint,2,opt,name=failed_rows,json=failedRows,proto3" json:"failed_rows,omitempty"`
+	// Errors are the first row errors.
+	Errors []*RowError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// PepperVersion is a version of server pepper values were hashed with.
+	PepperVersion string `protobuf:"bytes,4,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashFileReport) Reset() {
+	*x = HashFileReport{}
+	mi := &file_hasher_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashFileReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashFileReport) ProtoMessage() {}
+
+func (x *HashFileReport) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashFileReport.ProtoReflect.Descriptor instead.
+func (*HashFileReport) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HashFileReport) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *HashFileReport) GetFailedRows() int64 {
+	if x != nil {
+		return x.FailedRows
+	}
+	return 0
+}
+
+func (x *HashFileReport) GetErrors() []*RowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *HashFileReport) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type RowError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Row is one-based number of data row.
+	Row int64 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Column is empty if the whole row is malformed.
+	Column        string `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowError) Reset() {
+	*x = RowError{}
+	mi := &file_hasher_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowError) ProtoMessage() {}
+
+func (x *RowError) ProtoReflect() protoreflect.Message {
+	mi := &file_hasher_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowError.ProtoReflect.Descriptor instead.
+func (*RowError) Descriptor() ([]byte, []int) {
+	return file_hasher_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RowError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RowError) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *RowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_hasher_proto protoreflect.FileDescriptor
 
 const file_hasher_proto_rawDesc = "" +
 	"\n" +
-	"\fhasher.proto\x12\x11leadgen.hasher.v1\"c\n" +
+	"\fhasher.proto\x12\x11leadgen.hasher.v1\"\xdb\x03\n" +
 	"\vHashRequest\x12\x14\n" +
 	"\x05input\x18\x01 \x01(\tR\x05input\x12>\n" +
-	"\talgorithm\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\"\"\n" +
+	"\talgorithm\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x03 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\x12\x12\n" +
+	"\x04salt\x18\x04 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x05 \x01(\tR\rpepperVersion\x12E\n" +
+	"\finput_format\x18\x06 \x01(\x0e2\".leadgen.hasher.v1.HashInputFormatR\vinputFormat\x12%\n" +
+	"\x0einclude_fields\x18\a \x03(\tR\rincludeFields\x12%\n" +
+	"\x0eexclude_fields\x18\b \x03(\tR\rexcludeFields\x12!\n" +
+	"\fwith_receipt\x18\t \x01(\bR\vwithReceipt\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\n" +
+	" \x01(\tR\trequestId\x12\x18\n" +
+	"\acounter\x18\v \x01(\tR\acounter\"w\n" +
 	"\fHashResponse\x12\x12\n" +
-	"\x04hash\x18\x01 \x01(\tR\x04hash*b\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x03 \x01(\tR\rpepperVersion\x12\x18\n" +
+	"\areceipt\x18\x04 \x01(\tR\areceipt\"\xeb\x01\n" +
+	"\x11HashRecordRequest\x12\x16\n" +
+	"\x06recipe\x18\x01 \x01(\tR\x06recipe\x12H\n" +
+	"\x06fields\x18\x02 \x03(\v20.leadgen.hasher.v1.HashRecordRequest.FieldsEntryR\x06fields\x12\x12\n" +
+	"\x04salt\x18\x03 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x04 \x01(\tR\rpepperVersion\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"N\n" +
+	"\x10HashBatchRequest\x12:\n" +
+	"\brequests\x18\x01 \x03(\v2\x1e.leadgen.hasher.v1.HashRequestR\brequests\"Q\n" +
+	"\x11HashBatchResponse\x12<\n" +
+	"\aresults\x18\x01 \x03(\v2\".leadgen.hasher.v1.HashBatchResultR\aresults\"v\n" +
+	"\x0fHashBatchResult\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x12\n" +
+	"\x04salt\x18\x03 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x04 \x01(\tR\rpepperVersion\"\xcc\x01\n" +
+	"\x0fHashFileRequest\x125\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1d.leadgen.hasher.v1.FileFormatR\x06format\x123\n" +
+	"\x05rules\x18\x02 \x03(\v2\x1d.leadgen.hasher.v1.ColumnRuleR\x05rules\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x12\n" +
+	"\x04salt\x18\x04 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x05 \x01(\tR\rpepperVersion\"\xb0\x01\n" +
+	"\n" +
+	"ColumnRule\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12>\n" +
+	"\talgorithm\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x03 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\"p\n" +
+	"\x10HashFileResponse\x12\x14\n" +
+	"\x04data\x18\x01 \x01(\fH\x00R\x04data\x12;\n" +
+	"\x06report\x18\x02 \x01(\v2!.leadgen.hasher.v1.HashFileReportH\x00R\x06reportB\t\n" +
+	"\apayload\"\xa1\x01\n" +
+	"\x0eHashFileReport\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x03R\x04rows\x12\x1f\n" +
+	"\vfailed_rows\x18\x02 \x01(\x03R\n" +
+	"failedRows\x123\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1b.leadgen.hasher.v1.RowErrorR\x06errors\x12%\n" +
+	"\x0epepper_version\x18\x04 \x01(\tR\rpepperVersion\"J\n" +
+	"\bRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error*V\n" +
+	"\n" +
+	"FileFormat\x12\x1b\n" +
+	"\x17FILE_FORMAT_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fFILE_FORMAT_CSV\x10\x01\x12\x16\n" +
+	"\x12FILE_FORMAT_NDJSON\x10\x02*b\n" +
 	"\rHashAlgorithm\x12\x1e\n" +
 	"\x1aHASH_ALGORITHM_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12HASH_ALGORITHM_MD5\x10\x01\x12\x19\n" +
-	"\x15HASH_ALGORITHM_SHA256\x10\x022X\n" +
+	"\x15HASH_ALGORITHM_SHA256\x10\x02*\xae\x01\n" +
+	"\x11HashNormalization\x12\"\n" +
+	"\x1eHASH_NORMALIZATION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17HASH_NORMALIZATION_NONE\x10\x01\x12\x1b\n" +
+	"\x17HASH_NORMALIZATION_TRIM\x10\x02\x12\x1c\n" +
+	"\x18HASH_NORMALIZATION_LOWER\x10\x03\x12\x1d\n" +
+	"\x19HASH_NORMALIZATION_DIGITS\x10\x04*l\n" +
+	"\x0fHashInputFormat\x12!\n" +
+	"\x1dHASH_INPUT_FORMAT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16HASH_INPUT_FORMAT_TEXT\x10\x01\x12\x1a\n" +
+	"\x16HASH_INPUT_FORMAT_JSON\x10\x022\xde\x02\n" +
 	"\rHasherService\x12G\n" +
-	"\x04Hash\x12\x1e.leadgen.hasher.v1.HashRequest\x1a\x1f.leadgen.hasher.v1.HashResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+	"\x04Hash\x12\x1e.leadgen.hasher.v1.HashRequest\x1a\x1f.leadgen.hasher.v1.HashResponse\x12V\n" +
+	"\tHashBatch\x12#.leadgen.hasher.v1.HashBatchRequest\x1a$.leadgen.hasher.v1.HashBatchResponse\x12W\n" +
+	"\bHashFile\x12\".leadgen.hasher.v1.HashFileRequest\x1a#.leadgen.hasher.v1.HashFileResponse(\x010\x01\x12S\n" +
+	"\n" +
+	"HashRecord\x12$.leadgen.hasher.v1.HashRecordRequest\x1a\x1f.leadgen.hasher.v1.HashResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
 
 var (
 	file_hasher_proto_rawDescOnce sync.Once
@@ -195,22 +1121,52 @@ func file_hasher_proto_rawDescGZIP() []byte {
 	return file_hasher_proto_rawDescData
 }
 
-var file_hasher_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_hasher_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_hasher_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_hasher_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_hasher_proto_goTypes = []any{
-	(HashAlgorithm)(0),   // 0: leadgen.hasher.v1.HashAlgorithm
-	(*HashRequest)(nil),  // 1: leadgen.hasher.v1.HashRequest
-	(*HashResponse)(nil), // 2: leadgen.hasher.v1.HashResponse
+	(FileFormat)(0),           // 0: leadgen.hasher.v1.FileFormat
+	(HashAlgorithm)(0),        // 1: leadgen.hasher.v1.HashAlgorithm
+	(HashNormalization)(0),    // 2: leadgen.hasher.v1.HashNormalization
+	(HashInputFormat)(0),      // 3: leadgen.hasher.v1.HashInputFormat
+	(*HashRequest)(nil),       // 4: leadgen.hasher.v1.HashRequest
+	(*HashResponse)(nil),      // 5: leadgen.hasher.v1.HashResponse
+	(*HashRecordRequest)(nil), // 6: leadgen.hasher.v1.HashRecordRequest
+	(*HashBatchRequest)(nil),  // 7: leadgen.hasher.v1.HashBatchRequest
+	(*HashBatchResponse)(nil), // 8: leadgen.hasher.v1.HashBatchResponse
+	(*HashBatchResult)(nil),   // 9: leadgen.hasher.v1.HashBatchResult
+	(*HashFileRequest)(nil),   // 10: leadgen.hasher.v1.HashFileRequest
+	(*ColumnRule)(nil),        // 11: leadgen.hasher.v1.ColumnRule
+	(*HashFileResponse)(nil),  // 12: leadgen.hasher.v1.HashFileResponse
+	(*HashFileReport)(nil),    // 13: leadgen.hasher.v1.HashFileReport
+	(*RowError)(nil),          // 14: leadgen.hasher.v1.RowError
+	nil,                       // 15: leadgen.hasher.v1.HashRecordRequest.FieldsEntry
 }
 var file_hasher_proto_depIdxs = []int32{
-	0, // 0: leadgen.hasher.v1.HashRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
-	1, // 1: leadgen.hasher.v1.HasherService.Hash:input_type -> leadgen.hasher.v1.HashRequest
-	2, // 2: leadgen.hasher.v1.HasherService.Hash:output_type -> leadgen.hasher.v1.HashResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: leadgen.hasher.v1.HashRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	2,  // 1: leadgen.hasher.v1.HashRequest.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	3,  // 2: leadgen.hasher.v1.HashRequest.input_format:type_name -> leadgen.hasher.v1.HashInputFormat
+	15, // 3: leadgen.hasher.v1.HashRecordRequest.fields:type_name -> leadgen.hasher.v1.HashRecordRequest.FieldsEntry
+	4,  // 4: leadgen.hasher.v1.HashBatchRequest.requests:type_name -> leadgen.hasher.v1.HashRequest
+	9,  // 5: leadgen.hasher.v1.HashBatchResponse.results:type_name -> leadgen.hasher.v1.HashBatchResult
+	0,  // 6: leadgen.hasher.v1.HashFileRequest.format:type_name -> leadgen.hasher.v1.FileFormat
+	11, // 7: leadgen.hasher.v1.HashFileRequest.rules:type_name -> leadgen.hasher.v1.ColumnRule
+	1,  // 8: leadgen.hasher.v1.ColumnRule.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	2,  // 9: leadgen.hasher.v1.ColumnRule.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	13, // 10: leadgen.hasher.v1.HashFileResponse.report:type_name -> leadgen.hasher.v1.HashFileReport
+	14, // 11: leadgen.hasher.v1.HashFileReport.errors:type_name -> leadgen.hasher.v1.RowError
+	4,  // 12: leadgen.hasher.v1.HasherService.Hash:input_type -> leadgen.hasher.v1.HashRequest
+	7,  // 13: leadgen.hasher.v1.HasherService.HashBatch:input_type -> leadgen.hasher.v1.HashBatchRequest
+	10, // 14: leadgen.hasher.v1.HasherService.HashFile:input_type -> leadgen.hasher.v1.HashFileRequest
+	6,  // 15: leadgen.hasher.v1.HasherService.HashRecord:input_type -> leadgen.hasher.v1.HashRecordRequest
+	5,  // 16: leadgen.hasher.v1.HasherService.Hash:output_type -> leadgen.hasher.v1.HashResponse
+	8,  // 17: leadgen.hasher.v1.HasherService.HashBatch:output_type -> leadgen.hasher.v1.HashBatchResponse
+	12, // 18: leadgen.hasher.v1.HasherService.HashFile:output_type -> leadgen.hasher.v1.HashFileResponse
+	5,  // 19: leadgen.hasher.v1.HasherService.HashRecord:output_type -> leadgen.hasher.v1.HashResponse
+	16, // [16:20] is the sub-list for method output_type
+	12, // [12:16] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_hasher_proto_init() }
@@ -218,13 +1174,17 @@ func file_hasher_proto_init() {
 	if File_hasher_proto != nil {
 		return
 	}
+	file_hasher_proto_msgTypes[8].OneofWrappers = []any{
+		(*HashFileResponse_Data)(nil),
+		(*HashFileResponse_Report)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hasher_proto_rawDesc), len(file_hasher_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      4,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	HasherService_Hash_FullMethodName = "/leadgen.hasher.v1.HasherService/Hash"
+	HasherService_Hash_FullMethodName       = "/leadgen.hasher.v1.HasherService/Hash"
+	HasherService_HashBatch_FullMethodName  = "/leadgen.hasher.v1.HasherService/HashBatch"
+	HasherService_HashFile_FullMethodName   = "/leadgen.hasher.v1.HasherService/HashFile"
+	HasherService_HashRecord_FullMethodName = "/leadgen.hasher.v1.HasherService/HashRecord"
 )
 
 // HasherServiceClient is the client API for HasherService service.
@@ -27,6 +30,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type HasherServiceClient interface {
 	Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	HashBatch(ctx context.Context, in *HashBatchRequest, opts ...grpc.CallOption) (*HashBatchResponse, error)
+	// HashFile hashes columns of streamed CSV or NDJSON file. Hashed file is
+	// streamed back in data chunks followed by a single report message.
+	HashFile(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HashFileRequest, HashFileResponse], error)
+	// HashRecord hashes composite key of record fields built by named recipe
+	// defined in server config.
+	HashRecord(ctx context.Context, in *HashRecordRequest, opts ...grpc.CallOption) (*HashResponse, error)
 }
 
 type hasherServiceClient struct {
@@ -47,11 +57,51 @@ func (c *hasherServiceClient) Hash(ctx context.Context, in *HashRequest, opts ..
 	return out, nil
 }
 
+func (c *hasherServiceClient) HashBatch(ctx context.Context, in *HashBatchRequest, opts ...grpc.CallOption) (*HashBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HashBatchResponse)
+	err := c.cc.Invoke(ctx, HasherService_HashBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hasherServiceClient) HashFile(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HashFileRequest, HashFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HasherService_ServiceDesc.Streams[0], HasherService_HashFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HashFileRequest, HashFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HasherService_HashFileClient = grpc.BidiStreamingClient[HashFileRequest, HashFileResponse]
+
+func (c *hasherServiceClient) HashRecord(ctx context.Context, in *HashRecordRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, HasherService_HashRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HasherServiceServer is the server API for HasherService service.
 // All implementations must embed UnimplementedHasherServiceServer
 // for forward compatibility.
 type HasherServiceServer interface {
 	Hash(context.Context, *HashRequest) (*HashResponse, error)
+	HashBatch(context.Context, *HashBatchRequest) (*HashBatchResponse, error)
+	// HashFile hashes columns of streamed CSV or NDJSON file. Hashed file is
+	// streamed back in data chunks followed by a single report message.
+	HashFile(grpc.BidiStreamingServer[HashFileRequest, HashFileResponse]) error
+	// HashRecord hashes composite key of record fields built by named recipe
+	// defined in server config.
+	HashRecord(context.Context, *HashRecordRequest) (*HashResponse, error)
 	mustEmbedUnimplementedHasherServiceServer()
 }
 
@@ -65,6 +115,15 @@ type UnimplementedHasherServiceServer struct{}
 func (UnimplementedHasherServiceServer) Hash(context.Context, *HashRequest) (*HashResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Hash not implemented")
 }
+func (UnimplementedHasherServiceServer) HashBatch(context.Context, *HashBatchRequest) (*HashBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashBatch not implemented")
+}
+func (UnimplementedHasherServiceServer) HashFile(grpc.BidiStreamingServer[HashFileRequest, HashFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method HashFile not implemented")
+}
+func (UnimplementedHasherServiceServer) HashRecord(context.Context, *HashRecordRequest) (*HashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashRecord not implemented")
+}
 func (UnimplementedHasherServiceServer) mustEmbedUnimplementedHasherServiceServer() {}
 func (UnimplementedHasherServiceServer) testEmbeddedByValue()                       {}
 
@@ -104,6 +163,49 @@ func _HasherService_Hash_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HasherService_HashBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HasherServiceServer).HashBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HasherService_HashBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HasherServiceServer).HashBatch(ctx, req.(*HashBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HasherService_HashFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HasherServiceServer).HashFile(&grpc.GenericServerStream[HashFileRequest, HashFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HasherService_HashFileServer = grpc.BidiStreamingServer[HashFileRequest, HashFileResponse]
+
+func _HasherService_HashRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HasherServiceServer).HashRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HasherService_HashRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HasherServiceServer).HashRecord(ctx, req.(*HashRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HasherService_ServiceDesc is the grpc.ServiceDesc for HasherService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +217,22 @@ var HasherService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Hash",
 			Handler:    _HasherService_Hash_Handler,
 		},
+		{
+			MethodName: "HashBatch",
+			Handler:    _HasherService_HashBatch_Handler,
+		},
+		{
+			MethodName: "HashRecord",
+			Handler:    _HasherService_HashRecord_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HashFile",
+			Handler:       _HasherService_HashFile_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "hasher.proto",
 }
@@ -0,0 +1,679 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: job.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNSPECIFIED JobStatus = 0
+	JobStatus_JOB_STATUS_UPLOADING   JobStatus = 1
+	JobStatus_JOB_STATUS_PENDING     JobStatus = 2
+	JobStatus_JOB_STATUS_RUNNING     JobStatus = 3
+	JobStatus_JOB_STATUS_SUCCEEDED   JobStatus = 4
+	JobStatus_JOB_STATUS_FAILED      JobStatus = 5
+	JobStatus_JOB_STATUS_CANCELED    JobStatus = 6
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNSPECIFIED",
+		1: "JOB_STATUS_UPLOADING",
+		2: "JOB_STATUS_PENDING",
+		3: "JOB_STATUS_RUNNING",
+		4: "JOB_STATUS_SUCCEEDED",
+		5: "JOB_STATUS_FAILED",
+		6: "JOB_STATUS_CANCELED",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNSPECIFIED": 0,
+		"JOB_STATUS_UPLOADING":   1,
+		"JOB_STATUS_PENDING":     2,
+		"JOB_STATUS_RUNNING":     3,
+		"JOB_STATUS_SUCCEEDED":   4,
+		"JOB_STATUS_FAILED":      5,
+		"JOB_STATUS_CANCELED":    6,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_job_proto_enumTypes[0].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_job_proto_enumTypes[0]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{0}
+}
+
+type SubmitJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Settings are read from the first message only.
+	Algorithm     HashAlgorithm     `protobuf:"varint,1,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization `protobuf:"varint,2,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	Rows          []string          `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Salt and pepper version are applied to every row, see HashRequest.
+	Salt          string `protobuf:"bytes,4,opt,name=salt,proto3" json:"salt,omitempty"`
+	PepperVersion string `protobuf:"bytes,5,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobRequest) Reset() {
+	*x = SubmitJobRequest{}
+	mi := &file_job_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobRequest) ProtoMessage() {}
+
+func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitJobRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *SubmitJobRequest) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+func (x *SubmitJobRequest) GetRows() []string {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_job_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchJobRequest) Reset() {
+	*x = WatchJobRequest{}
+	mi := &file_job_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJobRequest) ProtoMessage() {}
+
+func (x *WatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DownloadResultsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Offset is an index of the first result to stream.
+	Offset        int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadResultsRequest) Reset() {
+	*x = DownloadResultsRequest{}
+	mi := &file_job_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadResultsRequest) ProtoMessage() {}
+
+func (x *DownloadResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadResultsRequest.ProtoReflect.Descriptor instead.
+func (*DownloadResultsRequest) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DownloadResultsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DownloadResultsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_job_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        JobStatus              `protobuf:"varint,2,opt,name=status,proto3,enum=leadgen.hasher.v1.JobStatus" json:"status,omitempty"`
+	Algorithm     HashAlgorithm          `protobuf:"varint,3,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Normalization HashNormalization      `protobuf:"varint,4,opt,name=normalization,proto3,enum=leadgen.hasher.v1.HashNormalization" json:"normalization,omitempty"`
+	TotalRows     int64                  `protobuf:"varint,5,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	ProcessedRows int64                  `protobuf:"varint,6,opt,name=processed_rows,json=processedRows,proto3" json:"processed_rows,omitempty"`
+	FailedRows    int64                  `protobuf:"varint,7,opt,name=failed_rows,json=failedRows,proto3" json:"failed_rows,omitempty"`
+	// Error is a reason of job failure.
+	Error     string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Salt      string                 `protobuf:"bytes,11,opt,name=salt,proto3" json:"salt,omitempty"`
+	// PepperVersion is a version of server pepper pinned to job.
+	PepperVersion string `protobuf:"bytes,12,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_job_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *Job) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *Job) GetNormalization() HashNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return HashNormalization_HASH_NORMALIZATION_UNSPECIFIED
+}
+
+func (x *Job) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *Job) GetProcessedRows() int64 {
+	if x != nil {
+		return x.ProcessedRows
+	}
+	return 0
+}
+
+func (x *Job) GetFailedRows() int64 {
+	if x != nil {
+		return x.FailedRows
+	}
+	return 0
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *Job) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+type JobResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index is zero-based index of row.
+	Index int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Hash  string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Error is set instead of hash if row failed.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobResult) Reset() {
+	*x = JobResult{}
+	mi := &file_job_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResult) ProtoMessage() {}
+
+func (x *JobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_job_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResult.ProtoReflect.Descriptor instead.
+func (*JobResult) Descriptor() ([]byte, []int) {
+	return file_job_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JobResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *JobResult) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *JobResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_job_proto protoreflect.FileDescriptor
+
+const file_job_proto_rawDesc = "" +
+	"\n" +
+	"\tjob.proto\x12\x11leadgen.hasher.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fhasher.proto\"\xed\x01\n" +
+	"\x10SubmitJobRequest\x12>\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x02 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\x12\x12\n" +
+	"\x04rows\x18\x03 \x03(\tR\x04rows\x12\x12\n" +
+	"\x04salt\x18\x04 \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\x05 \x01(\tR\rpepperVersion\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\x0fWatchJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x16DownloadResultsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x85\x04\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1c.leadgen.hasher.v1.JobStatusR\x06status\x12>\n" +
+	"\talgorithm\x18\x03 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12J\n" +
+	"\rnormalization\x18\x04 \x01(\x0e2$.leadgen.hasher.v1.HashNormalizationR\rnormalization\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x05 \x01(\x03R\ttotalRows\x12%\n" +
+	"\x0eprocessed_rows\x18\x06 \x01(\x03R\rprocessedRows\x12\x1f\n" +
+	"\vfailed_rows\x18\a \x01(\x03R\n" +
+	"failedRows\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04salt\x18\v \x01(\tR\x04salt\x12%\n" +
+	"\x0epepper_version\x18\f \x01(\tR\rpepperVersion\"K\n" +
+	"\tJobResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error*\xbb\x01\n" +
+	"\tJobStatus\x12\x1a\n" +
+	"\x16JOB_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14JOB_STATUS_UPLOADING\x10\x01\x12\x16\n" +
+	"\x12JOB_STATUS_PENDING\x10\x02\x12\x16\n" +
+	"\x12JOB_STATUS_RUNNING\x10\x03\x12\x18\n" +
+	"\x14JOB_STATUS_SUCCEEDED\x10\x04\x12\x15\n" +
+	"\x11JOB_STATUS_FAILED\x10\x05\x12\x17\n" +
+	"\x13JOB_STATUS_CANCELED\x10\x062\x8e\x03\n" +
+	"\n" +
+	"JobService\x12J\n" +
+	"\tSubmitJob\x12#.leadgen.hasher.v1.SubmitJobRequest\x1a\x16.leadgen.hasher.v1.Job(\x01\x12B\n" +
+	"\x06GetJob\x12 .leadgen.hasher.v1.GetJobRequest\x1a\x16.leadgen.hasher.v1.Job\x12H\n" +
+	"\bWatchJob\x12\".leadgen.hasher.v1.WatchJobRequest\x1a\x16.leadgen.hasher.v1.Job0\x01\x12\\\n" +
+	"\x0fDownloadResults\x12).leadgen.hasher.v1.DownloadResultsRequest\x1a\x1c.leadgen.hasher.v1.JobResult0\x01\x12H\n" +
+	"\tCancelJob\x12#.leadgen.hasher.v1.CancelJobRequest\x1a\x16.leadgen.hasher.v1.JobB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_job_proto_rawDescOnce sync.Once
+	file_job_proto_rawDescData []byte
+)
+
+func file_job_proto_rawDescGZIP() []byte {
+	file_job_proto_rawDescOnce.Do(func() {
+		file_job_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_job_proto_rawDesc), len(file_job_proto_rawDesc)))
+	})
+	return file_job_proto_rawDescData
+}
+
+var file_job_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_job_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_job_proto_goTypes = []any{
+	(JobStatus)(0),                 // 0: leadgen.hasher.v1.JobStatus
+	(*SubmitJobRequest)(nil),       // 1: leadgen.hasher.v1.SubmitJobRequest
+	(*GetJobRequest)(nil),          // 2: leadgen.hasher.v1.GetJobRequest
+	(*WatchJobRequest)(nil),        // 3: leadgen.hasher.v1.WatchJobRequest
+	(*DownloadResultsRequest)(nil), // 4: leadgen.hasher.v1.DownloadResultsRequest
+	(*CancelJobRequest)(nil),       // 5: leadgen.hasher.v1.CancelJobRequest
+	(*Job)(nil),                    // 6: leadgen.hasher.v1.Job
+	(*JobResult)(nil),              // 7: leadgen.hasher.v1.JobResult
+	(HashAlgorithm)(0),             // 8: leadgen.hasher.v1.HashAlgorithm
+	(HashNormalization)(0),         // 9: leadgen.hasher.v1.HashNormalization
+	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+}
+var file_job_proto_depIdxs = []int32{
+	8,  // 0: leadgen.hasher.v1.SubmitJobRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	9,  // 1: leadgen.hasher.v1.SubmitJobRequest.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	0,  // 2: leadgen.hasher.v1.Job.status:type_name -> leadgen.hasher.v1.JobStatus
+	8,  // 3: leadgen.hasher.v1.Job.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	9,  // 4: leadgen.hasher.v1.Job.normalization:type_name -> leadgen.hasher.v1.HashNormalization
+	10, // 5: leadgen.hasher.v1.Job.created_at:type_name -> google.protobuf.Timestamp
+	10, // 6: leadgen.hasher.v1.Job.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: leadgen.hasher.v1.JobService.SubmitJob:input_type -> leadgen.hasher.v1.SubmitJobRequest
+	2,  // 8: leadgen.hasher.v1.JobService.GetJob:input_type -> leadgen.hasher.v1.GetJobRequest
+	3,  // 9: leadgen.hasher.v1.JobService.WatchJob:input_type -> leadgen.hasher.v1.WatchJobRequest
+	4,  // 10: leadgen.hasher.v1.JobService.DownloadResults:input_type -> leadgen.hasher.v1.DownloadResultsRequest
+	5,  // 11: leadgen.hasher.v1.JobService.CancelJob:input_type -> leadgen.hasher.v1.CancelJobRequest
+	6,  // 12: leadgen.hasher.v1.JobService.SubmitJob:output_type -> leadgen.hasher.v1.Job
+	6,  // 13: leadgen.hasher.v1.JobService.GetJob:output_type -> leadgen.hasher.v1.Job
+	6,  // 14: leadgen.hasher.v1.JobService.WatchJob:output_type -> leadgen.hasher.v1.Job
+	7,  // 15: leadgen.hasher.v1.JobService.DownloadResults:output_type -> leadgen.hasher.v1.JobResult
+	6,  // 16: leadgen.hasher.v1.JobService.CancelJob:output_type -> leadgen.hasher.v1.Job
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_job_proto_init() }
+func file_job_proto_init() {
+	if File_job_proto != nil {
+		return
+	}
+	file_hasher_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_job_proto_rawDesc), len(file_job_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_job_proto_goTypes,
+		DependencyIndexes: file_job_proto_depIdxs,
+		EnumInfos:         file_job_proto_enumTypes,
+		MessageInfos:      file_job_proto_msgTypes,
+	}.Build()
+	File_job_proto = out.File
+	file_job_proto_goTypes = nil
+	file_job_proto_depIdxs = nil
+}
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: kdf.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DeriveHKDFRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unspecified algorithm means SHA256.
+	Algorithm HashAlgorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Salt      []byte        `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Info binds key to its purpose, e.g. "orders-db".
+	Info string `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+	// Length is a key length in bytes.
+	Length        uint32 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeriveHKDFRequest) Reset() {
+	*x = DeriveHKDFRequest{}
+	mi := &file_kdf_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeriveHKDFRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeriveHKDFRequest) ProtoMessage() {}
+
+func (x *DeriveHKDFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kdf_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeriveHKDFRequest.ProtoReflect.Descriptor instead.
+func (*DeriveHKDFRequest) Descriptor() ([]byte, []int) {
+	return file_kdf_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeriveHKDFRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *DeriveHKDFRequest) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *DeriveHKDFRequest) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+func (x *DeriveHKDFRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DerivePBKDF2Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unspecified algorithm means SHA256.
+	Algorithm HashAlgorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Password  string        `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Salt      []byte        `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Iterations are from 1000 to 600000, iterations times number of hash
+	// digests in key is at most 1200000.
+	Iterations uint32 `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	// Length is a key length in bytes.
+	Length        uint32 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DerivePBKDF2Request) Reset() {
+	*x = DerivePBKDF2Request{}
+	mi := &file_kdf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivePBKDF2Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivePBKDF2Request) ProtoMessage() {}
+
+func (x *DerivePBKDF2Request) ProtoReflect() protoreflect.Message {
+	mi := &file_kdf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivePBKDF2Request.ProtoReflect.Descriptor instead.
+func (*DerivePBKDF2Request) Descriptor() ([]byte, []int) {
+	return file_kdf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DerivePBKDF2Request) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *DerivePBKDF2Request) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DerivePBKDF2Request) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *DerivePBKDF2Request) GetIterations() uint32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *DerivePBKDF2Request) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DerivedKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DerivedKey) Reset() {
+	*x = DerivedKey{}
+	mi := &file_kdf_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivedKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedKey) ProtoMessage() {}
+
+func (x *DerivedKey) ProtoReflect() protoreflect.Message {
+	mi := &file_kdf_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedKey.ProtoReflect.Descriptor instead.
+func (*DerivedKey) Descriptor() ([]byte, []int) {
+	return file_kdf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DerivedKey) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+var File_kdf_proto protoreflect.FileDescriptor
+
+const file_kdf_proto_rawDesc = "" +
+	"\n" +
+	"\tkdf.proto\x12\x11leadgen.hasher.v1\x1a\fhasher.proto\"\x93\x01\n" +
+	"\x11DeriveHKDFRequest\x12>\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\fR\x04salt\x12\x12\n" +
+	"\x04info\x18\x03 \x01(\tR\x04info\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\rR\x06length\"\xbd\x01\n" +
+	"\x13DerivePBKDF2Request\x12>\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04salt\x18\x03 \x01(\fR\x04salt\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x04 \x01(\rR\n" +
+	"iterations\x12\x16\n" +
+	"\x06length\x18\x05 \x01(\rR\x06length\"\x1e\n" +
+	"\n" +
+	"DerivedKey\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key2\xb6\x01\n" +
+	"\n" +
+	"KDFService\x12Q\n" +
+	"\n" +
+	"DeriveHKDF\x12$.leadgen.hasher.v1.DeriveHKDFRequest\x1a\x1d.leadgen.hasher.v1.DerivedKey\x12U\n" +
+	"\fDerivePBKDF2\x12&.leadgen.hasher.v1.DerivePBKDF2Request\x1a\x1d.leadgen.hasher.v1.DerivedKeyB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_kdf_proto_rawDescOnce sync.Once
+	file_kdf_proto_rawDescData []byte
+)
+
+func file_kdf_proto_rawDescGZIP() []byte {
+	file_kdf_proto_rawDescOnce.Do(func() {
+		file_kdf_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kdf_proto_rawDesc), len(file_kdf_proto_rawDesc)))
+	})
+	return file_kdf_proto_rawDescData
+}
+
+var file_kdf_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_kdf_proto_goTypes = []any{
+	(*DeriveHKDFRequest)(nil),   // 0: leadgen.hasher.v1.DeriveHKDFRequest
+	(*DerivePBKDF2Request)(nil), // 1: leadgen.hasher.v1.DerivePBKDF2Request
+	(*DerivedKey)(nil),          // 2: leadgen.hasher.v1.DerivedKey
+	(HashAlgorithm)(0),          // 3: leadgen.hasher.v1.HashAlgorithm
+}
+var file_kdf_proto_depIdxs = []int32{
+	3, // 0: leadgen.hasher.v1.DeriveHKDFRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	3, // 1: leadgen.hasher.v1.DerivePBKDF2Request.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	0, // 2: leadgen.hasher.v1.KDFService.DeriveHKDF:input_type -> leadgen.hasher.v1.DeriveHKDFRequest
+	1, // 3: leadgen.hasher.v1.KDFService.DerivePBKDF2:input_type -> leadgen.hasher.v1.DerivePBKDF2Request
+	2, // 4: leadgen.hasher.v1.KDFService.DeriveHKDF:output_type -> leadgen.hasher.v1.DerivedKey
+	2, // 5: leadgen.hasher.v1.KDFService.DerivePBKDF2:output_type -> leadgen.hasher.v1.DerivedKey
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_kdf_proto_init() }
+func file_kdf_proto_init() {
+	if File_kdf_proto != nil {
+		return
+	}
+	file_hasher_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kdf_proto_rawDesc), len(file_kdf_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kdf_proto_goTypes,
+		DependencyIndexes: file_kdf_proto_depIdxs,
+		MessageInfos:      file_kdf_proto_msgTypes,
+	}.Build()
+	File_kdf_proto = out.File
+	file_kdf_proto_goTypes = nil
+	file_kdf_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: kdf.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KDFService_DeriveHKDF_FullMethodName   = "/leadgen.hasher.v1.KDFService/DeriveHKDF"
+	KDFService_DerivePBKDF2_FullMethodName = "/leadgen.hasher.v1.KDFService/DerivePBKDF2"
+)
+
+// KDFServiceClient is the client API for KDFService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KDFService derives keys, e.g. per-tenant subkeys of a shared master key.
+// Permitted to configured callers only, derived keys are never stored.
+type KDFServiceClient interface {
+	// DeriveHKDF derives key from server master key with HKDF. Info is bound
+	// to caller tenant, so callers of other tenants can't derive the key.
+	DeriveHKDF(ctx context.Context, in *DeriveHKDFRequest, opts ...grpc.CallOption) (*DerivedKey, error)
+	// DerivePBKDF2 derives key from password with PBKDF2.
+	DerivePBKDF2(ctx context.Context, in *DerivePBKDF2Request, opts ...grpc.CallOption) (*DerivedKey, error)
+}
+
+type kDFServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKDFServiceClient(cc grpc.ClientConnInterface) KDFServiceClient {
+	return &kDFServiceClient{cc}
+}
+
+func (c *kDFServiceClient) DeriveHKDF(ctx context.Context, in *DeriveHKDFRequest, opts ...grpc.CallOption) (*DerivedKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DerivedKey)
+	err := c.cc.Invoke(ctx, KDFService_DeriveHKDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kDFServiceClient) DerivePBKDF2(ctx context.Context, in *DerivePBKDF2Request, opts ...grpc.CallOption) (*DerivedKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DerivedKey)
+	err := c.cc.Invoke(ctx, KDFService_DerivePBKDF2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KDFServiceServer is the server API for KDFService service.
+// All implementations must embed UnimplementedKDFServiceServer
+// for forward compatibility.
+//
+// KDFService derives keys, e.g. per-tenant subkeys of a shared master key.
+// Permitted to configured callers only, derived keys are never stored.
+type KDFServiceServer interface {
+	// DeriveHKDF derives key from server master key with HKDF. Info is bound
+	// to caller tenant, so callers of other tenants can't derive the key.
+	DeriveHKDF(context.Context, *DeriveHKDFRequest) (*DerivedKey, error)
+	// DerivePBKDF2 derives key from password with PBKDF2.
+	DerivePBKDF2(context.Context, *DerivePBKDF2Request) (*DerivedKey, error)
+	mustEmbedUnimplementedKDFServiceServer()
+}
+
+// UnimplementedKDFServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKDFServiceServer struct{}
+
+func (UnimplementedKDFServiceServer) DeriveHKDF(context.Context, *DeriveHKDFRequest) (*DerivedKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeriveHKDF not implemented")
+}
+func (UnimplementedKDFServiceServer) DerivePBKDF2(context.Context, *DerivePBKDF2Request) (*DerivedKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DerivePBKDF2 not implemented")
+}
+func (UnimplementedKDFServiceServer) mustEmbedUnimplementedKDFServiceServer() {}
+func (UnimplementedKDFServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeKDFServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KDFServiceServer will
+// result in compilation errors.
+type UnsafeKDFServiceServer interface {
+	mustEmbedUnimplementedKDFServiceServer()
+}
+
+func RegisterKDFServiceServer(s grpc.ServiceRegistrar, srv KDFServiceServer) {
+	// If the following call pancis, it indicates UnimplementedKDFServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KDFService_ServiceDesc, srv)
+}
+
+func _KDFService_DeriveHKDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveHKDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KDFServiceServer).DeriveHKDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KDFService_DeriveHKDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KDFServiceServer).DeriveHKDF(ctx, req.(*DeriveHKDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KDFService_DerivePBKDF2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DerivePBKDF2Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KDFServiceServer).DerivePBKDF2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KDFService_DerivePBKDF2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KDFServiceServer).DerivePBKDF2(ctx, req.(*DerivePBKDF2Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KDFService_ServiceDesc is the grpc.ServiceDesc for KDFService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KDFService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.KDFService",
+	HandlerType: (*KDFServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeriveHKDF",
+			Handler:    _KDFService_DeriveHKDF_Handler,
+		},
+		{
+			MethodName: "DerivePBKDF2",
+			Handler:    _KDFService_DerivePBKDF2_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kdf.proto",
+}
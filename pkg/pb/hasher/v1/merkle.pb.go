@@ -0,0 +1,427 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: merkle.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BuildTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Leaves are hashed in given order.
+	Leaves    []string      `protobuf:"bytes,1,rep,name=leaves,proto3" json:"leaves,omitempty"`
+	Algorithm HashAlgorithm `protobuf:"varint,2,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	// IncludeProofs requests audit path of every leaf.
+	IncludeProofs bool `protobuf:"varint,3,opt,name=include_proofs,json=includeProofs,proto3" json:"include_proofs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildTreeRequest) Reset() {
+	*x = BuildTreeRequest{}
+	mi := &file_merkle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildTreeRequest) ProtoMessage() {}
+
+func (x *BuildTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_merkle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildTreeRequest.ProtoReflect.Descriptor instead.
+func (*BuildTreeRequest) Descriptor() ([]byte, []int) {
+	return file_merkle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BuildTreeRequest) GetLeaves() []string {
+	if x != nil {
+		return x.Leaves
+	}
+	return nil
+}
+
+func (x *BuildTreeRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *BuildTreeRequest) GetIncludeProofs() bool {
+	if x != nil {
+		return x.IncludeProofs
+	}
+	return false
+}
+
+type BuildTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Root  string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Size  int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Proofs are in the same order as leaves.
+	Proofs        []*InclusionProof `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildTreeResponse) Reset() {
+	*x = BuildTreeResponse{}
+	mi := &file_merkle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildTreeResponse) ProtoMessage() {}
+
+func (x *BuildTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_merkle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildTreeResponse.ProtoReflect.Descriptor instead.
+func (*BuildTreeResponse) Descriptor() ([]byte, []int) {
+	return file_merkle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BuildTreeResponse) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *BuildTreeResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BuildTreeResponse) GetProofs() []*InclusionProof {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+type InclusionProof struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Index    int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	LeafHash string                 `protobuf:"bytes,2,opt,name=leaf_hash,json=leafHash,proto3" json:"leaf_hash,omitempty"`
+	// AuditPath is a list of sibling hashes from leaf up to root.
+	AuditPath     []string `protobuf:"bytes,3,rep,name=audit_path,json=auditPath,proto3" json:"audit_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InclusionProof) Reset() {
+	*x = InclusionProof{}
+	mi := &file_merkle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InclusionProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InclusionProof) ProtoMessage() {}
+
+func (x *InclusionProof) ProtoReflect() protoreflect.Message {
+	mi := &file_merkle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InclusionProof.ProtoReflect.Descriptor instead.
+func (*InclusionProof) Descriptor() ([]byte, []int) {
+	return file_merkle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InclusionProof) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *InclusionProof) GetLeafHash() string {
+	if x != nil {
+		return x.LeafHash
+	}
+	return ""
+}
+
+func (x *InclusionProof) GetAuditPath() []string {
+	if x != nil {
+		return x.AuditPath
+	}
+	return nil
+}
+
+type VerifyInclusionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     HashAlgorithm          `protobuf:"varint,1,opt,name=algorithm,proto3,enum=leadgen.hasher.v1.HashAlgorithm" json:"algorithm,omitempty"`
+	Leaf          string                 `protobuf:"bytes,2,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Index         int64                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	TreeSize      int64                  `protobuf:"varint,4,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	AuditPath     []string               `protobuf:"bytes,5,rep,name=audit_path,json=auditPath,proto3" json:"audit_path,omitempty"`
+	Root          string                 `protobuf:"bytes,6,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyInclusionRequest) Reset() {
+	*x = VerifyInclusionRequest{}
+	mi := &file_merkle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyInclusionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyInclusionRequest) ProtoMessage() {}
+
+func (x *VerifyInclusionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_merkle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyInclusionRequest.ProtoReflect.Descriptor instead.
+func (*VerifyInclusionRequest) Descriptor() ([]byte, []int) {
+	return file_merkle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyInclusionRequest) GetAlgorithm() HashAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return HashAlgorithm_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *VerifyInclusionRequest) GetLeaf() string {
+	if x != nil {
+		return x.Leaf
+	}
+	return ""
+}
+
+func (x *VerifyInclusionRequest) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *VerifyInclusionRequest) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *VerifyInclusionRequest) GetAuditPath() []string {
+	if x != nil {
+		return x.AuditPath
+	}
+	return nil
+}
+
+func (x *VerifyInclusionRequest) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+type VerifyInclusionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyInclusionResponse) Reset() {
+	*x = VerifyInclusionResponse{}
+	mi := &file_merkle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyInclusionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyInclusionResponse) ProtoMessage() {}
+
+func (x *VerifyInclusionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_merkle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyInclusionResponse.ProtoReflect.Descriptor instead.
+func (*VerifyInclusionResponse) Descriptor() ([]byte, []int) {
+	return file_merkle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyInclusionResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+var File_merkle_proto protoreflect.FileDescriptor
+
+const file_merkle_proto_rawDesc = "" +
+	"\n" +
+	"\fmerkle.proto\x12\x11leadgen.hasher.v1\x1a\fhasher.proto\"\x91\x01\n" +
+	"\x10BuildTreeRequest\x12\x16\n" +
+	"\x06leaves\x18\x01 \x03(\tR\x06leaves\x12>\n" +
+	"\talgorithm\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12%\n" +
+	"\x0einclude_proofs\x18\x03 \x01(\bR\rincludeProofs\"v\n" +
+	"\x11BuildTreeResponse\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x129\n" +
+	"\x06proofs\x18\x03 \x03(\v2!.leadgen.hasher.v1.InclusionProofR\x06proofs\"b\n" +
+	"\x0eInclusionProof\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1b\n" +
+	"\tleaf_hash\x18\x02 \x01(\tR\bleafHash\x12\x1d\n" +
+	"\n" +
+	"audit_path\x18\x03 \x03(\tR\tauditPath\"\xd2\x01\n" +
+	"\x16VerifyInclusionRequest\x12>\n" +
+	"\talgorithm\x18\x01 \x01(\x0e2 .leadgen.hasher.v1.HashAlgorithmR\talgorithm\x12\x12\n" +
+	"\x04leaf\x18\x02 \x01(\tR\x04leaf\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\x03R\x05index\x12\x1b\n" +
+	"\ttree_size\x18\x04 \x01(\x03R\btreeSize\x12\x1d\n" +
+	"\n" +
+	"audit_path\x18\x05 \x03(\tR\tauditPath\x12\x12\n" +
+	"\x04root\x18\x06 \x01(\tR\x04root\"/\n" +
+	"\x17VerifyInclusionResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid2\xd1\x01\n" +
+	"\rMerkleService\x12V\n" +
+	"\tBuildTree\x12#.leadgen.hasher.v1.BuildTreeRequest\x1a$.leadgen.hasher.v1.BuildTreeResponse\x12h\n" +
+	"\x0fVerifyInclusion\x12).leadgen.hasher.v1.VerifyInclusionRequest\x1a*.leadgen.hasher.v1.VerifyInclusionResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_merkle_proto_rawDescOnce sync.Once
+	file_merkle_proto_rawDescData []byte
+)
+
+func file_merkle_proto_rawDescGZIP() []byte {
+	file_merkle_proto_rawDescOnce.Do(func() {
+		file_merkle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_merkle_proto_rawDesc), len(file_merkle_proto_rawDesc)))
+	})
+	return file_merkle_proto_rawDescData
+}
+
+var file_merkle_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_merkle_proto_goTypes = []any{
+	(*BuildTreeRequest)(nil),        // 0: leadgen.hasher.v1.BuildTreeRequest
+	(*BuildTreeResponse)(nil),       // 1: leadgen.hasher.v1.BuildTreeResponse
+	(*InclusionProof)(nil),          // 2: leadgen.hasher.v1.InclusionProof
+	(*VerifyInclusionRequest)(nil),  // 3: leadgen.hasher.v1.VerifyInclusionRequest
+	(*VerifyInclusionResponse)(nil), // 4: leadgen.hasher.v1.VerifyInclusionResponse
+	(HashAlgorithm)(0),              // 5: leadgen.hasher.v1.HashAlgorithm
+}
+var file_merkle_proto_depIdxs = []int32{
+	5, // 0: leadgen.hasher.v1.BuildTreeRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	2, // 1: leadgen.hasher.v1.BuildTreeResponse.proofs:type_name -> leadgen.hasher.v1.InclusionProof
+	5, // 2: leadgen.hasher.v1.VerifyInclusionRequest.algorithm:type_name -> leadgen.hasher.v1.HashAlgorithm
+	0, // 3: leadgen.hasher.v1.MerkleService.BuildTree:input_type -> leadgen.hasher.v1.BuildTreeRequest
+	3, // 4: leadgen.hasher.v1.MerkleService.VerifyInclusion:input_type -> leadgen.hasher.v1.VerifyInclusionRequest
+	1, // 5: leadgen.hasher.v1.MerkleService.BuildTree:output_type -> leadgen.hasher.v1.BuildTreeResponse
+	4, // 6: leadgen.hasher.v1.MerkleService.VerifyInclusion:output_type -> leadgen.hasher.v1.VerifyInclusionResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_merkle_proto_init() }
+func file_merkle_proto_init() {
+	if File_merkle_proto != nil {
+		return
+	}
+	file_hasher_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_merkle_proto_rawDesc), len(file_merkle_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_merkle_proto_goTypes,
+		DependencyIndexes: file_merkle_proto_depIdxs,
+		MessageInfos:      file_merkle_proto_msgTypes,
+	}.Build()
+	File_merkle_proto = out.File
+	file_merkle_proto_goTypes = nil
+	file_merkle_proto_depIdxs = nil
+}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: merkle.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MerkleService_BuildTree_FullMethodName       = "/leadgen.hasher.v1.MerkleService/BuildTree"
+	MerkleService_VerifyInclusion_FullMethodName = "/leadgen.hasher.v1.MerkleService/VerifyInclusion"
+)
+
+// MerkleServiceClient is the client API for MerkleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MerkleService builds RFC 6962 Merkle trees over batches, so a single leaf
+// can be proven to be in a batch without revealing other leaves.
+type MerkleServiceClient interface {
+	BuildTree(ctx context.Context, in *BuildTreeRequest, opts ...grpc.CallOption) (*BuildTreeResponse, error)
+	// VerifyInclusion checks leaf audit path against tree root.
+	VerifyInclusion(ctx context.Context, in *VerifyInclusionRequest, opts ...grpc.CallOption) (*VerifyInclusionResponse, error)
+}
+
+type merkleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMerkleServiceClient(cc grpc.ClientConnInterface) MerkleServiceClient {
+	return &merkleServiceClient{cc}
+}
+
+func (c *merkleServiceClient) BuildTree(ctx context.Context, in *BuildTreeRequest, opts ...grpc.CallOption) (*BuildTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildTreeResponse)
+	err := c.cc.Invoke(ctx, MerkleService_BuildTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleServiceClient) VerifyInclusion(ctx context.Context, in *VerifyInclusionRequest, opts ...grpc.CallOption) (*VerifyInclusionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyInclusionResponse)
+	err := c.cc.Invoke(ctx, MerkleService_VerifyInclusion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MerkleServiceServer is the server API for MerkleService service.
+// All implementations must embed UnimplementedMerkleServiceServer
+// for forward compatibility.
+//
+// MerkleService builds RFC 6962 Merkle trees over batches, so a single leaf
+// can be proven to be in a batch without revealing other leaves.
+type MerkleServiceServer interface {
+	BuildTree(context.Context, *BuildTreeRequest) (*BuildTreeResponse, error)
+	// VerifyInclusion checks leaf audit path against tree root.
+	VerifyInclusion(context.Context, *VerifyInclusionRequest) (*VerifyInclusionResponse, error)
+	mustEmbedUnimplementedMerkleServiceServer()
+}
+
+// UnimplementedMerkleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMerkleServiceServer struct{}
+
+func (UnimplementedMerkleServiceServer) BuildTree(context.Context, *BuildTreeRequest) (*BuildTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildTree not implemented")
+}
+func (UnimplementedMerkleServiceServer) VerifyInclusion(context.Context, *VerifyInclusionRequest) (*VerifyInclusionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyInclusion not implemented")
+}
+func (UnimplementedMerkleServiceServer) mustEmbedUnimplementedMerkleServiceServer() {}
+func (UnimplementedMerkleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeMerkleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MerkleServiceServer will
+// result in compilation errors.
+type UnsafeMerkleServiceServer interface {
+	mustEmbedUnimplementedMerkleServiceServer()
+}
+
+func RegisterMerkleServiceServer(s grpc.ServiceRegistrar, srv MerkleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMerkleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MerkleService_ServiceDesc, srv)
+}
+
+func _MerkleService_BuildTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleServiceServer).BuildTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MerkleService_BuildTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleServiceServer).BuildTree(ctx, req.(*BuildTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleService_VerifyInclusion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyInclusionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleServiceServer).VerifyInclusion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MerkleService_VerifyInclusion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleServiceServer).VerifyInclusion(ctx, req.(*VerifyInclusionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MerkleService_ServiceDesc is the grpc.ServiceDesc for MerkleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MerkleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.MerkleService",
+	HandlerType: (*MerkleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BuildTree",
+			Handler:    _MerkleService_BuildTree_Handler,
+		},
+		{
+			MethodName: "VerifyInclusion",
+			Handler:    _MerkleService_VerifyInclusion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "merkle.proto",
+}
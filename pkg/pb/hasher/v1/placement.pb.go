@@ -0,0 +1,661 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: placement.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlacementKind int32
+
+const (
+	PlacementKind_PLACEMENT_KIND_UNSPECIFIED PlacementKind = 0
+	// Ring is consistent hash ring with virtual nodes.
+	PlacementKind_PLACEMENT_KIND_RING PlacementKind = 1
+	// Rendezvous is highest random weight hashing.
+	PlacementKind_PLACEMENT_KIND_RENDEZVOUS PlacementKind = 2
+	// Jump is jump consistent hash. Only adding or removing the last nodes
+	// moves minimal number of keys.
+	PlacementKind_PLACEMENT_KIND_JUMP PlacementKind = 3
+)
+
+// Enum value maps for PlacementKind.
+var (
+	PlacementKind_name = map[int32]string{
+		0: "PLACEMENT_KIND_UNSPECIFIED",
+		1: "PLACEMENT_KIND_RING",
+		2: "PLACEMENT_KIND_RENDEZVOUS",
+		3: "PLACEMENT_KIND_JUMP",
+	}
+	PlacementKind_value = map[string]int32{
+		"PLACEMENT_KIND_UNSPECIFIED": 0,
+		"PLACEMENT_KIND_RING":        1,
+		"PLACEMENT_KIND_RENDEZVOUS":  2,
+		"PLACEMENT_KIND_JUMP":        3,
+	}
+)
+
+func (x PlacementKind) Enum() *PlacementKind {
+	p := new(PlacementKind)
+	*p = x
+	return p
+}
+
+func (x PlacementKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlacementKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_placement_proto_enumTypes[0].Descriptor()
+}
+
+func (PlacementKind) Type() protoreflect.EnumType {
+	return &file_placement_proto_enumTypes[0]
+}
+
+func (x PlacementKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlacementKind.Descriptor instead.
+func (PlacementKind) EnumDescriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{0}
+}
+
+type RegisterNodeSetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind  PlacementKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=leadgen.hasher.v1.PlacementKind" json:"kind,omitempty"`
+	// Nodes order matters for jump hash only.
+	Nodes []string `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// VirtualNodes is a number of ring points per node, 160 if unset.
+	VirtualNodes  uint32 `protobuf:"varint,4,opt,name=virtual_nodes,json=virtualNodes,proto3" json:"virtual_nodes,omitempty"`
+	Replicas      uint32 `protobuf:"varint,5,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterNodeSetRequest) Reset() {
+	*x = RegisterNodeSetRequest{}
+	mi := &file_placement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterNodeSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterNodeSetRequest) ProtoMessage() {}
+
+func (x *RegisterNodeSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterNodeSetRequest.ProtoReflect.Descriptor instead.
+func (*RegisterNodeSetRequest) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegisterNodeSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterNodeSetRequest) GetKind() PlacementKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PlacementKind_PLACEMENT_KIND_UNSPECIFIED
+}
+
+func (x *RegisterNodeSetRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *RegisterNodeSetRequest) GetVirtualNodes() uint32 {
+	if x != nil {
+		return x.VirtualNodes
+	}
+	return 0
+}
+
+func (x *RegisterNodeSetRequest) GetReplicas() uint32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+type GetNodeSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeSetRequest) Reset() {
+	*x = GetNodeSetRequest{}
+	mi := &file_placement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeSetRequest) ProtoMessage() {}
+
+func (x *GetNodeSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeSetRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeSetRequest) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetNodeSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateNodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nodes         []string               `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Replicas      uint32                 `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNodesRequest) Reset() {
+	*x = UpdateNodesRequest{}
+	mi := &file_placement_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNodesRequest) ProtoMessage() {}
+
+func (x *UpdateNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNodesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNodesRequest) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateNodesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateNodesRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *UpdateNodesRequest) GetReplicas() uint32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+type NodeSetChange struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	NodeSet *NodeSet               `protobuf:"bytes,1,opt,name=node_set,json=nodeSet,proto3" json:"node_set,omitempty"`
+	// MovedFraction is estimated fraction of keys whose nodes changed.
+	MovedFraction float64 `protobuf:"fixed64,2,opt,name=moved_fraction,json=movedFraction,proto3" json:"moved_fraction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSetChange) Reset() {
+	*x = NodeSetChange{}
+	mi := &file_placement_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSetChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSetChange) ProtoMessage() {}
+
+func (x *NodeSetChange) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSetChange.ProtoReflect.Descriptor instead.
+func (*NodeSetChange) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NodeSetChange) GetNodeSet() *NodeSet {
+	if x != nil {
+		return x.NodeSet
+	}
+	return nil
+}
+
+func (x *NodeSetChange) GetMovedFraction() float64 {
+	if x != nil {
+		return x.MovedFraction
+	}
+	return 0
+}
+
+type NodeSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          PlacementKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=leadgen.hasher.v1.PlacementKind" json:"kind,omitempty"`
+	Nodes         []string               `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	VirtualNodes  uint32                 `protobuf:"varint,4,opt,name=virtual_nodes,json=virtualNodes,proto3" json:"virtual_nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSet) Reset() {
+	*x = NodeSet{}
+	mi := &file_placement_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSet) ProtoMessage() {}
+
+func (x *NodeSet) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSet.ProtoReflect.Descriptor instead.
+func (*NodeSet) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NodeSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeSet) GetKind() PlacementKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PlacementKind_PLACEMENT_KIND_UNSPECIFIED
+}
+
+func (x *NodeSet) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NodeSet) GetVirtualNodes() uint32 {
+	if x != nil {
+		return x.VirtualNodes
+	}
+	return 0
+}
+
+type LocateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Keys  []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Replicas is a number of distinct nodes of every key, 1 if unset.
+	Replicas      uint32 `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocateRequest) Reset() {
+	*x = LocateRequest{}
+	mi := &file_placement_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateRequest) ProtoMessage() {}
+
+func (x *LocateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateRequest.ProtoReflect.Descriptor instead.
+func (*LocateRequest) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LocateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LocateRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *LocateRequest) GetReplicas() uint32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+type LocateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Placements are in the same order as keys.
+	Placements    []*KeyPlacement `protobuf:"bytes,1,rep,name=placements,proto3" json:"placements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocateResponse) Reset() {
+	*x = LocateResponse{}
+	mi := &file_placement_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateResponse) ProtoMessage() {}
+
+func (x *LocateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateResponse.ProtoReflect.Descriptor instead.
+func (*LocateResponse) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LocateResponse) GetPlacements() []*KeyPlacement {
+	if x != nil {
+		return x.Placements
+	}
+	return nil
+}
+
+type KeyPlacement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Nodes are distinct, the primary one first.
+	Nodes         []string `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyPlacement) Reset() {
+	*x = KeyPlacement{}
+	mi := &file_placement_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPlacement) ProtoMessage() {}
+
+func (x *KeyPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_placement_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPlacement.ProtoReflect.Descriptor instead.
+func (*KeyPlacement) Descriptor() ([]byte, []int) {
+	return file_placement_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *KeyPlacement) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyPlacement) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+var File_placement_proto protoreflect.FileDescriptor
+
+const file_placement_proto_rawDesc = "" +
+	"\n" +
+	"\x0fplacement.proto\x12\x11leadgen.hasher.v1\"\xb9\x01\n" +
+	"\x16RegisterNodeSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x124\n" +
+	"\x04kind\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.PlacementKindR\x04kind\x12\x14\n" +
+	"\x05nodes\x18\x03 \x03(\tR\x05nodes\x12#\n" +
+	"\rvirtual_nodes\x18\x04 \x01(\rR\fvirtualNodes\x12\x1a\n" +
+	"\breplicas\x18\x05 \x01(\rR\breplicas\"'\n" +
+	"\x11GetNodeSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Z\n" +
+	"\x12UpdateNodesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05nodes\x18\x02 \x03(\tR\x05nodes\x12\x1a\n" +
+	"\breplicas\x18\x03 \x01(\rR\breplicas\"m\n" +
+	"\rNodeSetChange\x125\n" +
+	"\bnode_set\x18\x01 \x01(\v2\x1a.leadgen.hasher.v1.NodeSetR\anodeSet\x12%\n" +
+	"\x0emoved_fraction\x18\x02 \x01(\x01R\rmovedFraction\"\x8e\x01\n" +
+	"\aNodeSet\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x124\n" +
+	"\x04kind\x18\x02 \x01(\x0e2 .leadgen.hasher.v1.PlacementKindR\x04kind\x12\x14\n" +
+	"\x05nodes\x18\x03 \x03(\tR\x05nodes\x12#\n" +
+	"\rvirtual_nodes\x18\x04 \x01(\rR\fvirtualNodes\"S\n" +
+	"\rLocateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\x1a\n" +
+	"\breplicas\x18\x03 \x01(\rR\breplicas\"Q\n" +
+	"\x0eLocateResponse\x12?\n" +
+	"\n" +
+	"placements\x18\x01 \x03(\v2\x1f.leadgen.hasher.v1.KeyPlacementR\n" +
+	"placements\"6\n" +
+	"\fKeyPlacement\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05nodes\x18\x02 \x03(\tR\x05nodes*\x80\x01\n" +
+	"\rPlacementKind\x12\x1e\n" +
+	"\x1aPLACEMENT_KIND_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13PLACEMENT_KIND_RING\x10\x01\x12\x1d\n" +
+	"\x19PLACEMENT_KIND_RENDEZVOUS\x10\x02\x12\x17\n" +
+	"\x13PLACEMENT_KIND_JUMP\x10\x032\xbe\x03\n" +
+	"\x10PlacementService\x12^\n" +
+	"\x0fRegisterNodeSet\x12).leadgen.hasher.v1.RegisterNodeSetRequest\x1a .leadgen.hasher.v1.NodeSetChange\x12N\n" +
+	"\n" +
+	"GetNodeSet\x12$.leadgen.hasher.v1.GetNodeSetRequest\x1a\x1a.leadgen.hasher.v1.NodeSet\x12S\n" +
+	"\bAddNodes\x12%.leadgen.hasher.v1.UpdateNodesRequest\x1a .leadgen.hasher.v1.NodeSetChange\x12V\n" +
+	"\vRemoveNodes\x12%.leadgen.hasher.v1.UpdateNodesRequest\x1a .leadgen.hasher.v1.NodeSetChange\x12M\n" +
+	"\x06Locate\x12 .leadgen.hasher.v1.LocateRequest\x1a!.leadgen.hasher.v1.LocateResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_placement_proto_rawDescOnce sync.Once
+	file_placement_proto_rawDescData []byte
+)
+
+func file_placement_proto_rawDescGZIP() []byte {
+	file_placement_proto_rawDescOnce.Do(func() {
+		file_placement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_placement_proto_rawDesc), len(file_placement_proto_rawDesc)))
+	})
+	return file_placement_proto_rawDescData
+}
+
+var file_placement_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_placement_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_placement_proto_goTypes = []any{
+	(PlacementKind)(0),             // 0: leadgen.hasher.v1.PlacementKind
+	(*RegisterNodeSetRequest)(nil), // 1: leadgen.hasher.v1.RegisterNodeSetRequest
+	(*GetNodeSetRequest)(nil),      // 2: leadgen.hasher.v1.GetNodeSetRequest
+	(*UpdateNodesRequest)(nil),     // 3: leadgen.hasher.v1.UpdateNodesRequest
+	(*NodeSetChange)(nil),          // 4: leadgen.hasher.v1.NodeSetChange
+	(*NodeSet)(nil),                // 5: leadgen.hasher.v1.NodeSet
+	(*LocateRequest)(nil),          // 6: leadgen.hasher.v1.LocateRequest
+	(*LocateResponse)(nil),         // 7: leadgen.hasher.v1.LocateResponse
+	(*KeyPlacement)(nil),           // 8: leadgen.hasher.v1.KeyPlacement
+}
+var file_placement_proto_depIdxs = []int32{
+	0, // 0: leadgen.hasher.v1.RegisterNodeSetRequest.kind:type_name -> leadgen.hasher.v1.PlacementKind
+	5, // 1: leadgen.hasher.v1.NodeSetChange.node_set:type_name -> leadgen.hasher.v1.NodeSet
+	0, // 2: leadgen.hasher.v1.NodeSet.kind:type_name -> leadgen.hasher.v1.PlacementKind
+	8, // 3: leadgen.hasher.v1.LocateResponse.placements:type_name -> leadgen.hasher.v1.KeyPlacement
+	1, // 4: leadgen.hasher.v1.PlacementService.RegisterNodeSet:input_type -> leadgen.hasher.v1.RegisterNodeSetRequest
+	2, // 5: leadgen.hasher.v1.PlacementService.GetNodeSet:input_type -> leadgen.hasher.v1.GetNodeSetRequest
+	3, // 6: leadgen.hasher.v1.PlacementService.AddNodes:input_type -> leadgen.hasher.v1.UpdateNodesRequest
+	3, // 7: leadgen.hasher.v1.PlacementService.RemoveNodes:input_type -> leadgen.hasher.v1.UpdateNodesRequest
+	6, // 8: leadgen.hasher.v1.PlacementService.Locate:input_type -> leadgen.hasher.v1.LocateRequest
+	4, // 9: leadgen.hasher.v1.PlacementService.RegisterNodeSet:output_type -> leadgen.hasher.v1.NodeSetChange
+	5, // 10: leadgen.hasher.v1.PlacementService.GetNodeSet:output_type -> leadgen.hasher.v1.NodeSet
+	4, // 11: leadgen.hasher.v1.PlacementService.AddNodes:output_type -> leadgen.hasher.v1.NodeSetChange
+	4, // 12: leadgen.hasher.v1.PlacementService.RemoveNodes:output_type -> leadgen.hasher.v1.NodeSetChange
+	7, // 13: leadgen.hasher.v1.PlacementService.Locate:output_type -> leadgen.hasher.v1.LocateResponse
+	9, // [9:14] is the sub-list for method output_type
+	4, // [4:9] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_placement_proto_init() }
+func file_placement_proto_init() {
+	if File_placement_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_placement_proto_rawDesc), len(file_placement_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_placement_proto_goTypes,
+		DependencyIndexes: file_placement_proto_depIdxs,
+		EnumInfos:         file_placement_proto_enumTypes,
+		MessageInfos:      file_placement_proto_msgTypes,
+	}.Build()
+	File_placement_proto = out.File
+	file_placement_proto_goTypes = nil
+	file_placement_proto_depIdxs = nil
+}
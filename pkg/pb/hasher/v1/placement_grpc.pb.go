@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: placement.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PlacementService_RegisterNodeSet_FullMethodName = "/leadgen.hasher.v1.PlacementService/RegisterNodeSet"
+	PlacementService_GetNodeSet_FullMethodName      = "/leadgen.hasher.v1.PlacementService/GetNodeSet"
+	PlacementService_AddNodes_FullMethodName        = "/leadgen.hasher.v1.PlacementService/AddNodes"
+	PlacementService_RemoveNodes_FullMethodName     = "/leadgen.hasher.v1.PlacementService/RemoveNodes"
+	PlacementService_Locate_FullMethodName          = "/leadgen.hasher.v1.PlacementService/Locate"
+)
+
+// PlacementServiceClient is the client API for PlacementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PlacementService places keys, e.g. lead ids, on named node sets by
+// consistent hashing. Node sets are kept per caller tenant. Changes report
+// estimated fraction of keys moved to other nodes for requested replicas
+// count, 1 if it's unset.
+type PlacementServiceClient interface {
+	// RegisterNodeSet registers node set replacing registered one of the
+	// same name.
+	RegisterNodeSet(ctx context.Context, in *RegisterNodeSetRequest, opts ...grpc.CallOption) (*NodeSetChange, error)
+	GetNodeSet(ctx context.Context, in *GetNodeSetRequest, opts ...grpc.CallOption) (*NodeSet, error)
+	// AddNodes appends nodes to node set.
+	AddNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*NodeSetChange, error)
+	RemoveNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*NodeSetChange, error)
+	// Locate returns nodes of every key.
+	Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error)
+}
+
+type placementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlacementServiceClient(cc grpc.ClientConnInterface) PlacementServiceClient {
+	return &placementServiceClient{cc}
+}
+
+func (c *placementServiceClient) RegisterNodeSet(ctx context.Context, in *RegisterNodeSetRequest, opts ...grpc.CallOption) (*NodeSetChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeSetChange)
+	err := c.cc.Invoke(ctx, PlacementService_RegisterNodeSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementServiceClient) GetNodeSet(ctx context.Context, in *GetNodeSetRequest, opts ...grpc.CallOption) (*NodeSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeSet)
+	err := c.cc.Invoke(ctx, PlacementService_GetNodeSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementServiceClient) AddNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*NodeSetChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeSetChange)
+	err := c.cc.Invoke(ctx, PlacementService_AddNodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementServiceClient) RemoveNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*NodeSetChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeSetChange)
+	err := c.cc.Invoke(ctx, PlacementService_RemoveNodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *placementServiceClient) Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocateResponse)
+	err := c.cc.Invoke(ctx, PlacementService_Locate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlacementServiceServer is the server API for PlacementService service.
+// All implementations must embed UnimplementedPlacementServiceServer
+// for forward compatibility.
+//
+// PlacementService places keys, e.g. lead ids, on named node sets by
+// consistent hashing. Node sets are kept per caller tenant. Changes report
+// estimated fraction of keys moved to other nodes for requested replicas
+// count, 1 if it's unset.
+type PlacementServiceServer interface {
+	// RegisterNodeSet registers node set replacing registered one of the
+	// same name.
+	RegisterNodeSet(context.Context, *RegisterNodeSetRequest) (*NodeSetChange, error)
+	GetNodeSet(context.Context, *GetNodeSetRequest) (*NodeSet, error)
+	// AddNodes appends nodes to node set.
+	AddNodes(context.Context, *UpdateNodesRequest) (*NodeSetChange, error)
+	RemoveNodes(context.Context, *UpdateNodesRequest) (*NodeSetChange, error)
+	// Locate returns nodes of every key.
+	Locate(context.Context, *LocateRequest) (*LocateResponse, error)
+	mustEmbedUnimplementedPlacementServiceServer()
+}
+
+// UnimplementedPlacementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPlacementServiceServer struct{}
+
+func (UnimplementedPlacementServiceServer) RegisterNodeSet(context.Context, *RegisterNodeSetRequest) (*NodeSetChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterNodeSet not implemented")
+}
+func (UnimplementedPlacementServiceServer) GetNodeSet(context.Context, *GetNodeSetRequest) (*NodeSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeSet not implemented")
+}
+func (UnimplementedPlacementServiceServer) AddNodes(context.Context, *UpdateNodesRequest) (*NodeSetChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddNodes not implemented")
+}
+func (UnimplementedPlacementServiceServer) RemoveNodes(context.Context, *UpdateNodesRequest) (*NodeSetChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNodes not implemented")
+}
+func (UnimplementedPlacementServiceServer) Locate(context.Context, *LocateRequest) (*LocateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Locate not implemented")
+}
+func (UnimplementedPlacementServiceServer) mustEmbedUnimplementedPlacementServiceServer() {}
+func (UnimplementedPlacementServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePlacementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlacementServiceServer will
+// result in compilation errors.
+type UnsafePlacementServiceServer interface {
+	mustEmbedUnimplementedPlacementServiceServer()
+}
+
+func RegisterPlacementServiceServer(s grpc.ServiceRegistrar, srv PlacementServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPlacementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PlacementService_ServiceDesc, srv)
+}
+
+func _PlacementService_RegisterNodeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterNodeSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementServiceServer).RegisterNodeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlacementService_RegisterNodeSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementServiceServer).RegisterNodeSet(ctx, req.(*RegisterNodeSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementService_GetNodeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementServiceServer).GetNodeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlacementService_GetNodeSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementServiceServer).GetNodeSet(ctx, req.(*GetNodeSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementService_AddNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementServiceServer).AddNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlacementService_AddNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementServiceServer).AddNodes(ctx, req.(*UpdateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementService_RemoveNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementServiceServer).RemoveNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlacementService_RemoveNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementServiceServer).RemoveNodes(ctx, req.(*UpdateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlacementService_Locate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlacementServiceServer).Locate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlacementService_Locate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlacementServiceServer).Locate(ctx, req.(*LocateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlacementService_ServiceDesc is the grpc.ServiceDesc for PlacementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlacementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.PlacementService",
+	HandlerType: (*PlacementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterNodeSet",
+			Handler:    _PlacementService_RegisterNodeSet_Handler,
+		},
+		{
+			MethodName: "GetNodeSet",
+			Handler:    _PlacementService_GetNodeSet_Handler,
+		},
+		{
+			MethodName: "AddNodes",
+			Handler:    _PlacementService_AddNodes_Handler,
+		},
+		{
+			MethodName: "RemoveNodes",
+			Handler:    _PlacementService_RemoveNodes_Handler,
+		},
+		{
+			MethodName: "Locate",
+			Handler:    _PlacementService_Locate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "placement.proto",
+}
@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: receipt.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetJWKSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJWKSRequest) Reset() {
+	*x = GetJWKSRequest{}
+	mi := &file_receipt_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJWKSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJWKSRequest) ProtoMessage() {}
+
+func (x *GetJWKSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_receipt_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJWKSRequest.ProtoReflect.Descriptor instead.
+func (*GetJWKSRequest) Descriptor() ([]byte, []int) {
+	return file_receipt_proto_rawDescGZIP(), []int{0}
+}
+
+// JWKS is an RFC 7517 key set, its JSON form is a valid JWKS document.
+type JWKS struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*JWK                 `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JWKS) Reset() {
+	*x = JWKS{}
+	mi := &file_receipt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWKS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWKS) ProtoMessage() {}
+
+func (x *JWKS) ProtoReflect() protoreflect.Message {
+	mi := &file_receipt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWKS.ProtoReflect.Descriptor instead.
+func (*JWKS) Descriptor() ([]byte, []int) {
+	return file_receipt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JWKS) GetKeys() []*JWK {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// JWK is an RFC 8037 Ed25519 public key.
+type JWK struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kty   string                 `protobuf:"bytes,1,opt,name=kty,proto3" json:"kty,omitempty"`
+	Crv   string                 `protobuf:"bytes,2,opt,name=crv,proto3" json:"crv,omitempty"`
+	// X is base64url-encoded public key.
+	X             string `protobuf:"bytes,3,opt,name=x,proto3" json:"x,omitempty"`
+	Kid           string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
+	Use           string `protobuf:"bytes,5,opt,name=use,proto3" json:"use,omitempty"`
+	Alg           string `protobuf:"bytes,6,opt,name=alg,proto3" json:"alg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JWK) Reset() {
+	*x = JWK{}
+	mi := &file_receipt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWK) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWK) ProtoMessage() {}
+
+func (x *JWK) ProtoReflect() protoreflect.Message {
+	mi := &file_receipt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWK.ProtoReflect.Descriptor instead.
+func (*JWK) Descriptor() ([]byte, []int) {
+	return file_receipt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JWK) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *JWK) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *JWK) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *JWK) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *JWK) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JWK) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+var File_receipt_proto protoreflect.FileDescriptor
+
+const file_receipt_proto_rawDesc = "" +
+	"\n" +
+	"\rreceipt.proto\x12\x11leadgen.hasher.v1\"\x10\n" +
+	"\x0eGetJWKSRequest\"2\n" +
+	"\x04JWKS\x12*\n" +
+	"\x04keys\x18\x01 \x03(\v2\x16.leadgen.hasher.v1.JWKR\x04keys\"m\n" +
+	"\x03JWK\x12\x10\n" +
+	"\x03kty\x18\x01 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03crv\x18\x02 \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\x03 \x01(\tR\x01x\x12\x10\n" +
+	"\x03kid\x18\x04 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03use\x18\x05 \x01(\tR\x03use\x12\x10\n" +
+	"\x03alg\x18\x06 \x01(\tR\x03alg2W\n" +
+	"\x0eReceiptService\x12E\n" +
+	"\aGetJWKS\x12!.leadgen.hasher.v1.GetJWKSRequest\x1a\x17.leadgen.hasher.v1.JWKSB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_receipt_proto_rawDescOnce sync.Once
+	file_receipt_proto_rawDescData []byte
+)
+
+func file_receipt_proto_rawDescGZIP() []byte {
+	file_receipt_proto_rawDescOnce.Do(func() {
+		file_receipt_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_receipt_proto_rawDesc), len(file_receipt_proto_rawDesc)))
+	})
+	return file_receipt_proto_rawDescData
+}
+
+var file_receipt_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_receipt_proto_goTypes = []any{
+	(*GetJWKSRequest)(nil), // 0: leadgen.hasher.v1.GetJWKSRequest
+	(*JWKS)(nil),           // 1: leadgen.hasher.v1.JWKS
+	(*JWK)(nil),            // 2: leadgen.hasher.v1.JWK
+}
+var file_receipt_proto_depIdxs = []int32{
+	2, // 0: leadgen.hasher.v1.JWKS.keys:type_name -> leadgen.hasher.v1.JWK
+	0, // 1: leadgen.hasher.v1.ReceiptService.GetJWKS:input_type -> leadgen.hasher.v1.GetJWKSRequest
+	1, // 2: leadgen.hasher.v1.ReceiptService.GetJWKS:output_type -> leadgen.hasher.v1.JWKS
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_receipt_proto_init() }
+func file_receipt_proto_init() {
+	if File_receipt_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_receipt_proto_rawDesc), len(file_receipt_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_receipt_proto_goTypes,
+		DependencyIndexes: file_receipt_proto_depIdxs,
+		MessageInfos:      file_receipt_proto_msgTypes,
+	}.Build()
+	File_receipt_proto = out.File
+	file_receipt_proto_goTypes = nil
+	file_receipt_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: receipt.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReceiptService_GetJWKS_FullMethodName = "/leadgen.hasher.v1.ReceiptService/GetJWKS"
+)
+
+// ReceiptServiceClient is the client API for ReceiptService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReceiptService publishes keys verifying hash receipts.
+type ReceiptServiceClient interface {
+	// GetJWKS returns verification keys as JSON Web Key Set, current key and
+	// previous ones receipts could be signed with.
+	GetJWKS(ctx context.Context, in *GetJWKSRequest, opts ...grpc.CallOption) (*JWKS, error)
+}
+
+type receiptServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReceiptServiceClient(cc grpc.ClientConnInterface) ReceiptServiceClient {
+	return &receiptServiceClient{cc}
+}
+
+func (c *receiptServiceClient) GetJWKS(ctx context.Context, in *GetJWKSRequest, opts ...grpc.CallOption) (*JWKS, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JWKS)
+	err := c.cc.Invoke(ctx, ReceiptService_GetJWKS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReceiptServiceServer is the server API for ReceiptService service.
+// All implementations must embed UnimplementedReceiptServiceServer
+// for forward compatibility.
+//
+// ReceiptService publishes keys verifying hash receipts.
+type ReceiptServiceServer interface {
+	// GetJWKS returns verification keys as JSON Web Key Set, current key and
+	// previous ones receipts could be signed with.
+	GetJWKS(context.Context, *GetJWKSRequest) (*JWKS, error)
+	mustEmbedUnimplementedReceiptServiceServer()
+}
+
+// UnimplementedReceiptServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReceiptServiceServer struct{}
+
+func (UnimplementedReceiptServiceServer) GetJWKS(context.Context, *GetJWKSRequest) (*JWKS, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedReceiptServiceServer) mustEmbedUnimplementedReceiptServiceServer() {}
+func (UnimplementedReceiptServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeReceiptServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReceiptServiceServer will
+// result in compilation errors.
+type UnsafeReceiptServiceServer interface {
+	mustEmbedUnimplementedReceiptServiceServer()
+}
+
+func RegisterReceiptServiceServer(s grpc.ServiceRegistrar, srv ReceiptServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReceiptServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReceiptService_ServiceDesc, srv)
+}
+
+func _ReceiptService_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJWKSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptServiceServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceiptService_GetJWKS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptServiceServer).GetJWKS(ctx, req.(*GetJWKSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReceiptService_ServiceDesc is the grpc.ServiceDesc for ReceiptService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReceiptService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.ReceiptService",
+	HandlerType: (*ReceiptServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJWKS",
+			Handler:    _ReceiptService_GetJWKS_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "receipt.proto",
+}
@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: similarity.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemRequest) Reset() {
+	*x = AddItemRequest{}
+	mi := &file_similarity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemRequest) ProtoMessage() {}
+
+func (x *AddItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_similarity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemRequest.ProtoReflect.Descriptor instead.
+func (*AddItemRequest) Descriptor() ([]byte, []int) {
+	return file_similarity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddItemRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *AddItemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddItemRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type AddItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemResponse) Reset() {
+	*x = AddItemResponse{}
+	mi := &file_similarity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemResponse) ProtoMessage() {}
+
+func (x *AddItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_similarity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemResponse.ProtoReflect.Descriptor instead.
+func (*AddItemResponse) Descriptor() ([]byte, []int) {
+	return file_similarity_proto_rawDescGZIP(), []int{1}
+}
+
+type FindSimilarRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Text  string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Min similarity is from 0 to 1.
+	MinSimilarity float64 `protobuf:"fixed64,3,opt,name=min_similarity,json=minSimilarity,proto3" json:"min_similarity,omitempty"`
+	// Limit is capped at 100 matches, unset limit means 10.
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarRequest) Reset() {
+	*x = FindSimilarRequest{}
+	mi := &file_similarity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarRequest) ProtoMessage() {}
+
+func (x *FindSimilarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_similarity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarRequest) Descriptor() ([]byte, []int) {
+	return file_similarity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindSimilarRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *FindSimilarRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FindSimilarRequest) GetMinSimilarity() float64 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+func (x *FindSimilarRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FindSimilarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SimilarItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarResponse) Reset() {
+	*x = FindSimilarResponse{}
+	mi := &file_similarity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarResponse) ProtoMessage() {}
+
+func (x *FindSimilarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_similarity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarResponse) Descriptor() ([]byte, []int) {
+	return file_similarity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindSimilarResponse) GetItems() []*SimilarItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SimilarItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Similarity is estimated Jaccard similarity of text shingles for MinHash
+	// indexes and share of equal fingerprint bits for SimHash ones.
+	Similarity    float64 `protobuf:"fixed64,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarItem) Reset() {
+	*x = SimilarItem{}
+	mi := &file_similarity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarItem) ProtoMessage() {}
+
+func (x *SimilarItem) ProtoReflect() protoreflect.Message {
+	mi := &file_similarity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarItem.ProtoReflect.Descriptor instead.
+func (*SimilarItem) Descriptor() ([]byte, []int) {
+	return file_similarity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SimilarItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+var File_similarity_proto protoreflect.FileDescriptor
+
+const file_similarity_proto_rawDesc = "" +
+	"\n" +
+	"\x10similarity.proto\x12\x11leadgen.hasher.v1\"J\n" +
+	"\x0eAddItemRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\x11\n" +
+	"\x0fAddItemResponse\"{\n" +
+	"\x12FindSimilarRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12%\n" +
+	"\x0emin_similarity\x18\x03 \x01(\x01R\rminSimilarity\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"K\n" +
+	"\x13FindSimilarResponse\x124\n" +
+	"\x05items\x18\x01 \x03(\v2\x1e.leadgen.hasher.v1.SimilarItemR\x05items\"=\n" +
+	"\vSimilarItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x01R\n" +
+	"similarity2\xc3\x01\n" +
+	"\x11SimilarityService\x12P\n" +
+	"\aAddItem\x12!.leadgen.hasher.v1.AddItemRequest\x1a\".leadgen.hasher.v1.AddItemResponse\x12\\\n" +
+	"\vFindSimilar\x12%.leadgen.hasher.v1.FindSimilarRequest\x1a&.leadgen.hasher.v1.FindSimilarResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_similarity_proto_rawDescOnce sync.Once
+	file_similarity_proto_rawDescData []byte
+)
+
+func file_similarity_proto_rawDescGZIP() []byte {
+	file_similarity_proto_rawDescOnce.Do(func() {
+		file_similarity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_similarity_proto_rawDesc), len(file_similarity_proto_rawDesc)))
+	})
+	return file_similarity_proto_rawDescData
+}
+
+var file_similarity_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_similarity_proto_goTypes = []any{
+	(*AddItemRequest)(nil),      // 0: leadgen.hasher.v1.AddItemRequest
+	(*AddItemResponse)(nil),     // 1: leadgen.hasher.v1.AddItemResponse
+	(*FindSimilarRequest)(nil),  // 2: leadgen.hasher.v1.FindSimilarRequest
+	(*FindSimilarResponse)(nil), // 3: leadgen.hasher.v1.FindSimilarResponse
+	(*SimilarItem)(nil),         // 4: leadgen.hasher.v1.SimilarItem
+}
+var file_similarity_proto_depIdxs = []int32{
+	4, // 0: leadgen.hasher.v1.FindSimilarResponse.items:type_name -> leadgen.hasher.v1.SimilarItem
+	0, // 1: leadgen.hasher.v1.SimilarityService.AddItem:input_type -> leadgen.hasher.v1.AddItemRequest
+	2, // 2: leadgen.hasher.v1.SimilarityService.FindSimilar:input_type -> leadgen.hasher.v1.FindSimilarRequest
+	1, // 3: leadgen.hasher.v1.SimilarityService.AddItem:output_type -> leadgen.hasher.v1.AddItemResponse
+	3, // 4: leadgen.hasher.v1.SimilarityService.FindSimilar:output_type -> leadgen.hasher.v1.FindSimilarResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_similarity_proto_init() }
+func file_similarity_proto_init() {
+	if File_similarity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_similarity_proto_rawDesc), len(file_similarity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_similarity_proto_goTypes,
+		DependencyIndexes: file_similarity_proto_depIdxs,
+		MessageInfos:      file_similarity_proto_msgTypes,
+	}.Build()
+	File_similarity_proto = out.File
+	file_similarity_proto_goTypes = nil
+	file_similarity_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: similarity.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SimilarityService_AddItem_FullMethodName     = "/leadgen.hasher.v1.SimilarityService/AddItem"
+	SimilarityService_FindSimilar_FullMethodName = "/leadgen.hasher.v1.SimilarityService/FindSimilar"
+)
+
+// SimilarityServiceClient is the client API for SimilarityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SimilarityService finds near-duplicate texts by MinHash or SimHash
+// signatures in configured LSH indexes. Items are kept per caller tenant, only
+// their signatures are stored.
+type SimilarityServiceClient interface {
+	// AddItem adds text signature to index by item id. Adding the same id
+	// again replaces its signature.
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*AddItemResponse, error)
+	// FindSimilar returns indexed items similar to text, most similar first.
+	FindSimilar(ctx context.Context, in *FindSimilarRequest, opts ...grpc.CallOption) (*FindSimilarResponse, error)
+}
+
+type similarityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimilarityServiceClient(cc grpc.ClientConnInterface) SimilarityServiceClient {
+	return &similarityServiceClient{cc}
+}
+
+func (c *similarityServiceClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*AddItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddItemResponse)
+	err := c.cc.Invoke(ctx, SimilarityService_AddItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *similarityServiceClient) FindSimilar(ctx context.Context, in *FindSimilarRequest, opts ...grpc.CallOption) (*FindSimilarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarResponse)
+	err := c.cc.Invoke(ctx, SimilarityService_FindSimilar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimilarityServiceServer is the server API for SimilarityService service.
+// All implementations must embed UnimplementedSimilarityServiceServer
+// for forward compatibility.
+//
+// SimilarityService finds near-duplicate texts by MinHash or SimHash
+// signatures in configured LSH indexes. Items are kept per caller tenant, only
+// their signatures are stored.
+type SimilarityServiceServer interface {
+	// AddItem adds text signature to index by item id. Adding the same id
+	// again replaces its signature.
+	AddItem(context.Context, *AddItemRequest) (*AddItemResponse, error)
+	// FindSimilar returns indexed items similar to text, most similar first.
+	FindSimilar(context.Context, *FindSimilarRequest) (*FindSimilarResponse, error)
+	mustEmbedUnimplementedSimilarityServiceServer()
+}
+
+// UnimplementedSimilarityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSimilarityServiceServer struct{}
+
+func (UnimplementedSimilarityServiceServer) AddItem(context.Context, *AddItemRequest) (*AddItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedSimilarityServiceServer) FindSimilar(context.Context, *FindSimilarRequest) (*FindSimilarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilar not implemented")
+}
+func (UnimplementedSimilarityServiceServer) mustEmbedUnimplementedSimilarityServiceServer() {}
+func (UnimplementedSimilarityServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSimilarityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimilarityServiceServer will
+// result in compilation errors.
+type UnsafeSimilarityServiceServer interface {
+	mustEmbedUnimplementedSimilarityServiceServer()
+}
+
+func RegisterSimilarityServiceServer(s grpc.ServiceRegistrar, srv SimilarityServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSimilarityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SimilarityService_ServiceDesc, srv)
+}
+
+func _SimilarityService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimilarityServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimilarityService_AddItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimilarityServiceServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimilarityService_FindSimilar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimilarityServiceServer).FindSimilar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimilarityService_FindSimilar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimilarityServiceServer).FindSimilar(ctx, req.(*FindSimilarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimilarityService_ServiceDesc is the grpc.ServiceDesc for SimilarityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimilarityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.SimilarityService",
+	HandlerType: (*SimilarityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddItem",
+			Handler:    _SimilarityService_AddItem_Handler,
+		},
+		{
+			MethodName: "FindSimilar",
+			Handler:    _SimilarityService_FindSimilar_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "similarity.proto",
+}
@@ -0,0 +1,694 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: translog.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSignedTreeHeadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSignedTreeHeadRequest) Reset() {
+	*x = GetSignedTreeHeadRequest{}
+	mi := &file_translog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSignedTreeHeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSignedTreeHeadRequest) ProtoMessage() {}
+
+func (x *GetSignedTreeHeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSignedTreeHeadRequest.ProtoReflect.Descriptor instead.
+func (*GetSignedTreeHeadRequest) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{0}
+}
+
+type SignedTreeHead struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TreeSize  int64                  `protobuf:"varint,1,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	RootHash  string                 `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	KeyId     string                 `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Signature is Ed25519 signature of RFC 6962 TreeHeadSignature structure
+	// with millisecond timestamp.
+	Signature     []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedTreeHead) Reset() {
+	*x = SignedTreeHead{}
+	mi := &file_translog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedTreeHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedTreeHead) ProtoMessage() {}
+
+func (x *SignedTreeHead) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedTreeHead.ProtoReflect.Descriptor instead.
+func (*SignedTreeHead) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignedTreeHead) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *SignedTreeHead) GetRootHash() string {
+	if x != nil {
+		return x.RootHash
+	}
+	return ""
+}
+
+func (x *SignedTreeHead) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SignedTreeHead) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SignedTreeHead) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type GetEntriesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Limit is capped at 1000 entries.
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntriesRequest) Reset() {
+	*x = GetEntriesRequest{}
+	mi := &file_translog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntriesRequest) ProtoMessage() {}
+
+func (x *GetEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntriesRequest.ProtoReflect.Descriptor instead.
+func (*GetEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEntriesRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetEntriesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LogEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntriesResponse) Reset() {
+	*x = GetEntriesResponse{}
+	mi := &file_translog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntriesResponse) ProtoMessage() {}
+
+func (x *GetEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntriesResponse.ProtoReflect.Descriptor instead.
+func (*GetEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEntriesResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// LogEntry is an issued hash. Content fields and preimage are set for
+// callers of tenant hash was issued to only, others get leaf and commitment.
+type LogEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Leaf is JSON data hashed into tree, index and commitment.
+	Leaf          string                 `protobuf:"bytes,2,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	PepperVersion string                 `protobuf:"bytes,6,opt,name=pepper_version,json=pepperVersion,proto3" json:"pepper_version,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// Commitment is hex-encoded SHA-256 of preimage.
+	Commitment string `protobuf:"bytes,8,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// Preimage is JSON entry content with random nonce, content fields are
+	// parsed from it.
+	Preimage      string `protobuf:"bytes,9,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_translog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LogEntry) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LogEntry) GetLeaf() string {
+	if x != nil {
+		return x.Leaf
+	}
+	return ""
+}
+
+func (x *LogEntry) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *LogEntry) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *LogEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *LogEntry) GetPepperVersion() string {
+	if x != nil {
+		return x.PepperVersion
+	}
+	return ""
+}
+
+func (x *LogEntry) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *LogEntry) GetCommitment() string {
+	if x != nil {
+		return x.Commitment
+	}
+	return ""
+}
+
+func (x *LogEntry) GetPreimage() string {
+	if x != nil {
+		return x.Preimage
+	}
+	return ""
+}
+
+type GetInclusionProofRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// TreeSize is the latest signed tree head size if zero.
+	TreeSize      int64 `protobuf:"varint,2,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInclusionProofRequest) Reset() {
+	*x = GetInclusionProofRequest{}
+	mi := &file_translog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInclusionProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInclusionProofRequest) ProtoMessage() {}
+
+func (x *GetInclusionProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInclusionProofRequest.ProtoReflect.Descriptor instead.
+func (*GetInclusionProofRequest) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetInclusionProofRequest) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *GetInclusionProofRequest) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+type GetInclusionProofResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *LogEntry              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	TreeSize      int64                  `protobuf:"varint,2,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	AuditPath     []string               `protobuf:"bytes,3,rep,name=audit_path,json=auditPath,proto3" json:"audit_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInclusionProofResponse) Reset() {
+	*x = GetInclusionProofResponse{}
+	mi := &file_translog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInclusionProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInclusionProofResponse) ProtoMessage() {}
+
+func (x *GetInclusionProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInclusionProofResponse.ProtoReflect.Descriptor instead.
+func (*GetInclusionProofResponse) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetInclusionProofResponse) GetEntry() *LogEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetInclusionProofResponse) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *GetInclusionProofResponse) GetAuditPath() []string {
+	if x != nil {
+		return x.AuditPath
+	}
+	return nil
+}
+
+type GetConsistencyProofRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	First int64                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	// Second is the latest signed tree head size if zero.
+	Second        int64 `protobuf:"varint,2,opt,name=second,proto3" json:"second,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsistencyProofRequest) Reset() {
+	*x = GetConsistencyProofRequest{}
+	mi := &file_translog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsistencyProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsistencyProofRequest) ProtoMessage() {}
+
+func (x *GetConsistencyProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsistencyProofRequest.ProtoReflect.Descriptor instead.
+func (*GetConsistencyProofRequest) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetConsistencyProofRequest) GetFirst() int64 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetConsistencyProofRequest) GetSecond() int64 {
+	if x != nil {
+		return x.Second
+	}
+	return 0
+}
+
+type GetConsistencyProofResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         int64                  `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second        int64                  `protobuf:"varint,2,opt,name=second,proto3" json:"second,omitempty"`
+	Proof         []string               `protobuf:"bytes,3,rep,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsistencyProofResponse) Reset() {
+	*x = GetConsistencyProofResponse{}
+	mi := &file_translog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsistencyProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsistencyProofResponse) ProtoMessage() {}
+
+func (x *GetConsistencyProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsistencyProofResponse.ProtoReflect.Descriptor instead.
+func (*GetConsistencyProofResponse) Descriptor() ([]byte, []int) {
+	return file_translog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetConsistencyProofResponse) GetFirst() int64 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *GetConsistencyProofResponse) GetSecond() int64 {
+	if x != nil {
+		return x.Second
+	}
+	return 0
+}
+
+func (x *GetConsistencyProofResponse) GetProof() []string {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_translog_proto protoreflect.FileDescriptor
+
+const file_translog_proto_rawDesc = "" +
+	"\n" +
+	"\x0etranslog.proto\x12\x11leadgen.hasher.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1a\n" +
+	"\x18GetSignedTreeHeadRequest\"\xb9\x01\n" +
+	"\x0eSignedTreeHead\x12\x1b\n" +
+	"\ttree_size\x18\x01 \x01(\x03R\btreeSize\x12\x1b\n" +
+	"\troot_hash\x18\x02 \x01(\tR\brootHash\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\tR\x05keyId\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\"A\n" +
+	"\x11GetEntriesRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"K\n" +
+	"\x12GetEntriesResponse\x125\n" +
+	"\aentries\x18\x01 \x03(\v2\x1b.leadgen.hasher.v1.LogEntryR\aentries\"\x9a\x02\n" +
+	"\bLogEntry\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x12\n" +
+	"\x04leaf\x18\x02 \x01(\tR\x04leaf\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12\x1c\n" +
+	"\talgorithm\x18\x04 \x01(\tR\talgorithm\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\x12%\n" +
+	"\x0epepper_version\x18\x06 \x01(\tR\rpepperVersion\x127\n" +
+	"\tissued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\b \x01(\tR\n" +
+	"commitment\x12\x1a\n" +
+	"\bpreimage\x18\t \x01(\tR\bpreimage\"M\n" +
+	"\x18GetInclusionProofRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1b\n" +
+	"\ttree_size\x18\x02 \x01(\x03R\btreeSize\"\x8a\x01\n" +
+	"\x19GetInclusionProofResponse\x121\n" +
+	"\x05entry\x18\x01 \x01(\v2\x1b.leadgen.hasher.v1.LogEntryR\x05entry\x12\x1b\n" +
+	"\ttree_size\x18\x02 \x01(\x03R\btreeSize\x12\x1d\n" +
+	"\n" +
+	"audit_path\x18\x03 \x03(\tR\tauditPath\"J\n" +
+	"\x1aGetConsistencyProofRequest\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x03R\x05first\x12\x16\n" +
+	"\x06second\x18\x02 \x01(\x03R\x06second\"a\n" +
+	"\x1bGetConsistencyProofResponse\x12\x14\n" +
+	"\x05first\x18\x01 \x01(\x03R\x05first\x12\x16\n" +
+	"\x06second\x18\x02 \x01(\x03R\x06second\x12\x14\n" +
+	"\x05proof\x18\x03 \x03(\tR\x05proof2\xbe\x03\n" +
+	"\x16TransparencyLogService\x12c\n" +
+	"\x11GetSignedTreeHead\x12+.leadgen.hasher.v1.GetSignedTreeHeadRequest\x1a!.leadgen.hasher.v1.SignedTreeHead\x12Y\n" +
+	"\n" +
+	"GetEntries\x12$.leadgen.hasher.v1.GetEntriesRequest\x1a%.leadgen.hasher.v1.GetEntriesResponse\x12n\n" +
+	"\x11GetInclusionProof\x12+.leadgen.hasher.v1.GetInclusionProofRequest\x1a,.leadgen.hasher.v1.GetInclusionProofResponse\x12t\n" +
+	"\x13GetConsistencyProof\x12-.leadgen.hasher.v1.GetConsistencyProofRequest\x1a..leadgen.hasher.v1.GetConsistencyProofResponseB6Z4github.com/tmybsv/leadgen-test-task/pkg/pb/hasher/v1b\x06proto3"
+
+var (
+	file_translog_proto_rawDescOnce sync.Once
+	file_translog_proto_rawDescData []byte
+)
+
+func file_translog_proto_rawDescGZIP() []byte {
+	file_translog_proto_rawDescOnce.Do(func() {
+		file_translog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_translog_proto_rawDesc), len(file_translog_proto_rawDesc)))
+	})
+	return file_translog_proto_rawDescData
+}
+
+var file_translog_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_translog_proto_goTypes = []any{
+	(*GetSignedTreeHeadRequest)(nil),    // 0: leadgen.hasher.v1.GetSignedTreeHeadRequest
+	(*SignedTreeHead)(nil),              // 1: leadgen.hasher.v1.SignedTreeHead
+	(*GetEntriesRequest)(nil),           // 2: leadgen.hasher.v1.GetEntriesRequest
+	(*GetEntriesResponse)(nil),          // 3: leadgen.hasher.v1.GetEntriesResponse
+	(*LogEntry)(nil),                    // 4: leadgen.hasher.v1.LogEntry
+	(*GetInclusionProofRequest)(nil),    // 5: leadgen.hasher.v1.GetInclusionProofRequest
+	(*GetInclusionProofResponse)(nil),   // 6: leadgen.hasher.v1.GetInclusionProofResponse
+	(*GetConsistencyProofRequest)(nil),  // 7: leadgen.hasher.v1.GetConsistencyProofRequest
+	(*GetConsistencyProofResponse)(nil), // 8: leadgen.hasher.v1.GetConsistencyProofResponse
+	(*timestamppb.Timestamp)(nil),       // 9: google.protobuf.Timestamp
+}
+var file_translog_proto_depIdxs = []int32{
+	9, // 0: leadgen.hasher.v1.SignedTreeHead.timestamp:type_name -> google.protobuf.Timestamp
+	4, // 1: leadgen.hasher.v1.GetEntriesResponse.entries:type_name -> leadgen.hasher.v1.LogEntry
+	9, // 2: leadgen.hasher.v1.LogEntry.issued_at:type_name -> google.protobuf.Timestamp
+	4, // 3: leadgen.hasher.v1.GetInclusionProofResponse.entry:type_name -> leadgen.hasher.v1.LogEntry
+	0, // 4: leadgen.hasher.v1.TransparencyLogService.GetSignedTreeHead:input_type -> leadgen.hasher.v1.GetSignedTreeHeadRequest
+	2, // 5: leadgen.hasher.v1.TransparencyLogService.GetEntries:input_type -> leadgen.hasher.v1.GetEntriesRequest
+	5, // 6: leadgen.hasher.v1.TransparencyLogService.GetInclusionProof:input_type -> leadgen.hasher.v1.GetInclusionProofRequest
+	7, // 7: leadgen.hasher.v1.TransparencyLogService.GetConsistencyProof:input_type -> leadgen.hasher.v1.GetConsistencyProofRequest
+	1, // 8: leadgen.hasher.v1.TransparencyLogService.GetSignedTreeHead:output_type -> leadgen.hasher.v1.SignedTreeHead
+	3, // 9: leadgen.hasher.v1.TransparencyLogService.GetEntries:output_type -> leadgen.hasher.v1.GetEntriesResponse
+	6, // 10: leadgen.hasher.v1.TransparencyLogService.GetInclusionProof:output_type -> leadgen.hasher.v1.GetInclusionProofResponse
+	8, // 11: leadgen.hasher.v1.TransparencyLogService.GetConsistencyProof:output_type -> leadgen.hasher.v1.GetConsistencyProofResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_translog_proto_init() }
+func file_translog_proto_init() {
+	if File_translog_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_translog_proto_rawDesc), len(file_translog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_translog_proto_goTypes,
+		DependencyIndexes: file_translog_proto_depIdxs,
+		MessageInfos:      file_translog_proto_msgTypes,
+	}.Build()
+	File_translog_proto = out.File
+	file_translog_proto_goTypes = nil
+	file_translog_proto_depIdxs = nil
+}
@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: translog.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TransparencyLogService_GetSignedTreeHead_FullMethodName   = "/leadgen.hasher.v1.TransparencyLogService/GetSignedTreeHead"
+	TransparencyLogService_GetEntries_FullMethodName          = "/leadgen.hasher.v1.TransparencyLogService/GetEntries"
+	TransparencyLogService_GetInclusionProof_FullMethodName   = "/leadgen.hasher.v1.TransparencyLogService/GetInclusionProof"
+	TransparencyLogService_GetConsistencyProof_FullMethodName = "/leadgen.hasher.v1.TransparencyLogService/GetConsistencyProof"
+)
+
+// TransparencyLogServiceClient is the client API for TransparencyLogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TransparencyLogService serves append-only log of issued hashes. Log is an
+// RFC 6962 Merkle tree over entries with SHA-256, its heads are periodically
+// signed by Ed25519 key.
+type TransparencyLogServiceClient interface {
+	GetSignedTreeHead(ctx context.Context, in *GetSignedTreeHeadRequest, opts ...grpc.CallOption) (*SignedTreeHead, error)
+	GetEntries(ctx context.Context, in *GetEntriesRequest, opts ...grpc.CallOption) (*GetEntriesResponse, error)
+	// GetInclusionProof proves entry is in tree of given size.
+	GetInclusionProof(ctx context.Context, in *GetInclusionProofRequest, opts ...grpc.CallOption) (*GetInclusionProofResponse, error)
+	// GetConsistencyProof proves tree of first size is a prefix of tree of
+	// second size, i.e. log was only appended to.
+	GetConsistencyProof(ctx context.Context, in *GetConsistencyProofRequest, opts ...grpc.CallOption) (*GetConsistencyProofResponse, error)
+}
+
+type transparencyLogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransparencyLogServiceClient(cc grpc.ClientConnInterface) TransparencyLogServiceClient {
+	return &transparencyLogServiceClient{cc}
+}
+
+func (c *transparencyLogServiceClient) GetSignedTreeHead(ctx context.Context, in *GetSignedTreeHeadRequest, opts ...grpc.CallOption) (*SignedTreeHead, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignedTreeHead)
+	err := c.cc.Invoke(ctx, TransparencyLogService_GetSignedTreeHead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transparencyLogServiceClient) GetEntries(ctx context.Context, in *GetEntriesRequest, opts ...grpc.CallOption) (*GetEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEntriesResponse)
+	err := c.cc.Invoke(ctx, TransparencyLogService_GetEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transparencyLogServiceClient) GetInclusionProof(ctx context.Context, in *GetInclusionProofRequest, opts ...grpc.CallOption) (*GetInclusionProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInclusionProofResponse)
+	err := c.cc.Invoke(ctx, TransparencyLogService_GetInclusionProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transparencyLogServiceClient) GetConsistencyProof(ctx context.Context, in *GetConsistencyProofRequest, opts ...grpc.CallOption) (*GetConsistencyProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConsistencyProofResponse)
+	err := c.cc.Invoke(ctx, TransparencyLogService_GetConsistencyProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransparencyLogServiceServer is the server API for TransparencyLogService service.
+// All implementations must embed UnimplementedTransparencyLogServiceServer
+// for forward compatibility.
+//
+// TransparencyLogService serves append-only log of issued hashes. Log is an
+// RFC 6962 Merkle tree over entries with SHA-256, its heads are periodically
+// signed by Ed25519 key.
+type TransparencyLogServiceServer interface {
+	GetSignedTreeHead(context.Context, *GetSignedTreeHeadRequest) (*SignedTreeHead, error)
+	GetEntries(context.Context, *GetEntriesRequest) (*GetEntriesResponse, error)
+	// GetInclusionProof proves entry is in tree of given size.
+	GetInclusionProof(context.Context, *GetInclusionProofRequest) (*GetInclusionProofResponse, error)
+	// GetConsistencyProof proves tree of first size is a prefix of tree of
+	// second size, i.e. log was only appended to.
+	GetConsistencyProof(context.Context, *GetConsistencyProofRequest) (*GetConsistencyProofResponse, error)
+	mustEmbedUnimplementedTransparencyLogServiceServer()
+}
+
+// UnimplementedTransparencyLogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTransparencyLogServiceServer struct{}
+
+func (UnimplementedTransparencyLogServiceServer) GetSignedTreeHead(context.Context, *GetSignedTreeHeadRequest) (*SignedTreeHead, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSignedTreeHead not implemented")
+}
+func (UnimplementedTransparencyLogServiceServer) GetEntries(context.Context, *GetEntriesRequest) (*GetEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntries not implemented")
+}
+func (UnimplementedTransparencyLogServiceServer) GetInclusionProof(context.Context, *GetInclusionProofRequest) (*GetInclusionProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionProof not implemented")
+}
+func (UnimplementedTransparencyLogServiceServer) GetConsistencyProof(context.Context, *GetConsistencyProofRequest) (*GetConsistencyProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsistencyProof not implemented")
+}
+func (UnimplementedTransparencyLogServiceServer) mustEmbedUnimplementedTransparencyLogServiceServer() {
+}
+func (UnimplementedTransparencyLogServiceServer) testEmbeddedByValue() {}
+
+// UnsafeTransparencyLogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransparencyLogServiceServer will
+// result in compilation errors.
+type UnsafeTransparencyLogServiceServer interface {
+	mustEmbedUnimplementedTransparencyLogServiceServer()
+}
+
+func RegisterTransparencyLogServiceServer(s grpc.ServiceRegistrar, srv TransparencyLogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTransparencyLogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TransparencyLogService_ServiceDesc, srv)
+}
+
+func _TransparencyLogService_GetSignedTreeHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSignedTreeHeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransparencyLogServiceServer).GetSignedTreeHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransparencyLogService_GetSignedTreeHead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransparencyLogServiceServer).GetSignedTreeHead(ctx, req.(*GetSignedTreeHeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransparencyLogService_GetEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransparencyLogServiceServer).GetEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransparencyLogService_GetEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransparencyLogServiceServer).GetEntries(ctx, req.(*GetEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransparencyLogService_GetInclusionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInclusionProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransparencyLogServiceServer).GetInclusionProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransparencyLogService_GetInclusionProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransparencyLogServiceServer).GetInclusionProof(ctx, req.(*GetInclusionProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransparencyLogService_GetConsistencyProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsistencyProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransparencyLogServiceServer).GetConsistencyProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransparencyLogService_GetConsistencyProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransparencyLogServiceServer).GetConsistencyProof(ctx, req.(*GetConsistencyProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TransparencyLogService_ServiceDesc is the grpc.ServiceDesc for TransparencyLogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransparencyLogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leadgen.hasher.v1.TransparencyLogService",
+	HandlerType: (*TransparencyLogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSignedTreeHead",
+			Handler:    _TransparencyLogService_GetSignedTreeHead_Handler,
+		},
+		{
+			MethodName: "GetEntries",
+			Handler:    _TransparencyLogService_GetEntries_Handler,
+		},
+		{
+			MethodName: "GetInclusionProof",
+			Handler:    _TransparencyLogService_GetInclusionProof_Handler,
+		},
+		{
+			MethodName: "GetConsistencyProof",
+			Handler:    _TransparencyLogService_GetConsistencyProof_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "translog.proto",
+}